@@ -24,6 +24,8 @@ func main() {
 			commands.InvoiceCommand,
 			commands.PaymentsCommand,
 			commands.TokensCommand,
+			commands.DistributionsCommand,
+			commands.AdminCommand,
 		},
 	}).Run(context.Background(), os.Args)
 }
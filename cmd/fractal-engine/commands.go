@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+/*
+* splitCommand takes the command words before the first flag, e.g. "snapshot export" in
+* "fractal-engine snapshot export --snapshot-file state.json", out of os.Args so the flags parse as usual.
+ */
+func splitCommand() []string {
+	command := []string{}
+	for len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		command = append(command, os.Args[1])
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	return command
+}
+
+// runCommand runs a maintenance command against the database and node instead of starting the engine.
+func runCommand(command []string, cfg *config.Config, tokenStore *store.TokenisationStore, snapshotFile string, fromHeight int64) error {
+	err := tokenStore.Migrate()
+	if err != nil && err.Error() != "no change" {
+		return fmt.Errorf("failed to migrate tokenisation store: %v", err)
+	}
+
+	switch strings.Join(command, " ") {
+	case "snapshot export":
+		return exportSnapshot(tokenStore, snapshotFile)
+	case "snapshot import":
+		return importSnapshot(cfg, tokenStore, snapshotFile)
+	case "reindex":
+		if fromHeight < 0 {
+			return fmt.Errorf("--from-height is required")
+		}
+		return service.NewReindexer(cfg, tokenStore).Run(fromHeight)
+	}
+
+	return fmt.Errorf("unknown command %q, expected snapshot export, snapshot import or reindex", strings.Join(command, " "))
+}
+
+// exportSnapshot writes the state at the current chain position, replacing the file only once the snapshot is complete.
+func exportSnapshot(tokenStore *store.TokenisationStore, snapshotFile string) error {
+	if snapshotFile == "" {
+		return fmt.Errorf("--snapshot-file is required")
+	}
+
+	snapshot, err := tokenStore.ExportSnapshot()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(snapshotFile + ".tmp")
+	if err != nil {
+		return err
+	}
+
+	err = json.NewEncoder(file).Encode(snapshot)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(snapshotFile+".tmp", snapshotFile)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Snapshot at block %d %s written to %s (hash %s)\n", snapshot.BlockHeight, snapshot.BlockHash, snapshotFile, snapshot.Hash)
+	return nil
+}
+
+// importSnapshot loads a snapshot into a new database once the node agrees on its block, so the follower resumes from it.
+func importSnapshot(cfg *config.Config, tokenStore *store.TokenisationStore, snapshotFile string) error {
+	if snapshotFile == "" {
+		return fmt.Errorf("--snapshot-file is required")
+	}
+
+	file, err := os.Open(snapshotFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	snapshot, err := store.ReadSnapshot(file)
+	if err != nil {
+		return err
+	}
+
+	err = snapshot.Verify()
+	if err != nil {
+		return err
+	}
+
+	hash, err := doge.NewRpcClient(cfg).GetBlockHash(int(snapshot.BlockHeight))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d from the node: %v", snapshot.BlockHeight, err)
+	}
+
+	if hash != snapshot.BlockHash {
+		return fmt.Errorf("snapshot block %d %s is not on the chain of the node, which has %s", snapshot.BlockHeight, snapshot.BlockHash, hash)
+	}
+
+	err = tokenStore.ImportSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Snapshot at block %d %s imported, the follower resumes from it\n", snapshot.BlockHeight, snapshot.BlockHash)
+	return nil
+}
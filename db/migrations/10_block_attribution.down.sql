@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS onchain_transactions_block_height_idx;
+DROP INDEX IF EXISTS pending_token_balances_block_height_idx;
+DROP INDEX IF EXISTS token_balances_block_height_idx;
+
+ALTER TABLE pending_token_balances DROP COLUMN block_hash;
+ALTER TABLE pending_token_balances DROP COLUMN block_height;
+
+ALTER TABLE token_balances DROP COLUMN block_hash;
+ALTER TABLE token_balances DROP COLUMN block_height;
+
+ALTER TABLE invoices DROP COLUMN paid_block_hash;
+ALTER TABLE invoices DROP COLUMN paid_block_height;
+ALTER TABLE invoices DROP COLUMN block_hash;
+
+ALTER TABLE mints DROP COLUMN block_hash;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS unique_token_burns_burn_hash_idx;
+DROP INDEX IF EXISTS token_burns_block_height_idx;
+DROP INDEX IF EXISTS token_burns_mint_hash_idx;
+DROP TABLE IF EXISTS token_burns;
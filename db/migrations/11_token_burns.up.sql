@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS token_burns (
+    id UUID PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    address TEXT NOT NULL,
+    quantity INT NOT NULL,
+    burn_hash TEXT,
+    transaction_hash TEXT NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS token_burns_mint_hash_idx ON token_burns (mint_hash);
+CREATE INDEX IF NOT EXISTS token_burns_block_height_idx ON token_burns (block_height);
+CREATE UNIQUE INDEX IF NOT EXISTS unique_token_burns_burn_hash_idx ON token_burns (burn_hash);
@@ -0,0 +1 @@
+ALTER TABLE onchain_transactions DROP COLUMN block_time;
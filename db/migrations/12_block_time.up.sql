@@ -0,0 +1 @@
+ALTER TABLE onchain_transactions ADD COLUMN block_time BIGINT NOT NULL DEFAULT 0;
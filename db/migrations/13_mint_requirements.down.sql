@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS trade_rejections_block_height_idx;
+DROP INDEX IF EXISTS trade_rejections_mint_hash_idx;
+DROP INDEX IF EXISTS trade_rejections_invoice_hash_idx;
+DROP TABLE IF EXISTS trade_rejections;
+DROP INDEX IF EXISTS mint_allowlist_entries_block_height_idx;
+DROP INDEX IF EXISTS mint_allowlist_entries_mint_hash_idx;
+DROP TABLE IF EXISTS mint_allowlist_entries;
+DROP TABLE IF EXISTS unconfirmed_mint_allowlist_entries;
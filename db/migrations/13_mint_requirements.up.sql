@@ -0,0 +1,49 @@
+-- Allowlist entries only apply once anchored on chain, so every node evaluates a trade against the same allowlist
+CREATE TABLE IF NOT EXISTS unconfirmed_mint_allowlist_entries (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    entry TEXT NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    timestamp BIGINT NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mint_allowlist_entries (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    entry TEXT NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    timestamp BIGINT NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    transaction_number INTEGER NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS mint_allowlist_entries_mint_hash_idx ON mint_allowlist_entries (mint_hash, block_height);
+CREATE INDEX IF NOT EXISTS mint_allowlist_entries_block_height_idx ON mint_allowlist_entries (block_height);
+
+CREATE TABLE IF NOT EXISTS trade_rejections (
+    id UUID PRIMARY KEY,
+    invoice_hash TEXT NOT NULL,
+    mint_hash TEXT NOT NULL,
+    buyer_address TEXT NOT NULL,
+    seller_address TEXT NOT NULL,
+    quantity INT NOT NULL,
+    reason_code TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    action_type INTEGER NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS trade_rejections_invoice_hash_idx ON trade_rejections (invoice_hash);
+CREATE INDEX IF NOT EXISTS trade_rejections_mint_hash_idx ON trade_rejections (mint_hash);
+CREATE INDEX IF NOT EXISTS trade_rejections_block_height_idx ON trade_rejections (block_height);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS distribution_payouts_paid_block_height_idx;
+DROP TABLE IF EXISTS distribution_payouts;
+DROP INDEX IF EXISTS distributions_block_height_idx;
+DROP INDEX IF EXISTS distributions_mint_hash_idx;
+DROP TABLE IF EXISTS distributions;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS distributions (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    owner_address TEXT NOT NULL,
+    total_koinu BIGINT NOT NULL,
+    record_height BIGINT NOT NULL,
+    total_fractions INTEGER NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS distributions_mint_hash_idx ON distributions (mint_hash);
+CREATE INDEX IF NOT EXISTS distributions_block_height_idx ON distributions (block_height);
+
+CREATE TABLE IF NOT EXISTS distribution_payouts (
+    distribution_hash TEXT NOT NULL,
+    address TEXT NOT NULL,
+    fractions INTEGER NOT NULL,
+    amount_koinu BIGINT NOT NULL,
+    paid_transaction_hash TEXT,
+    paid_block_height BIGINT,
+    paid_block_hash TEXT,
+    PRIMARY KEY (distribution_hash, address)
+);
+
+CREATE INDEX IF NOT EXISTS distribution_payouts_paid_block_height_idx ON distribution_payouts (paid_block_height);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS mint_amendments_block_height_idx;
+DROP TABLE IF EXISTS mint_amendments;
+DROP TABLE IF EXISTS unconfirmed_mint_amendments;
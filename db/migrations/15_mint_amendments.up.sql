@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS unconfirmed_mint_amendments (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    description TEXT NOT NULL,
+    metadata TEXT NOT NULL,
+    feed_url TEXT NOT NULL,
+    contract_of_sale TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mint_amendments (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    metadata TEXT NOT NULL,
+    feed_url TEXT NOT NULL,
+    contract_of_sale TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (mint_hash, revision)
+);
+
+CREATE INDEX IF NOT EXISTS mint_amendments_block_height_idx ON mint_amendments (block_height);
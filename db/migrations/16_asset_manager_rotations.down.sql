@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS asset_manager_rotations_mint_hash_idx;
+DROP TABLE IF EXISTS asset_manager_rotations;
+DROP TABLE IF EXISTS unconfirmed_asset_manager_rotations;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS unconfirmed_asset_manager_rotations (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    action TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    manager TEXT,
+    timestamp BIGINT NOT NULL,
+    approvals TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS asset_manager_rotations (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    action TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    manager TEXT,
+    timestamp BIGINT NOT NULL,
+    approvals TEXT NOT NULL,
+    asset_managers TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    transaction_number INTEGER NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS asset_manager_rotations_mint_hash_idx ON asset_manager_rotations (mint_hash, block_height);
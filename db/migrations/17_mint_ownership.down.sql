@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS mint_ownership_transfers_mint_hash_idx;
+DROP TABLE IF EXISTS mint_ownership_transfers;
+DROP TABLE IF EXISTS unconfirmed_mint_ownership_transfers;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS unconfirmed_mint_ownership_transfers (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    new_owner_address TEXT NOT NULL,
+    new_owner_public_key TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    owner_public_key TEXT NOT NULL,
+    owner_signature TEXT NOT NULL,
+    new_owner_signature TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS mint_ownership_transfers (
+    hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    new_owner_address TEXT NOT NULL,
+    new_owner_public_key TEXT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    owner_public_key TEXT NOT NULL,
+    owner_signature TEXT NOT NULL,
+    new_owner_signature TEXT NOT NULL,
+    previous_owner_address TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    transaction_number INTEGER NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS mint_ownership_transfers_mint_hash_idx ON mint_ownership_transfers (mint_hash, block_height);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS onchain_transaction_outcomes_outcome_idx;
+DROP INDEX IF EXISTS onchain_transaction_outcomes_block_height_idx;
+DROP TABLE IF EXISTS onchain_transaction_outcomes;
@@ -0,0 +1,23 @@
+-- The outcome of every on chain transaction the processor has finished with, so each is processed once.
+-- Transactions not applied keep their payload, outputs and block time so an operator can queue them again.
+CREATE TABLE IF NOT EXISTS onchain_transaction_outcomes (
+    tx_hash TEXT PRIMARY KEY,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    transaction_number INTEGER NOT NULL,
+    action_type INTEGER NOT NULL,
+    action_version INTEGER NOT NULL,
+    action_data BYTEA,
+    address TEXT NOT NULL,
+    "values" JSONB,
+    block_time BIGINT NOT NULL DEFAULT 0,
+    outcome TEXT NOT NULL,
+    reason_code TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    confirmations INTEGER NOT NULL DEFAULT 0,
+    required_confirmations INTEGER NOT NULL DEFAULT 0,
+    processed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS onchain_transaction_outcomes_block_height_idx ON onchain_transaction_outcomes (block_height, transaction_number);
+CREATE INDEX IF NOT EXISTS onchain_transaction_outcomes_outcome_idx ON onchain_transaction_outcomes (outcome);
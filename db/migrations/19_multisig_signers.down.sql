@@ -0,0 +1,11 @@
+ALTER TABLE invoices DROP COLUMN multisig_signatures;
+ALTER TABLE invoices DROP COLUMN redeem_script;
+
+ALTER TABLE mints DROP COLUMN multisig_signatures;
+ALTER TABLE mints DROP COLUMN redeem_script;
+
+ALTER TABLE unconfirmed_invoices DROP COLUMN multisig_signatures;
+ALTER TABLE unconfirmed_invoices DROP COLUMN redeem_script;
+
+ALTER TABLE unconfirmed_mints DROP COLUMN multisig_signatures;
+ALTER TABLE unconfirmed_mints DROP COLUMN redeem_script;
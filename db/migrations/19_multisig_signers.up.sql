@@ -0,0 +1,11 @@
+ALTER TABLE unconfirmed_mints ADD COLUMN redeem_script TEXT NOT NULL DEFAULT '';
+ALTER TABLE unconfirmed_mints ADD COLUMN multisig_signatures TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE unconfirmed_invoices ADD COLUMN redeem_script TEXT NOT NULL DEFAULT '';
+ALTER TABLE unconfirmed_invoices ADD COLUMN multisig_signatures TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE mints ADD COLUMN redeem_script TEXT NOT NULL DEFAULT '';
+ALTER TABLE mints ADD COLUMN multisig_signatures TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE invoices ADD COLUMN redeem_script TEXT NOT NULL DEFAULT '';
+ALTER TABLE invoices ADD COLUMN multisig_signatures TEXT NOT NULL DEFAULT '[]';
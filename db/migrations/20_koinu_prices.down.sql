@@ -0,0 +1,17 @@
+ALTER TABLE onchain_transactions DROP COLUMN values_unit;
+
+ALTER TABLE invoices ADD COLUMN price INT NOT NULL DEFAULT 0;
+UPDATE invoices SET price = price_koinu / 100000000;
+ALTER TABLE invoices DROP COLUMN price_koinu;
+
+ALTER TABLE unconfirmed_invoices ADD COLUMN price INT NOT NULL DEFAULT 0;
+UPDATE unconfirmed_invoices SET price = price_koinu / 100000000;
+ALTER TABLE unconfirmed_invoices DROP COLUMN price_koinu;
+
+ALTER TABLE buy_offers ADD COLUMN price INT NOT NULL DEFAULT 0;
+UPDATE buy_offers SET price = price_koinu / 100000000;
+ALTER TABLE buy_offers DROP COLUMN price_koinu;
+
+ALTER TABLE sell_offers ADD COLUMN price INT NOT NULL DEFAULT 0;
+UPDATE sell_offers SET price = price_koinu / 100000000;
+ALTER TABLE sell_offers DROP COLUMN price_koinu;
@@ -0,0 +1,19 @@
+-- Prices were whole DOGE per fraction; they are now exact koinu (1 DOGE = 100000000 koinu) per fraction.
+ALTER TABLE sell_offers ADD COLUMN price_koinu BIGINT NOT NULL DEFAULT 0;
+UPDATE sell_offers SET price_koinu = CAST(price AS BIGINT) * 100000000;
+ALTER TABLE sell_offers DROP COLUMN price;
+
+ALTER TABLE buy_offers ADD COLUMN price_koinu BIGINT NOT NULL DEFAULT 0;
+UPDATE buy_offers SET price_koinu = CAST(price AS BIGINT) * 100000000;
+ALTER TABLE buy_offers DROP COLUMN price;
+
+ALTER TABLE unconfirmed_invoices ADD COLUMN price_koinu BIGINT NOT NULL DEFAULT 0;
+UPDATE unconfirmed_invoices SET price_koinu = CAST(price AS BIGINT) * 100000000;
+ALTER TABLE unconfirmed_invoices DROP COLUMN price;
+
+ALTER TABLE invoices ADD COLUMN price_koinu BIGINT NOT NULL DEFAULT 0;
+UPDATE invoices SET price_koinu = CAST(price AS BIGINT) * 100000000;
+ALTER TABLE invoices DROP COLUMN price;
+
+-- Output values of pending on-chain transactions were recorded as DOGE floats; they are converted to koinu on startup.
+ALTER TABLE onchain_transactions ADD COLUMN values_unit TEXT NOT NULL DEFAULT 'doge';
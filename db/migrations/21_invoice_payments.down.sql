@@ -0,0 +1,4 @@
+ALTER TABLE invoices DROP COLUMN paid_koinu;
+
+DROP INDEX IF EXISTS invoice_payments_invoice_hash_idx;
+DROP TABLE IF EXISTS invoice_payments;
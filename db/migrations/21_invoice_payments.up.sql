@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS invoice_payments (
+    tx_hash TEXT NOT NULL,
+    invoice_hash TEXT NOT NULL,
+    amount_koinu BIGINT NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tx_hash, invoice_hash)
+);
+
+CREATE INDEX IF NOT EXISTS invoice_payments_invoice_hash_idx ON invoice_payments (invoice_hash);
+
+ALTER TABLE invoices ADD COLUMN paid_koinu BIGINT NOT NULL DEFAULT 0;
+
+-- Invoices settled before payments were accumulated were paid exactly
+UPDATE invoices SET paid_koinu = quantity * price_koinu WHERE paid_at IS NOT NULL;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS batch_payment_outcomes (
+    tx_hash TEXT NOT NULL,
+    invoice_hash TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    amount_koinu BIGINT NOT NULL,
+    reason_code TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tx_hash, position)
+);
+
+CREATE INDEX IF NOT EXISTS batch_payment_outcomes_invoice_hash_idx ON batch_payment_outcomes (invoice_hash);
+CREATE INDEX IF NOT EXISTS batch_payment_outcomes_block_height_idx ON batch_payment_outcomes (block_height);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS invoice_releases_block_height_idx;
+DROP TABLE IF EXISTS invoice_releases;
+
+ALTER TABLE pending_token_balances DROP COLUMN expiry_height;
+ALTER TABLE invoices DROP COLUMN expiry_height;
@@ -0,0 +1,21 @@
+ALTER TABLE invoices ADD COLUMN expiry_height BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE pending_token_balances ADD COLUMN expiry_height BIGINT NOT NULL DEFAULT 0;
+
+-- Reservations released by expiry or by the seller, kept so they can be restored on rollback
+CREATE TABLE IF NOT EXISTS invoice_releases (
+    invoice_hash TEXT PRIMARY KEY,
+    mint_hash TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    owner_address TEXT NOT NULL,
+    quantity INT NOT NULL,
+    onchain_transaction_id TEXT NOT NULL,
+    expiry_height BIGINT NOT NULL,
+    reserved_block_height BIGINT,
+    reserved_block_hash TEXT,
+    block_height BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS invoice_releases_block_height_idx ON invoice_releases (block_height);
@@ -0,0 +1,2 @@
+ALTER TABLE onchain_transactions DROP COLUMN required_confirmations;
+ALTER TABLE onchain_transactions DROP COLUMN confirmations;
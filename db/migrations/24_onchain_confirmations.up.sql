@@ -0,0 +1,3 @@
+-- Confirmations last seen for a transaction waiting for its confirmation depth
+ALTER TABLE onchain_transactions ADD COLUMN confirmations INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE onchain_transactions ADD COLUMN required_confirmations INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS admin_audit_log_created_at_idx;
+DROP TABLE IF EXISTS admin_audit_log;
+ALTER TABLE onchain_transactions DROP COLUMN requeued;
@@ -0,0 +1,14 @@
+-- Transactions queued again by an operator are not trimmed
+ALTER TABLE onchain_transactions ADD COLUMN requeued BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Every action taken through the admin API
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id TEXT PRIMARY KEY,
+    action TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    detail TEXT NOT NULL,
+    remote_address TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_created_at_idx ON admin_audit_log (created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS mempool_transactions_hash_idx;
+DROP TABLE IF EXISTS mempool_transactions;
@@ -0,0 +1,15 @@
+-- Fractal transactions seen in the mempool of the node, one row per mint or invoice they refer to.
+-- Rows are only read to show what is about to be confirmed, balances are never derived from them.
+CREATE TABLE IF NOT EXISTS mempool_transactions (
+    tx_hash TEXT NOT NULL,
+    hash TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    action_type INTEGER NOT NULL,
+    action_version INTEGER NOT NULL,
+    address TEXT NOT NULL,
+    "values" JSONB,
+    first_seen_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tx_hash, hash)
+);
+
+CREATE INDEX IF NOT EXISTS mempool_transactions_hash_idx ON mempool_transactions (hash);
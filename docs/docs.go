@@ -15,6 +15,293 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/audit-log": {
+            "get": {
+                "description": "Returns the actions taken through the admin API, most recent first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the admin audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.GetAdminAuditLogResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stuck-transactions": {
+            "get": {
+                "description": "Returns the on chain transactions awaiting data or expired while waiting, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get stuck on chain transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.GetStuckTransactionsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stuck-transactions/{hash}/ignore": {
+            "post": {
+                "description": "Finishes with a transaction awaiting data or expired without processing it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Ignore a stuck on chain transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the action",
+                        "name": "request",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/rpc.AdminActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.AdminActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stuck-transactions/{hash}/payload": {
+            "post": {
+                "description": "Saves the mint or invoice a transaction is waiting for and queues the transaction to be processed again. The hash of the payload must match the hash committed on chain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Supply the off chain payload of a stuck on chain transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Off chain payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rpc.SupplyPayloadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.AdminActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/stuck-transactions/{hash}/reprocess": {
+            "post": {
+                "description": "Queues a transaction awaiting data or expired to be processed again. Expired transactions are restored and are not trimmed again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reprocess a stuck on chain transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the action",
+                        "name": "request",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/rpc.AdminActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.AdminActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/doge/confirm": {
             "post": {
                 "description": "Generates 10 blocks for transaction confirmation",
@@ -174,7 +461,7 @@ const docTemplate = `{
         },
         "/invoices/{address}": {
             "get": {
-                "description": "Returns a list of invoices with optional filtering by mint_hash and address",
+                "description": "Returns a list of invoices with optional filtering by mint_hash and address, with the payment of each invoice waiting in the mempool if there is one",
                 "consumes": [
                     "application/json"
                 ],
@@ -228,35 +515,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/invoices/{hash}/signatures": {
+        "/invoices/{hash}/cancellation": {
             "post": {
-                "description": "Creates a new invoice signature",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Generates an encoded transaction body for cancelling an unpaid invoice, which releases its reserved fractions. The transaction must be sent by the seller of the invoice.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "invoices"
                 ],
-                "summary": "Create an invoice signature",
+                "summary": "Prepares an encoded transaction body for an invoice cancellation",
                 "parameters": [
                     {
-                        "description": "Invoice signature request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rpc.CreateInvoiceSignatureRequest"
-                        }
+                        "type": "string",
+                        "description": "Invoice hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/rpc.CreateInvoiceSignatureResponse"
+                            "$ref": "#/definitions/rpc.CreateInvoiceCancellationResponse"
                         }
                     },
                     "400": {
@@ -264,13 +546,19 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/mints": {
-            "get": {
-                "description": "Returns a list of mints",
+        "/invoices/{hash}/signatures": {
+            "post": {
+                "description": "Creates a new invoice signature",
                 "consumes": [
                     "application/json"
                 ],
@@ -278,9 +566,113 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "mints"
+                    "invoices"
                 ],
-                "summary": "Get all mints",
+                "summary": "Create an invoice signature",
+                "parameters": [
+                    {
+                        "description": "Invoice signature request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rpc.CreateInvoiceSignatureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.CreateInvoiceSignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mempool-transactions": {
+            "get": {
+                "description": "Returns the mints, invoices and payments seen in the mempool and not yet in a block, most recently seen first. They do not change balances and are cleared when they leave the mempool.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mempool"
+                ],
+                "summary": "Get mempool transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash of the mint or invoice",
+                        "name": "hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject (mint, invoice, payment)",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Address of the sender",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.GetMempoolTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/mints": {
+            "get": {
+                "description": "Returns a list of mints",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "mints"
+                ],
+                "summary": "Get all mints",
                 "parameters": [
                     {
                         "type": "integer",
@@ -361,6 +753,165 @@ const docTemplate = `{
                 }
             }
         },
+        "/onchain-transactions": {
+            "get": {
+                "description": "Returns what became of on chain transactions, most recent first: applied, rejected with a reason code, awaiting data (with the confirmations seen and required for their action) or expired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "onchain-transactions"
+                ],
+                "summary": "Get on chain transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Status (applied, rejected, awaiting_data, expired, ignored)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "tx_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.GetOnChainTransactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/batch/new": {
+            "post": {
+                "description": "Generates an encoded transaction body for paying several invoices in one transaction, with one output per payment address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Prepares an encoded transaction body for a batch payment",
+                "parameters": [
+                    {
+                        "description": "Batch payment request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rpc.CreateNewBatchPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/batch/outcomes": {
+            "get": {
+                "description": "Returns which invoices of batch payments were settled, partially paid or rejected, and why",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get batch payment outcomes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "tx_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invoice hash",
+                        "name": "invoice_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rpc.GetBatchPaymentOutcomesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/payments/new": {
             "post": {
                 "description": "Generates an encoded transaction body for paying an invoice",
@@ -554,6 +1105,33 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "rpc.AdminActionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "rpc.AdminActionResponse": {
+            "type": "object",
+            "properties": {
+                "transaction": {
+                    "$ref": "#/definitions/store.OnChainTransactionOutcome"
+                }
+            }
+        },
+        "rpc.CreateInvoiceCancellationResponse": {
+            "type": "object",
+            "properties": {
+                "encoded_transaction_body": {
+                    "type": "string"
+                },
+                "invoice_hash": {
+                    "type": "string"
+                }
+            }
+        },
         "rpc.CreateInvoiceSignatureRequest": {
             "type": "object",
             "properties": {
@@ -659,11 +1237,56 @@ const docTemplate = `{
                 }
             }
         },
-        "rpc.CreateNewPaymentRequest": {
+        "rpc.CreateNewBatchPaymentRequest": {
             "type": "object",
             "properties": {
-                "invoice_hash": {
-                    "type": "string"
+                "invoice_hashes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rpc.CreateNewPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "invoice_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "rpc.GetAdminAuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.AdminAuditEntry"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rpc.GetBatchPaymentOutcomesResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "outcomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.BatchPaymentOutcome"
+                    }
+                },
+                "page": {
+                    "type": "integer"
                 }
             }
         },
@@ -710,6 +1333,23 @@ const docTemplate = `{
                 }
             }
         },
+        "rpc.GetMempoolTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.MempoolTransaction"
+                    }
+                }
+            }
+        },
         "rpc.GetMintsResponse": {
             "type": "object",
             "properties": {
@@ -730,6 +1370,23 @@ const docTemplate = `{
                 }
             }
         },
+        "rpc.GetOnChainTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.OnChainTransactionOutcome"
+                    }
+                }
+            }
+        },
         "rpc.GetStatsResponse": {
             "type": "object",
             "properties": {
@@ -741,6 +1398,23 @@ const docTemplate = `{
                 }
             }
         },
+        "rpc.GetStuckTransactionsResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.OnChainTransactionOutcome"
+                    }
+                }
+            }
+        },
         "rpc.SendRequest": {
             "type": "object",
             "properties": {
@@ -757,6 +1431,17 @@ const docTemplate = `{
                 }
             }
         },
+        "rpc.SupplyPayloadRequest": {
+            "type": "object",
+            "properties": {
+                "invoice": {
+                    "$ref": "#/definitions/store.UnconfirmedInvoice"
+                },
+                "mint": {
+                    "$ref": "#/definitions/store.MintWithoutID"
+                }
+            }
+        },
         "sql.NullTime": {
             "type": "object",
             "properties": {
@@ -769,6 +1454,29 @@ const docTemplate = `{
                 }
             }
         },
+        "store.AdminAuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "remote_address": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                }
+            }
+        },
         "store.AssetManager": {
             "type": "object",
             "properties": {
@@ -783,6 +1491,41 @@ const docTemplate = `{
                 }
             }
         },
+        "store.BatchPaymentOutcome": {
+            "type": "object",
+            "properties": {
+                "amount_koinu": {
+                    "type": "integer"
+                },
+                "block_hash": {
+                    "type": "string"
+                },
+                "block_height": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "invoice_hash": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reason_code": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                }
+            }
+        },
         "store.Invoice": {
             "type": "object",
             "properties": {
@@ -795,25 +1538,37 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "expiry_height": {
+                    "type": "integer"
+                },
                 "hash": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "mempool_payment_tx_hash": {
+                    "type": "string"
+                },
                 "mint_hash": {
                     "type": "string"
                 },
                 "paid_at": {
                     "$ref": "#/definitions/sql.NullTime"
                 },
+                "paid_koinu": {
+                    "type": "integer"
+                },
                 "payment_address": {
                     "type": "string"
                 },
+                "payment_status": {
+                    "type": "string"
+                },
                 "pending_token_balance_id": {
                     "type": "string"
                 },
-                "price": {
+                "price_koinu": {
                     "type": "integer"
                 },
                 "public_key": {
@@ -822,17 +1577,59 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "release_reason": {
+                    "type": "string"
+                },
                 "seller_address": {
                     "type": "string"
                 },
                 "signature": {
                     "type": "string"
                 },
+                "surplus_koinu": {
+                    "type": "integer"
+                },
                 "transaction_hash": {
                     "type": "string"
                 }
             }
         },
+        "store.MempoolTransaction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "action_type": {
+                    "type": "integer"
+                },
+                "action_version": {
+                    "type": "integer"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "first_seen_at": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
         "store.Mint": {
             "type": "object",
             "properties": {
@@ -904,6 +1701,137 @@ const docTemplate = `{
                 }
             }
         },
+        "store.MintWithoutID": {
+            "type": "object",
+            "properties": {
+                "asset_managers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/store.AssetManager"
+                    }
+                },
+                "block_height": {
+                    "type": "integer"
+                },
+                "contract_of_sale": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "feed_url": {
+                    "type": "string"
+                },
+                "fraction_count": {
+                    "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "lockup_options": {
+                    "$ref": "#/definitions/store.StringInterfaceMap"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/store.StringInterfaceMap"
+                },
+                "min_signatures": {
+                    "type": "integer"
+                },
+                "owner_address": {
+                    "type": "string"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "$ref": "#/definitions/store.StringInterfaceMap"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signature_requirement_type": {
+                    "$ref": "#/definitions/store.SignatureRequirementType"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "transaction_hash": {
+                    "type": "string"
+                }
+            }
+        },
+        "store.OnChainTransactionOutcome": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "action_data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "action_type": {
+                    "type": "integer"
+                },
+                "action_version": {
+                    "type": "integer"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "block_hash": {
+                    "type": "string"
+                },
+                "block_time": {
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reason_code": {
+                    "type": "string"
+                },
+                "required_confirmations": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_number": {
+                    "type": "integer"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                }
+            }
+        },
         "store.SignatureRequirementType": {
             "type": "string",
             "enum": [
@@ -942,6 +1870,47 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "store.UnconfirmedInvoice": {
+            "type": "object",
+            "properties": {
+                "buyer_address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mint_hash": {
+                    "type": "string"
+                },
+                "payment_address": {
+                    "type": "string"
+                },
+                "price_koinu": {
+                    "type": "integer"
+                },
+                "public_key": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "seller_address": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
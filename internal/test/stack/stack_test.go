@@ -5,6 +5,8 @@ import (
 	"log"
 	"testing"
 	"time"
+
+	"github.com/dogeorg/doge/koinu"
 )
 
 func TestSimpleFlow(t *testing.T) {
@@ -45,7 +47,7 @@ func TestSimpleFlow(t *testing.T) {
 	}, 30, 5*time.Second)
 
 	// Create invoice
-	invoiceHash := Invoice(seller, buyer.Address, mintHash, sellQty, 20)
+	invoiceHash := Invoice(seller, buyer.Address, mintHash, sellQty, 20*koinu.OneDoge)
 	AssertEqualWithRetry(t, func() interface{} {
 		return GetPendingTokenBalance(seller, mintHash)
 	}, sellQty, 10, 3*time.Second)
@@ -70,7 +72,7 @@ func TestSimpleFlow(t *testing.T) {
 	}, 30, 10*time.Second)
 
 	// Pay for invoice
-	paymentTrxn := Payment(buyer, seller, invoiceHash, sellQty, 20)
+	paymentTrxn := Payment(buyer, seller, invoiceHash, sellQty, 20*koinu.OneDoge)
 
 	// Ensure buyer token balance is updated
 	AssertEqualWithRetry(t, func() interface{} {
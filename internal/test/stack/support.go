@@ -185,7 +185,7 @@ func GetUnspentUtxos(stackConfig *StackConfig, address string) ([]indexer.UTXOIt
 	return unspent, nil
 }
 
-func WriteToBlockchain(stackConfig *StackConfig, paymentAddress string, hexBody string, amount int64) string {
+func WriteToBlockchain(stackConfig *StackConfig, paymentAddress string, hexBody string, amountKoinu int64) string {
 	blockChainInfo, err := stackConfig.DogeClient.GetBlockchainInfo()
 	if err != nil {
 		panic(err)
@@ -244,7 +244,7 @@ func WriteToBlockchain(stackConfig *StackConfig, paymentAddress string, hexBody
 
 	address := stackConfig.Address
 	fee, _ := koinu.ParseKoinu("1")
-	koinuAmount := koinu.Koinu(amount * koinu.OneDoge)
+	koinuAmount := koinu.Koinu(amountKoinu)
 
 	var outputs map[string]interface{}
 	if paymentAddress == "" && paymentAddress == address {
@@ -349,11 +349,11 @@ func TopUp(stackConfig *StackConfig) {
 	fmt.Println("Topped up address " + stackConfig.Address)
 }
 
-func Payment(buyerConfig *StackConfig, sellerConfig *StackConfig, invoiceHash string, quantity int, price int) string {
+func Payment(buyerConfig *StackConfig, sellerConfig *StackConfig, invoiceHash string, quantity int, priceKoinu int64) string {
 	envelope := protocol.NewPaymentTransactionEnvelope(invoiceHash, protocol.ACTION_PAYMENT)
 	encodedTransactionBody := envelope.Serialize()
 
-	total := int64(quantity * price)
+	total := int64(quantity) * priceKoinu
 
 	txId := WriteToBlockchain(buyerConfig, sellerConfig.Address, hex.EncodeToString(encodedTransactionBody), total)
 	ConfirmBlocks(sellerConfig)
@@ -362,13 +362,13 @@ func Payment(buyerConfig *StackConfig, sellerConfig *StackConfig, invoiceHash st
 	return txId
 }
 
-func Invoice(stackConfig *StackConfig, buyerAddress string, mintHash string, quantity int, price int) string {
+func Invoice(stackConfig *StackConfig, buyerAddress string, mintHash string, quantity int, priceKoinu int64) string {
 	invoicePayload := rpc.CreateInvoiceRequestPayload{
 		PaymentAddress: stackConfig.Address,
 		BuyerAddress:   buyerAddress,
 		MintHash:       mintHash,
 		Quantity:       quantity,
-		Price:          price,
+		PriceKoinu:     priceKoinu,
 		SellerAddress:  stackConfig.Address,
 	}
 
@@ -389,11 +389,11 @@ func Invoice(stackConfig *StackConfig, buyerAddress string, mintHash string, qua
 		panic(err)
 	}
 
-	envelope := protocol.NewInvoiceTransactionEnvelope(res.Hash, mintHash, int32(quantity), protocol.ACTION_INVOICE)
+	envelope := protocol.NewInvoiceTransactionEnvelope(res.Hash, mintHash, int32(quantity), 0, protocol.ACTION_INVOICE)
 	encodedTransactionBody := envelope.Serialize()
 
 	// just network fees
-	WriteToBlockchain(stackConfig, stackConfig.Address, hex.EncodeToString(encodedTransactionBody), int64(5))
+	WriteToBlockchain(stackConfig, stackConfig.Address, hex.EncodeToString(encodedTransactionBody), 5*koinu.OneDoge)
 
 	ConfirmBlocks(stackConfig)
 
@@ -404,7 +404,7 @@ func InvoiceSignature(stackConfig *StackConfig, invoice store.UnconfirmedInvoice
 	invoiceBody := store.InvoiceSignatureBody{
 		Hash:           invoice.Hash,
 		MintHash:       invoice.MintHash,
-		Price:          invoice.Price,
+		PriceKoinu:     invoice.PriceKoinu,
 		Quantity:       invoice.Quantity,
 		BuyerAddress:   invoice.BuyerAddress,
 		PaymentAddress: invoice.PaymentAddress,
@@ -475,7 +475,7 @@ func Mint(stackConfig *StackConfig) string {
 	encodedTransactionBody := envelope.Serialize()
 
 	// Only need 1 to cover network fees
-	WriteToBlockchain(stackConfig, stackConfig.Address, hex.EncodeToString(encodedTransactionBody), 5)
+	WriteToBlockchain(stackConfig, stackConfig.Address, hex.EncodeToString(encodedTransactionBody), 5*koinu.OneDoge)
 
 	ConfirmBlocks(stackConfig)
 
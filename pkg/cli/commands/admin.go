@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	fecli "dogecoin.org/fractal-engine/pkg/cli"
+	climodels "dogecoin.org/fractal-engine/pkg/cli/climodels"
+	"dogecoin.org/fractal-engine/pkg/client"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/urfave/cli/v3"
+)
+
+var adminFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "config-path",
+		Usage: "Path to the config file",
+		Value: "config.toml",
+	},
+	&cli.StringFlag{
+		Name:  "admin-api-key",
+		Usage: "Admin API key of the fractal engine, defaults to admin_api_key in the config file",
+	},
+}
+
+var AdminCommand = &cli.Command{
+	Name:  "admin",
+	Usage: "Operate on on-chain transactions stuck waiting for off-chain data",
+	Commands: []*cli.Command{
+		{
+			Name:   "stuck",
+			Usage:  "List transactions awaiting data or expired while waiting",
+			Action: listStuckTransactionsAction,
+			Flags:  adminFlags,
+		},
+		{
+			Name:   "reprocess",
+			Usage:  "Queue a stuck transaction to be processed again",
+			Action: reprocessStuckTransactionAction,
+			Flags:  adminFlags,
+		},
+		{
+			Name:   "supply-payload",
+			Usage:  "Supply the mint or invoice a stuck transaction is waiting for, from a JSON file",
+			Action: supplyStuckTransactionPayloadAction,
+			Flags:  adminFlags,
+		},
+		{
+			Name:   "ignore",
+			Usage:  "Mark a stuck transaction as ignored",
+			Action: ignoreStuckTransactionAction,
+			Flags:  adminFlags,
+		},
+		{
+			Name:   "audit-log",
+			Usage:  "List the actions taken through the admin API",
+			Action: adminAuditLogAction,
+			Flags:  adminFlags,
+		},
+	},
+}
+
+func getAdminClient(cmd *cli.Command) *client.TokenisationClient {
+	config, err := fecli.LoadConfig(cmd.String("config-path"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	adminApiKey := cmd.String("admin-api-key")
+	if adminApiKey == "" {
+		adminApiKey = config.AdminApiKey
+	}
+
+	url := fmt.Sprintf("http://%s:%s", config.FractalEngineHost, config.FractalEnginePort)
+
+	tokenisationClient := client.NewTokenisationClient(url, "", "")
+	tokenisationClient.SetAdminApiKey(adminApiKey)
+
+	return tokenisationClient
+}
+
+func listStuckTransactionsAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient := getAdminClient(cmd)
+
+	response, err := tokenisationClient.GetStuckTransactions(0, 100)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := []table.Row{}
+	for _, transaction := range response.Transactions {
+		rows = append(rows, table.Row{transaction.TxHash, strconv.FormatInt(transaction.Height, 10), transaction.Action, transaction.Status, transaction.ReasonCode, transaction.Reason})
+	}
+
+	stuckTable := climodels.CliTableModel{
+		Table: table.New(
+			table.WithColumns([]table.Column{
+				{Title: "Transaction Hash", Width: 64},
+				{Title: "Height", Width: 10},
+				{Title: "Action", Width: 12},
+				{Title: "Status", Width: 14},
+				{Title: "Reason Code", Width: 22},
+				{Title: "Reason", Width: 40},
+			}),
+		),
+	}
+
+	stuckTable.Table.SetRows(rows)
+
+	p := tea.NewProgram(stuckTable)
+	_, err = p.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return nil
+}
+
+// askAdminAction asks for the transaction to act on and the reason, which is written to the audit log.
+func askAdminAction() (string, string) {
+	var txHash string
+	var reason string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the transaction hash?").
+			Value(&txHash),
+		huh.NewInput().
+			Title("Why? (written to the audit log)").
+			Value(&reason),
+	)
+
+	form := huh.NewForm(group)
+	err := form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return txHash, reason
+}
+
+func printAdminActionResult(transaction store.OnChainTransactionOutcome) {
+	fmt.Printf("Transaction %s is %s", transaction.TxHash, transaction.Status)
+	if transaction.ReasonCode != "" {
+		fmt.Printf(" (%s)", transaction.ReasonCode)
+	}
+	fmt.Println()
+}
+
+func reprocessStuckTransactionAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient := getAdminClient(cmd)
+
+	txHash, reason := askAdminAction()
+
+	response, err := tokenisationClient.ReprocessStuckTransaction(txHash, reason)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printAdminActionResult(response.Transaction)
+	return nil
+}
+
+func supplyStuckTransactionPayloadAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient := getAdminClient(cmd)
+
+	var txHash string
+	var payloadPath string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the transaction hash?").
+			Value(&txHash),
+		huh.NewInput().
+			Title(`Path to the JSON payload ({"mint": {...}} or {"invoice": {...}})`).
+			Value(&payloadPath),
+	)
+
+	form := huh.NewForm(group)
+	err := form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	payload, err := os.ReadFile(payloadPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var request rpc.SupplyPayloadRequest
+	err = json.Unmarshal(payload, &request)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	response, err := tokenisationClient.SupplyStuckTransactionPayload(txHash, request)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printAdminActionResult(response.Transaction)
+	return nil
+}
+
+func ignoreStuckTransactionAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient := getAdminClient(cmd)
+
+	txHash, reason := askAdminAction()
+
+	response, err := tokenisationClient.IgnoreStuckTransaction(txHash, reason)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printAdminActionResult(response.Transaction)
+	return nil
+}
+
+func adminAuditLogAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient := getAdminClient(cmd)
+
+	response, err := tokenisationClient.GetAdminAuditLog(0, 100)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rows := []table.Row{}
+	for _, entry := range response.Entries {
+		rows = append(rows, table.Row{entry.CreatedAt.Format("2006-01-02 15:04:05"), entry.Action, entry.TxHash, entry.RemoteAddress, entry.Detail})
+	}
+
+	auditTable := climodels.CliTableModel{
+		Table: table.New(
+			table.WithColumns([]table.Column{
+				{Title: "Time", Width: 20},
+				{Title: "Action", Width: 16},
+				{Title: "Transaction Hash", Width: 64},
+				{Title: "From", Width: 22},
+				{Title: "Detail", Width: 40},
+			}),
+		),
+	}
+
+	auditTable.Table.SetRows(rows)
+
+	p := tea.NewProgram(auditTable)
+	_, err = p.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	fecli "dogecoin.org/fractal-engine/pkg/cli"
+	"dogecoin.org/fractal-engine/pkg/cli/keys"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"github.com/charmbracelet/huh"
+	"github.com/dogeorg/doge/koinu"
+	"github.com/urfave/cli/v3"
+)
+
+var DistributionsCommand = &cli.Command{
+	Name:  "distributions",
+	Usage: "Manage distributions to token holders",
+	Commands: []*cli.Command{
+		{
+			Name:   "create",
+			Usage:  "Publish a pro rata distribution to the holders of a mint you own",
+			Action: createDistributionAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config-path",
+					Usage: "Path to the config file",
+					Value: "config.toml",
+				},
+			},
+		},
+		{
+			Name:   "pay",
+			Usage:  "Pay the unpaid holders of a distribution in a single transaction",
+			Action: payDistributionAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config-path",
+					Usage: "Path to the config file",
+					Value: "config.toml",
+				},
+			},
+		},
+	},
+}
+
+func createDistributionAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient, err := getTokenisationClient(ctx, cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config, err := fecli.LoadConfig(cmd.String("config-path"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secureStore := keys.NewSecureStore()
+
+	privHex, err := secureStore.Get(config.ActiveKey + "_private_key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	address, err := secureStore.Get(config.ActiveKey + "_address")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chain, err := secureStore.Get(config.ActiveKey + "_chain")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chainByte, err := doge.GetPrefix(chain)
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainCfg := doge.GetChainCfg(chainByte)
+
+	var mintHash string
+	var total string
+	var recordHeight string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the Mint Hash?").
+			Value(&mintHash),
+		huh.NewInput().
+			Title("How much DOGE to distribute?").
+			Value(&total).
+			Validate(func(s string) error {
+				if _, err := koinu.ParseKoinu(s); err != nil {
+					return errors.New("amount must be a DOGE value")
+				}
+				return nil
+			}),
+		huh.NewInput().
+			Title("At which block height are holders recorded?").
+			Value(&recordHeight).
+			Validate(func(s string) error {
+				if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+					return errors.New("record height must be a number")
+				}
+				return nil
+			}),
+	)
+
+	form := huh.NewForm(group)
+	err = form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	totalKoinu, _ := koinu.ParseKoinu(total)
+	recordHeightInt, _ := strconv.ParseInt(recordHeight, 10, 64)
+
+	distributionResponse, err := tokenisationClient.CreateDistribution(&rpc.CreateDistributionRequest{
+		Payload: rpc.CreateDistributionRequestPayload{
+			MintHash:     mintHash,
+			OwnerAddress: address,
+			TotalKoinu:   int64(totalKoinu),
+			RecordHeight: recordHeightInt,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, payout := range distributionResponse.Payouts {
+		fmt.Printf("%s: %d fractions, %s DOGE\n", payout.Address, payout.Fractions, koinu.Koinu(payout.AmountKoinu))
+	}
+
+	txid, err := sendDataTransaction(config, privHex, address, chainCfg, distributionResponse.EncodedTransactionBody)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Distribution sent:", txid)
+	fmt.Println("Once confirmed, pay it out with: distributions pay", txid)
+
+	return nil
+}
+
+func payDistributionAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient, err := getTokenisationClient(ctx, cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	config, err := fecli.LoadConfig(cmd.String("config-path"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secureStore := keys.NewSecureStore()
+
+	privHex, err := secureStore.Get(config.ActiveKey + "_private_key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	address, err := secureStore.Get(config.ActiveKey + "_address")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chain, err := secureStore.Get(config.ActiveKey + "_chain")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chainByte, err := doge.GetPrefix(chain)
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainCfg := doge.GetChainCfg(chainByte)
+
+	distributionHash := cmd.Args().First()
+	if distributionHash == "" {
+		group := huh.NewGroup(
+			huh.NewInput().
+				Title("What is the Distribution Hash?").
+				Value(&distributionHash),
+		)
+
+		err = huh.NewForm(group).Run()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	payoutTransaction, err := tokenisationClient.GetDistributionPayoutTransaction(distributionHash)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	payments := map[string]koinu.Koinu{}
+	for _, output := range payoutTransaction.Outputs {
+		payments[output.Address] = koinu.Koinu(output.AmountKoinu)
+	}
+
+	fmt.Printf("Paying %d holders a total of %s DOGE\n", len(payments), koinu.Koinu(payoutTransaction.TotalKoinu))
+	if payoutTransaction.DustKoinu > 0 {
+		fmt.Printf("Skipping %s DOGE owed to holders below the dust limit\n", koinu.Koinu(payoutTransaction.DustKoinu))
+	}
+
+	txid, err := sendDataTransactionWithPayments(config, privHex, address, chainCfg, payoutTransaction.EncodedTransactionBody, payments)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Distribution payout sent:", txid)
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/rpc"
 	"github.com/charmbracelet/huh"
+	"github.com/dogeorg/doge/koinu"
 	"github.com/urfave/cli/v3"
 )
 
@@ -47,6 +48,18 @@ var InvoiceCommand = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:   "cancel",
+			Usage:  "Cancel an unpaid invoice and release its reserved fractions",
+			Action: cancelInvoiceAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config-path",
+					Usage: "Path to the config file",
+					Value: "config.toml",
+				},
+			},
+		},
 		{
 			Name:   "pay",
 			Usage:  "Pay an invoice",
@@ -119,6 +132,7 @@ func createInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 	var buyerAddress string
 	var quantity string
 	var pricePer string
+	var expiryBlocks string
 
 	group := huh.NewGroup(
 		huh.NewInput().
@@ -131,8 +145,11 @@ func createInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 			Title("What is the quantity?").
 			Value(&quantity),
 		huh.NewInput().
-			Title("What is the price per?").
+			Title("What is the price per fraction (DOGE)?").
 			Value(&pricePer),
+		huh.NewInput().
+			Title("How many blocks until the invoice expires? (leave blank for no expiry)").
+			Value(&expiryBlocks),
 	)
 
 	form := huh.NewForm(group)
@@ -167,19 +184,35 @@ func createInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 		log.Fatal(err)
 	}
 
-	pricePerInt, err := strconv.Atoi(pricePer)
+	pricePerKoinu, err := koinu.ParseKoinu(pricePer)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	var expiryHeight int64
+	if expiryBlocks != "" {
+		blocks, err := strconv.ParseInt(expiryBlocks, 10, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		health, err := tokenisationClient.GetHealth()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		expiryHeight = health.CurrentBlockHeight + blocks
+	}
+
 	invoiceRequest := rpc.CreateInvoiceRequest{
 		Payload: rpc.CreateInvoiceRequestPayload{
 			PaymentAddress: address,
 			BuyerAddress:   buyerAddress,
 			MintHash:       mintHash,
 			Quantity:       quantityInt,
-			Price:          pricePerInt,
+			PriceKoinu:     int64(pricePerKoinu),
 			SellerAddress:  address,
+			ExpiryHeight:   expiryHeight,
 		},
 	}
 
@@ -215,7 +248,7 @@ func createInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 		log.Fatal("No utxos found for address", address)
 	}
 
-	envelope := protocol.NewInvoiceTransactionEnvelope(response.Hash, mintHash, int32(quantityInt), protocol.ACTION_INVOICE)
+	envelope := protocol.NewInvoiceTransactionEnvelope(response.Hash, mintHash, int32(quantityInt), expiryHeight, protocol.ACTION_INVOICE)
 	encodedTransactionBody := envelope.Serialize()
 
 	inputs := []interface{}{
@@ -278,3 +311,68 @@ func createInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+func cancelInvoiceAction(ctx context.Context, cmd *cli.Command) error {
+	configPath := cmd.String("config-path")
+
+	config, err := fecli.LoadConfig(configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokenisationClient, err := getTokenisationClient(ctx, cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secureStore := keys.NewSecureStore()
+
+	privHex, err := secureStore.Get(config.ActiveKey + "_private_key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	address, err := secureStore.Get(config.ActiveKey + "_address")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chain, err := secureStore.Get(config.ActiveKey + "_chain")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chainByte, err := doge.GetPrefix(chain)
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainCfg := doge.GetChainCfg(chainByte)
+
+	var invoiceHash string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the invoice hash?").
+			Value(&invoiceHash),
+	)
+
+	form := huh.NewForm(group)
+	err = form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cancellationResponse, err := tokenisationClient.CreateInvoiceCancellation(invoiceHash)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	txid, err := sendDataTransaction(config, privHex, address, chainCfg, cancellationResponse.EncodedTransactionBody)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Invoice cancellation sent:", txid)
+
+	return nil
+}
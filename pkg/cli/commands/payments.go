@@ -3,21 +3,15 @@ package commands
 import (
 	"context"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
 
 	fecli "dogecoin.org/fractal-engine/pkg/cli"
-	climodels "dogecoin.org/fractal-engine/pkg/cli/climodels"
 	"dogecoin.org/fractal-engine/pkg/cli/keys"
-	fecfg "dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/doge"
-	"dogecoin.org/fractal-engine/pkg/indexer"
 	"dogecoin.org/fractal-engine/pkg/protocol"
-	"dogecoin.org/fractal-engine/pkg/store"
-	"github.com/charmbracelet/bubbles/list"
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/dogeorg/doge/koinu"
 	"github.com/urfave/cli/v3"
@@ -29,7 +23,7 @@ var PaymentsCommand = &cli.Command{
 	Commands: []*cli.Command{
 		{
 			Name:   "pay-invoice",
-			Usage:  "Pay an invoice",
+			Usage:  "Pay one or more invoices in a single transaction",
 			Action: payInvoiceAction,
 			Flags: []cli.Flag{
 				&cli.StringFlag{
@@ -97,127 +91,59 @@ func payInvoiceAction(ctx context.Context, cmd *cli.Command) error {
 		log.Fatal(err)
 	}
 
-	items := []list.Item{}
+	options := []huh.Option[string]{}
 	for _, invoice := range invoices.Invoices {
-		items = append(items, climodels.SelectSimpleListItem{
-			OfferId: invoice.Id,
-			Name:    "Invoice: " + invoice.Hash + " (Seller: " + invoice.SellerAddress + ")",
-			Desc:    "Price: " + strconv.Itoa(invoice.Price) + " Qty: " + strconv.Itoa(invoice.Quantity),
-		})
-	}
-
-	m := climodels.SelectSimpleListModel{List: list.New(items, list.NewDefaultDelegate(), 0, 0)}
-	m.List.Title = "Invoices"
-
-	p := tea.NewProgram(m)
-	_, err = p.Run()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	selectedInvoiceId := m.List.SelectedItem().(climodels.SelectSimpleListItem)
-
-	var selectedInvoice store.Invoice
-	for _, invoice := range invoices.Invoices {
-		if invoice.Id == selectedInvoiceId.OfferId {
-			selectedInvoice = invoice
-			break
+		// Partial payments accumulate, so invoices are listed until nothing is owed
+		if invoice.TotalKoinu()-invoice.PaidKoinu <= 0 {
+			continue
 		}
-	}
-
-	indexerClient := indexer.NewIndexerClient(config.IndexerURL)
-
-	utxos, err := indexerClient.GetUTXO(address)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	if len(utxos.UTXOs) == 0 {
-		log.Fatal("No utxos found for address", address)
-	}
-
-	envelope := protocol.NewPaymentTransactionEnvelope(selectedInvoice.Hash, protocol.ACTION_PAYMENT)
-	encodedTransactionBody := envelope.Serialize()
-
-	inputs := []interface{}{
-		map[string]interface{}{
-			"txid": utxos.UTXOs[0].TxID,
-			"vout": utxos.UTXOs[0].VOut,
-		},
-	}
-
-	dogeUtxoValue := utxos.UTXOs[0].Value
-	buyOfferValue := koinu.Koinu(selectedInvoice.Quantity * selectedInvoice.Price)
-	fee, err := koinu.ParseKoinu("0.002")
-
-	if err != nil {
-		log.Fatal("Failed to parse fee value", err)
-	}
 
-	if dogeUtxoValue < buyOfferValue {
-		log.Fatal("Insufficient balance for invoice", selectedInvoice.Hash)
+		label := "Invoice: " + invoice.Hash + " (Seller: " + invoice.SellerAddress + ") Price: " + koinu.Koinu(invoice.PriceKoinu).String() + " DOGE Qty: " + strconv.Itoa(invoice.Quantity) + " (" + string(invoice.PaymentStatus) + ")"
+		options = append(options, huh.NewOption(label, invoice.Hash))
 	}
 
-	change := dogeUtxoValue - buyOfferValue - fee
-	sellerAddress := selectedInvoice.SellerAddress
-
-	outputs := map[string]interface{}{
-		"data": hex.EncodeToString(encodedTransactionBody),
-	}
-
-	if address == sellerAddress {
-		outputs[address] = change
-	} else {
-		outputs[address] = change
-		outputs[sellerAddress] = buyOfferValue
+	if len(options) == 0 {
+		log.Fatal("No unpaid invoices found for token", mintHash)
 	}
 
-	fmt.Println(outputs[address])
-	fmt.Println(outputs[sellerAddress])
+	var selectedHashes []string
 
-	dogeClient := doge.NewRpcClient(&fecfg.Config{
-		DogeScheme:   config.DogeScheme,
-		DogeHost:     config.DogeHost,
-		DogePort:     config.DogePort,
-		DogeUser:     config.DogeUser,
-		DogePassword: config.DogePassword,
-	})
-
-	rawTx, err := dogeClient.Request("createrawtransaction", []interface{}{inputs, outputs})
+	err = huh.NewForm(huh.NewGroup(
+		huh.NewMultiSelect[string]().
+			Title("Which invoices do you want to pay?").
+			Description("Invoices selected together are paid in one transaction").
+			Options(options...).
+			Value(&selectedHashes),
+	)).Run()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	var rawTxResponse string
-	if err := json.Unmarshal(*rawTx, &rawTxResponse); err != nil {
-		log.Fatal(err)
+	if len(selectedHashes) == 0 {
+		log.Fatal("No invoices selected")
 	}
 
-	fmt.Println("rawTxResponse", rawTxResponse)
+	payments := map[string]koinu.Koinu{}
+	for _, invoice := range invoices.Invoices {
+		if !slices.Contains(selectedHashes, invoice.Hash) {
+			continue
+		}
 
-	encodedTx, err := doge.SignRawTransaction(rawTxResponse, privHex, []doge.PrevOutput{
-		{
-			Address: address,
-			Amount:  int64(utxos.UTXOs[0].Value),
-		},
-	}, chainCfg)
+		// Only what is still owed is paid, each payment address receiving one output for all of its invoices
+		payments[invoice.PayableAddress()] += koinu.Koinu(invoice.TotalKoinu() - invoice.PaidKoinu)
+	}
 
-	if err != nil {
-		log.Fatal(err)
+	envelope := protocol.NewPaymentTransactionEnvelope(selectedHashes[0], protocol.ACTION_PAYMENT)
+	if len(selectedHashes) > 1 {
+		envelope = protocol.NewBatchPaymentTransactionEnvelope(selectedHashes, protocol.ACTION_BATCH_PAYMENT)
 	}
 
-	res, err := dogeClient.Request("sendrawtransaction", []interface{}{encodedTx})
+	txid, err := sendDataTransactionWithPayments(config, privHex, address, chainCfg, hex.EncodeToString(envelope.Serialize()), payments)
 	if err != nil {
-		log.Println("error sending raw transaction", err)
 		return err
 	}
 
-	var txid string
-
-	if err := json.Unmarshal(*res, &txid); err != nil {
-		log.Println("error parsing send raw transaction response", err)
-		return err
-	}
+	fmt.Println("Payment sent:", txid)
 
 	return nil
 }
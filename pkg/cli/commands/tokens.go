@@ -2,15 +2,25 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"strconv"
 
 	fecli "dogecoin.org/fractal-engine/pkg/cli"
 	climodels "dogecoin.org/fractal-engine/pkg/cli/climodels"
 	"dogecoin.org/fractal-engine/pkg/cli/keys"
+	fecfg "dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/indexer"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/validation"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
+	"github.com/dogeorg/doge/koinu"
 	"github.com/urfave/cli/v3"
 )
 
@@ -30,6 +40,30 @@ var TokensCommand = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:   "transfer",
+			Usage:  "Transfer tokens to another address",
+			Action: transferTokensAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config-path",
+					Usage: "Path to the config file",
+					Value: "config.toml",
+				},
+			},
+		},
+		{
+			Name:   "burn",
+			Usage:  "Burn tokens, removing them from circulation",
+			Action: burnTokensAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "config-path",
+					Usage: "Path to the config file",
+					Value: "config.toml",
+				},
+			},
+		},
 	},
 }
 
@@ -96,3 +130,284 @@ func listTokensAction(ctx context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+func transferTokensAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient, err := getTokenisationClient(ctx, cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	configPath := cmd.String("config-path")
+
+	config, err := fecli.LoadConfig(configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secureStore := keys.NewSecureStore()
+
+	privHex, err := secureStore.Get(config.ActiveKey + "_private_key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	address, err := secureStore.Get(config.ActiveKey + "_address")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chain, err := secureStore.Get(config.ActiveKey + "_chain")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chainByte, err := doge.GetPrefix(chain)
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainCfg := doge.GetChainCfg(chainByte)
+
+	var mintHash string
+	var toAddress string
+	var quantity string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the Mint Hash?").
+			Value(&mintHash),
+		huh.NewInput().
+			Title("Which address should receive the tokens?").
+			Value(&toAddress).
+			Validate(validation.ValidateAddress),
+		huh.NewInput().
+			Title("How many tokens?").
+			Value(&quantity).
+			Validate(func(s string) error {
+				if _, err := strconv.Atoi(s); err != nil {
+					return errors.New("quantity must be a number")
+				}
+				return nil
+			}),
+	)
+
+	form := huh.NewForm(group)
+	err = form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	quantityInt, _ := strconv.Atoi(quantity)
+
+	transferResponse, err := tokenisationClient.CreateTransfer(&rpc.CreateTransferRequest{
+		Payload: rpc.CreateTransferRequestPayload{
+			MintHash:    mintHash,
+			FromAddress: address,
+			ToAddress:   toAddress,
+			Quantity:    quantityInt,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	txid, err := sendDataTransaction(config, privHex, address, chainCfg, transferResponse.EncodedTransactionBody)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Transfer sent:", txid)
+
+	return nil
+}
+
+func burnTokensAction(ctx context.Context, cmd *cli.Command) error {
+	tokenisationClient, err := getTokenisationClient(ctx, cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	configPath := cmd.String("config-path")
+
+	config, err := fecli.LoadConfig(configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	secureStore := keys.NewSecureStore()
+
+	privHex, err := secureStore.Get(config.ActiveKey + "_private_key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	address, err := secureStore.Get(config.ActiveKey + "_address")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chain, err := secureStore.Get(config.ActiveKey + "_chain")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	chainByte, err := doge.GetPrefix(chain)
+	if err != nil {
+		log.Fatal(err)
+	}
+	chainCfg := doge.GetChainCfg(chainByte)
+
+	var mintHash string
+	var quantity string
+
+	group := huh.NewGroup(
+		huh.NewInput().
+			Title("What is the Mint Hash?").
+			Value(&mintHash),
+		huh.NewInput().
+			Title("How many tokens?").
+			Value(&quantity).
+			Validate(func(s string) error {
+				if _, err := strconv.Atoi(s); err != nil {
+					return errors.New("quantity must be a number")
+				}
+				return nil
+			}),
+	)
+
+	form := huh.NewForm(group)
+	err = form.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	quantityInt, _ := strconv.Atoi(quantity)
+
+	burnResponse, err := tokenisationClient.CreateBurn(&rpc.CreateBurnRequest{
+		Payload: rpc.CreateBurnRequestPayload{
+			MintHash: mintHash,
+			Address:  address,
+			Quantity: quantityInt,
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	txid, err := sendDataTransaction(config, privHex, address, chainCfg, burnResponse.EncodedTransactionBody)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Burn sent:", txid)
+
+	if burnResponse.Hash != "" {
+		fmt.Println("Burn requires asset manager signatures for hash:", burnResponse.Hash)
+	}
+
+	return nil
+}
+
+// sendDataTransaction spends utxos of address with the encoded body as a data output.
+// The change output goes back to the sender, which proves the sender on chain.
+func sendDataTransaction(config *fecli.Config, privHex string, address string, chainCfg *chaincfg.Params, encodedTransactionBody string) (string, error) {
+	return sendDataTransactionWithPayments(config, privHex, address, chainCfg, encodedTransactionBody, nil)
+}
+
+// sendDataTransactionWithPayments is sendDataTransaction with additional payment outputs.
+// Utxos of address are spent in order until they cover the payments and the fee.
+func sendDataTransactionWithPayments(config *fecli.Config, privHex string, address string, chainCfg *chaincfg.Params, encodedTransactionBody string, payments map[string]koinu.Koinu) (string, error) {
+	indexerClient := indexer.NewIndexerClient(config.IndexerURL)
+
+	utxos, err := indexerClient.GetUTXO(address)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(utxos.UTXOs) == 0 {
+		log.Fatal("No utxos found for address", address)
+	}
+
+	fee, err := koinu.ParseKoinu("0.002")
+	if err != nil {
+		log.Fatal("Failed to parse fee value", err)
+	}
+
+	outputs := map[string]interface{}{
+		"data": encodedTransactionBody,
+	}
+
+	required := fee
+	selfPayment := koinu.Koinu(0)
+	for paymentAddress, amount := range payments {
+		// A payment to the sender is folded into the change output
+		if paymentAddress == address {
+			selfPayment += amount
+		} else {
+			outputs[paymentAddress] = amount
+		}
+		required += amount
+	}
+
+	inputs := []interface{}{}
+	prevOutputs := []doge.PrevOutput{}
+	total := koinu.Koinu(0)
+	for _, utxo := range utxos.UTXOs {
+		if total > required {
+			break
+		}
+
+		inputs = append(inputs, map[string]interface{}{
+			"txid": utxo.TxID,
+			"vout": utxo.VOut,
+		})
+		prevOutputs = append(prevOutputs, doge.PrevOutput{
+			Address: address,
+			Amount:  int64(utxo.Value),
+		})
+		total += utxo.Value
+	}
+
+	if total <= required {
+		log.Fatal("Insufficient balance for transaction", address)
+	}
+
+	outputs[address] = total - required + selfPayment
+
+	dogeClient := doge.NewRpcClient(&fecfg.Config{
+		DogeScheme:   config.DogeScheme,
+		DogeHost:     config.DogeHost,
+		DogePort:     config.DogePort,
+		DogeUser:     config.DogeUser,
+		DogePassword: config.DogePassword,
+	})
+
+	rawTx, err := dogeClient.Request("createrawtransaction", []interface{}{inputs, outputs})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var rawTxResponse string
+	if err := json.Unmarshal(*rawTx, &rawTxResponse); err != nil {
+		log.Fatal(err)
+	}
+
+	encodedTx, err := doge.SignRawTransaction(rawTxResponse, privHex, prevOutputs, chainCfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	res, err := dogeClient.Request("sendrawtransaction", []interface{}{encodedTx})
+	if err != nil {
+		log.Println("error sending raw transaction", err)
+		return "", err
+	}
+
+	var txid string
+
+	if err := json.Unmarshal(*res, &txid); err != nil {
+		log.Println("error parsing send raw transaction response", err)
+		return "", err
+	}
+
+	return txid, nil
+}
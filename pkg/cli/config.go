@@ -17,6 +17,7 @@ type Config struct {
 	DogePassword      string   `toml:"doge_password"`
 	KeyLabels         []string `toml:"key_labels"`
 	ActiveKey         string   `toml:"active_key"`
+	AdminApiKey       string   `toml:"admin_api_key,omitempty"`
 }
 
 func SaveConfig(config *Config, path string) error {
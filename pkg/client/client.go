@@ -14,10 +14,11 @@ import (
 )
 
 type TokenisationClient struct {
-	baseUrl    string
-	httpClient *http.Client
-	privHex    string
-	pubHex     string
+	baseUrl     string
+	httpClient  *http.Client
+	privHex     string
+	pubHex      string
+	adminApiKey string
 }
 
 func NewTokenisationClient(baseUrl string, privHex string, pubHex string) *TokenisationClient {
@@ -25,6 +26,11 @@ func NewTokenisationClient(baseUrl string, privHex string, pubHex string) *Token
 	return &TokenisationClient{baseUrl: baseUrl, httpClient: httpClient, privHex: privHex, pubHex: pubHex}
 }
 
+// SetAdminApiKey sets the key sent with requests to the /admin routes.
+func (c *TokenisationClient) SetAdminApiKey(adminApiKey string) {
+	c.adminApiKey = adminApiKey
+}
+
 func (c *TokenisationClient) CreateInvoice(invoice *rpc.CreateInvoiceRequest) (rpc.CreateInvoiceResponse, error) {
 	signature, err := doge.SignPayload(invoice.Payload, c.privHex, c.pubHex)
 	if err != nil {
@@ -63,6 +69,29 @@ func (c *TokenisationClient) CreateInvoice(invoice *rpc.CreateInvoiceRequest) (r
 	return result, nil
 }
 
+func (c *TokenisationClient) CreateInvoiceCancellation(invoiceHash string) (rpc.CreateInvoiceCancellationResponse, error) {
+	resp, err := c.httpClient.Post(c.baseUrl+"/invoices/"+invoiceHash+"/cancellation", "application/json", nil)
+	if err != nil {
+		return rpc.CreateInvoiceCancellationResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateInvoiceCancellationResponse{}, fmt.Errorf("failed to create invoice cancellation: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rpc.CreateInvoiceCancellationResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateInvoiceCancellationResponse{}, err
+	}
+
+	return result, nil
+}
+
 func (c *TokenisationClient) GetInvoices(page int, limit int, mintHash string, address string) (rpc.GetInvoicesResponse, error) {
 	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/invoices/%s?page=%d&limit=%d&mint_hash=%s", address, page, limit, mintHash))
 	if err != nil {
@@ -429,6 +458,29 @@ func (c *TokenisationClient) GetTokenBalance(address string, mintHash string) ([
 	return result, nil
 }
 
+func (c *TokenisationClient) GetLockedTokenBalances(address string, mintHash string) (rpc.GetLockedTokenBalancesResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/locked-token-balances/%s?mint_hash=%s", address, mintHash))
+	if err != nil {
+		return rpc.GetLockedTokenBalancesResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetLockedTokenBalancesResponse{}, fmt.Errorf("failed to get locked token balances: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetLockedTokenBalancesResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetLockedTokenBalancesResponse{}, err
+	}
+
+	return result, nil
+}
+
 func (c *TokenisationClient) GetTokenBalanceWithMintDetails(address string) (rpc.GetTokenBalanceWithMintsResponse, error) {
 	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/token-balances/%s?include_mint_details=true", address))
 	if err != nil {
@@ -532,3 +584,622 @@ func (c *TokenisationClient) CreateInvoiceSignature(signature *rpc.CreateInvoice
 
 	return result, nil
 }
+
+func (c *TokenisationClient) CreateTransfer(transfer *rpc.CreateTransferRequest) (rpc.CreateTransferResponse, error) {
+	jsonValue, err := json.Marshal(transfer)
+	if err != nil {
+		return rpc.CreateTransferResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/transfers", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateTransferResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateTransferResponse{}, fmt.Errorf("failed to create transfer: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rpc.CreateTransferResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateTransferResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateBurn(burn *rpc.CreateBurnRequest) (rpc.CreateBurnResponse, error) {
+	jsonValue, err := json.Marshal(burn)
+	if err != nil {
+		return rpc.CreateBurnResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/burns", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateBurnResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateBurnResponse{}, fmt.Errorf("failed to create burn: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rpc.CreateBurnResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateBurnResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateBurnSignature(burnHash string, signature *rpc.CreateBurnSignatureRequest) (rpc.CreateBurnSignatureResponse, error) {
+	jsonValue, err := json.Marshal(signature)
+	if err != nil {
+		return rpc.CreateBurnSignatureResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/burns/"+burnHash+"/signatures", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateBurnSignatureResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateBurnSignatureResponse{}, fmt.Errorf("failed to create burn signature: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rpc.CreateBurnSignatureResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateBurnSignatureResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetMintAllowlist(mintHash string) (rpc.GetMintAllowlistResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/mints/" + mintHash + "/allowlist")
+	if err != nil {
+		return rpc.GetMintAllowlistResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetMintAllowlistResponse{}, fmt.Errorf("failed to get mint allowlist: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetMintAllowlistResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetMintAllowlistResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateMintAllowlistEntry(mintHash string, entry *rpc.CreateMintAllowlistEntryRequest) (rpc.CreateMintAllowlistEntryResponse, error) {
+	jsonValue, err := json.Marshal(entry)
+	if err != nil {
+		return rpc.CreateMintAllowlistEntryResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/mints/"+mintHash+"/allowlist", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateMintAllowlistEntryResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateMintAllowlistEntryResponse{}, fmt.Errorf("failed to create allowlist entry: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.CreateMintAllowlistEntryResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateMintAllowlistEntryResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetOnChainTransactions(status string, txHash string, page int, limit int) (rpc.GetOnChainTransactionsResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/onchain-transactions?status=%s&tx_hash=%s&page=%d&limit=%d", status, txHash, page, limit))
+	if err != nil {
+		return rpc.GetOnChainTransactionsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetOnChainTransactionsResponse{}, fmt.Errorf("failed to get onchain transactions: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetOnChainTransactionsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetOnChainTransactionsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetMempoolTransactions(hash string, subject string, address string, page int, limit int) (rpc.GetMempoolTransactionsResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/mempool-transactions?hash=%s&subject=%s&address=%s&page=%d&limit=%d", hash, subject, address, page, limit))
+	if err != nil {
+		return rpc.GetMempoolTransactionsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetMempoolTransactionsResponse{}, fmt.Errorf("failed to get mempool transactions: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetMempoolTransactionsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetMempoolTransactionsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetTradeRejections(mintHash string, invoiceHash string) (rpc.GetTradeRejectionsResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/trade-rejections?mint_hash=%s&invoice_hash=%s", mintHash, invoiceHash))
+	if err != nil {
+		return rpc.GetTradeRejectionsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetTradeRejectionsResponse{}, fmt.Errorf("failed to get trade rejections: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetTradeRejectionsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetTradeRejectionsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateDistribution(distribution *rpc.CreateDistributionRequest) (rpc.CreateDistributionResponse, error) {
+	jsonValue, err := json.Marshal(distribution)
+	if err != nil {
+		return rpc.CreateDistributionResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/distributions", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateDistributionResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateDistributionResponse{}, fmt.Errorf("failed to create distribution: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var result rpc.CreateDistributionResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateDistributionResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetDistributions(page int, limit int, mintHash string) (rpc.GetDistributionsResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/distributions?page=%d&limit=%d&mint_hash=%s", page, limit, mintHash))
+	if err != nil {
+		return rpc.GetDistributionsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetDistributionsResponse{}, fmt.Errorf("failed to get distributions: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetDistributionsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetDistributionsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetDistribution(hash string) (rpc.GetDistributionResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/distributions/" + hash)
+	if err != nil {
+		return rpc.GetDistributionResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetDistributionResponse{}, fmt.Errorf("failed to get distribution: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetDistributionResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetDistributionResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetDistributionPayoutTransaction(hash string) (rpc.GetDistributionPayoutTransactionResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/distributions/" + hash + "/payout-transaction")
+	if err != nil {
+		return rpc.GetDistributionPayoutTransactionResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.GetDistributionPayoutTransactionResponse{}, fmt.Errorf("failed to get distribution payout transaction: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetDistributionPayoutTransactionResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetDistributionPayoutTransactionResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateMintAmendment(mintHash string, amendment *rpc.CreateMintAmendmentRequest) (rpc.CreateMintAmendmentResponse, error) {
+	jsonValue, err := json.Marshal(amendment)
+	if err != nil {
+		return rpc.CreateMintAmendmentResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/mints/"+mintHash+"/amendments", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateMintAmendmentResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateMintAmendmentResponse{}, fmt.Errorf("failed to create mint amendment: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.CreateMintAmendmentResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateMintAmendmentResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetMintAmendments(mintHash string) (rpc.GetMintAmendmentsResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/mints/" + mintHash + "/amendments")
+	if err != nil {
+		return rpc.GetMintAmendmentsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetMintAmendmentsResponse{}, fmt.Errorf("failed to get mint amendments: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetMintAmendmentsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetMintAmendmentsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateAssetManagerRotation(mintHash string, rotation *rpc.CreateAssetManagerRotationRequest) (rpc.CreateAssetManagerRotationResponse, error) {
+	jsonValue, err := json.Marshal(rotation)
+	if err != nil {
+		return rpc.CreateAssetManagerRotationResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/mints/"+mintHash+"/asset-managers", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateAssetManagerRotationResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateAssetManagerRotationResponse{}, fmt.Errorf("failed to create asset manager rotation: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.CreateAssetManagerRotationResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateAssetManagerRotationResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetAssetManagers(mintHash string) (rpc.GetAssetManagersResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/mints/" + mintHash + "/asset-managers")
+	if err != nil {
+		return rpc.GetAssetManagersResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetAssetManagersResponse{}, fmt.Errorf("failed to get asset managers: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetAssetManagersResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetAssetManagersResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) CreateMintOwnershipTransfer(mintHash string, request *rpc.CreateMintOwnershipTransferRequest) (rpc.CreateMintOwnershipTransferResponse, error) {
+	jsonValue, err := json.Marshal(request)
+	if err != nil {
+		return rpc.CreateMintOwnershipTransferResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/mints/"+mintHash+"/owners", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.CreateMintOwnershipTransferResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.CreateMintOwnershipTransferResponse{}, fmt.Errorf("failed to create mint ownership transfer: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.CreateMintOwnershipTransferResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.CreateMintOwnershipTransferResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) AcceptMintOwnershipTransfer(mintHash string, transferHash string, request *rpc.AcceptMintOwnershipTransferRequest) (rpc.AcceptMintOwnershipTransferResponse, error) {
+	jsonValue, err := json.Marshal(request)
+	if err != nil {
+		return rpc.AcceptMintOwnershipTransferResponse{}, err
+	}
+
+	resp, err := c.httpClient.Post(c.baseUrl+"/mints/"+mintHash+"/owners/"+transferHash+"/accept", "application/json", bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return rpc.AcceptMintOwnershipTransferResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.AcceptMintOwnershipTransferResponse{}, fmt.Errorf("failed to accept mint ownership transfer: %s", string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.AcceptMintOwnershipTransferResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.AcceptMintOwnershipTransferResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetMintOwners(mintHash string) (rpc.GetMintOwnersResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + "/mints/" + mintHash + "/owners")
+	if err != nil {
+		return rpc.GetMintOwnersResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetMintOwnersResponse{}, fmt.Errorf("failed to get mint owners: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetMintOwnersResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetMintOwnersResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetBatchPaymentOutcomes(txHash string, invoiceHash string) (rpc.GetBatchPaymentOutcomesResponse, error) {
+	resp, err := c.httpClient.Get(c.baseUrl + fmt.Sprintf("/payments/batch/outcomes?tx_hash=%s&invoice_hash=%s", txHash, invoiceHash))
+	if err != nil {
+		return rpc.GetBatchPaymentOutcomesResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rpc.GetBatchPaymentOutcomesResponse{}, fmt.Errorf("failed to get batch payment outcomes: %s", resp.Status)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetBatchPaymentOutcomesResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetBatchPaymentOutcomesResponse{}, err
+	}
+
+	return result, nil
+}
+
+// adminRequest sends a request to an /admin route with the admin API key.
+func (c *TokenisationClient) adminRequest(method string, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		jsonValue, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(jsonValue)
+	}
+
+	req, err := http.NewRequest(method, c.baseUrl+path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.adminApiKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.httpClient.Do(req)
+}
+
+func (c *TokenisationClient) GetStuckTransactions(page int, limit int) (rpc.GetStuckTransactionsResponse, error) {
+	resp, err := c.adminRequest(http.MethodGet, fmt.Sprintf("/admin/stuck-transactions?page=%d&limit=%d", page, limit), nil)
+	if err != nil {
+		return rpc.GetStuckTransactionsResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.GetStuckTransactionsResponse{}, fmt.Errorf("failed to get stuck transactions: %s %s", resp.Status, string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetStuckTransactionsResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetStuckTransactionsResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) ReprocessStuckTransaction(txHash string, reason string) (rpc.AdminActionResponse, error) {
+	return c.postAdminAction("/admin/stuck-transactions/"+txHash+"/reprocess", rpc.AdminActionRequest{Reason: reason})
+}
+
+func (c *TokenisationClient) SupplyStuckTransactionPayload(txHash string, request rpc.SupplyPayloadRequest) (rpc.AdminActionResponse, error) {
+	return c.postAdminAction("/admin/stuck-transactions/"+txHash+"/payload", request)
+}
+
+func (c *TokenisationClient) IgnoreStuckTransaction(txHash string, reason string) (rpc.AdminActionResponse, error) {
+	return c.postAdminAction("/admin/stuck-transactions/"+txHash+"/ignore", rpc.AdminActionRequest{Reason: reason})
+}
+
+func (c *TokenisationClient) postAdminAction(path string, request interface{}) (rpc.AdminActionResponse, error) {
+	resp, err := c.adminRequest(http.MethodPost, path, request)
+	if err != nil {
+		return rpc.AdminActionResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.AdminActionResponse{}, fmt.Errorf("failed admin action: %s %s", resp.Status, string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.AdminActionResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.AdminActionResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (c *TokenisationClient) GetAdminAuditLog(page int, limit int) (rpc.GetAdminAuditLogResponse, error) {
+	resp, err := c.adminRequest(http.MethodGet, fmt.Sprintf("/admin/audit-log?page=%d&limit=%d", page, limit), nil)
+	if err != nil {
+		return rpc.GetAdminAuditLogResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return rpc.GetAdminAuditLogResponse{}, fmt.Errorf("failed to get audit log: %s %s", resp.Status, string(body))
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	var result rpc.GetAdminAuditLogResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return rpc.GetAdminAuditLogResponse{}, err
+	}
+
+	return result, nil
+}
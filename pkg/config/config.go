@@ -1,11 +1,21 @@
 package config
 
-import "code.dogecoin.org/gossip/dnet"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.dogecoin.org/gossip/dnet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
 
 type Config struct {
-	RpcServerHost      string
-	RpcServerPort      string
-	RpcApiKey          string
+	RpcServerHost string
+	RpcServerPort string
+	RpcApiKey     string
+	// Bearer token for the /admin routes; the admin routes are disabled when empty
+	AdminApiKey        string
 	DogeNetChain       string
 	DogeNetNetwork     string
 	DogeNetAddress     string
@@ -23,28 +33,132 @@ type Config struct {
 	BuyOfferLimit      int
 	SellOfferLimit     int
 	CORSAllowedOrigins string
+	// Confirmations an on chain transaction waits for before it is processed, by action.
+	// Actions without an entry are processed as soon as they are seen.
+	ConfirmationDepths map[uint8]int
+	// How often the mempool is checked for mints, invoices and payments not yet in a block, 0 to not watch it
+	MempoolPollInterval time.Duration
+	// ZMQ address the node publishes hashblock and rawtx notifications on, e.g. tcp://dogecoin:28332.
+	// The node is polled when empty.
+	DogeZmqAddress string
+	// Block a node without a chain position starts following from, the first block of the protocol.
+	// The built-in activation block of the chain of the node is used when the hash is empty.
+	ActivationHeight int64
+	ActivationHash   string
 }
 
 func NewConfig() *Config {
 	return &Config{
-		RpcServerHost:      "0.0.0.0",
-		RpcServerPort:      "8891",
-		DogeNetChain:       "regtest",
-		DogeNetNetwork:     "tcp",
-		DogeNetAddress:     "0.0.0.0:42069",
-		DogeNetWebAddress:  "0.0.0.0:8085",
-		DogeNetKeyPair:     dnet.KeyPair{},
-		DogeScheme:         "http",
-		DogeHost:           "dogecoin",
-		DogePort:           "22555",
-		DogeUser:           "test",
-		DogePassword:       "test",
-		DatabaseURL:        "sqlite://fractal-engine.db",
-		PersistFollower:    true,
-		RateLimitPerSecond: 10,
-		InvoiceLimit:       10,
-		BuyOfferLimit:      10,
-		SellOfferLimit:     10,
-		CORSAllowedOrigins: "*",
+		RpcServerHost:       "0.0.0.0",
+		RpcServerPort:       "8891",
+		DogeNetChain:        "regtest",
+		DogeNetNetwork:      "tcp",
+		DogeNetAddress:      "0.0.0.0:42069",
+		DogeNetWebAddress:   "0.0.0.0:8085",
+		DogeNetKeyPair:      dnet.KeyPair{},
+		DogeScheme:          "http",
+		DogeHost:            "dogecoin",
+		DogePort:            "22555",
+		DogeUser:            "test",
+		DogePassword:        "test",
+		DatabaseURL:         "sqlite://fractal-engine.db",
+		PersistFollower:     true,
+		RateLimitPerSecond:  10,
+		InvoiceLimit:        10,
+		BuyOfferLimit:       10,
+		SellOfferLimit:      10,
+		CORSAllowedOrigins:  "*",
+		ConfirmationDepths:  DefaultConfirmationDepths(),
+		MempoolPollInterval: 5 * time.Second,
+	}
+}
+
+/*
+* Activation is the first block of the protocol on a chain. A node without a chain position starts
+* following from it, so it sees every fractal transaction without scanning the blocks before it.
+ */
+type Activation struct {
+	Height int64
+	Hash   string
+}
+
+// IsSet reports whether the activation block is known.
+func (a Activation) IsSet() bool {
+	return a.Hash != ""
+}
+
+/*
+* DefaultActivations are the built-in activation blocks, by the chain name the node reports
+* (main, test or regtest). Main and test start at their genesis blocks, which every node agrees on
+* and which no fractal transaction precedes; a later block can be configured to skip the history before it.
+ */
+var DefaultActivations = map[string]Activation{
+	"main": {Height: 0, Hash: "1a91e3dace36e2be3bf030a65679fe821aa1d6ef92e7c9902eb318182c355691"},
+	"test": {Height: 0, Hash: "bb0a78264637406b6360aad926284d544d7049f45189db5664f3c4d07350559e"},
+}
+
+/*
+* RequiresActivation reports whether a node on the chain needs an activation block to start without
+* a chain position. Starting near the tip would miss every fractal transaction before it, so only
+* regtest, a local chain, may do so.
+ */
+func RequiresActivation(chain string) bool {
+	return chain != "regtest"
+}
+
+// ActivationFor returns the configured activation block, or else the built-in one for the chain.
+func (c *Config) ActivationFor(chain string) Activation {
+	activation := Activation{Height: c.ActivationHeight, Hash: c.ActivationHash}
+	if activation.IsSet() {
+		return activation
 	}
+
+	return DefaultActivations[chain]
+}
+
+// DefaultConfirmationDepths returns the confirmations payments wait for; other actions are processed when seen.
+func DefaultConfirmationDepths() map[uint8]int {
+	return map[uint8]int{
+		protocol.ACTION_PAYMENT:       6,
+		protocol.ACTION_BATCH_PAYMENT: 6,
+	}
+}
+
+// ConfirmationDepth returns the confirmations required before an action is processed.
+func (c *Config) ConfirmationDepth(action uint8) int {
+	return c.ConfirmationDepths[action]
+}
+
+/*
+* ParseConfirmationDepths parses a comma separated list of action=depth pairs, e.g. "mint=1,payment=6",
+* where action is the protocol name of the action. Actions that are not listed keep their default depth.
+ */
+func ParseConfirmationDepths(value string) (map[uint8]int, error) {
+	depths := DefaultConfirmationDepths()
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, depthStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid confirmation depth %q, expected action=depth", pair)
+		}
+
+		action, ok := protocol.ActionByName(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", protocol.ErrUnsupportedAction, name)
+		}
+
+		depth, err := strconv.Atoi(strings.TrimSpace(depthStr))
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("invalid confirmation depth for %s: %s", name, depthStr)
+		}
+
+		depths[action] = depth
+	}
+
+	return depths, nil
 }
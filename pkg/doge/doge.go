@@ -31,6 +31,9 @@ const (
 	PrefixRegtest = 0x6f
 )
 
+// DustLimitKoinu is the default dust limit of Dogecoin Core (0.01 DOGE). Outputs below it are not relayed without paying the limit again as a fee.
+const DustLimitKoinu = 1_000_000
+
 type PrevOutput struct {
 	Address string // The address of the previous output
 	Amount  int64  // The amount in satoshis of the previous output
@@ -384,3 +387,19 @@ func PublicKeyToDogeAddress(pubKeyHex string, prefix byte) (string, error) {
 	address := base58.Encode(fullPayload)
 	return address, nil
 }
+
+// AddressMatchesPublicKey reports whether the P2PKH address was derived from the public key,
+// using the network prefix of the address.
+func AddressMatchesPublicKey(address string, pubKeyHex string) bool {
+	_, prefix, err := base58.CheckDecode(address)
+	if err != nil {
+		return false
+	}
+
+	derived, err := PublicKeyToDogeAddress(pubKeyHex, prefix)
+	if err != nil {
+		return false
+	}
+
+	return derived == address
+}
@@ -0,0 +1,242 @@
+package doge
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/cosmos/btcutil/base58"
+)
+
+const (
+	ScriptHashPrefixMainnet = 0x16
+	ScriptHashPrefixTestnet = 0xC4
+	ScriptHashPrefixRegtest = 0xC4
+)
+
+// Address types, named as the node reports them in scriptPubKey.type
+const (
+	AddressType_PUBKEYHASH = "pubkeyhash"
+	AddressType_SCRIPTHASH = "scripthash"
+)
+
+const (
+	opCheckMultisig = 0xae
+	op1             = 0x51
+	op16            = 0x60
+	maxMultisigKeys = 15
+)
+
+var ErrInvalidRedeemScript = errors.New("invalid multisig redeem script")
+
+// MultisigSignature is the signature of one of the keys of a multisig redeem script.
+type MultisigSignature struct {
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+/*
+* Signer is whoever signed a payload on behalf of an address: either a single key,
+* or a P2SH multisig redeem script together with the signatures of its keys.
+ */
+type Signer struct {
+	PublicKey    string
+	Signature    string
+	RedeemScript string
+	Signatures   []MultisigSignature
+}
+
+func (s Signer) IsMultisig() bool {
+	return s.RedeemScript != ""
+}
+
+// Validate checks the signature, or at least M of the N multisig signatures, over the payload.
+func (s Signer) Validate(payload interface{}) error {
+	if s.IsMultisig() {
+		return ValidateMultisigSignatures(payload, s.RedeemScript, s.Signatures)
+	}
+
+	return ValidateSignature(payload, s.PublicKey, s.Signature)
+}
+
+// Address returns the P2PKH or P2SH address of the signer on the network of the P2PKH prefix.
+func (s Signer) Address(prefix byte) (string, error) {
+	if s.IsMultisig() {
+		return ScriptHashAddress(s.RedeemScript, prefix)
+	}
+
+	return PublicKeyToDogeAddress(s.PublicKey, prefix)
+}
+
+// MatchesAddress reports whether the address belongs to the signer, using the network of the address.
+func (s Signer) MatchesAddress(address string) bool {
+	if s.IsMultisig() {
+		return AddressMatchesRedeemScript(address, s.RedeemScript)
+	}
+
+	return AddressMatchesPublicKey(address, s.PublicKey)
+}
+
+// ScriptHashPrefix returns the P2SH version byte of the network of the P2PKH prefix.
+func ScriptHashPrefix(prefix byte) (byte, error) {
+	switch prefix {
+	case PrefixMainnet:
+		return ScriptHashPrefixMainnet, nil
+	case PrefixTestnet:
+		return ScriptHashPrefixTestnet, nil
+	case PrefixRegtest:
+		return ScriptHashPrefixRegtest, nil
+	}
+
+	return 0, fmt.Errorf("invalid prefix: %x", prefix)
+}
+
+// GetAddressType returns whether the address is a P2PKH or a P2SH address.
+func GetAddressType(address string) (string, error) {
+	_, version, err := base58.CheckDecode(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address: %v", err)
+	}
+
+	switch version {
+	case PrefixMainnet, PrefixTestnet, PrefixRegtest:
+		return AddressType_PUBKEYHASH, nil
+	case ScriptHashPrefixMainnet, ScriptHashPrefixTestnet:
+		return AddressType_SCRIPTHASH, nil
+	}
+
+	return "", fmt.Errorf("unknown address version: %x", version)
+}
+
+// NewMultisigRedeemScript builds the M-of-N OP_CHECKMULTISIG redeem script of the public keys.
+func NewMultisigRedeemScript(required int, publicKeys []string) (string, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > maxMultisigKeys {
+		return "", fmt.Errorf("%w: %d keys", ErrInvalidRedeemScript, len(publicKeys))
+	}
+
+	if required < 1 || required > len(publicKeys) {
+		return "", fmt.Errorf("%w: %d of %d keys required", ErrInvalidRedeemScript, required, len(publicKeys))
+	}
+
+	script := []byte{byte(op1 - 1 + required)}
+	for _, publicKey := range publicKeys {
+		keyBytes, err := hex.DecodeString(publicKey)
+		if err != nil || (len(keyBytes) != 33 && len(keyBytes) != 65) {
+			return "", fmt.Errorf("%w: invalid public key %s", ErrInvalidRedeemScript, publicKey)
+		}
+
+		script = append(script, byte(len(keyBytes)))
+		script = append(script, keyBytes...)
+	}
+	script = append(script, byte(op1-1+len(publicKeys)), opCheckMultisig)
+
+	return hex.EncodeToString(script), nil
+}
+
+// ParseMultisigRedeemScript returns the number of required signatures and the public keys of the redeem script.
+func ParseMultisigRedeemScript(redeemScriptHex string) (int, []string, error) {
+	script, err := hex.DecodeString(redeemScriptHex)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", ErrInvalidRedeemScript, err)
+	}
+
+	if len(script) < 3 || script[len(script)-1] != opCheckMultisig {
+		return 0, nil, fmt.Errorf("%w: not an OP_CHECKMULTISIG script", ErrInvalidRedeemScript)
+	}
+
+	if script[0] < op1 || script[0] > op16 {
+		return 0, nil, fmt.Errorf("%w: missing required signature count", ErrInvalidRedeemScript)
+	}
+	required := int(script[0]-op1) + 1
+
+	var publicKeys []string
+	i := 1
+	for i < len(script)-2 {
+		size := int(script[i])
+		if (size != 33 && size != 65) || i+1+size > len(script)-2 {
+			return 0, nil, fmt.Errorf("%w: invalid public key push", ErrInvalidRedeemScript)
+		}
+
+		publicKeys = append(publicKeys, hex.EncodeToString(script[i+1:i+1+size]))
+		i += 1 + size
+	}
+
+	total := script[len(script)-2]
+	if total < op1 || total > op16 || int(total-op1)+1 != len(publicKeys) {
+		return 0, nil, fmt.Errorf("%w: key count does not match", ErrInvalidRedeemScript)
+	}
+
+	if required > len(publicKeys) {
+		return 0, nil, fmt.Errorf("%w: %d of %d keys required", ErrInvalidRedeemScript, required, len(publicKeys))
+	}
+
+	return required, publicKeys, nil
+}
+
+// ScriptHashAddress returns the P2SH address of the redeem script on the network of the P2PKH prefix.
+func ScriptHashAddress(redeemScriptHex string, prefix byte) (string, error) {
+	script, err := hex.DecodeString(redeemScriptHex)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidRedeemScript, err)
+	}
+
+	scriptHashPrefix, err := ScriptHashPrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return base58.CheckEncode(btcutil.Hash160(script), scriptHashPrefix), nil
+}
+
+// AddressMatchesRedeemScript reports whether the P2SH address is the hash of the redeem script.
+func AddressMatchesRedeemScript(address string, redeemScriptHex string) bool {
+	scriptHash, version, err := base58.CheckDecode(address)
+	if err != nil || (version != ScriptHashPrefixMainnet && version != ScriptHashPrefixTestnet) {
+		return false
+	}
+
+	script, err := hex.DecodeString(redeemScriptHex)
+	if err != nil {
+		return false
+	}
+
+	return hex.EncodeToString(btcutil.Hash160(script)) == hex.EncodeToString(scriptHash)
+}
+
+/*
+* ValidateMultisigSignatures checks the payload is signed by at least M distinct keys of the M-of-N redeem script.
+* Signatures by keys outside the script, or that do not verify, fail the validation.
+ */
+func ValidateMultisigSignatures(payload interface{}, redeemScriptHex string, signatures []MultisigSignature) error {
+	required, publicKeys, err := ParseMultisigRedeemScript(redeemScriptHex)
+	if err != nil {
+		return err
+	}
+
+	scriptKeys := map[string]bool{}
+	for _, publicKey := range publicKeys {
+		scriptKeys[strings.ToLower(publicKey)] = true
+	}
+
+	signed := map[string]bool{}
+	for _, signature := range signatures {
+		publicKey := strings.ToLower(signature.PublicKey)
+		if !scriptKeys[publicKey] {
+			return fmt.Errorf("public key is not part of the redeem script: %s", signature.PublicKey)
+		}
+
+		if err := ValidateSignature(payload, signature.PublicKey, signature.Signature); err != nil {
+			return err
+		}
+
+		signed[publicKey] = true
+	}
+
+	if len(signed) < required {
+		return fmt.Errorf("not enough multisig signatures: %d < %d", len(signed), required)
+	}
+
+	return nil
+}
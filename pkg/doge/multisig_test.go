@@ -0,0 +1,119 @@
+package doge_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil"
+	"gotest.tools/assert"
+)
+
+type multisigKey struct {
+	privHex string
+	pubHex  string
+}
+
+func newMultisigKeys(t *testing.T, n int) []multisigKey {
+	keys := make([]multisigKey, n)
+	for i := range keys {
+		privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+		assert.NilError(t, err)
+		keys[i] = multisigKey{privHex: privHex, pubHex: pubHex}
+	}
+
+	return keys
+}
+
+func TestMultisigRedeemScript(t *testing.T) {
+	keys := newMultisigKeys(t, 3)
+	publicKeys := []string{keys[0].pubHex, keys[1].pubHex, keys[2].pubHex}
+
+	redeemScript, err := doge.NewMultisigRedeemScript(2, publicKeys)
+	assert.NilError(t, err)
+
+	// Matches the script the wallet builds for addmultisigaddress
+	var addressPubKeys []*btcutil.AddressPubKey
+	for _, publicKey := range publicKeys {
+		keyBytes, _ := hex.DecodeString(publicKey)
+		addressPubKey, err := btcutil.NewAddressPubKey(keyBytes, &chaincfg.RegressionNetParams)
+		assert.NilError(t, err)
+		addressPubKeys = append(addressPubKeys, addressPubKey)
+	}
+	expected, err := txscript.MultiSigScript(addressPubKeys, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, redeemScript, hex.EncodeToString(expected))
+
+	required, parsedKeys, err := doge.ParseMultisigRedeemScript(redeemScript)
+	assert.NilError(t, err)
+	assert.Equal(t, required, 2)
+	assert.DeepEqual(t, parsedKeys, publicKeys)
+
+	_, _, err = doge.ParseMultisigRedeemScript("76a914")
+	assert.ErrorContains(t, err, "invalid multisig redeem script")
+
+	_, err = doge.NewMultisigRedeemScript(4, publicKeys)
+	assert.ErrorContains(t, err, "invalid multisig redeem script")
+}
+
+func TestScriptHashAddress(t *testing.T) {
+	keys := newMultisigKeys(t, 2)
+	redeemScript, err := doge.NewMultisigRedeemScript(1, []string{keys[0].pubHex, keys[1].pubHex})
+	assert.NilError(t, err)
+
+	mainnetAddress, err := doge.ScriptHashAddress(redeemScript, doge.PrefixMainnet)
+	assert.NilError(t, err)
+	assert.Assert(t, mainnetAddress[0] == '9' || mainnetAddress[0] == 'A')
+
+	regtestAddress, err := doge.ScriptHashAddress(redeemScript, doge.PrefixRegtest)
+	assert.NilError(t, err)
+	assert.Equal(t, regtestAddress[0], byte('2'))
+
+	addressType, err := doge.GetAddressType(regtestAddress)
+	assert.NilError(t, err)
+	assert.Equal(t, addressType, doge.AddressType_SCRIPTHASH)
+
+	_, _, pubKeyHashAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	addressType, err = doge.GetAddressType(pubKeyHashAddress)
+	assert.NilError(t, err)
+	assert.Equal(t, addressType, doge.AddressType_PUBKEYHASH)
+
+	assert.Assert(t, doge.AddressMatchesRedeemScript(regtestAddress, redeemScript))
+	assert.Assert(t, !doge.AddressMatchesRedeemScript(pubKeyHashAddress, redeemScript))
+}
+
+func TestValidateMultisigSignatures(t *testing.T) {
+	keys := newMultisigKeys(t, 3)
+	redeemScript, err := doge.NewMultisigRedeemScript(2, []string{keys[0].pubHex, keys[1].pubHex, keys[2].pubHex})
+	assert.NilError(t, err)
+
+	payload := map[string]string{"hello": "doge"}
+	sign := func(key multisigKey) doge.MultisigSignature {
+		signature, err := doge.SignPayload(payload, key.privHex, key.pubHex)
+		assert.NilError(t, err)
+		return doge.MultisigSignature{PublicKey: key.pubHex, Signature: signature}
+	}
+
+	signer := doge.Signer{RedeemScript: redeemScript, Signatures: []doge.MultisigSignature{sign(keys[0]), sign(keys[2])}}
+	assert.NilError(t, signer.Validate(payload))
+
+	address, err := signer.Address(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	assert.Assert(t, signer.MatchesAddress(address))
+
+	// The same key twice does not make a quorum
+	signer.Signatures = []doge.MultisigSignature{sign(keys[0]), sign(keys[0])}
+	assert.ErrorContains(t, signer.Validate(payload), "not enough multisig signatures: 1 < 2")
+
+	outsider := newMultisigKeys(t, 1)[0]
+	signer.Signatures = []doge.MultisigSignature{sign(keys[0]), sign(outsider)}
+	assert.ErrorContains(t, signer.Validate(payload), "public key is not part of the redeem script")
+
+	forged := sign(keys[1])
+	forged.Signature = sign(keys[2]).Signature
+	signer.Signatures = []doge.MultisigSignature{sign(keys[0]), forged}
+	assert.ErrorContains(t, signer.Validate(payload), "signature verification failed")
+}
@@ -0,0 +1,175 @@
+package doge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-zeromq/zmq4"
+)
+
+const (
+	ZmqTopic_HASHBLOCK = "hashblock"
+	ZmqTopic_RAWTX     = "rawtx"
+)
+
+// ZMQ_RECONNECT_DELAY is how long the subscriber waits before connecting again after losing the node.
+const ZMQ_RECONNECT_DELAY = 5 * time.Second
+
+/*
+* NOTIFIED_POLL_INTERVAL is how often services woken by notifications still poll the node,
+* as ZMQ drops notifications while the subscriber is disconnected.
+ */
+const NOTIFIED_POLL_INTERVAL = 60 * time.Second
+
+/*
+* Signal wakes a service waiting for the node to change. Notifications arriving while the service is
+* busy are folded into one, so a service catching up never falls behind its signal.
+* A nil Signal never fires, leaving the service to poll.
+ */
+type Signal chan struct{}
+
+func NewSignal() Signal {
+	return make(Signal, 1)
+}
+
+// Notify wakes the service waiting on the signal, if it is not already due to wake.
+func (s Signal) Notify() {
+	select {
+	case s <- struct{}{}:
+	default:
+	}
+}
+
+// Wait returns when the signal fires, the interval passes or the context is done.
+func (s Signal) Wait(ctx context.Context, interval time.Duration) {
+	select {
+	case <-s:
+	case <-time.After(interval):
+	case <-ctx.Done():
+	}
+}
+
+/*
+* ZmqSubscriber follows the hashblock and rawtx notifications Dogecoin Core publishes over ZMQ
+* (-zmqpubhashblock and -zmqpubrawtx, both on the same address), and fires the signals of the services
+* that asked for them. It connects again whenever the connection to the node is lost.
+ */
+type ZmqSubscriber struct {
+	address      string
+	mu           sync.Mutex
+	blocks       []Signal
+	transactions []Signal
+	context      context.Context
+	cancel       context.CancelFunc
+	Running      bool
+}
+
+func NewZmqSubscriber(address string) *ZmqSubscriber {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ZmqSubscriber{address: address, context: ctx, cancel: cancel}
+}
+
+// BlockSignal returns a signal fired for each hashblock notification.
+func (z *ZmqSubscriber) BlockSignal() Signal {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	signal := NewSignal()
+	z.blocks = append(z.blocks, signal)
+	return signal
+}
+
+// TransactionSignal returns a signal fired for each rawtx notification, which includes the transactions of each new block.
+func (z *ZmqSubscriber) TransactionSignal() Signal {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	signal := NewSignal()
+	z.transactions = append(z.transactions, signal)
+	return signal
+}
+
+func (z *ZmqSubscriber) Start() {
+	z.Running = true
+
+	for {
+		err := z.subscribe()
+		if err != nil {
+			log.Println("Error following ZMQ notifications:", err)
+		}
+
+		select {
+		case <-z.context.Done():
+			fmt.Println("Exiting ZMQ subscriber")
+			return
+		case <-time.After(ZMQ_RECONNECT_DELAY):
+		}
+	}
+}
+
+// subscribe follows the notifications until the connection is lost or the subscriber is stopped.
+func (z *ZmqSubscriber) subscribe() error {
+	sub := zmq4.NewSub(z.context)
+	defer sub.Close()
+
+	err := sub.Dial(z.address)
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range []string{ZmqTopic_HASHBLOCK, ZmqTopic_RAWTX} {
+		err = sub.SetOption(zmq4.OptionSubscribe, topic)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Println("Following ZMQ notifications from", z.address)
+
+	// Anything could have happened while disconnected, so services check the node straight away
+	z.fire(ZmqTopic_HASHBLOCK)
+	z.fire(ZmqTopic_RAWTX)
+
+	for {
+		msg, err := sub.Recv()
+		if err != nil {
+			if z.context.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		// A notification is made of its topic, body and sequence number
+		if len(msg.Frames) > 0 {
+			z.fire(string(msg.Frames[0]))
+		}
+	}
+}
+
+// fire wakes the services following the topic.
+func (z *ZmqSubscriber) fire(topic string) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	signals := z.blocks
+	if topic == ZmqTopic_RAWTX {
+		signals = z.transactions
+	} else if topic != ZmqTopic_HASHBLOCK {
+		return
+	}
+
+	for _, signal := range signals {
+		signal.Notify()
+	}
+}
+
+func (z *ZmqSubscriber) Stop() {
+	fmt.Println("Stopping ZMQ subscriber")
+	if z.Running {
+		z.cancel()
+		z.Running = false
+	}
+}
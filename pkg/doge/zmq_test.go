@@ -0,0 +1,116 @@
+package doge_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"github.com/go-zeromq/zmq4"
+)
+
+// startPublisher stands in for a node publishing notifications on a free local port.
+func startPublisher(t *testing.T) (zmq4.Socket, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := "tcp://" + listener.Addr().String()
+	listener.Close()
+
+	pub := zmq4.NewPub(context.Background())
+	err = pub.Listen(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { pub.Close() })
+
+	return pub, address
+}
+
+func publish(t *testing.T, pub zmq4.Socket, topic string, body []byte) {
+	sequence := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sequence, 1)
+
+	err := pub.Send(zmq4.NewMsgFrom([]byte(topic), body, sequence))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fired publishes the notification until the signal fires, as a publisher drops what it sends before the subscription reaches it.
+func fired(t *testing.T, pub zmq4.Socket, topic string, signal doge.Signal) bool {
+	for i := 0; i < 50; i++ {
+		publish(t, pub, topic, []byte{0x01})
+
+		select {
+		case <-signal:
+			return true
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	return false
+}
+
+func TestZmqSubscriberFiresSignals(t *testing.T) {
+	pub, address := startPublisher(t)
+
+	subscriber := doge.NewZmqSubscriber(address)
+	blocks := subscriber.BlockSignal()
+	transactions := subscriber.TransactionSignal()
+
+	go subscriber.Start()
+	defer subscriber.Stop()
+
+	// Both signals fire once connected, for anything missed while disconnected
+	for _, signal := range []doge.Signal{blocks, transactions} {
+		select {
+		case <-signal:
+		case <-time.After(5 * time.Second):
+			t.Fatal("signal did not fire on connecting")
+		}
+	}
+
+	if !fired(t, pub, doge.ZmqTopic_HASHBLOCK, blocks) {
+		t.Fatal("block signal did not fire for hashblock")
+	}
+
+	select {
+	case <-transactions:
+		t.Fatal("transaction signal fired for hashblock")
+	default:
+	}
+
+	if !fired(t, pub, doge.ZmqTopic_RAWTX, transactions) {
+		t.Fatal("transaction signal did not fire for rawtx")
+	}
+}
+
+func TestSignalFoldsNotifications(t *testing.T) {
+	signal := doge.NewSignal()
+	signal.Notify()
+	signal.Notify()
+
+	start := time.Now()
+	signal.Wait(context.Background(), time.Second)
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("signal did not fire")
+	}
+
+	start = time.Now()
+	signal.Wait(context.Background(), 100*time.Millisecond)
+	if time.Since(start) < 100*time.Millisecond {
+		t.Fatal("notifications were not folded into one")
+	}
+
+	// Without notifications the interval is waited, as when the node is polled
+	var none doge.Signal
+	start = time.Now()
+	none.Wait(context.Background(), 100*time.Millisecond)
+	if time.Since(start) < 100*time.Millisecond {
+		t.Fatal("nil signal fired")
+	}
+}
@@ -0,0 +1,101 @@
+package dogenet
+
+import (
+	"log"
+
+	"code.dogecoin.org/gossip/dnet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func (c *DogeNetClient) GossipMintAllowlistEntry(record store.MintAllowlistEntry) error {
+	allowlistEntryMessage := protocol.MintAllowlistEntryMessage{
+		MintHash:  record.MintHash,
+		Entry:     record.Entry,
+		Allowed:   record.Allowed,
+		Timestamp: record.Timestamp,
+	}
+
+	envelope := protocol.MintAllowlistEntryMessageEnvelope{
+		Type:      protocol.ACTION_ALLOWLIST_ENTRY,
+		Version:   protocol.DEFAULT_VERSION,
+		Payload:   &allowlistEntryMessage,
+		PublicKey: record.PublicKey,
+		Signature: record.Signature,
+	}
+
+	data, err := proto.Marshal(&envelope)
+	if err != nil {
+		log.Fatalf("Failed to marshal: %v", err)
+	}
+
+	encodedMsg := dnet.EncodeMessageRaw(ChanFE, TagMintAllowlistEntry, c.feKey, data)
+
+	err = encodedMsg.Send(c.sock)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *DogeNetClient) recvMintAllowlistEntry(msg dnet.Message) {
+	log.Printf("[FE] received mint allowlist entry message")
+
+	envelope := protocol.MintAllowlistEntryMessageEnvelope{}
+	err := proto.Unmarshal(msg.Payload, &envelope)
+	if err != nil {
+		log.Println("Error deserializing message envelope:", err)
+		return
+	}
+
+	if envelope.Type != protocol.ACTION_ALLOWLIST_ENTRY {
+		log.Printf("[FE] unexpected action: [%s][%s][%d]", msg.Chan, msg.Tag, envelope.Type)
+		return
+	}
+
+	entry := store.MintAllowlistEntry{
+		MintAllowlistEntryBody: store.MintAllowlistEntryBody{
+			MintHash:  envelope.Payload.MintHash,
+			Entry:     envelope.Payload.Entry,
+			Allowed:   envelope.Payload.Allowed,
+			Timestamp: envelope.Payload.Timestamp,
+		},
+		PublicKey: envelope.PublicKey,
+		Signature: envelope.Signature,
+	}
+
+	// The hash anchored on chain is derived from the signed payload
+	entry.Hash, err = entry.GenerateHash()
+	if err != nil {
+		log.Println("Error hashing allowlist entry:", err)
+		return
+	}
+
+	mint, err := c.store.GetMintByHash(entry.MintHash)
+	if err != nil || mint.Id == "" {
+		log.Println("Mint not found for allowlist entry:", entry.MintHash)
+		return
+	}
+
+	mint, err = c.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		log.Println("Error getting asset managers:", err)
+		return
+	}
+
+	err = store.ValidateMintAllowlistEntry(mint, entry)
+	if err != nil {
+		log.Println("Invalid allowlist entry:", err)
+		return
+	}
+
+	err = c.store.SaveUnconfirmedMintAllowlistEntry(&entry)
+	if err != nil {
+		log.Println("Error saving unconfirmed allowlist entry:", err)
+		return
+	}
+
+	log.Printf("[FE] unconfirmed mint allowlist entry saved: %s", entry.Hash)
+}
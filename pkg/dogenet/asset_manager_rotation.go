@@ -0,0 +1,123 @@
+package dogenet
+
+import (
+	"log"
+
+	"code.dogecoin.org/gossip/dnet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func (c *DogeNetClient) GossipAssetManagerRotation(record store.AssetManagerRotation) error {
+	rotationMessage := protocol.AssetManagerRotationMessage{
+		Hash:      record.Hash,
+		MintHash:  record.MintHash,
+		Action:    record.Action,
+		PublicKey: record.PublicKey,
+		Timestamp: record.Timestamp,
+	}
+
+	if record.Manager != nil {
+		rotationMessage.Manager = &protocol.RotatedAssetManager{
+			Name:      record.Manager.Name,
+			PublicKey: record.Manager.PublicKey,
+			Url:       record.Manager.URL,
+		}
+	}
+
+	for _, approval := range record.Approvals {
+		rotationMessage.Approvals = append(rotationMessage.Approvals, &protocol.AssetManagerApproval{
+			PublicKey: approval.PublicKey,
+			Signature: approval.Signature,
+		})
+	}
+
+	envelope := protocol.AssetManagerRotationMessageEnvelope{
+		Type:    protocol.ACTION_ASSET_MANAGER_ROTATION,
+		Version: protocol.DEFAULT_VERSION,
+		Payload: &rotationMessage,
+	}
+
+	data, err := proto.Marshal(&envelope)
+	if err != nil {
+		log.Fatalf("Failed to marshal: %v", err)
+	}
+
+	encodedMsg := dnet.EncodeMessageRaw(ChanFE, TagAssetManagerRotation, c.feKey, data)
+
+	err = encodedMsg.Send(c.sock)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *DogeNetClient) recvAssetManagerRotation(msg dnet.Message) {
+	log.Printf("[FE] received asset manager rotation message")
+
+	envelope := protocol.AssetManagerRotationMessageEnvelope{}
+	err := proto.Unmarshal(msg.Payload, &envelope)
+	if err != nil {
+		log.Println("Error deserializing message envelope:", err)
+		return
+	}
+
+	if envelope.Type != protocol.ACTION_ASSET_MANAGER_ROTATION || envelope.Payload == nil {
+		log.Printf("[FE] unexpected action: [%s][%s][%d]", msg.Chan, msg.Tag, envelope.Type)
+		return
+	}
+
+	rotation := store.AssetManagerRotation{
+		AssetManagerRotationBody: store.AssetManagerRotationBody{
+			MintHash:  envelope.Payload.MintHash,
+			Action:    envelope.Payload.Action,
+			PublicKey: envelope.Payload.PublicKey,
+			Timestamp: envelope.Payload.Timestamp,
+		},
+		Hash:      envelope.Payload.Hash,
+		Approvals: store.AssetManagerApprovals{},
+	}
+
+	if envelope.Payload.Manager != nil {
+		rotation.Manager = &store.AssetManager{
+			Name:      envelope.Payload.Manager.Name,
+			PublicKey: envelope.Payload.Manager.PublicKey,
+			URL:       envelope.Payload.Manager.Url,
+		}
+	}
+
+	for _, approval := range envelope.Payload.Approvals {
+		rotation.Approvals = append(rotation.Approvals, store.AssetManagerApproval{
+			PublicKey: approval.PublicKey,
+			Signature: approval.Signature,
+		})
+	}
+
+	mint, err := c.store.GetMintByHash(rotation.MintHash)
+	if err != nil || mint.Id == "" {
+		log.Println("Mint not found for asset manager rotation:", rotation.MintHash)
+		return
+	}
+
+	mint, err = c.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		log.Println("Error getting asset managers:", err)
+		return
+	}
+
+	_, err = store.ValidateAssetManagerRotation(mint, rotation)
+	if err != nil {
+		log.Println("Invalid asset manager rotation:", err)
+		return
+	}
+
+	err = c.store.SaveUnconfirmedAssetManagerRotation(&rotation)
+	if err != nil {
+		log.Println("Error saving unconfirmed asset manager rotation:", err)
+		return
+	}
+
+	log.Printf("[FE] unconfirmed asset manager rotation saved: %s", rotation.Hash)
+}
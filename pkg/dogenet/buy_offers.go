@@ -23,7 +23,7 @@ func (c *DogeNetClient) GossipBuyOffer(record store.BuyOffer) error {
 			SellerAddress:  record.SellerAddress,
 			MintHash:       record.MintHash,
 			Quantity:       int32(record.Quantity),
-			Price:          int32(record.Price),
+			PriceKoinu:     record.PriceKoinu,
 		},
 	}
 
@@ -100,7 +100,7 @@ func (c *DogeNetClient) recvBuyOffer(msg dnet.Message) {
 		SellerAddress:  offer.Payload.SellerAddress,
 		MintHash:       offer.Payload.MintHash,
 		Quantity:       offer.Payload.Quantity,
-		Price:          offer.Payload.Price,
+		PriceKoinu:     offer.Payload.PriceKoinu,
 	}
 
 	offerPayload, err := protojson.Marshal(&buyOfferPayload)
@@ -138,7 +138,7 @@ func (c *DogeNetClient) recvBuyOffer(msg dnet.Message) {
 		Hash:           offer.Hash,
 		MintHash:       offer.Payload.MintHash,
 		Quantity:       int(offer.Payload.Quantity),
-		Price:          int(offer.Payload.Price),
+		PriceKoinu:     offer.Payload.PriceKoinu,
 		CreatedAt:      offer.CreatedAt.AsTime(),
 		PublicKey:      envelope.PublicKey,
 		Signature:      envelope.Signature,
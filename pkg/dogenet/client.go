@@ -44,6 +44,10 @@ type GossipClient interface {
 	GossipDeleteSellOffer(hash string, publicKey string, signature string) error
 	GossipUnconfirmedInvoice(record store.UnconfirmedInvoice) error
 	GossipInvoiceSignature(record store.InvoiceSignature) error
+	GossipMintAllowlistEntry(record store.MintAllowlistEntry) error
+	GossipMintAmendment(record store.MintAmendment) error
+	GossipAssetManagerRotation(record store.AssetManagerRotation) error
+	GossipMintOwnershipTransfer(record store.MintOwnershipTransfer) error
 	GetNodes() (GetNodesResponse, error)
 	AddPeer(addPeer AddPeer) error
 	CheckRunning() error
@@ -68,7 +72,12 @@ const GossipInterval = 71 * time.Second // gossip a random identity to peers
 func convertToStructPBMap(m map[string]interface{}) map[string]*structpb.Value {
 	fields := make(map[string]*structpb.Value)
 	for k, v := range m {
-		fields[k] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v.(string)}}
+		value, err := structpb.NewValue(v)
+		if err != nil {
+			log.Printf("[FE] skipping field %s: %v", k, err)
+			continue
+		}
+		fields[k] = value
 	}
 	return fields
 }
@@ -260,6 +269,14 @@ func (c *DogeNetClient) Run() {
 			c.recvDeleteSellOffer(msg)
 		case TagInvoiceSignature:
 			c.recvInvoiceSignature(msg)
+		case TagMintAllowlistEntry:
+			c.recvMintAllowlistEntry(msg)
+		case TagMintAmendment:
+			c.recvMintAmendment(msg)
+		case TagAssetManagerRotation:
+			c.recvAssetManagerRotation(msg)
+		case TagMintOwnershipTransfer:
+			c.recvMintOwnershipTransfer(msg)
 		default:
 			log.Printf("[FE] unknown message: [%s][%s]", msg.Chan, msg.Tag)
 		}
@@ -318,7 +335,7 @@ func (s *DogeNetClient) gossipRandomInvoices() {
 			BuyerAddress:   invoice.BuyerAddress,
 			MintHash:       invoice.MintHash,
 			Quantity:       invoice.Quantity,
-			Price:          invoice.Price,
+			PriceKoinu:     invoice.PriceKoinu,
 			CreatedAt:      invoice.CreatedAt,
 			SellerAddress:  invoice.SellerAddress,
 			PublicKey:      invoice.PublicKey,
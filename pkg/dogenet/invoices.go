@@ -19,7 +19,7 @@ func (c *DogeNetClient) GossipUnconfirmedInvoice(record store.UnconfirmedInvoice
 			MintHash:       record.MintHash,
 			BuyerAddress:   record.BuyerAddress,
 			Quantity:       int32(record.Quantity),
-			Price:          int32(record.Price),
+			PriceKoinu:     record.PriceKoinu,
 			SellerAddress:  record.SellerAddress,
 		},
 		Hash:      record.Hash,
@@ -27,11 +27,13 @@ func (c *DogeNetClient) GossipUnconfirmedInvoice(record store.UnconfirmedInvoice
 	}
 
 	envelope := protocol.InvoiceMessageEnvelope{
-		Type:      protocol.ACTION_INVOICE,
-		Version:   protocol.DEFAULT_VERSION,
-		Payload:   &invoiceMessage,
-		PublicKey: record.PublicKey,
-		Signature: record.Signature,
+		Type:         protocol.ACTION_INVOICE,
+		Version:      protocol.DEFAULT_VERSION,
+		Payload:      &invoiceMessage,
+		PublicKey:    record.PublicKey,
+		Signature:    record.Signature,
+		RedeemScript: record.RedeemScript,
+		Signatures:   convertToProtocolMultisigSignatures(record.MultisigSignatures),
 	}
 
 	data, err := proto.Marshal(&envelope)
@@ -71,11 +73,13 @@ func (c *DogeNetClient) recvInvoice(msg dnet.Message) {
 		BuyerAddress:   invoice.Payload.BuyerAddress,
 		MintHash:       invoice.Payload.MintHash,
 		Quantity:       invoice.Payload.Quantity,
-		Price:          invoice.Payload.Price,
+		PriceKoinu:     invoice.Payload.PriceKoinu,
 		SellerAddress:  invoice.Payload.SellerAddress,
 	}
 
-	err = doge.ValidateSignature(invoiceSignaturePayload, envelope.PublicKey, envelope.Signature)
+	signer := envelopeSigner(envelope.PublicKey, envelope.Signature, envelope.RedeemScript, envelope.Signatures)
+
+	err = signer.Validate(invoiceSignaturePayload)
 	if err != nil {
 		log.Println("Error validating signature:", err)
 		return
@@ -87,9 +91,9 @@ func (c *DogeNetClient) recvInvoice(msg dnet.Message) {
 		return
 	}
 
-	address, err := doge.PublicKeyToDogeAddress(envelope.PublicKey, prefix)
+	address, err := signer.Address(prefix)
 	if err != nil {
-		log.Println("Error converting public key to doge address:", err)
+		log.Println("Error converting signer to doge address:", err)
 		return
 	}
 
@@ -99,17 +103,19 @@ func (c *DogeNetClient) recvInvoice(msg dnet.Message) {
 	}
 
 	invoiceWithoutID := store.UnconfirmedInvoice{
-		PaymentAddress: invoice.Payload.PaymentAddress,
-		MintHash:       invoice.Payload.MintHash,
-		BuyerAddress:   invoice.Payload.BuyerAddress,
-		Quantity:       int(invoice.Payload.Quantity),
-		Price:          int(invoice.Payload.Price),
-		CreatedAt:      invoice.CreatedAt.AsTime(),
-		Hash:           invoice.Hash,
-		Id:             invoice.Id,
-		PublicKey:      envelope.PublicKey,
-		SellerAddress:  invoice.Payload.SellerAddress,
-		Signature:      envelope.Signature,
+		PaymentAddress:     invoice.Payload.PaymentAddress,
+		MintHash:           invoice.Payload.MintHash,
+		BuyerAddress:       invoice.Payload.BuyerAddress,
+		Quantity:           int(invoice.Payload.Quantity),
+		PriceKoinu:         invoice.Payload.PriceKoinu,
+		CreatedAt:          invoice.CreatedAt.AsTime(),
+		Hash:               invoice.Hash,
+		Id:                 invoice.Id,
+		PublicKey:          envelope.PublicKey,
+		SellerAddress:      invoice.Payload.SellerAddress,
+		Signature:          envelope.Signature,
+		RedeemScript:       envelope.RedeemScript,
+		MultisigSignatures: convertFromProtocolMultisigSignatures(envelope.Signatures),
 	}
 
 	id, err := c.store.SaveUnconfirmedInvoice(&invoiceWithoutID)
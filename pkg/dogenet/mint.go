@@ -41,11 +41,13 @@ func (c *DogeNetClient) GossipMint(record store.Mint) error {
 	}
 
 	envelope := protocol.MintMessageEnvelope{
-		Type:      protocol.ACTION_MINT,
-		Version:   protocol.DEFAULT_VERSION,
-		Payload:   &mintMessage,
-		PublicKey: record.PublicKey,
-		Signature: record.Signature,
+		Type:         protocol.ACTION_MINT,
+		Version:      protocol.DEFAULT_VERSION,
+		Payload:      &mintMessage,
+		PublicKey:    record.PublicKey,
+		Signature:    record.Signature,
+		RedeemScript: record.RedeemScript,
+		Signatures:   convertToProtocolMultisigSignatures(record.MultisigSignatures),
 	}
 
 	data, err := proto.Marshal(&envelope)
@@ -108,6 +110,8 @@ func (c *DogeNetClient) recvMint(msg dnet.Message) {
 		SignatureRequirementType: store.SignatureRequirementType(mintMessage.SignatureRequirementType),
 		AssetManagers:            assetManagers,
 		MinSignatures:            int(mintMessage.MinSignatures),
+		RedeemScript:             envelope.RedeemScript,
+		MultisigSignatures:       convertFromProtocolMultisigSignatures(envelope.Signatures),
 	}
 
 	mintSignaturePayload := protocol.MintMessage{
@@ -138,7 +142,9 @@ func (c *DogeNetClient) recvMint(msg dnet.Message) {
 		mintSignaturePayload.LockupOptions = nil
 	}
 
-	err = doge.ValidateSignature(&mintSignaturePayload, envelope.PublicKey, envelope.Signature)
+	signer := envelopeSigner(envelope.PublicKey, envelope.Signature, envelope.RedeemScript, envelope.Signatures)
+
+	err = signer.Validate(&mintSignaturePayload)
 	if err != nil {
 		log.Println("Error validating signature:", err)
 		return
@@ -150,9 +156,9 @@ func (c *DogeNetClient) recvMint(msg dnet.Message) {
 		return
 	}
 
-	address, err := doge.PublicKeyToDogeAddress(envelope.PublicKey, prefix)
+	address, err := signer.Address(prefix)
 	if err != nil {
-		log.Println("Error converting public key to doge address:", err)
+		log.Println("Error converting signer to doge address:", err)
 		return
 	}
 
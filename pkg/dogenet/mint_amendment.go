@@ -0,0 +1,109 @@
+package dogenet
+
+import (
+	"encoding/json"
+	"log"
+
+	"code.dogecoin.org/gossip/dnet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func (c *DogeNetClient) GossipMintAmendment(record store.MintAmendment) error {
+	metadata, err := json.Marshal(record.Metadata)
+	if err != nil {
+		return err
+	}
+
+	amendmentMessage := protocol.MintAmendmentMessage{
+		Hash:           record.Hash,
+		MintHash:       record.MintHash,
+		Description:    record.Description,
+		Metadata:       string(metadata),
+		FeedUrl:        record.FeedURL,
+		ContractOfSale: record.ContractOfSale,
+		Timestamp:      record.Timestamp,
+	}
+
+	envelope := protocol.MintAmendmentMessageEnvelope{
+		Type:      protocol.ACTION_MINT_AMENDMENT,
+		Version:   protocol.DEFAULT_VERSION,
+		Payload:   &amendmentMessage,
+		PublicKey: record.PublicKey,
+		Signature: record.Signature,
+	}
+
+	data, err := proto.Marshal(&envelope)
+	if err != nil {
+		log.Fatalf("Failed to marshal: %v", err)
+	}
+
+	encodedMsg := dnet.EncodeMessageRaw(ChanFE, TagMintAmendment, c.feKey, data)
+
+	err = encodedMsg.Send(c.sock)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *DogeNetClient) recvMintAmendment(msg dnet.Message) {
+	log.Printf("[FE] received mint amendment message")
+
+	envelope := protocol.MintAmendmentMessageEnvelope{}
+	err := proto.Unmarshal(msg.Payload, &envelope)
+	if err != nil {
+		log.Println("Error deserializing message envelope:", err)
+		return
+	}
+
+	if envelope.Type != protocol.ACTION_MINT_AMENDMENT {
+		log.Printf("[FE] unexpected action: [%s][%s][%d]", msg.Chan, msg.Tag, envelope.Type)
+		return
+	}
+
+	var metadata store.StringInterfaceMap
+	if envelope.Payload.Metadata != "" {
+		err = json.Unmarshal([]byte(envelope.Payload.Metadata), &metadata)
+		if err != nil {
+			log.Println("Error decoding amendment metadata:", err)
+			return
+		}
+	}
+
+	amendment := store.MintAmendment{
+		MintAmendmentBody: store.MintAmendmentBody{
+			MintHash:       envelope.Payload.MintHash,
+			Description:    envelope.Payload.Description,
+			Metadata:       metadata,
+			FeedURL:        envelope.Payload.FeedUrl,
+			ContractOfSale: envelope.Payload.ContractOfSale,
+			Timestamp:      envelope.Payload.Timestamp,
+		},
+		Hash:      envelope.Payload.Hash,
+		PublicKey: envelope.PublicKey,
+		Signature: envelope.Signature,
+	}
+
+	mint, err := c.store.GetMintByHash(amendment.MintHash)
+	if err != nil || mint.Id == "" {
+		log.Println("Mint not found for amendment:", amendment.MintHash)
+		return
+	}
+
+	err = store.ValidateMintAmendment(mint, amendment)
+	if err != nil {
+		log.Println("Invalid mint amendment:", err)
+		return
+	}
+
+	err = c.store.SaveUnconfirmedMintAmendment(&amendment)
+	if err != nil {
+		log.Println("Error saving unconfirmed mint amendment:", err)
+		return
+	}
+
+	log.Printf("[FE] unconfirmed mint amendment saved: %s", amendment.Hash)
+}
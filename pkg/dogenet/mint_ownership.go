@@ -0,0 +1,92 @@
+package dogenet
+
+import (
+	"log"
+
+	"code.dogecoin.org/gossip/dnet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func (c *DogeNetClient) GossipMintOwnershipTransfer(record store.MintOwnershipTransfer) error {
+	transferMessage := protocol.MintOwnershipTransferMessage{
+		Hash:              record.Hash,
+		MintHash:          record.MintHash,
+		NewOwnerAddress:   record.NewOwnerAddress,
+		NewOwnerPublicKey: record.NewOwnerPublicKey,
+		Timestamp:         record.Timestamp,
+		OwnerPublicKey:    record.OwnerPublicKey,
+		OwnerSignature:    record.OwnerSignature,
+		NewOwnerSignature: record.NewOwnerSignature,
+	}
+
+	envelope := protocol.MintOwnershipTransferMessageEnvelope{
+		Type:    protocol.ACTION_MINT_OWNERSHIP_TRANSFER,
+		Version: protocol.DEFAULT_VERSION,
+		Payload: &transferMessage,
+	}
+
+	data, err := proto.Marshal(&envelope)
+	if err != nil {
+		log.Fatalf("Failed to marshal: %v", err)
+	}
+
+	encodedMsg := dnet.EncodeMessageRaw(ChanFE, TagMintOwnershipTransfer, c.feKey, data)
+
+	err = encodedMsg.Send(c.sock)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *DogeNetClient) recvMintOwnershipTransfer(msg dnet.Message) {
+	log.Printf("[FE] received mint ownership transfer message")
+
+	envelope := protocol.MintOwnershipTransferMessageEnvelope{}
+	err := proto.Unmarshal(msg.Payload, &envelope)
+	if err != nil {
+		log.Println("Error deserializing message envelope:", err)
+		return
+	}
+
+	if envelope.Type != protocol.ACTION_MINT_OWNERSHIP_TRANSFER || envelope.Payload == nil {
+		log.Printf("[FE] unexpected action: [%s][%s][%d]", msg.Chan, msg.Tag, envelope.Type)
+		return
+	}
+
+	transfer := store.MintOwnershipTransfer{
+		MintOwnershipTransferBody: store.MintOwnershipTransferBody{
+			MintHash:          envelope.Payload.MintHash,
+			NewOwnerAddress:   envelope.Payload.NewOwnerAddress,
+			NewOwnerPublicKey: envelope.Payload.NewOwnerPublicKey,
+			Timestamp:         envelope.Payload.Timestamp,
+		},
+		Hash:              envelope.Payload.Hash,
+		OwnerPublicKey:    envelope.Payload.OwnerPublicKey,
+		OwnerSignature:    envelope.Payload.OwnerSignature,
+		NewOwnerSignature: envelope.Payload.NewOwnerSignature,
+	}
+
+	mint, err := c.store.GetMintByHash(transfer.MintHash)
+	if err != nil || mint.Id == "" {
+		log.Println("Mint not found for ownership transfer:", transfer.MintHash)
+		return
+	}
+
+	err = store.ValidateMintOwnershipTransfer(mint, transfer)
+	if err != nil {
+		log.Println("Invalid mint ownership transfer:", err)
+		return
+	}
+
+	err = c.store.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	if err != nil {
+		log.Println("Error saving unconfirmed mint ownership transfer:", err)
+		return
+	}
+
+	log.Printf("[FE] unconfirmed mint ownership transfer saved: %s", transfer.Hash)
+}
@@ -0,0 +1,41 @@
+package dogenet
+
+import (
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+func convertToProtocolMultisigSignatures(signatures store.MultisigSignatures) []*protocol.MultisigSignature {
+	var result []*protocol.MultisigSignature
+	for _, signature := range signatures {
+		result = append(result, &protocol.MultisigSignature{
+			PublicKey: signature.PublicKey,
+			Signature: signature.Signature,
+		})
+	}
+
+	return result
+}
+
+func convertFromProtocolMultisigSignatures(signatures []*protocol.MultisigSignature) store.MultisigSignatures {
+	result := store.MultisigSignatures{}
+	for _, signature := range signatures {
+		result = append(result, doge.MultisigSignature{
+			PublicKey: signature.PublicKey,
+			Signature: signature.Signature,
+		})
+	}
+
+	return result
+}
+
+// envelopeSigner returns the single key, or multisig redeem script, that signed a gossiped envelope.
+func envelopeSigner(publicKey string, signature string, redeemScript string, signatures []*protocol.MultisigSignature) doge.Signer {
+	return doge.Signer{
+		PublicKey:    publicKey,
+		Signature:    signature,
+		RedeemScript: redeemScript,
+		Signatures:   convertFromProtocolMultisigSignatures(signatures),
+	}
+}
@@ -21,7 +21,7 @@ func (c *DogeNetClient) GossipSellOffer(record store.SellOffer) error {
 			OffererAddress: record.OffererAddress,
 			MintHash:       record.MintHash,
 			Quantity:       int32(record.Quantity),
-			Price:          int32(record.Price),
+			PriceKoinu:     record.PriceKoinu,
 		},
 	}
 
@@ -97,7 +97,7 @@ func (c *DogeNetClient) recvSellOffer(msg dnet.Message) {
 		OffererAddress: offer.Payload.OffererAddress,
 		MintHash:       offer.Payload.MintHash,
 		Quantity:       offer.Payload.Quantity,
-		Price:          offer.Payload.Price,
+		PriceKoinu:     offer.Payload.PriceKoinu,
 	}
 
 	offerPayload, err := protojson.Marshal(&signaturePayload)
@@ -137,7 +137,7 @@ func (c *DogeNetClient) recvSellOffer(msg dnet.Message) {
 		MintHash:       offer.Payload.MintHash,
 		Hash:           offer.Hash,
 		Quantity:       int(offer.Payload.Quantity),
-		Price:          int(offer.Payload.Price),
+		PriceKoinu:     offer.Payload.PriceKoinu,
 		CreatedAt:      offer.CreatedAt.AsTime(),
 		PublicKey:      envelope.PublicKey,
 		Signature:      envelope.Signature,
@@ -10,6 +10,10 @@ var TagInvoice = dnet.NewTag("Invo")
 var TagInvoiceSignature = dnet.NewTag("Sign")
 var TagDeleteBuyOffer = dnet.NewTag("DBuyO")
 var TagDeleteSellOffer = dnet.NewTag("DSell")
+var TagMintAllowlistEntry = dnet.NewTag("Allw")
+var TagMintAmendment = dnet.NewTag("Amnd")
+var TagAssetManagerRotation = dnet.NewTag("AMgr")
+var TagMintOwnershipTransfer = dnet.NewTag("Ownr")
 
 type GossipMessage struct {
 	Topic string `json:"topic"`
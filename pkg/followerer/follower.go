@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	fecfg "dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/doge"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 
@@ -20,6 +22,9 @@ import (
 	"github.com/dogecoinfoundation/chainfollower/pkg/types"
 )
 
+// SENDER_RETRY_INTERVAL is how long the follower waits before asking the node for the inputs of a transaction again.
+const SENDER_RETRY_INTERVAL = 5 * time.Second
+
 type DogeFollower struct {
 	cfg           *fecfg.Config
 	store         *store.TokenisationStore
@@ -29,8 +34,14 @@ type DogeFollower struct {
 	context       context.Context
 	cancel        context.CancelFunc
 	rpcClient     rpc.RpcTransportInterface
+	senders       *SenderResolver
+	onBlock       BlockHandler
+	transport     *NotifiedTransport
 }
 
+// BlockHandler is handed the height of each block once its transactions have been saved, in chain order.
+type BlockHandler func(blockHeight int64)
+
 func NewFollower(cfg *fecfg.Config, store *store.TokenisationStore) *DogeFollower {
 	rpcClient := rpc.NewRpcTransport(&config.Config{
 		RpcUrl:  cfg.DogeScheme + "://" + cfg.DogeHost + ":" + cfg.DogePort,
@@ -38,30 +49,43 @@ func NewFollower(cfg *fecfg.Config, store *store.TokenisationStore) *DogeFollowe
 		RpcPass: cfg.DogePassword,
 	})
 
-	chainfollower := chainfollower.NewChainFollower(rpcClient)
-
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &DogeFollower{cfg: cfg, store: store, chainfollower: chainfollower, Running: false, context: ctx, cancel: cancel}
+	transport := NewNotifiedTransport(ctx, rpcClient)
+	chainfollower := chainfollower.NewChainFollower(transport)
+	senders := NewSenderResolver(NewRpcTransactionSource(rpcClient), DEFAULT_PREVOUT_CACHE_SIZE)
+
+	return &DogeFollower{cfg: cfg, store: store, chainfollower: chainfollower, Running: false, context: ctx, cancel: cancel, rpcClient: rpcClient, senders: senders, transport: transport}
 }
 
-func NewFollowerWithCustomChainFollower(cfg *fecfg.Config, store *store.TokenisationStore, chainfollower chainfollower.ChainFollowerInterface) *DogeFollower {
+func NewFollowerWithCustomChainFollower(cfg *fecfg.Config, store *store.TokenisationStore, chainfollower chainfollower.ChainFollowerInterface, transactions TransactionSource) *DogeFollower {
 	ctx, cancel := context.WithCancel(context.Background())
-	return &DogeFollower{cfg: cfg, store: store, chainfollower: chainfollower, Running: false, context: ctx, cancel: cancel}
+	senders := NewSenderResolver(transactions, DEFAULT_PREVOUT_CACHE_SIZE)
+	return &DogeFollower{cfg: cfg, store: store, chainfollower: chainfollower, Running: false, context: ctx, cancel: cancel, senders: senders}
+}
+
+// OnBlock sets the handler the follower hands each block to, such as the transaction processor.
+func (f *DogeFollower) OnBlock(handler BlockHandler) {
+	f.onBlock = handler
+}
+
+// WakeOn follows the tip when the signal fires for a new block instead of asking the node every second.
+func (f *DogeFollower) WakeOn(blocks doge.Signal) {
+	if f.transport != nil {
+		f.transport.WakeOn(blocks, doge.NOTIFIED_POLL_INTERVAL)
+	}
 }
 
 func (f *DogeFollower) Start() error {
 	f.Running = true
 
-	blockHeight, blockHash, _, err := f.store.GetChainPosition()
+	chainPos, err := f.startPosition()
 	if err != nil {
+		f.Running = false
 		return err
 	}
 
-	f.msgChan = f.chainfollower.Start(&state.ChainPos{
-		BlockHash:   blockHash,
-		BlockHeight: blockHeight,
-	})
+	f.msgChan = f.chainfollower.Start(chainPos)
 
 	for {
 		select {
@@ -73,31 +97,31 @@ func (f *DogeFollower) Start() error {
 			switch msg := msg.(type) {
 			case messages.BlockMessage:
 				transactionNumber := 0
+				for _, tx := range msg.Block.Tx {
+					f.senders.Remember(tx)
+				}
+
 				for _, tx := range msg.Block.Tx {
 					fractalMessage, err := GetFractalMessageFromVout(tx.VOut)
 					if err != nil {
 						continue
 					}
 
-					address, err := GetAddressFromVout(tx.VOut)
-					if err != nil {
+					// The action is attributed to the signer of the inputs, outputs can be added to any address
+					address, err := f.resolveSender(tx)
+					if errors.Is(err, ErrNoSender) {
+						log.Println("Skipping transaction without a sender:", tx.Hash, err)
 						continue
 					}
-
-					addressValues := make(map[string]interface{})
-					for _, vout := range tx.VOut {
-						if len(vout.ScriptPubKey.Addresses) == 1 {
-							addy := vout.ScriptPubKey.Addresses[0]
-							value := vout.Value.InexactFloat64()
-							if _, ok := addressValues[addy]; !ok {
-								addressValues[addy] = value
-							} else {
-								addressValues[addy] = addressValues[addy].(float64) + value
-							}
-						}
+					if err != nil {
+						// Stopped while the node could not be reached, the block is followed again on restart
+						fmt.Println("Exiting follower")
+						return nil
 					}
 
-					_, err = f.store.SaveOnChainTransaction(tx.Hash, msg.Block.Height, blockHash, transactionNumber, fractalMessage.Action, fractalMessage.Version, fractalMessage.Data, address, addressValues)
+					addressValues := GetAddressValues(tx.VOut)
+
+					_, err = f.store.SaveOnChainTransactionWithBlockTime(tx.Hash, msg.Block.Height, msg.Block.Hash, int64(msg.Block.Time), transactionNumber, fractalMessage.Action, fractalMessage.Version, fractalMessage.Data, address, addressValues)
 					if err != nil {
 						log.Println("Error saving on chain transaction:", err)
 					}
@@ -112,12 +136,21 @@ func (f *DogeFollower) Start() error {
 					}
 				}
 
+				if f.onBlock != nil {
+					f.onBlock(msg.Block.Height)
+				}
+
 			case messages.RollbackMessage:
-				log.Println("Received rollback message from chainfollower:")
+				log.Println("Received rollback message from chainfollower:", msg.NewChainPos.BlockHeight)
 				if f.cfg.PersistFollower {
-					err := f.store.UpsertChainPosition(msg.NewChainPos.BlockHeight, msg.NewChainPos.BlockHash, msg.NewChainPos.WaitingForNextHash)
+					err := f.store.RollbackToChainPosition(msg.NewChainPos.BlockHeight, msg.NewChainPos.BlockHash, msg.NewChainPos.WaitingForNextHash)
 					if err != nil {
-						log.Println("Error setting chain position:", err)
+						log.Println("Error rolling back to chain position:", err)
+					}
+				} else {
+					err := f.store.RollbackToBlockHeight(msg.NewChainPos.BlockHeight)
+					if err != nil {
+						log.Println("Error rolling back to block height:", err)
 					}
 				}
 
@@ -128,6 +161,43 @@ func (f *DogeFollower) Start() error {
 	}
 }
 
+/*
+* resolveSender returns the sender of the transaction, or ErrNoSender if it has none. Any other error
+* means the node could not look up an input, because it is unreachable or runs without txindex=1,
+* so the lookup is retried until it succeeds: skipping the transaction, or moving the chain position
+* past its block, would lose it. An error is only returned otherwise once the follower is stopped.
+ */
+func (f *DogeFollower) resolveSender(tx types.RawTxn) (string, error) {
+	for {
+		address, err := f.senders.GetSender(tx)
+		if err == nil || errors.Is(err, ErrNoSender) {
+			return address, err
+		}
+
+		log.Println("Error resolving sender of transaction, retrying:", tx.Hash, err)
+
+		select {
+		case <-f.context.Done():
+			return "", err
+		case <-time.After(SENDER_RETRY_INTERVAL):
+		}
+	}
+}
+
+// startPosition checks the stored chain position against the node, which a custom chain follower goes without.
+func (f *DogeFollower) startPosition() (*state.ChainPos, error) {
+	if f.rpcClient != nil {
+		return StartPosition(f.cfg, f.store, f.rpcClient)
+	}
+
+	blockHeight, blockHash, _, err := f.store.GetChainPosition()
+	if err != nil {
+		return nil, err
+	}
+
+	return &state.ChainPos{BlockHash: blockHash, BlockHeight: blockHeight}, nil
+}
+
 func GetFractalMessageFromVout(vout []types.RawTxnVOut) (protocol.MessageEnvelope, error) {
 	var bytes []byte
 	for _, vout := range vout {
@@ -151,14 +221,19 @@ func GetFractalMessageFromVout(vout []types.RawTxnVOut) (protocol.MessageEnvelop
 	return protocol.MessageEnvelope{}, errors.New("no fractal engine message")
 }
 
-func GetAddressFromVout(vout []types.RawTxnVOut) (string, error) {
+// GetAddressValues sums the value paid to each P2PKH and P2SH address by the outputs, in koinu.
+func GetAddressValues(vout []types.RawTxnVOut) store.KoinuValues {
+	addressValues := store.KoinuValues{}
 	for _, vout := range vout {
-		if vout.ScriptPubKey.Type == "pubkeyhash" {
-			return vout.ScriptPubKey.Addresses[0], nil
+		if !IsParticipantOutput(vout) {
+			continue
 		}
+
+		address := vout.ScriptPubKey.Addresses[0]
+		addressValues[address] += vout.Value.Shift(8).IntPart()
 	}
 
-	return "", errors.New("no address found")
+	return addressValues
 }
 
 func ParseOpReturnData(vout types.RawTxnVOut) []byte {
@@ -2,6 +2,8 @@ package followerer_test
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -31,6 +33,38 @@ func (f *FakeChainFollower) Stop() {
 	close(f.Messages)
 }
 
+type FakeTransactionSource struct {
+	Transactions map[string]*types.RawTxn
+}
+
+func (s *FakeTransactionSource) GetRawTransaction(txId string) (*types.RawTxn, error) {
+	tx, ok := s.Transactions[txId]
+	if !ok {
+		return nil, fmt.Errorf("no such transaction: %s", txId)
+	}
+
+	return tx, nil
+}
+
+func fundingSource(txId string, address string) *FakeTransactionSource {
+	return &FakeTransactionSource{Transactions: map[string]*types.RawTxn{
+		txId: {
+			TxID: txId,
+			Hash: txId,
+			VOut: []types.RawTxnVOut{
+				{
+					N: 0,
+					ScriptPubKey: types.RawTxnScriptPubKey{
+						Type:      "pubkeyhash",
+						Addresses: []string{address},
+					},
+					Value: decimal.NewFromInt(1000),
+				},
+			},
+		},
+	}}
+}
+
 func TestDogeFollower(t *testing.T) {
 	tokenisationStore := test_support.SetupTestDB()
 
@@ -38,7 +72,7 @@ func TestDogeFollower(t *testing.T) {
 		Messages: make(chan messages.Message),
 	}
 
-	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{}, tokenisationStore, chainFollower)
+	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{}, tokenisationStore, chainFollower, fundingSource("FundingTX", "1234567890"))
 	go dogeFollower.Start()
 
 	hash := "MyMintHash123"
@@ -52,6 +86,7 @@ func TestDogeFollower(t *testing.T) {
 			Tx: []types.RawTxn{
 				{
 					Hash: "TX123213123123",
+					VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
 					VOut: []types.RawTxnVOut{
 						{
 							ScriptPubKey: types.RawTxnScriptPubKey{
@@ -91,6 +126,328 @@ func TestDogeFollower(t *testing.T) {
 	assert.Equal(t, uint8(protocol.DEFAULT_VERSION), transactions[0].ActionVersion)
 	assert.Equal(t, hex.EncodeToString(mintMessage.Data), hex.EncodeToString(transactions[0].ActionData))
 	assert.Equal(t, "1234567890", transactions[0].Address)
-	assert.Assert(t, transactions[0].Values.Equal(store.StringInterfaceMap{"1234567890": 100}))
+	assert.DeepEqual(t, transactions[0].Values, store.KoinuValues{"1234567890": 10_000_000_000})
+
+}
+
+func TestDogeFollowerRollback(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+
+	chainFollower := &FakeChainFollower{
+		Messages: make(chan messages.Message),
+	}
+
+	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{PersistFollower: true}, tokenisationStore, chainFollower, fundingSource("FundingTX", "1234567890"))
+	go dogeFollower.Start()
+
+	envelope := protocol.NewMintTransactionEnvelope("MyMintHash123", protocol.ACTION_MINT)
+	encodedTransactionBody := envelope.Serialize()
+
+	for height := int64(99); height <= 101; height++ {
+		chainFollower.Messages <- messages.BlockMessage{
+			Block: &types.Block{
+				Hash:   fmt.Sprintf("block%d", height),
+				Height: height,
+				Tx: []types.RawTxn{
+					{
+						Hash: fmt.Sprintf("TX%d", height),
+						VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+						VOut: []types.RawTxnVOut{
+							{
+								ScriptPubKey: types.RawTxnScriptPubKey{
+									Type:      "pubkeyhash",
+									Addresses: []string{"1234567890"},
+									Asm:       "OP_RETURN " + hex.EncodeToString(encodedTransactionBody),
+								},
+								Value: decimal.NewFromInt(100),
+							},
+						},
+					},
+				},
+			},
+			ChainPos: &state.ChainPos{
+				BlockHash:   fmt.Sprintf("block%d", height),
+				BlockHeight: height,
+			},
+		}
+	}
+
+	chainFollower.Messages <- messages.RollbackMessage{
+		OldChainPos: &state.ChainPos{BlockHash: "block101", BlockHeight: 101},
+		NewChainPos: &state.ChainPos{BlockHash: "block99", BlockHeight: 99},
+	}
+
+	time.Sleep(1 * time.Second)
+
+	transactions, err := tokenisationStore.GetOnChainTransactions(0, 100)
+	if err != nil {
+		t.Fatalf("Failed to get on chain transactions: %v", err)
+	}
+
+	assert.Equal(t, 1, len(transactions))
+	assert.Equal(t, "TX99", transactions[0].TxHash)
+	assert.Equal(t, "block99", transactions[0].BlockHash)
+
+	blockHeight, blockHash, _, err := tokenisationStore.GetChainPosition()
+	if err != nil {
+		t.Fatalf("Failed to get chain position: %v", err)
+	}
+
+	assert.Equal(t, int64(99), blockHeight)
+	assert.Equal(t, "block99", blockHash)
+}
+
+func TestDogeFollowerAttributesSenderFromInputs(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+
+	chainFollower := &FakeChainFollower{
+		Messages: make(chan messages.Message),
+	}
+
+	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{}, tokenisationStore, chainFollower, fundingSource("FundingTX", "SignerAddress"))
+	go dogeFollower.Start()
+
+	envelope := protocol.NewMintTransactionEnvelope("MyMintHash123", protocol.ACTION_MINT)
+	encodedTransactionBody := envelope.Serialize()
+
+	chainFollower.Messages <- messages.BlockMessage{
+		Block: &types.Block{
+			Hash:   "block99",
+			Height: 99,
+			Tx: []types.RawTxn{
+				{
+					// Spends an output created earlier in the same block, resolved without the transaction source
+					Hash: "ParentTX",
+					TxID: "ParentTX",
+					VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+					VOut: []types.RawTxnVOut{
+						{
+							N: 1,
+							ScriptPubKey: types.RawTxnScriptPubKey{
+								Type:      "pubkeyhash",
+								Addresses: []string{"ChildSignerAddress"},
+							},
+							Value: decimal.NewFromInt(10),
+						},
+					},
+				},
+				{
+					Hash: "SpoofedTX",
+					VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+					VOut: []types.RawTxnVOut{
+						{
+							N: 0,
+							ScriptPubKey: types.RawTxnScriptPubKey{
+								Type:      "pubkeyhash",
+								Addresses: []string{"VictimAddress"},
+							},
+							Value: decimal.NewFromInt(1),
+						},
+						{
+							N:            1,
+							ScriptPubKey: types.RawTxnScriptPubKey{Asm: "OP_RETURN " + hex.EncodeToString(encodedTransactionBody)},
+						},
+					},
+				},
+				{
+					Hash: "ChildTX",
+					VIn:  []types.RawTxnVIn{{TxID: "ParentTX", VOut: 1}},
+					VOut: []types.RawTxnVOut{
+						{
+							N:            0,
+							ScriptPubKey: types.RawTxnScriptPubKey{Asm: "OP_RETURN " + hex.EncodeToString(encodedTransactionBody)},
+						},
+					},
+				},
+				{
+					// Spends an output the funding transaction does not have, so it has no sender and is skipped
+					Hash: "UnknownOutputTX",
+					VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 5}},
+					VOut: []types.RawTxnVOut{
+						{
+							N:            0,
+							ScriptPubKey: types.RawTxnScriptPubKey{Asm: "OP_RETURN " + hex.EncodeToString(encodedTransactionBody)},
+						},
+					},
+				},
+			},
+		},
+		ChainPos: &state.ChainPos{
+			BlockHash:   "block99",
+			BlockHeight: 99,
+		},
+	}
+
+	time.Sleep(1 * time.Second)
+
+	transactions, err := tokenisationStore.GetOnChainTransactions(0, 100)
+	if err != nil {
+		t.Fatalf("Failed to get on chain transactions: %v", err)
+	}
+
+	assert.Equal(t, 2, len(transactions))
+	assert.Equal(t, "SpoofedTX", transactions[0].TxHash)
+	assert.Equal(t, "SignerAddress", transactions[0].Address)
+	assert.Equal(t, "ChildTX", transactions[1].TxHash)
+	assert.Equal(t, "ChildSignerAddress", transactions[1].Address)
+}
+
+func TestDogeFollowerHoldsBlockWhenNodeCannotResolveSender(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+
+	chainFollower := &FakeChainFollower{
+		Messages: make(chan messages.Message),
+	}
+
+	// A node without txindex=1 cannot look up the transaction spent by the input
+	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{PersistFollower: true}, tokenisationStore, chainFollower, &FakeTransactionSource{})
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- dogeFollower.Start()
+	}()
+
+	envelope := protocol.NewMintTransactionEnvelope("MyMintHash123", protocol.ACTION_MINT)
+
+	chainFollower.Messages <- messages.BlockMessage{
+		Block: &types.Block{
+			Hash:   "block99",
+			Height: 99,
+			Tx: []types.RawTxn{
+				{
+					Hash: "MintTX",
+					VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+					VOut: []types.RawTxnVOut{
+						{
+							N:            0,
+							ScriptPubKey: types.RawTxnScriptPubKey{Asm: "OP_RETURN " + hex.EncodeToString(envelope.Serialize())},
+						},
+					},
+				},
+			},
+		},
+		ChainPos: &state.ChainPos{
+			BlockHash:   "block99",
+			BlockHeight: 99,
+		},
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	dogeFollower.Stop()
+	assert.NilError(t, <-stopped)
+
+	transactions, err := tokenisationStore.GetOnChainTransactions(0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions), 0)
+
+	// The block is followed again on restart
+	blockHeight, blockHash, _, err := tokenisationStore.GetChainPosition()
+	assert.NilError(t, err)
+	assert.Equal(t, blockHeight, int64(0))
+	assert.Equal(t, blockHash, "")
+}
+
+func TestGetAddressValues(t *testing.T) {
+	values := followerer.GetAddressValues([]types.RawTxnVOut{
+		{
+			ScriptPubKey: types.RawTxnScriptPubKey{Type: "pubkeyhash", Addresses: []string{"SellerAddress"}},
+			Value:        decimal.RequireFromString("0.1"),
+		},
+		{
+			ScriptPubKey: types.RawTxnScriptPubKey{Type: "scripthash", Addresses: []string{"CustodianAddress"}},
+			Value:        decimal.RequireFromString("2.5"),
+		},
+		{
+			ScriptPubKey: types.RawTxnScriptPubKey{Type: "pubkeyhash", Addresses: []string{"SellerAddress"}},
+			Value:        decimal.RequireFromString("0.2"),
+		},
+		{
+			// Bare multisig outputs are not paid to a single address
+			ScriptPubKey: types.RawTxnScriptPubKey{Type: "multisig", Addresses: []string{"KeyAddress1", "KeyAddress2"}},
+			Value:        decimal.NewFromInt(1),
+		},
+	})
+
+	assert.Equal(t, len(values), 2)
+	// 0.1 + 0.2 DOGE is exactly 0.3 DOGE in koinu
+	assert.Equal(t, values["SellerAddress"], int64(30_000_000))
+	assert.Equal(t, values["CustodianAddress"], int64(250_000_000))
+}
+
+func TestSenderResolverScriptHashInput(t *testing.T) {
+	resolver := followerer.NewSenderResolver(nil, followerer.DEFAULT_PREVOUT_CACHE_SIZE)
+
+	resolver.Remember(types.RawTxn{
+		TxID: "MultisigFundingTX",
+		VOut: []types.RawTxnVOut{
+			{N: 0, ScriptPubKey: types.RawTxnScriptPubKey{Type: "scripthash", Addresses: []string{"2N767L9qGYgvpLe83nqiy8vPUxvrCmqKhbK"}}},
+			{N: 1, ScriptPubKey: types.RawTxnScriptPubKey{Type: "nonstandard"}},
+		},
+	})
+
+	sender, err := resolver.GetSender(types.RawTxn{Hash: "SpendTX", VIn: []types.RawTxnVIn{{TxID: "MultisigFundingTX", VOut: 0}}})
+	assert.NilError(t, err)
+	assert.Equal(t, sender, "2N767L9qGYgvpLe83nqiy8vPUxvrCmqKhbK")
+
+	_, err = resolver.GetSender(types.RawTxn{Hash: "NonstandardSpendTX", VIn: []types.RawTxnVIn{{TxID: "MultisigFundingTX", VOut: 1}}})
+	assert.Assert(t, errors.Is(err, followerer.ErrNoSender))
+}
+
+func TestDogeFollowerHandsOverBlocksAfterSavingThem(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+
+	chainFollower := &FakeChainFollower{
+		Messages: make(chan messages.Message),
+	}
+
+	dogeFollower := followerer.NewFollowerWithCustomChainFollower(&config.Config{}, tokenisationStore, chainFollower, fundingSource("FundingTX", "1234567890"))
+
+	type handedOver struct {
+		height       int64
+		transactions int
+	}
+	blocks := make(chan handedOver, 2)
+	dogeFollower.OnBlock(func(blockHeight int64) {
+		transactions, err := tokenisationStore.GetOnChainTransactions(0, 100)
+		assert.NilError(t, err)
+		blocks <- handedOver{height: blockHeight, transactions: len(transactions)}
+	})
+
+	go dogeFollower.Start()
+
+	envelope := protocol.NewMintTransactionEnvelope("MyMintHash123", protocol.ACTION_MINT)
+
+	for height := int64(99); height <= 100; height++ {
+		chainFollower.Messages <- messages.BlockMessage{
+			Block: &types.Block{
+				Hash:   fmt.Sprintf("block%d", height),
+				Height: height,
+				Tx: []types.RawTxn{
+					{
+						Hash: fmt.Sprintf("tx%d", height),
+						VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+						VOut: []types.RawTxnVOut{
+							{
+								ScriptPubKey: types.RawTxnScriptPubKey{
+									Type:      "pubkeyhash",
+									Addresses: []string{"1234567890"},
+									Asm:       "OP_RETURN " + hex.EncodeToString(envelope.Serialize()),
+								},
+								Value: decimal.NewFromInt(100),
+							},
+						},
+					},
+				},
+			},
+			ChainPos: &state.ChainPos{
+				BlockHash:   fmt.Sprintf("block%d", height),
+				BlockHeight: height,
+			},
+		}
+	}
+
+	assert.Equal(t, <-blocks, handedOver{height: 99, transactions: 1})
+	assert.Equal(t, <-blocks, handedOver{height: 100, transactions: 2})
 
+	dogeFollower.Stop()
 }
@@ -0,0 +1,231 @@
+package followerer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	fecfg "dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+
+	"github.com/dogecoinfoundation/chainfollower/pkg/config"
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// MempoolSource lists the mempool of the node and looks up the transactions in it.
+type MempoolSource interface {
+	TransactionSource
+	GetRawMempool() ([]string, error)
+}
+
+/*
+* MempoolWatcher records the mints, invoices and payments waiting in the mempool of the node, so they
+* can be shown before the follower sees them in a block. Only the mempool_transactions table is written:
+* balances are left to the processor. A transaction is cleared once it leaves the mempool, which covers
+* transactions that were mined, evicted, or dropped because a conflicting spend was mined.
+ */
+type MempoolWatcher struct {
+	store    *store.TokenisationStore
+	source   MempoolSource
+	senders  *SenderResolver
+	interval time.Duration
+	wake     doge.Signal
+	// Transactions already looked up, fractal or not, so they are fetched once while in the mempool
+	seen    map[string]bool
+	Running bool
+	context context.Context
+	cancel  context.CancelFunc
+}
+
+func NewMempoolWatcher(cfg *fecfg.Config, store *store.TokenisationStore) *MempoolWatcher {
+	rpcClient := rpc.NewRpcTransport(&config.Config{
+		RpcUrl:  cfg.DogeScheme + "://" + cfg.DogeHost + ":" + cfg.DogePort,
+		RpcUser: cfg.DogeUser,
+		RpcPass: cfg.DogePassword,
+	})
+
+	return NewMempoolWatcherWithSource(store, NewRpcTransactionSource(rpcClient), cfg.MempoolPollInterval)
+}
+
+func NewMempoolWatcherWithSource(store *store.TokenisationStore, source MempoolSource, interval time.Duration) *MempoolWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	senders := NewSenderResolver(source, DEFAULT_PREVOUT_CACHE_SIZE)
+	return &MempoolWatcher{store: store, source: source, senders: senders, interval: interval, seen: map[string]bool{}, context: ctx, cancel: cancel}
+}
+
+func (w *MempoolWatcher) Start() {
+	w.Running = true
+
+	for {
+		err := w.Poll()
+		if err != nil {
+			log.Println("Error watching mempool:", err)
+		}
+
+		w.wake.Wait(w.context, w.interval)
+
+		if w.context.Err() != nil {
+			fmt.Println("Exiting mempool watcher")
+			return
+		}
+	}
+}
+
+// WakeOn checks the mempool when the signal fires for a new transaction, polling the node less often.
+func (w *MempoolWatcher) WakeOn(transactions doge.Signal) {
+	w.wake = transactions
+	w.interval = doge.NOTIFIED_POLL_INTERVAL
+}
+
+// Poll records the fractal transactions that entered the mempool and clears the ones that left it.
+func (w *MempoolWatcher) Poll() error {
+	txIds, err := w.source.GetRawMempool()
+	if err != nil {
+		return err
+	}
+
+	inMempool := map[string]bool{}
+	for _, txId := range txIds {
+		inMempool[txId] = true
+
+		if w.seen[txId] {
+			continue
+		}
+
+		tx, err := w.source.GetRawTransaction(txId)
+		if err != nil {
+			// The transaction may have left the mempool since it was listed
+			log.Println("Error getting mempool transaction:", txId, err)
+			continue
+		}
+		w.seen[txId] = true
+
+		if tx.TxID == "" {
+			tx.TxID = txId
+		}
+
+		transactions, err := w.mempoolTransactions(*tx)
+		if err != nil {
+			log.Println("Error reading mempool transaction:", txId, err)
+			continue
+		}
+
+		if len(transactions) == 0 {
+			continue
+		}
+
+		err = w.store.SaveMempoolTransactions(transactions)
+		if err != nil {
+			return err
+		}
+	}
+
+	for txId := range w.seen {
+		if !inMempool[txId] {
+			delete(w.seen, txId)
+		}
+	}
+
+	recorded, err := w.store.GetMempoolTxHashes()
+	if err != nil {
+		return err
+	}
+
+	left := []string{}
+	for _, txHash := range recorded {
+		if !inMempool[txHash] {
+			left = append(left, txHash)
+		}
+	}
+
+	return w.store.ClearMempoolTransactions(left)
+}
+
+// mempoolTransactions returns a row for each mint or invoice the transaction refers to, none if it is not a fractal mint, invoice or payment.
+func (w *MempoolWatcher) mempoolTransactions(tx types.RawTxn) ([]store.MempoolTransaction, error) {
+	fractalMessage, err := GetFractalMessageFromVout(tx.VOut)
+	if err != nil {
+		return nil, nil
+	}
+
+	subject, hashes, err := MempoolSubjects(fractalMessage)
+	if err != nil || len(hashes) == 0 {
+		return nil, err
+	}
+
+	address, err := w.senders.GetSender(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := GetAddressValues(tx.VOut)
+
+	transactions := []store.MempoolTransaction{}
+	for _, hash := range hashes {
+		transactions = append(transactions, store.MempoolTransaction{
+			TxHash:        tx.TxID,
+			Hash:          hash,
+			Subject:       subject,
+			ActionType:    fractalMessage.Action,
+			ActionVersion: fractalMessage.Version,
+			Address:       address,
+			Values:        values,
+		})
+	}
+
+	return transactions, nil
+}
+
+// MempoolSubjects returns whether the message is a mint, invoice or payment, with the hashes of the mints or invoices it refers to.
+func MempoolSubjects(message protocol.MessageEnvelope) (string, []string, error) {
+	switch message.Action {
+	case protocol.ACTION_MINT:
+		var mint protocol.OnChainMintMessage
+		if err := proto.Unmarshal(message.Data, &mint); err != nil {
+			return "", nil, err
+		}
+		return store.MempoolSubject_MINT, []string{mint.Hash}, nil
+
+	case protocol.ACTION_INVOICE:
+		var invoice protocol.OnChainInvoiceMessage
+		if err := proto.Unmarshal(message.Data, &invoice); err != nil {
+			return "", nil, err
+		}
+		return store.MempoolSubject_INVOICE, []string{hex.EncodeToString(invoice.InvoiceHash)}, nil
+
+	case protocol.ACTION_PAYMENT:
+		var payment protocol.OnChainPaymentMessage
+		if err := proto.Unmarshal(message.Data, &payment); err != nil {
+			return "", nil, err
+		}
+		return store.MempoolSubject_PAYMENT, []string{payment.Hash}, nil
+
+	case protocol.ACTION_BATCH_PAYMENT:
+		var batch protocol.OnChainBatchPaymentMessage
+		if err := proto.Unmarshal(message.Data, &batch); err != nil {
+			return "", nil, err
+		}
+
+		hashes := []string{}
+		for _, invoiceHash := range batch.InvoiceHashes {
+			hashes = append(hashes, hex.EncodeToString(invoiceHash))
+		}
+		return store.MempoolSubject_PAYMENT, hashes, nil
+	}
+
+	return "", nil, nil
+}
+
+func (w *MempoolWatcher) Stop() {
+	fmt.Println("Stopping mempool watcher")
+	if w.Running {
+		w.cancel()
+		w.Running = false
+	}
+}
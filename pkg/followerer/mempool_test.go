@@ -0,0 +1,85 @@
+package followerer_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/followerer"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+	"github.com/shopspring/decimal"
+	"gotest.tools/assert"
+)
+
+type FakeMempoolSource struct {
+	*FakeTransactionSource
+	Mempool []string
+}
+
+func (s *FakeMempoolSource) GetRawMempool() ([]string, error) {
+	return s.Mempool, nil
+}
+
+func TestMempoolWatcherRecordsAndClearsPayments(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+
+	invoiceHash := "aa" + hex.EncodeToString(make([]byte, 31))
+	envelope := protocol.NewPaymentTransactionEnvelope(invoiceHash, protocol.ACTION_PAYMENT)
+
+	source := &FakeMempoolSource{FakeTransactionSource: fundingSource("FundingTX", "BuyerAddress")}
+	source.Transactions["PaymentTX"] = &types.RawTxn{
+		TxID: "PaymentTX",
+		Hash: "PaymentTX",
+		VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+		VOut: []types.RawTxnVOut{
+			{
+				N: 0,
+				ScriptPubKey: types.RawTxnScriptPubKey{
+					Type:      "pubkeyhash",
+					Addresses: []string{"SellerAddress"},
+				},
+				Value: decimal.NewFromInt(25),
+			},
+			{
+				N:            1,
+				ScriptPubKey: types.RawTxnScriptPubKey{Asm: "OP_RETURN " + hex.EncodeToString(envelope.Serialize())},
+			},
+		},
+	}
+	source.Mempool = []string{"FundingTX", "PaymentTX"}
+
+	watcher := followerer.NewMempoolWatcherWithSource(tokenisationStore, source, 0)
+
+	err := watcher.Poll()
+	assert.NilError(t, err)
+
+	transactions, err := tokenisationStore.GetMempoolTransactions(invoiceHash, "", "", 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(transactions))
+	assert.Equal(t, "PaymentTX", transactions[0].TxHash)
+	assert.Equal(t, store.MempoolSubject_PAYMENT, transactions[0].Subject)
+	assert.Equal(t, "BuyerAddress", transactions[0].Address)
+	assert.Equal(t, int64(2500000000), transactions[0].Values["SellerAddress"])
+
+	invoices, err := tokenisationStore.WithMempoolPayments([]store.Invoice{{Hash: "UnpaidInvoiceHash"}, {Hash: invoiceHash}})
+	assert.NilError(t, err)
+	assert.Equal(t, "", invoices[0].MempoolPaymentTxHash)
+	assert.Equal(t, "PaymentTX", invoices[1].MempoolPaymentTxHash)
+
+	// Nothing is saved for the processor until the transaction is in a block
+	onchainTransactions, err := tokenisationStore.GetOnChainTransactions(0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(onchainTransactions))
+
+	// Evicted, or dropped for a double spend
+	source.Mempool = []string{"FundingTX"}
+
+	err = watcher.Poll()
+	assert.NilError(t, err)
+
+	transactions, err = tokenisationStore.GetMempoolTransactions("", "", "", 0, 100)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(transactions))
+}
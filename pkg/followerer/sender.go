@@ -0,0 +1,164 @@
+package followerer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+)
+
+const DEFAULT_PREVOUT_CACHE_SIZE = 10000
+
+var ErrNoSender = errors.New("no sender found")
+
+// TransactionSource looks up a transaction by id, used to resolve the outputs spent by a transaction's inputs.
+type TransactionSource interface {
+	GetRawTransaction(txId string) (*types.RawTxn, error)
+}
+
+// RpcTransactionSource resolves transactions with getrawtransaction, which requires txindex=1 on the node.
+type RpcTransactionSource struct {
+	transport *rpc.RpcTransport
+}
+
+func NewRpcTransactionSource(transport *rpc.RpcTransport) *RpcTransactionSource {
+	return &RpcTransactionSource{transport: transport}
+}
+
+func (s *RpcTransactionSource) GetRawTransaction(txId string) (*types.RawTxn, error) {
+	res, err := s.transport.Request("getrawtransaction", []any{txId, true})
+	if err != nil {
+		return nil, err
+	}
+
+	var tx types.RawTxn
+	err = json.Unmarshal(*res, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("json-rpc unmarshal error: %v | %v", err, string(*res))
+	}
+
+	return &tx, nil
+}
+
+// GetRawMempool returns the ids of the transactions in the mempool of the node.
+func (s *RpcTransactionSource) GetRawMempool() ([]string, error) {
+	res, err := s.transport.Request("getrawmempool", []any{false})
+	if err != nil {
+		return nil, err
+	}
+
+	var txIds []string
+	err = json.Unmarshal(*res, &txIds)
+	if err != nil {
+		return nil, fmt.Errorf("json-rpc unmarshal error: %v | %v", err, string(*res))
+	}
+
+	return txIds, nil
+}
+
+/*
+* SenderResolver attributes a transaction to the address that signed it, which is the address
+* of the output spent by its first input. For a P2SH multisig input that is the script hash
+* address, the spend itself proving the redeem script's signatures. Outputs of transactions seen in followed blocks are
+* kept in a bounded cache so most prevouts resolve without a round trip to the node.
+ */
+type SenderResolver struct {
+	source    TransactionSource
+	cacheSize int
+	cache     map[string][]types.RawTxnVOut
+	order     []string
+	mu        sync.Mutex
+}
+
+func NewSenderResolver(source TransactionSource, cacheSize int) *SenderResolver {
+	return &SenderResolver{source: source, cacheSize: cacheSize, cache: map[string][]types.RawTxnVOut{}}
+}
+
+// Remember caches the outputs of the transaction so later transactions spending them resolve locally.
+func (r *SenderResolver) Remember(tx types.RawTxn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cacheSize <= 0 {
+		return
+	}
+
+	txId := tx.TxID
+	if txId == "" {
+		txId = tx.Hash
+	}
+
+	if _, ok := r.cache[txId]; ok {
+		return
+	}
+
+	if len(r.order) >= r.cacheSize {
+		delete(r.cache, r.order[0])
+		r.order = r.order[1:]
+	}
+
+	r.cache[txId] = tx.VOut
+	r.order = append(r.order, txId)
+}
+
+func (r *SenderResolver) GetSender(tx types.RawTxn) (string, error) {
+	if len(tx.VIn) == 0 || tx.VIn[0].TxID == "" {
+		return "", fmt.Errorf("%w: transaction %s has no spendable inputs", ErrNoSender, tx.Hash)
+	}
+
+	prevOut, err := r.prevOut(tx.VIn[0])
+	if err != nil {
+		return "", err
+	}
+
+	if !IsParticipantOutput(prevOut) {
+		return "", fmt.Errorf("%w: input %s:%d is not a pubkeyhash or scripthash output", ErrNoSender, tx.VIn[0].TxID, tx.VIn[0].VOut)
+	}
+
+	return prevOut.ScriptPubKey.Addresses[0], nil
+}
+
+// IsParticipantOutput reports whether the output pays a single P2PKH or P2SH (e.g. multisig) address.
+func IsParticipantOutput(vout types.RawTxnVOut) bool {
+	if len(vout.ScriptPubKey.Addresses) != 1 {
+		return false
+	}
+
+	return vout.ScriptPubKey.Type == doge.AddressType_PUBKEYHASH || vout.ScriptPubKey.Type == doge.AddressType_SCRIPTHASH
+}
+
+func (r *SenderResolver) prevOut(vin types.RawTxnVIn) (types.RawTxnVOut, error) {
+	r.mu.Lock()
+	vouts, ok := r.cache[vin.TxID]
+	r.mu.Unlock()
+
+	if !ok {
+		if r.source == nil {
+			return types.RawTxnVOut{}, fmt.Errorf("%w: input %s is not known", ErrNoSender, vin.TxID)
+		}
+
+		prevTx, err := r.source.GetRawTransaction(vin.TxID)
+		if err != nil {
+			return types.RawTxnVOut{}, err
+		}
+
+		if prevTx.TxID == "" {
+			prevTx.TxID = vin.TxID
+		}
+
+		r.Remember(*prevTx)
+		vouts = prevTx.VOut
+	}
+
+	for _, vout := range vouts {
+		if vout.N == vin.VOut {
+			return vout, nil
+		}
+	}
+
+	return types.RawTxnVOut{}, fmt.Errorf("%w: output %s:%d not found", ErrNoSender, vin.TxID, vin.VOut)
+}
@@ -0,0 +1,76 @@
+package followerer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	fecfg "dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/store"
+
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/state"
+)
+
+var ErrChainPositionNotOnNode = errors.New("chain position is not known to the node")
+var ErrActivationMismatch = errors.New("activation block is not on the chain of the node")
+var ErrActivationRequired = errors.New("no activation block for the chain of the node")
+
+/*
+* StartPosition returns where the follower starts. A stored chain position is checked against the node:
+* a block the node does not know, or knows at another height, means the database was made on another
+* chain and following would mix the two. A stored block that was reorganised away is kept, as the
+* follower rolls back from it. A node without a chain position starts at the activation block of the
+* chain, once the node agrees on its hash. Without one it refuses to start, except on regtest, which
+* starts near the tip of the node.
+ */
+func StartPosition(cfg *fecfg.Config, tokenStore *store.TokenisationStore, node rpc.RpcTransportInterface) (*state.ChainPos, error) {
+	blockHeight, blockHash, _, err := tokenStore.GetChainPosition()
+	if err != nil {
+		return nil, err
+	}
+
+	if blockHash != "" {
+		header, err := node.GetBlockHeader(blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d %s: %v", ErrChainPositionNotOnNode, blockHeight, blockHash, err)
+		}
+
+		if header.Height != blockHeight {
+			return nil, fmt.Errorf("%w: %s is at height %d, not %d", ErrChainPositionNotOnNode, blockHash, header.Height, blockHeight)
+		}
+
+		if !header.IsOnChain() {
+			log.Println("Chain position is no longer on the active chain, rolling back from:", blockHeight, blockHash)
+		}
+
+		return &state.ChainPos{BlockHash: blockHash, BlockHeight: blockHeight}, nil
+	}
+
+	info, err := node.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	activation := cfg.ActivationFor(info.Chain)
+	if !activation.IsSet() {
+		if fecfg.RequiresActivation(info.Chain) {
+			return nil, fmt.Errorf("%w: %s, set --activation-height and --activation-hash", ErrActivationRequired, info.Chain)
+		}
+
+		log.Println("No activation block for chain, following from near the tip:", info.Chain)
+		return &state.ChainPos{}, nil
+	}
+
+	hash, err := node.GetBlockHash(activation.Height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %d %s: %v", ErrActivationMismatch, activation.Height, activation.Hash, err)
+	}
+
+	if hash != activation.Hash {
+		return nil, fmt.Errorf("%w: the node has %s at height %d, not %s", ErrActivationMismatch, hash, activation.Height, activation.Hash)
+	}
+
+	log.Println("Following from the activation block:", activation.Height, activation.Hash)
+	return &state.ChainPos{BlockHash: activation.Hash, BlockHeight: activation.Height}, nil
+}
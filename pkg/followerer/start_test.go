@@ -0,0 +1,112 @@
+package followerer_test
+
+import (
+	"errors"
+	"testing"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/followerer"
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+	"gotest.tools/assert"
+)
+
+type FakeNode struct {
+	rpc.RpcTransportInterface
+	Chain   string
+	Hashes  map[int64]string
+	Headers map[string]*types.BlockHeader
+}
+
+func (n *FakeNode) GetBlockchainInfo() (*types.BlockchainInfo, error) {
+	return &types.BlockchainInfo{Chain: n.Chain}, nil
+}
+
+func (n *FakeNode) GetBlockHash(height int64) (string, error) {
+	hash, ok := n.Hashes[height]
+	if !ok {
+		return "", errors.New("block height out of range")
+	}
+	return hash, nil
+}
+
+func (n *FakeNode) GetBlockHeader(hash string) (*types.BlockHeader, error) {
+	header, ok := n.Headers[hash]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+	return header, nil
+}
+
+func TestStartPositionUsesActivationBlock(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	node := &FakeNode{Chain: "main", Hashes: map[int64]string{100: "ActivationHash"}}
+
+	// Starting near the tip of a chain without a built-in activation would miss the transactions before it
+	cfg := config.NewConfig()
+	other := &FakeNode{Chain: "other"}
+	_, err := followerer.StartPosition(cfg, tokenisationStore, other)
+	assert.Assert(t, errors.Is(err, followerer.ErrActivationRequired))
+
+	regtest := &FakeNode{Chain: "regtest"}
+	chainPos, err := followerer.StartPosition(cfg, tokenisationStore, regtest)
+	assert.NilError(t, err)
+	assert.Equal(t, "", chainPos.BlockHash)
+
+	cfg.ActivationHeight = 100
+	cfg.ActivationHash = "ActivationHash"
+	chainPos, err = followerer.StartPosition(cfg, tokenisationStore, node)
+	assert.NilError(t, err)
+	assert.Equal(t, int64(100), chainPos.BlockHeight)
+	assert.Equal(t, "ActivationHash", chainPos.BlockHash)
+
+	cfg.ActivationHash = "OtherHash"
+	_, err = followerer.StartPosition(cfg, tokenisationStore, node)
+	assert.Assert(t, errors.Is(err, followerer.ErrActivationMismatch))
+}
+
+func TestStartPositionUsesBuiltInActivationBlocks(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	cfg := config.NewConfig()
+
+	// Nodes on main and test start without any activation flags
+	for _, chain := range []string{"main", "test"} {
+		activation := config.DefaultActivations[chain]
+		node := &FakeNode{Chain: chain, Hashes: map[int64]string{activation.Height: activation.Hash}}
+
+		chainPos, err := followerer.StartPosition(cfg, tokenisationStore, node)
+		assert.NilError(t, err, chain)
+		assert.Equal(t, activation.Height, chainPos.BlockHeight)
+		assert.Equal(t, activation.Hash, chainPos.BlockHash)
+	}
+}
+
+func TestStartPositionChecksStoredPosition(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	node := &FakeNode{Chain: "main", Headers: map[string]*types.BlockHeader{
+		"StaleHash": {Hash: "StaleHash", Height: 120, Confirmations: -1},
+	}}
+
+	cfg := config.NewConfig()
+	cfg.ActivationHeight = 100
+	cfg.ActivationHash = "ActivationHash"
+
+	// A block reorganised away is kept for the follower to roll back from
+	err := tokenisationStore.UpsertChainPosition(120, "StaleHash", false)
+	assert.NilError(t, err)
+	chainPos, err := followerer.StartPosition(cfg, tokenisationStore, node)
+	assert.NilError(t, err)
+	assert.Equal(t, "StaleHash", chainPos.BlockHash)
+
+	// A block from another chain is not
+	err = tokenisationStore.UpsertChainPosition(130, "OtherChainHash", false)
+	assert.NilError(t, err)
+	_, err = followerer.StartPosition(cfg, tokenisationStore, node)
+	assert.Assert(t, errors.Is(err, followerer.ErrChainPositionNotOnNode))
+
+	err = tokenisationStore.UpsertChainPosition(121, "StaleHash", false)
+	assert.NilError(t, err)
+	_, err = followerer.StartPosition(cfg, tokenisationStore, node)
+	assert.Assert(t, errors.Is(err, followerer.ErrChainPositionNotOnNode))
+}
@@ -0,0 +1,64 @@
+package followerer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+)
+
+/*
+* NotifiedTransport holds the chain follower back once it has caught up with the tip, which it otherwise
+* asks the node about every second: the header of the tip is only looked up again once the node announces
+* a block, or the poll interval passes. Without a signal every lookup goes straight to the node.
+ */
+type NotifiedTransport struct {
+	rpc.RpcTransportInterface
+	context  context.Context
+	mu       sync.Mutex
+	blocks   doge.Signal
+	interval time.Duration
+	tip      string
+}
+
+func NewNotifiedTransport(ctx context.Context, transport rpc.RpcTransportInterface) *NotifiedTransport {
+	return &NotifiedTransport{RpcTransportInterface: transport, context: ctx}
+}
+
+// WakeOn looks the tip up again when the signal fires for a new block, or after the interval.
+func (t *NotifiedTransport) WakeOn(blocks doge.Signal, interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.blocks = blocks
+	t.interval = interval
+}
+
+func (t *NotifiedTransport) GetBlockHeader(hash string) (*types.BlockHeader, error) {
+	t.mu.Lock()
+	blocks, interval := t.blocks, t.interval
+	atTip := blocks != nil && hash == t.tip
+	t.mu.Unlock()
+
+	if atTip {
+		blocks.Wait(t.context, interval)
+	}
+
+	header, err := t.RpcTransportInterface.GetBlockHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	if header.NextBlockHash == "" {
+		t.tip = hash
+	} else {
+		t.tip = ""
+	}
+	t.mu.Unlock()
+
+	return header, nil
+}
@@ -0,0 +1,79 @@
+package followerer_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/followerer"
+	"github.com/dogecoinfoundation/chainfollower/pkg/rpc"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+	"gotest.tools/assert"
+)
+
+type FakeHeaderTransport struct {
+	rpc.RpcTransportInterface
+	mu      sync.Mutex
+	headers map[string]*types.BlockHeader
+	lookups int
+}
+
+func (f *FakeHeaderTransport) GetBlockHeader(hash string) (*types.BlockHeader, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lookups++
+	header := *f.headers[hash]
+	return &header, nil
+}
+
+func (f *FakeHeaderTransport) setNext(hash string, next string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.headers[hash].NextBlockHash = next
+}
+
+func TestNotifiedTransportWaitsForBlockAtTip(t *testing.T) {
+	fake := &FakeHeaderTransport{headers: map[string]*types.BlockHeader{
+		"A": {Hash: "A", Height: 1, NextBlockHash: "B"},
+		"B": {Hash: "B", Height: 2},
+	}}
+
+	transport := followerer.NewNotifiedTransport(context.Background(), fake)
+	blocks := doge.NewSignal()
+	transport.WakeOn(blocks, time.Minute)
+
+	// Behind the tip and on reaching it, lookups are not held back
+	_, err := transport.GetBlockHeader("A")
+	assert.NilError(t, err)
+	header, err := transport.GetBlockHeader("B")
+	assert.NilError(t, err)
+	assert.Equal(t, "", header.NextBlockHash)
+
+	result := make(chan *types.BlockHeader)
+	go func() {
+		header, _ := transport.GetBlockHeader("B")
+		result <- header
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("tip was looked up again before a block was announced")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	fake.setNext("B", "C")
+	blocks.Notify()
+
+	select {
+	case header := <-result:
+		assert.Equal(t, "C", header.NextBlockHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("tip was not looked up when a block was announced")
+	}
+
+	assert.Equal(t, 3, fake.lookups)
+}
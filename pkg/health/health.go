@@ -1,6 +1,7 @@
 package health
 
 import (
+	"context"
 	"log"
 	"time"
 
@@ -12,10 +13,18 @@ type HealthService struct {
 	dogeClient *doge.RpcClient
 	tokenStore *store.TokenisationStore
 	running    bool
+	blocks     doge.Signal
+	interval   time.Duration
 }
 
 func NewHealthService(dogeClient *doge.RpcClient, tokenStore *store.TokenisationStore) *HealthService {
-	return &HealthService{dogeClient: dogeClient, tokenStore: tokenStore, running: false}
+	return &HealthService{dogeClient: dogeClient, tokenStore: tokenStore, running: false, interval: 10 * time.Second}
+}
+
+// WakeOn updates the health when the signal fires for a new block, polling the node less often.
+func (h *HealthService) WakeOn(blocks doge.Signal) {
+	h.blocks = blocks
+	h.interval = doge.NOTIFIED_POLL_INTERVAL
 }
 
 func (h *HealthService) Start() {
@@ -59,7 +68,7 @@ func (h *HealthService) Start() {
 			log.Println("Error upserting health:", err)
 		}
 
-		time.Sleep(10 * time.Second)
+		h.blocks.Wait(context.Background(), h.interval)
 
 		if !h.running {
 			break
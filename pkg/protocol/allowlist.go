@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewAllowlistEntryTransactionEnvelope(entryHash string, mintHash string, action uint8) MessageEnvelope {
+	entryHashBytes, err := hex.DecodeString(entryHash)
+	if err != nil {
+		log.Printf("Failed to decode allowlist entry hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainAllowlistEntryMessage{
+		EntryHash: entryHashBytes,
+		MintHash:  mintHashBytes,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
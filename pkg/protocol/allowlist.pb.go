@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/allowlist.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// Entries are authorised by the signature of an asset manager, so any address may anchor them
+type OnChainAllowlistEntryMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	EntryHash     []byte                 `protobuf:"bytes,2,opt,name=entry_hash,json=entryHash,proto3" json:"entry_hash,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainAllowlistEntryMessage) Reset() {
+	*x = OnChainAllowlistEntryMessage{}
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainAllowlistEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainAllowlistEntryMessage) ProtoMessage() {}
+
+func (x *OnChainAllowlistEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainAllowlistEntryMessage.ProtoReflect.Descriptor instead.
+func (*OnChainAllowlistEntryMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_allowlist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainAllowlistEntryMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainAllowlistEntryMessage) GetEntryHash() []byte {
+	if x != nil {
+		return x.EntryHash
+	}
+	return nil
+}
+
+func (x *OnChainAllowlistEntryMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+// Allowlist entries are maintained by a mint's asset managers, gossiped and stored until anchored on chain
+type MintAllowlistEntryMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MintHash      string                 `protobuf:"bytes,1,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	Entry         string                 `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	Allowed       bool                   `protobuf:"varint,3,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintAllowlistEntryMessage) Reset() {
+	*x = MintAllowlistEntryMessage{}
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintAllowlistEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAllowlistEntryMessage) ProtoMessage() {}
+
+func (x *MintAllowlistEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAllowlistEntryMessage.ProtoReflect.Descriptor instead.
+func (*MintAllowlistEntryMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_allowlist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MintAllowlistEntryMessage) GetMintHash() string {
+	if x != nil {
+		return x.MintHash
+	}
+	return ""
+}
+
+func (x *MintAllowlistEntryMessage) GetEntry() string {
+	if x != nil {
+		return x.Entry
+	}
+	return ""
+}
+
+func (x *MintAllowlistEntryMessage) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *MintAllowlistEntryMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type MintAllowlistEntryMessageEnvelope struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Type          int32                      `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version       int32                      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload       *MintAllowlistEntryMessage `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	PublicKey     string                     `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     string                     `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) Reset() {
+	*x = MintAllowlistEntryMessageEnvelope{}
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAllowlistEntryMessageEnvelope) ProtoMessage() {}
+
+func (x *MintAllowlistEntryMessageEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_allowlist_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAllowlistEntryMessageEnvelope.ProtoReflect.Descriptor instead.
+func (*MintAllowlistEntryMessageEnvelope) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_allowlist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) GetPayload() *MintAllowlistEntryMessage {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *MintAllowlistEntryMessageEnvelope) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+var File_pkg_protocol_allowlist_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_allowlist_proto_rawDesc = "" +
+	"\n" +
+	"\x1cpkg/protocol/allowlist.proto\x12\rfractalengine\"t\n" +
+	"\x1cOnChainAllowlistEntryMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1d\n" +
+	"\n" +
+	"entry_hash\x18\x02 \x01(\fR\tentryHash\x12\x1b\n" +
+	"\tmint_hash\x18\x03 \x01(\fR\bmintHash\"\x86\x01\n" +
+	"\x19MintAllowlistEntryMessage\x12\x1b\n" +
+	"\tmint_hash\x18\x01 \x01(\tR\bmintHash\x12\x14\n" +
+	"\x05entry\x18\x02 \x01(\tR\x05entry\x12\x18\n" +
+	"\aallowed\x18\x03 \x01(\bR\aallowed\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\xd2\x01\n" +
+	"!MintAllowlistEntryMessageEnvelope\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12B\n" +
+	"\apayload\x18\x03 \x01(\v2(.fractalengine.MintAllowlistEntryMessageR\apayload\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\tR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\tR\tsignatureB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_allowlist_proto_rawDescOnce sync.Once
+	file_pkg_protocol_allowlist_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_allowlist_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_allowlist_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_allowlist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_allowlist_proto_rawDesc), len(file_pkg_protocol_allowlist_proto_rawDesc)))
+	})
+	return file_pkg_protocol_allowlist_proto_rawDescData
+}
+
+var file_pkg_protocol_allowlist_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_protocol_allowlist_proto_goTypes = []any{
+	(*OnChainAllowlistEntryMessage)(nil),      // 0: fractalengine.OnChainAllowlistEntryMessage
+	(*MintAllowlistEntryMessage)(nil),         // 1: fractalengine.MintAllowlistEntryMessage
+	(*MintAllowlistEntryMessageEnvelope)(nil), // 2: fractalengine.MintAllowlistEntryMessageEnvelope
+}
+var file_pkg_protocol_allowlist_proto_depIdxs = []int32{
+	1, // 0: fractalengine.MintAllowlistEntryMessageEnvelope.payload:type_name -> fractalengine.MintAllowlistEntryMessage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_allowlist_proto_init() }
+func file_pkg_protocol_allowlist_proto_init() {
+	if File_pkg_protocol_allowlist_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_allowlist_proto_rawDesc), len(file_pkg_protocol_allowlist_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_allowlist_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_allowlist_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_allowlist_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_allowlist_proto = out.File
+	file_pkg_protocol_allowlist_proto_goTypes = nil
+	file_pkg_protocol_allowlist_proto_depIdxs = nil
+}
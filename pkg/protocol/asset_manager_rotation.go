@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewAssetManagerRotationTransactionEnvelope(rotationHash string, mintHash string, action uint8) MessageEnvelope {
+	rotationHashBytes, err := hex.DecodeString(rotationHash)
+	if err != nil {
+		log.Printf("Failed to decode rotation hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainAssetManagerRotationMessage{
+		RotationHash: rotationHashBytes,
+		MintHash:     mintHashBytes,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
@@ -0,0 +1,438 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/asset_manager_rotation.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// The rotation is authorised by the asset manager approvals in the gossiped message
+type OnChainAssetManagerRotationMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	RotationHash  []byte                 `protobuf:"bytes,2,opt,name=rotation_hash,json=rotationHash,proto3" json:"rotation_hash,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainAssetManagerRotationMessage) Reset() {
+	*x = OnChainAssetManagerRotationMessage{}
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainAssetManagerRotationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainAssetManagerRotationMessage) ProtoMessage() {}
+
+func (x *OnChainAssetManagerRotationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainAssetManagerRotationMessage.ProtoReflect.Descriptor instead.
+func (*OnChainAssetManagerRotationMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainAssetManagerRotationMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainAssetManagerRotationMessage) GetRotationHash() []byte {
+	if x != nil {
+		return x.RotationHash
+	}
+	return nil
+}
+
+func (x *OnChainAssetManagerRotationMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+// This is what gets gossiped + stored until the rotation is anchored on chain
+type AssetManagerRotationMessageEnvelope struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Type          int32                        `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version       int32                        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload       *AssetManagerRotationMessage `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetManagerRotationMessageEnvelope) Reset() {
+	*x = AssetManagerRotationMessageEnvelope{}
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetManagerRotationMessageEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetManagerRotationMessageEnvelope) ProtoMessage() {}
+
+func (x *AssetManagerRotationMessageEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetManagerRotationMessageEnvelope.ProtoReflect.Descriptor instead.
+func (*AssetManagerRotationMessageEnvelope) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssetManagerRotationMessageEnvelope) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *AssetManagerRotationMessageEnvelope) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AssetManagerRotationMessageEnvelope) GetPayload() *AssetManagerRotationMessage {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// Payload of an asset manager rotation
+// public_key is the manager being removed or replaced, manager is the manager being added
+type AssetManagerRotationMessage struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Hash          string                  `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	MintHash      string                  `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	Action        string                  `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	PublicKey     string                  `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Manager       *RotatedAssetManager    `protobuf:"bytes,5,opt,name=manager,proto3" json:"manager,omitempty"`
+	Timestamp     int64                   `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Approvals     []*AssetManagerApproval `protobuf:"bytes,7,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetManagerRotationMessage) Reset() {
+	*x = AssetManagerRotationMessage{}
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetManagerRotationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetManagerRotationMessage) ProtoMessage() {}
+
+func (x *AssetManagerRotationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetManagerRotationMessage.ProtoReflect.Descriptor instead.
+func (*AssetManagerRotationMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AssetManagerRotationMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *AssetManagerRotationMessage) GetMintHash() string {
+	if x != nil {
+		return x.MintHash
+	}
+	return ""
+}
+
+func (x *AssetManagerRotationMessage) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AssetManagerRotationMessage) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *AssetManagerRotationMessage) GetManager() *RotatedAssetManager {
+	if x != nil {
+		return x.Manager
+	}
+	return nil
+}
+
+func (x *AssetManagerRotationMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *AssetManagerRotationMessage) GetApprovals() []*AssetManagerApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type RotatedAssetManager struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PublicKey     string                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotatedAssetManager) Reset() {
+	*x = RotatedAssetManager{}
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotatedAssetManager) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotatedAssetManager) ProtoMessage() {}
+
+func (x *RotatedAssetManager) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotatedAssetManager.ProtoReflect.Descriptor instead.
+func (*RotatedAssetManager) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RotatedAssetManager) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RotatedAssetManager) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *RotatedAssetManager) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type AssetManagerApproval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     string                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetManagerApproval) Reset() {
+	*x = AssetManagerApproval{}
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetManagerApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetManagerApproval) ProtoMessage() {}
+
+func (x *AssetManagerApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_asset_manager_rotation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetManagerApproval.ProtoReflect.Descriptor instead.
+func (*AssetManagerApproval) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AssetManagerApproval) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *AssetManagerApproval) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+var File_pkg_protocol_asset_manager_rotation_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_asset_manager_rotation_proto_rawDesc = "" +
+	"\n" +
+	")pkg/protocol/asset_manager_rotation.proto\x12\rfractalengine\"\x80\x01\n" +
+	"\"OnChainAssetManagerRotationMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12#\n" +
+	"\rrotation_hash\x18\x02 \x01(\fR\frotationHash\x12\x1b\n" +
+	"\tmint_hash\x18\x03 \x01(\fR\bmintHash\"\x99\x01\n" +
+	"#AssetManagerRotationMessageEnvelope\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12D\n" +
+	"\apayload\x18\x03 \x01(\v2*.fractalengine.AssetManagerRotationMessageR\apayload\"\xa4\x02\n" +
+	"\x1bAssetManagerRotationMessage\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x1b\n" +
+	"\tmint_hash\x18\x02 \x01(\tR\bmintHash\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\tR\tpublicKey\x12<\n" +
+	"\amanager\x18\x05 \x01(\v2\".fractalengine.RotatedAssetManagerR\amanager\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12A\n" +
+	"\tapprovals\x18\a \x03(\v2#.fractalengine.AssetManagerApprovalR\tapprovals\"Z\n" +
+	"\x13RotatedAssetManager\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\tR\tpublicKey\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"S\n" +
+	"\x14AssetManagerApproval\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\tR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignatureB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_asset_manager_rotation_proto_rawDescOnce sync.Once
+	file_pkg_protocol_asset_manager_rotation_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_asset_manager_rotation_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_asset_manager_rotation_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_asset_manager_rotation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_asset_manager_rotation_proto_rawDesc), len(file_pkg_protocol_asset_manager_rotation_proto_rawDesc)))
+	})
+	return file_pkg_protocol_asset_manager_rotation_proto_rawDescData
+}
+
+var file_pkg_protocol_asset_manager_rotation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_pkg_protocol_asset_manager_rotation_proto_goTypes = []any{
+	(*OnChainAssetManagerRotationMessage)(nil),  // 0: fractalengine.OnChainAssetManagerRotationMessage
+	(*AssetManagerRotationMessageEnvelope)(nil), // 1: fractalengine.AssetManagerRotationMessageEnvelope
+	(*AssetManagerRotationMessage)(nil),         // 2: fractalengine.AssetManagerRotationMessage
+	(*RotatedAssetManager)(nil),                 // 3: fractalengine.RotatedAssetManager
+	(*AssetManagerApproval)(nil),                // 4: fractalengine.AssetManagerApproval
+}
+var file_pkg_protocol_asset_manager_rotation_proto_depIdxs = []int32{
+	2, // 0: fractalengine.AssetManagerRotationMessageEnvelope.payload:type_name -> fractalengine.AssetManagerRotationMessage
+	3, // 1: fractalengine.AssetManagerRotationMessage.manager:type_name -> fractalengine.RotatedAssetManager
+	4, // 2: fractalengine.AssetManagerRotationMessage.approvals:type_name -> fractalengine.AssetManagerApproval
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_asset_manager_rotation_proto_init() }
+func file_pkg_protocol_asset_manager_rotation_proto_init() {
+	if File_pkg_protocol_asset_manager_rotation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_asset_manager_rotation_proto_rawDesc), len(file_pkg_protocol_asset_manager_rotation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_asset_manager_rotation_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_asset_manager_rotation_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_asset_manager_rotation_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_asset_manager_rotation_proto = out.File
+	file_pkg_protocol_asset_manager_rotation_proto_goTypes = nil
+	file_pkg_protocol_asset_manager_rotation_proto_depIdxs = nil
+}
@@ -0,0 +1,35 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewBurnTransactionEnvelope(mintHash string, quantity int32, burnHash string, action uint8) MessageEnvelope {
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	burnHashBytes, err := hex.DecodeString(burnHash)
+	if err != nil {
+		log.Printf("Failed to decode burn hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainBurnMessage{
+		MintHash: mintHashBytes,
+		Quantity: quantity,
+		BurnHash: burnHashBytes,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
@@ -172,9 +172,10 @@ type BuyOfferPayload struct {
 	SellerAddress  string                 `protobuf:"bytes,2,opt,name=seller_address,json=sellerAddress,proto3" json:"seller_address,omitempty"`
 	MintHash       string                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
 	Quantity       int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price          int32                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Price per fraction, in koinu
+	PriceKoinu    int64 `protobuf:"varint,6,opt,name=price_koinu,json=priceKoinu,proto3" json:"price_koinu,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BuyOfferPayload) Reset() {
@@ -235,9 +236,9 @@ func (x *BuyOfferPayload) GetQuantity() int32 {
 	return 0
 }
 
-func (x *BuyOfferPayload) GetPrice() int32 {
+func (x *BuyOfferPayload) GetPriceKoinu() int64 {
 	if x != nil {
-		return x.Price
+		return x.PriceKoinu
 	}
 	return 0
 }
@@ -379,13 +380,14 @@ const file_pkg_protocol_buy_offers_proto_rawDesc = "" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x128\n" +
 	"\apayload\x18\x03 \x01(\v2\x1e.fractalengine.BuyOfferPayloadR\apayload\x129\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb0\x01\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc1\x01\n" +
 	"\x0fBuyOfferPayload\x12'\n" +
 	"\x0fofferer_address\x18\x01 \x01(\tR\x0eoffererAddress\x12%\n" +
 	"\x0eseller_address\x18\x02 \x01(\tR\rsellerAddress\x12\x1b\n" +
 	"\tmint_hash\x18\x03 \x01(\tR\bmintHash\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x05R\x05price\"+\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vprice_koinu\x18\x06 \x01(\x03R\n" +
+	"priceKoinuJ\x04\b\x05\x10\x06\"+\n" +
 	"\x15DeleteBuyOfferMessage\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\tR\x04hash\"\xca\x01\n" +
 	"\x1dDeleteBuyOfferMessageEnvelope\x12\x12\n" +
@@ -0,0 +1,48 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewDistributionTransactionEnvelope(mintHash string, totalKoinu int64, recordHeight int64, action uint8) MessageEnvelope {
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainDistributionMessage{
+		MintHash:     mintHashBytes,
+		TotalKoinu:   totalKoinu,
+		RecordHeight: recordHeight,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
+
+func NewDistributionPayoutTransactionEnvelope(distributionHash string, action uint8) MessageEnvelope {
+	distributionHashBytes, err := hex.DecodeString(distributionHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainDistributionPayoutMessage{
+		DistributionHash: distributionHashBytes,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
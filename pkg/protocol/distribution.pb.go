@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/distribution.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// The mint owner is the address proven by the transaction itself
+// Holders are snapshotted at record_height and total_koinu is shared pro rata
+type OnChainDistributionMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	TotalKoinu    int64                  `protobuf:"varint,3,opt,name=total_koinu,json=totalKoinu,proto3" json:"total_koinu,omitempty"`
+	RecordHeight  int64                  `protobuf:"varint,4,opt,name=record_height,json=recordHeight,proto3" json:"record_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainDistributionMessage) Reset() {
+	*x = OnChainDistributionMessage{}
+	mi := &file_pkg_protocol_distribution_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainDistributionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainDistributionMessage) ProtoMessage() {}
+
+func (x *OnChainDistributionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_distribution_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainDistributionMessage.ProtoReflect.Descriptor instead.
+func (*OnChainDistributionMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_distribution_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainDistributionMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainDistributionMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+func (x *OnChainDistributionMessage) GetTotalKoinu() int64 {
+	if x != nil {
+		return x.TotalKoinu
+	}
+	return 0
+}
+
+func (x *OnChainDistributionMessage) GetRecordHeight() int64 {
+	if x != nil {
+		return x.RecordHeight
+	}
+	return 0
+}
+
+// Written to the OP_RETURN of a transaction paying out a distribution
+// distribution_hash is the transaction hash of the distribution record
+type OnChainDistributionPayoutMessage struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Version          int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	DistributionHash []byte                 `protobuf:"bytes,2,opt,name=distribution_hash,json=distributionHash,proto3" json:"distribution_hash,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *OnChainDistributionPayoutMessage) Reset() {
+	*x = OnChainDistributionPayoutMessage{}
+	mi := &file_pkg_protocol_distribution_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainDistributionPayoutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainDistributionPayoutMessage) ProtoMessage() {}
+
+func (x *OnChainDistributionPayoutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_distribution_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainDistributionPayoutMessage.ProtoReflect.Descriptor instead.
+func (*OnChainDistributionPayoutMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_distribution_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OnChainDistributionPayoutMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainDistributionPayoutMessage) GetDistributionHash() []byte {
+	if x != nil {
+		return x.DistributionHash
+	}
+	return nil
+}
+
+var File_pkg_protocol_distribution_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_distribution_proto_rawDesc = "" +
+	"\n" +
+	"\x1fpkg/protocol/distribution.proto\x12\rfractalengine\"\x99\x01\n" +
+	"\x1aOnChainDistributionMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1b\n" +
+	"\tmint_hash\x18\x02 \x01(\fR\bmintHash\x12\x1f\n" +
+	"\vtotal_koinu\x18\x03 \x01(\x03R\n" +
+	"totalKoinu\x12#\n" +
+	"\rrecord_height\x18\x04 \x01(\x03R\frecordHeight\"i\n" +
+	" OnChainDistributionPayoutMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12+\n" +
+	"\x11distribution_hash\x18\x02 \x01(\fR\x10distributionHashB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_distribution_proto_rawDescOnce sync.Once
+	file_pkg_protocol_distribution_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_distribution_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_distribution_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_distribution_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_distribution_proto_rawDesc), len(file_pkg_protocol_distribution_proto_rawDesc)))
+	})
+	return file_pkg_protocol_distribution_proto_rawDescData
+}
+
+var file_pkg_protocol_distribution_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_protocol_distribution_proto_goTypes = []any{
+	(*OnChainDistributionMessage)(nil),       // 0: fractalengine.OnChainDistributionMessage
+	(*OnChainDistributionPayoutMessage)(nil), // 1: fractalengine.OnChainDistributionPayoutMessage
+}
+var file_pkg_protocol_distribution_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_distribution_proto_init() }
+func file_pkg_protocol_distribution_proto_init() {
+	if File_pkg_protocol_distribution_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_distribution_proto_rawDesc), len(file_pkg_protocol_distribution_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_distribution_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_distribution_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_distribution_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_distribution_proto = out.File
+	file_pkg_protocol_distribution_proto_goTypes = nil
+	file_pkg_protocol_distribution_proto_depIdxs = nil
+}
@@ -3,7 +3,7 @@ package protocol
 import (
 	"bytes"
 	"encoding/hex"
-	"fmt"
+	"log"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -12,24 +12,45 @@ type InvoiceTransaction struct {
 	InvoiceID string `json:"invoice_id"`
 }
 
-func NewInvoiceTransactionEnvelope(hash string, mintHash string, quantity int32, action uint8) MessageEnvelope {
+func NewInvoiceTransactionEnvelope(hash string, mintHash string, quantity int32, expiryHeight int64, action uint8) MessageEnvelope {
 	hashBytes, err := hex.DecodeString(hash)
 	if err != nil {
-		fmt.Errorf("Failed to decode hash: %s", err.Error())
+		log.Printf("Failed to decode hash: %s", err.Error())
 		return MessageEnvelope{}
 	}
 
 	mintHashBytes, err := hex.DecodeString(mintHash)
 	if err != nil {
-		fmt.Errorf("Failed to decode hash: %s", err.Error())
+		log.Printf("Failed to decode hash: %s", err.Error())
 		return MessageEnvelope{}
 	}
 
 	message := &OnChainInvoiceMessage{
+		Version:      DEFAULT_VERSION,
+		InvoiceHash:  hashBytes,
+		MintHash:     mintHashBytes,
+		Quantity:     quantity,
+		ExpiryHeight: expiryHeight,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
+
+func NewInvoiceCancellationTransactionEnvelope(invoiceHash string, action uint8) MessageEnvelope {
+	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainInvoiceCancellationMessage{
 		Version:     DEFAULT_VERSION,
-		InvoiceHash: hashBytes,
-		MintHash:    mintHashBytes,
-		Quantity:    quantity,
+		InvoiceHash: invoiceHashBytes,
 	}
 
 	protoBytes, err := proto.Marshal(message)
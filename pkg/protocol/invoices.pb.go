@@ -24,11 +24,13 @@ const (
 
 // This is what gets written to the OP_RETURN on the L1
 type OnChainInvoiceMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
-	InvoiceHash   []byte                 `protobuf:"bytes,2,opt,name=invoice_hash,json=invoiceHash,proto3" json:"invoice_hash,omitempty"`
-	MintHash      []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Version     int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	InvoiceHash []byte                 `protobuf:"bytes,2,opt,name=invoice_hash,json=invoiceHash,proto3" json:"invoice_hash,omitempty"`
+	MintHash    []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	Quantity    int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Last block height a payment for the invoice is accepted at, 0 for no expiry
+	ExpiryHeight  int64 `protobuf:"varint,5,opt,name=expiry_height,json=expiryHeight,proto3" json:"expiry_height,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -91,21 +93,85 @@ func (x *OnChainInvoiceMessage) GetQuantity() int32 {
 	return 0
 }
 
+func (x *OnChainInvoiceMessage) GetExpiryHeight() int64 {
+	if x != nil {
+		return x.ExpiryHeight
+	}
+	return 0
+}
+
+// Written to the OP_RETURN by the seller to cancel an unpaid invoice
+// The seller is the address proven by the transaction itself
+type OnChainInvoiceCancellationMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	InvoiceHash   []byte                 `protobuf:"bytes,2,opt,name=invoice_hash,json=invoiceHash,proto3" json:"invoice_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainInvoiceCancellationMessage) Reset() {
+	*x = OnChainInvoiceCancellationMessage{}
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainInvoiceCancellationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainInvoiceCancellationMessage) ProtoMessage() {}
+
+func (x *OnChainInvoiceCancellationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainInvoiceCancellationMessage.ProtoReflect.Descriptor instead.
+func (*OnChainInvoiceCancellationMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OnChainInvoiceCancellationMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainInvoiceCancellationMessage) GetInvoiceHash() []byte {
+	if x != nil {
+		return x.InvoiceHash
+	}
+	return nil
+}
+
 // This is what gets gossiped + stored in the gossip mempool + confirmed_transactions
 type InvoiceMessageEnvelope struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
-	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Payload       *InvoiceMessage        `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
-	PublicKey     string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	Signature     string                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Type      int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version   int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload   *InvoiceMessage        `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	PublicKey string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature string                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Set instead of public_key and signature when signed for a P2SH multisig address
+	RedeemScript  string               `protobuf:"bytes,6,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	Signatures    []*MultisigSignature `protobuf:"bytes,7,rep,name=signatures,proto3" json:"signatures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InvoiceMessageEnvelope) Reset() {
 	*x = InvoiceMessageEnvelope{}
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[1]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -117,7 +183,7 @@ func (x *InvoiceMessageEnvelope) String() string {
 func (*InvoiceMessageEnvelope) ProtoMessage() {}
 
 func (x *InvoiceMessageEnvelope) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[1]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -130,7 +196,7 @@ func (x *InvoiceMessageEnvelope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvoiceMessageEnvelope.ProtoReflect.Descriptor instead.
 func (*InvoiceMessageEnvelope) Descriptor() ([]byte, []int) {
-	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{1}
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InvoiceMessageEnvelope) GetType() int32 {
@@ -168,21 +234,36 @@ func (x *InvoiceMessageEnvelope) GetSignature() string {
 	return ""
 }
 
+func (x *InvoiceMessageEnvelope) GetRedeemScript() string {
+	if x != nil {
+		return x.RedeemScript
+	}
+	return ""
+}
+
+func (x *InvoiceMessageEnvelope) GetSignatures() []*MultisigSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
 type InvoicePayload struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	PaymentAddress string                 `protobuf:"bytes,1,opt,name=payment_address,json=paymentAddress,proto3" json:"payment_address,omitempty"`
 	BuyerAddress   string                 `protobuf:"bytes,2,opt,name=buyer_address,json=buyerAddress,proto3" json:"buyer_address,omitempty"`
 	MintHash       string                 `protobuf:"bytes,4,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
 	Quantity       int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price          int32                  `protobuf:"varint,6,opt,name=price,proto3" json:"price,omitempty"`
-	SellerAddress  string                 `protobuf:"bytes,7,opt,name=seller_address,json=sellerAddress,proto3" json:"seller_address,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Price per fraction, in koinu
+	PriceKoinu    int64  `protobuf:"varint,8,opt,name=price_koinu,json=priceKoinu,proto3" json:"price_koinu,omitempty"`
+	SellerAddress string `protobuf:"bytes,7,opt,name=seller_address,json=sellerAddress,proto3" json:"seller_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *InvoicePayload) Reset() {
 	*x = InvoicePayload{}
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[2]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -194,7 +275,7 @@ func (x *InvoicePayload) String() string {
 func (*InvoicePayload) ProtoMessage() {}
 
 func (x *InvoicePayload) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[2]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,7 +288,7 @@ func (x *InvoicePayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvoicePayload.ProtoReflect.Descriptor instead.
 func (*InvoicePayload) Descriptor() ([]byte, []int) {
-	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{2}
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *InvoicePayload) GetPaymentAddress() string {
@@ -238,9 +319,9 @@ func (x *InvoicePayload) GetQuantity() int32 {
 	return 0
 }
 
-func (x *InvoicePayload) GetPrice() int32 {
+func (x *InvoicePayload) GetPriceKoinu() int64 {
 	if x != nil {
-		return x.Price
+		return x.PriceKoinu
 	}
 	return 0
 }
@@ -264,7 +345,7 @@ type InvoiceMessage struct {
 
 func (x *InvoiceMessage) Reset() {
 	*x = InvoiceMessage{}
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[3]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -276,7 +357,7 @@ func (x *InvoiceMessage) String() string {
 func (*InvoiceMessage) ProtoMessage() {}
 
 func (x *InvoiceMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[3]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +370,7 @@ func (x *InvoiceMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvoiceMessage.ProtoReflect.Descriptor instead.
 func (*InvoiceMessage) Descriptor() ([]byte, []int) {
-	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{3}
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *InvoiceMessage) GetId() string {
@@ -332,7 +413,7 @@ type InvoiceSignatureMessage struct {
 
 func (x *InvoiceSignatureMessage) Reset() {
 	*x = InvoiceSignatureMessage{}
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[4]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -344,7 +425,7 @@ func (x *InvoiceSignatureMessage) String() string {
 func (*InvoiceSignatureMessage) ProtoMessage() {}
 
 func (x *InvoiceSignatureMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[4]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,7 +438,7 @@ func (x *InvoiceSignatureMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvoiceSignatureMessage.ProtoReflect.Descriptor instead.
 func (*InvoiceSignatureMessage) Descriptor() ([]byte, []int) {
-	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{4}
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *InvoiceSignatureMessage) GetInvoiceHash() string {
@@ -399,7 +480,7 @@ type InvoiceSignatureMessageEnvelope struct {
 
 func (x *InvoiceSignatureMessageEnvelope) Reset() {
 	*x = InvoiceSignatureMessageEnvelope{}
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[5]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -411,7 +492,7 @@ func (x *InvoiceSignatureMessageEnvelope) String() string {
 func (*InvoiceSignatureMessageEnvelope) ProtoMessage() {}
 
 func (x *InvoiceSignatureMessageEnvelope) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_protocol_invoices_proto_msgTypes[5]
+	mi := &file_pkg_protocol_invoices_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +505,7 @@ func (x *InvoiceSignatureMessageEnvelope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvoiceSignatureMessageEnvelope.ProtoReflect.Descriptor instead.
 func (*InvoiceSignatureMessageEnvelope) Descriptor() ([]byte, []int) {
-	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{5}
+	return file_pkg_protocol_invoices_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *InvoiceSignatureMessageEnvelope) GetType() int32 {
@@ -452,26 +533,35 @@ var File_pkg_protocol_invoices_proto protoreflect.FileDescriptor
 
 const file_pkg_protocol_invoices_proto_rawDesc = "" +
 	"\n" +
-	"\x1bpkg/protocol/invoices.proto\x12\rfractalengine\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x01\n" +
+	"\x1bpkg/protocol/invoices.proto\x12\rfractalengine\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bpkg/protocol/multisig.proto\"\xb2\x01\n" +
 	"\x15OnChainInvoiceMessage\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x05R\aversion\x12!\n" +
 	"\finvoice_hash\x18\x02 \x01(\fR\vinvoiceHash\x12\x1b\n" +
 	"\tmint_hash\x18\x03 \x01(\fR\bmintHash\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\xbc\x01\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12#\n" +
+	"\rexpiry_height\x18\x05 \x01(\x03R\fexpiryHeight\"`\n" +
+	"!OnChainInvoiceCancellationMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12!\n" +
+	"\finvoice_hash\x18\x02 \x01(\fR\vinvoiceHash\"\xa3\x02\n" +
 	"\x16InvoiceMessageEnvelope\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x05R\aversion\x127\n" +
 	"\apayload\x18\x03 \x01(\v2\x1d.fractalengine.InvoiceMessageR\apayload\x12\x1d\n" +
 	"\n" +
 	"public_key\x18\x04 \x01(\tR\tpublicKey\x12\x1c\n" +
-	"\tsignature\x18\x05 \x01(\tR\tsignature\"\xd4\x01\n" +
+	"\tsignature\x18\x05 \x01(\tR\tsignature\x12#\n" +
+	"\rredeem_script\x18\x06 \x01(\tR\fredeemScript\x12@\n" +
+	"\n" +
+	"signatures\x18\a \x03(\v2 .fractalengine.MultisigSignatureR\n" +
+	"signatures\"\xe5\x01\n" +
 	"\x0eInvoicePayload\x12'\n" +
 	"\x0fpayment_address\x18\x01 \x01(\tR\x0epaymentAddress\x12#\n" +
 	"\rbuyer_address\x18\x02 \x01(\tR\fbuyerAddress\x12\x1b\n" +
 	"\tmint_hash\x18\x04 \x01(\tR\bmintHash\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x06 \x01(\x05R\x05price\x12%\n" +
-	"\x0eseller_address\x18\a \x01(\tR\rsellerAddress\"\xa8\x01\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vprice_koinu\x18\b \x01(\x03R\n" +
+	"priceKoinu\x12%\n" +
+	"\x0eseller_address\x18\a \x01(\tR\rsellerAddressJ\x04\b\x06\x10\a\"\xa8\x01\n" +
 	"\x0eInvoiceMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x127\n" +
@@ -502,27 +592,30 @@ func file_pkg_protocol_invoices_proto_rawDescGZIP() []byte {
 	return file_pkg_protocol_invoices_proto_rawDescData
 }
 
-var file_pkg_protocol_invoices_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_pkg_protocol_invoices_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_pkg_protocol_invoices_proto_goTypes = []any{
-	(*OnChainInvoiceMessage)(nil),           // 0: fractalengine.OnChainInvoiceMessage
-	(*InvoiceMessageEnvelope)(nil),          // 1: fractalengine.InvoiceMessageEnvelope
-	(*InvoicePayload)(nil),                  // 2: fractalengine.InvoicePayload
-	(*InvoiceMessage)(nil),                  // 3: fractalengine.InvoiceMessage
-	(*InvoiceSignatureMessage)(nil),         // 4: fractalengine.InvoiceSignatureMessage
-	(*InvoiceSignatureMessageEnvelope)(nil), // 5: fractalengine.InvoiceSignatureMessageEnvelope
-	(*timestamppb.Timestamp)(nil),           // 6: google.protobuf.Timestamp
+	(*OnChainInvoiceMessage)(nil),             // 0: fractalengine.OnChainInvoiceMessage
+	(*OnChainInvoiceCancellationMessage)(nil), // 1: fractalengine.OnChainInvoiceCancellationMessage
+	(*InvoiceMessageEnvelope)(nil),            // 2: fractalengine.InvoiceMessageEnvelope
+	(*InvoicePayload)(nil),                    // 3: fractalengine.InvoicePayload
+	(*InvoiceMessage)(nil),                    // 4: fractalengine.InvoiceMessage
+	(*InvoiceSignatureMessage)(nil),           // 5: fractalengine.InvoiceSignatureMessage
+	(*InvoiceSignatureMessageEnvelope)(nil),   // 6: fractalengine.InvoiceSignatureMessageEnvelope
+	(*MultisigSignature)(nil),                 // 7: fractalengine.MultisigSignature
+	(*timestamppb.Timestamp)(nil),             // 8: google.protobuf.Timestamp
 }
 var file_pkg_protocol_invoices_proto_depIdxs = []int32{
-	3, // 0: fractalengine.InvoiceMessageEnvelope.payload:type_name -> fractalengine.InvoiceMessage
-	2, // 1: fractalengine.InvoiceMessage.payload:type_name -> fractalengine.InvoicePayload
-	6, // 2: fractalengine.InvoiceMessage.created_at:type_name -> google.protobuf.Timestamp
-	6, // 3: fractalengine.InvoiceSignatureMessage.created_at:type_name -> google.protobuf.Timestamp
-	4, // 4: fractalengine.InvoiceSignatureMessageEnvelope.payload:type_name -> fractalengine.InvoiceSignatureMessage
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	4, // 0: fractalengine.InvoiceMessageEnvelope.payload:type_name -> fractalengine.InvoiceMessage
+	7, // 1: fractalengine.InvoiceMessageEnvelope.signatures:type_name -> fractalengine.MultisigSignature
+	3, // 2: fractalengine.InvoiceMessage.payload:type_name -> fractalengine.InvoicePayload
+	8, // 3: fractalengine.InvoiceMessage.created_at:type_name -> google.protobuf.Timestamp
+	8, // 4: fractalengine.InvoiceSignatureMessage.created_at:type_name -> google.protobuf.Timestamp
+	5, // 5: fractalengine.InvoiceSignatureMessageEnvelope.payload:type_name -> fractalengine.InvoiceSignatureMessage
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_pkg_protocol_invoices_proto_init() }
@@ -530,13 +623,14 @@ func file_pkg_protocol_invoices_proto_init() {
 	if File_pkg_protocol_invoices_proto != nil {
 		return
 	}
+	file_pkg_protocol_multisig_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_invoices_proto_rawDesc), len(file_pkg_protocol_invoices_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
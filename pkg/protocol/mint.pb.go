@@ -70,12 +70,15 @@ func (x *OnChainMintMessage) GetHash() string {
 
 // This is what gets gossiped + stored in the gossip mempool + confirmed_transactions
 type MintMessageEnvelope struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
-	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
-	Payload       *MintMessage           `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
-	PublicKey     string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	Signature     string                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Type      int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version   int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload   *MintMessage           `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	PublicKey string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature string                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	// Set instead of public_key and signature when signed for a P2SH multisig address
+	RedeemScript  string               `protobuf:"bytes,6,opt,name=redeem_script,json=redeemScript,proto3" json:"redeem_script,omitempty"`
+	Signatures    []*MultisigSignature `protobuf:"bytes,7,rep,name=signatures,proto3" json:"signatures,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -145,6 +148,20 @@ func (x *MintMessageEnvelope) GetSignature() string {
 	return ""
 }
 
+func (x *MintMessageEnvelope) GetRedeemScript() string {
+	if x != nil {
+		return x.RedeemScript
+	}
+	return ""
+}
+
+func (x *MintMessageEnvelope) GetSignatures() []*MultisigSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
 type AssetManager struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -382,16 +399,20 @@ var File_pkg_protocol_mint_proto protoreflect.FileDescriptor
 
 const file_pkg_protocol_mint_proto_rawDesc = "" +
 	"\n" +
-	"\x17pkg/protocol/mint.proto\x12\rfractalengine\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"(\n" +
+	"\x17pkg/protocol/mint.proto\x12\rfractalengine\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bpkg/protocol/multisig.proto\"(\n" +
 	"\x12OnChainMintMessage\x12\x12\n" +
-	"\x04hash\x18\x01 \x01(\tR\x04hash\"\xb6\x01\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\"\x9d\x02\n" +
 	"\x13MintMessageEnvelope\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x05R\aversion\x124\n" +
 	"\apayload\x18\x03 \x01(\v2\x1a.fractalengine.MintMessageR\apayload\x12\x1d\n" +
 	"\n" +
 	"public_key\x18\x04 \x01(\tR\tpublicKey\x12\x1c\n" +
-	"\tsignature\x18\x05 \x01(\tR\tsignature\"S\n" +
+	"\tsignature\x18\x05 \x01(\tR\tsignature\x12#\n" +
+	"\rredeem_script\x18\x06 \x01(\tR\fredeemScript\x12@\n" +
+	"\n" +
+	"signatures\x18\a \x03(\v2 .fractalengine.MultisigSignatureR\n" +
+	"signatures\"S\n" +
 	"\fAssetManager\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
@@ -436,21 +457,23 @@ var file_pkg_protocol_mint_proto_goTypes = []any{
 	(*MintMessageEnvelope)(nil),   // 1: fractalengine.MintMessageEnvelope
 	(*AssetManager)(nil),          // 2: fractalengine.AssetManager
 	(*MintMessage)(nil),           // 3: fractalengine.MintMessage
-	(*structpb.Struct)(nil),       // 4: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*MultisigSignature)(nil),     // 4: fractalengine.MultisigSignature
+	(*structpb.Struct)(nil),       // 5: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_pkg_protocol_mint_proto_depIdxs = []int32{
 	3, // 0: fractalengine.MintMessageEnvelope.payload:type_name -> fractalengine.MintMessage
-	4, // 1: fractalengine.MintMessage.metadata:type_name -> google.protobuf.Struct
-	4, // 2: fractalengine.MintMessage.requirements:type_name -> google.protobuf.Struct
-	4, // 3: fractalengine.MintMessage.lockup_options:type_name -> google.protobuf.Struct
-	5, // 4: fractalengine.MintMessage.created_at:type_name -> google.protobuf.Timestamp
-	2, // 5: fractalengine.MintMessage.asset_managers:type_name -> fractalengine.AssetManager
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4, // 1: fractalengine.MintMessageEnvelope.signatures:type_name -> fractalengine.MultisigSignature
+	5, // 2: fractalengine.MintMessage.metadata:type_name -> google.protobuf.Struct
+	5, // 3: fractalengine.MintMessage.requirements:type_name -> google.protobuf.Struct
+	5, // 4: fractalengine.MintMessage.lockup_options:type_name -> google.protobuf.Struct
+	6, // 5: fractalengine.MintMessage.created_at:type_name -> google.protobuf.Timestamp
+	2, // 6: fractalengine.MintMessage.asset_managers:type_name -> fractalengine.AssetManager
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_pkg_protocol_mint_proto_init() }
@@ -458,6 +481,7 @@ func file_pkg_protocol_mint_proto_init() {
 	if File_pkg_protocol_mint_proto != nil {
 		return
 	}
+	file_pkg_protocol_multisig_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
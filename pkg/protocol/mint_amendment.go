@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewMintAmendmentTransactionEnvelope(amendmentHash string, mintHash string, action uint8) MessageEnvelope {
+	amendmentHashBytes, err := hex.DecodeString(amendmentHash)
+	if err != nil {
+		log.Printf("Failed to decode amendment hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainMintAmendmentMessage{
+		AmendmentHash: amendmentHashBytes,
+		MintHash:      mintHashBytes,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
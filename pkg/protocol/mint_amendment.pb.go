@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/mint_amendment.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// The mint owner is the address proven by the transaction itself
+type OnChainMintAmendmentMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	AmendmentHash []byte                 `protobuf:"bytes,2,opt,name=amendment_hash,json=amendmentHash,proto3" json:"amendment_hash,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainMintAmendmentMessage) Reset() {
+	*x = OnChainMintAmendmentMessage{}
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainMintAmendmentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainMintAmendmentMessage) ProtoMessage() {}
+
+func (x *OnChainMintAmendmentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainMintAmendmentMessage.ProtoReflect.Descriptor instead.
+func (*OnChainMintAmendmentMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_amendment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainMintAmendmentMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainMintAmendmentMessage) GetAmendmentHash() []byte {
+	if x != nil {
+		return x.AmendmentHash
+	}
+	return nil
+}
+
+func (x *OnChainMintAmendmentMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+// This is what gets gossiped + stored until the amendment is anchored on chain
+type MintAmendmentMessageEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          int32                  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload       *MintAmendmentMessage  `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	PublicKey     string                 `protobuf:"bytes,4,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     string                 `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintAmendmentMessageEnvelope) Reset() {
+	*x = MintAmendmentMessageEnvelope{}
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintAmendmentMessageEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAmendmentMessageEnvelope) ProtoMessage() {}
+
+func (x *MintAmendmentMessageEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAmendmentMessageEnvelope.ProtoReflect.Descriptor instead.
+func (*MintAmendmentMessageEnvelope) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_amendment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MintAmendmentMessageEnvelope) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MintAmendmentMessageEnvelope) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MintAmendmentMessageEnvelope) GetPayload() *MintAmendmentMessage {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *MintAmendmentMessageEnvelope) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessageEnvelope) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+// Payload of a mint amendment
+// metadata is JSON encoded so the signed payload survives the round trip unchanged
+type MintAmendmentMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Hash           string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	MintHash       string                 `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Metadata       string                 `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	FeedUrl        string                 `protobuf:"bytes,5,opt,name=feed_url,json=feedUrl,proto3" json:"feed_url,omitempty"`
+	ContractOfSale string                 `protobuf:"bytes,6,opt,name=contract_of_sale,json=contractOfSale,proto3" json:"contract_of_sale,omitempty"`
+	Timestamp      int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MintAmendmentMessage) Reset() {
+	*x = MintAmendmentMessage{}
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintAmendmentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintAmendmentMessage) ProtoMessage() {}
+
+func (x *MintAmendmentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_amendment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintAmendmentMessage.ProtoReflect.Descriptor instead.
+func (*MintAmendmentMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_amendment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MintAmendmentMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetMintHash() string {
+	if x != nil {
+		return x.MintHash
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetFeedUrl() string {
+	if x != nil {
+		return x.FeedUrl
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetContractOfSale() string {
+	if x != nil {
+		return x.ContractOfSale
+	}
+	return ""
+}
+
+func (x *MintAmendmentMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_pkg_protocol_mint_amendment_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_mint_amendment_proto_rawDesc = "" +
+	"\n" +
+	"!pkg/protocol/mint_amendment.proto\x12\rfractalengine\"{\n" +
+	"\x1bOnChainMintAmendmentMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12%\n" +
+	"\x0eamendment_hash\x18\x02 \x01(\fR\ramendmentHash\x12\x1b\n" +
+	"\tmint_hash\x18\x03 \x01(\fR\bmintHash\"\xc8\x01\n" +
+	"\x1cMintAmendmentMessageEnvelope\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12=\n" +
+	"\apayload\x18\x03 \x01(\v2#.fractalengine.MintAmendmentMessageR\apayload\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x04 \x01(\tR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\tR\tsignature\"\xe8\x01\n" +
+	"\x14MintAmendmentMessage\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x1b\n" +
+	"\tmint_hash\x18\x02 \x01(\tR\bmintHash\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x19\n" +
+	"\bfeed_url\x18\x05 \x01(\tR\afeedUrl\x12(\n" +
+	"\x10contract_of_sale\x18\x06 \x01(\tR\x0econtractOfSale\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestampB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_mint_amendment_proto_rawDescOnce sync.Once
+	file_pkg_protocol_mint_amendment_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_mint_amendment_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_mint_amendment_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_mint_amendment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_mint_amendment_proto_rawDesc), len(file_pkg_protocol_mint_amendment_proto_rawDesc)))
+	})
+	return file_pkg_protocol_mint_amendment_proto_rawDescData
+}
+
+var file_pkg_protocol_mint_amendment_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_protocol_mint_amendment_proto_goTypes = []any{
+	(*OnChainMintAmendmentMessage)(nil),  // 0: fractalengine.OnChainMintAmendmentMessage
+	(*MintAmendmentMessageEnvelope)(nil), // 1: fractalengine.MintAmendmentMessageEnvelope
+	(*MintAmendmentMessage)(nil),         // 2: fractalengine.MintAmendmentMessage
+}
+var file_pkg_protocol_mint_amendment_proto_depIdxs = []int32{
+	2, // 0: fractalengine.MintAmendmentMessageEnvelope.payload:type_name -> fractalengine.MintAmendmentMessage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_mint_amendment_proto_init() }
+func file_pkg_protocol_mint_amendment_proto_init() {
+	if File_pkg_protocol_mint_amendment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_mint_amendment_proto_rawDesc), len(file_pkg_protocol_mint_amendment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_mint_amendment_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_mint_amendment_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_mint_amendment_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_mint_amendment_proto = out.File
+	file_pkg_protocol_mint_amendment_proto_goTypes = nil
+	file_pkg_protocol_mint_amendment_proto_depIdxs = nil
+}
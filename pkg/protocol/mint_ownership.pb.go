@@ -0,0 +1,321 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/mint_ownership.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// The current owner is the address proven by the transaction itself
+type OnChainMintOwnershipTransferMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	TransferHash  []byte                 `protobuf:"bytes,2,opt,name=transfer_hash,json=transferHash,proto3" json:"transfer_hash,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,3,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainMintOwnershipTransferMessage) Reset() {
+	*x = OnChainMintOwnershipTransferMessage{}
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainMintOwnershipTransferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainMintOwnershipTransferMessage) ProtoMessage() {}
+
+func (x *OnChainMintOwnershipTransferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainMintOwnershipTransferMessage.ProtoReflect.Descriptor instead.
+func (*OnChainMintOwnershipTransferMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_ownership_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainMintOwnershipTransferMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainMintOwnershipTransferMessage) GetTransferHash() []byte {
+	if x != nil {
+		return x.TransferHash
+	}
+	return nil
+}
+
+func (x *OnChainMintOwnershipTransferMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+// This is what gets gossiped + stored until the transfer is anchored on chain
+type MintOwnershipTransferMessageEnvelope struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Type          int32                         `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Version       int32                         `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Payload       *MintOwnershipTransferMessage `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintOwnershipTransferMessageEnvelope) Reset() {
+	*x = MintOwnershipTransferMessageEnvelope{}
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintOwnershipTransferMessageEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintOwnershipTransferMessageEnvelope) ProtoMessage() {}
+
+func (x *MintOwnershipTransferMessageEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintOwnershipTransferMessageEnvelope.ProtoReflect.Descriptor instead.
+func (*MintOwnershipTransferMessageEnvelope) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_ownership_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MintOwnershipTransferMessageEnvelope) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *MintOwnershipTransferMessageEnvelope) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MintOwnershipTransferMessageEnvelope) GetPayload() *MintOwnershipTransferMessage {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// Payload of a mint ownership transfer
+// owner_signature is the offer of the current owner, new_owner_signature the acceptance of the new owner
+type MintOwnershipTransferMessage struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Hash              string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	MintHash          string                 `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	NewOwnerAddress   string                 `protobuf:"bytes,3,opt,name=new_owner_address,json=newOwnerAddress,proto3" json:"new_owner_address,omitempty"`
+	NewOwnerPublicKey string                 `protobuf:"bytes,4,opt,name=new_owner_public_key,json=newOwnerPublicKey,proto3" json:"new_owner_public_key,omitempty"`
+	Timestamp         int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OwnerPublicKey    string                 `protobuf:"bytes,6,opt,name=owner_public_key,json=ownerPublicKey,proto3" json:"owner_public_key,omitempty"`
+	OwnerSignature    string                 `protobuf:"bytes,7,opt,name=owner_signature,json=ownerSignature,proto3" json:"owner_signature,omitempty"`
+	NewOwnerSignature string                 `protobuf:"bytes,8,opt,name=new_owner_signature,json=newOwnerSignature,proto3" json:"new_owner_signature,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MintOwnershipTransferMessage) Reset() {
+	*x = MintOwnershipTransferMessage{}
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintOwnershipTransferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintOwnershipTransferMessage) ProtoMessage() {}
+
+func (x *MintOwnershipTransferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_mint_ownership_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintOwnershipTransferMessage.ProtoReflect.Descriptor instead.
+func (*MintOwnershipTransferMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_mint_ownership_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MintOwnershipTransferMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetMintHash() string {
+	if x != nil {
+		return x.MintHash
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetNewOwnerAddress() string {
+	if x != nil {
+		return x.NewOwnerAddress
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetNewOwnerPublicKey() string {
+	if x != nil {
+		return x.NewOwnerPublicKey
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MintOwnershipTransferMessage) GetOwnerPublicKey() string {
+	if x != nil {
+		return x.OwnerPublicKey
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetOwnerSignature() string {
+	if x != nil {
+		return x.OwnerSignature
+	}
+	return ""
+}
+
+func (x *MintOwnershipTransferMessage) GetNewOwnerSignature() string {
+	if x != nil {
+		return x.NewOwnerSignature
+	}
+	return ""
+}
+
+var File_pkg_protocol_mint_ownership_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_mint_ownership_proto_rawDesc = "" +
+	"\n" +
+	"!pkg/protocol/mint_ownership.proto\x12\rfractalengine\"\x81\x01\n" +
+	"#OnChainMintOwnershipTransferMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12#\n" +
+	"\rtransfer_hash\x18\x02 \x01(\fR\ftransferHash\x12\x1b\n" +
+	"\tmint_hash\x18\x03 \x01(\fR\bmintHash\"\x9b\x01\n" +
+	"$MintOwnershipTransferMessageEnvelope\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x05R\x04type\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12E\n" +
+	"\apayload\x18\x03 \x01(\v2+.fractalengine.MintOwnershipTransferMessageR\apayload\"\xcd\x02\n" +
+	"\x1cMintOwnershipTransferMessage\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\tR\x04hash\x12\x1b\n" +
+	"\tmint_hash\x18\x02 \x01(\tR\bmintHash\x12*\n" +
+	"\x11new_owner_address\x18\x03 \x01(\tR\x0fnewOwnerAddress\x12/\n" +
+	"\x14new_owner_public_key\x18\x04 \x01(\tR\x11newOwnerPublicKey\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12(\n" +
+	"\x10owner_public_key\x18\x06 \x01(\tR\x0eownerPublicKey\x12'\n" +
+	"\x0fowner_signature\x18\a \x01(\tR\x0eownerSignature\x12.\n" +
+	"\x13new_owner_signature\x18\b \x01(\tR\x11newOwnerSignatureB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_mint_ownership_proto_rawDescOnce sync.Once
+	file_pkg_protocol_mint_ownership_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_mint_ownership_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_mint_ownership_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_mint_ownership_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_mint_ownership_proto_rawDesc), len(file_pkg_protocol_mint_ownership_proto_rawDesc)))
+	})
+	return file_pkg_protocol_mint_ownership_proto_rawDescData
+}
+
+var file_pkg_protocol_mint_ownership_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_protocol_mint_ownership_proto_goTypes = []any{
+	(*OnChainMintOwnershipTransferMessage)(nil),  // 0: fractalengine.OnChainMintOwnershipTransferMessage
+	(*MintOwnershipTransferMessageEnvelope)(nil), // 1: fractalengine.MintOwnershipTransferMessageEnvelope
+	(*MintOwnershipTransferMessage)(nil),         // 2: fractalengine.MintOwnershipTransferMessage
+}
+var file_pkg_protocol_mint_ownership_proto_depIdxs = []int32{
+	2, // 0: fractalengine.MintOwnershipTransferMessageEnvelope.payload:type_name -> fractalengine.MintOwnershipTransferMessage
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_mint_ownership_proto_init() }
+func file_pkg_protocol_mint_ownership_proto_init() {
+	if File_pkg_protocol_mint_ownership_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_mint_ownership_proto_rawDesc), len(file_pkg_protocol_mint_ownership_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_mint_ownership_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_mint_ownership_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_mint_ownership_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_mint_ownership_proto = out.File
+	file_pkg_protocol_mint_ownership_proto_goTypes = nil
+	file_pkg_protocol_mint_ownership_proto_depIdxs = nil
+}
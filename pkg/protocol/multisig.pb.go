@@ -0,0 +1,133 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/multisig.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The signature of one of the keys of a P2SH multisig redeem script
+type MultisigSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     string                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultisigSignature) Reset() {
+	*x = MultisigSignature{}
+	mi := &file_pkg_protocol_multisig_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultisigSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultisigSignature) ProtoMessage() {}
+
+func (x *MultisigSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_multisig_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultisigSignature.ProtoReflect.Descriptor instead.
+func (*MultisigSignature) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_multisig_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MultisigSignature) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *MultisigSignature) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+var File_pkg_protocol_multisig_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_multisig_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpkg/protocol/multisig.proto\x12\rfractalengine\"P\n" +
+	"\x11MultisigSignature\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\tR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignatureB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_multisig_proto_rawDescOnce sync.Once
+	file_pkg_protocol_multisig_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_multisig_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_multisig_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_multisig_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_multisig_proto_rawDesc), len(file_pkg_protocol_multisig_proto_rawDesc)))
+	})
+	return file_pkg_protocol_multisig_proto_rawDescData
+}
+
+var file_pkg_protocol_multisig_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_protocol_multisig_proto_goTypes = []any{
+	(*MultisigSignature)(nil), // 0: fractalengine.MultisigSignature
+}
+var file_pkg_protocol_multisig_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_multisig_proto_init() }
+func file_pkg_protocol_multisig_proto_init() {
+	if File_pkg_protocol_multisig_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_multisig_proto_rawDesc), len(file_pkg_protocol_multisig_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_multisig_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_multisig_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_multisig_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_multisig_proto = out.File
+	file_pkg_protocol_multisig_proto_goTypes = nil
+	file_pkg_protocol_multisig_proto_depIdxs = nil
+}
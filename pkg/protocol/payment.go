@@ -1,6 +1,11 @@
 package protocol
 
-import "google.golang.org/protobuf/proto"
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
 
 func NewPaymentTransactionEnvelope(invoiceHash string, action uint8) MessageEnvelope {
 	message := &OnChainPaymentMessage{
@@ -14,3 +19,24 @@ func NewPaymentTransactionEnvelope(invoiceHash string, action uint8) MessageEnve
 
 	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
 }
+
+func NewBatchPaymentTransactionEnvelope(invoiceHashes []string, action uint8) MessageEnvelope {
+	message := &OnChainBatchPaymentMessage{}
+
+	for _, invoiceHash := range invoiceHashes {
+		invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+		if err != nil {
+			log.Printf("Failed to decode hash: %s", err.Error())
+			return MessageEnvelope{}
+		}
+
+		message.InvoiceHashes = append(message.InvoiceHashes, invoiceHashBytes)
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
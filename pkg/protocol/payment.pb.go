@@ -180,6 +180,60 @@ func (x *PaymentMessage) GetInvoiceHash() string {
 	return ""
 }
 
+// Pays several invoices in one transaction, each seller's output being checked on its own
+// Invoice hashes are written as raw bytes to keep the OP_RETURN small
+type OnChainBatchPaymentMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	InvoiceHashes [][]byte               `protobuf:"bytes,2,rep,name=invoice_hashes,json=invoiceHashes,proto3" json:"invoice_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainBatchPaymentMessage) Reset() {
+	*x = OnChainBatchPaymentMessage{}
+	mi := &file_pkg_protocol_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainBatchPaymentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainBatchPaymentMessage) ProtoMessage() {}
+
+func (x *OnChainBatchPaymentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainBatchPaymentMessage.ProtoReflect.Descriptor instead.
+func (*OnChainBatchPaymentMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OnChainBatchPaymentMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainBatchPaymentMessage) GetInvoiceHashes() [][]byte {
+	if x != nil {
+		return x.InvoiceHashes
+	}
+	return nil
+}
+
 var File_pkg_protocol_payment_proto protoreflect.FileDescriptor
 
 const file_pkg_protocol_payment_proto_rawDesc = "" +
@@ -193,7 +247,10 @@ const file_pkg_protocol_payment_proto_rawDesc = "" +
 	"\aversion\x18\x02 \x01(\x05R\aversion\x127\n" +
 	"\apayload\x18\x03 \x01(\v2\x1d.fractalengine.PaymentMessageR\apayload\"3\n" +
 	"\x0ePaymentMessage\x12!\n" +
-	"\finvoice_hash\x18\x01 \x01(\tR\vinvoiceHashB\x0eZ\fpkg/protocolb\x06proto3"
+	"\finvoice_hash\x18\x01 \x01(\tR\vinvoiceHash\"]\n" +
+	"\x1aOnChainBatchPaymentMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12%\n" +
+	"\x0einvoice_hashes\x18\x02 \x03(\fR\rinvoiceHashesB\x0eZ\fpkg/protocolb\x06proto3"
 
 var (
 	file_pkg_protocol_payment_proto_rawDescOnce sync.Once
@@ -207,11 +264,12 @@ func file_pkg_protocol_payment_proto_rawDescGZIP() []byte {
 	return file_pkg_protocol_payment_proto_rawDescData
 }
 
-var file_pkg_protocol_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_pkg_protocol_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_pkg_protocol_payment_proto_goTypes = []any{
-	(*OnChainPaymentMessage)(nil),  // 0: fractalengine.OnChainPaymentMessage
-	(*PaymentMessageEnvelope)(nil), // 1: fractalengine.PaymentMessageEnvelope
-	(*PaymentMessage)(nil),         // 2: fractalengine.PaymentMessage
+	(*OnChainPaymentMessage)(nil),      // 0: fractalengine.OnChainPaymentMessage
+	(*PaymentMessageEnvelope)(nil),     // 1: fractalengine.PaymentMessageEnvelope
+	(*PaymentMessage)(nil),             // 2: fractalengine.PaymentMessage
+	(*OnChainBatchPaymentMessage)(nil), // 3: fractalengine.OnChainBatchPaymentMessage
 }
 var file_pkg_protocol_payment_proto_depIdxs = []int32{
 	2, // 0: fractalengine.PaymentMessageEnvelope.payload:type_name -> fractalengine.PaymentMessage
@@ -233,7 +291,7 @@ func file_pkg_protocol_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_payment_proto_rawDesc), len(file_pkg_protocol_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
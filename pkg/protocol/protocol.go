@@ -12,16 +12,26 @@ import (
 // 1.0.0
 
 const (
-	FRACTAL_ENGINE_IDENTIFIER = 0xFE0001FE
-	DEFAULT_VERSION           = 1
-	ACTION_MINT               = 0x01
-	ACTION_BUY_OFFER          = 0x02
-	ACTION_SELL_OFFER         = 0x03
-	ACTION_INVOICE            = 0x04
-	ACTION_PAYMENT            = 0x05
-	ACTION_DELETE_BUY_OFFER   = 0x06
-	ACTION_DELETE_SELL_OFFER  = 0x07
-	ACTION_INVOICE_SIGNATURE  = 0x08
+	FRACTAL_ENGINE_IDENTIFIER      = 0xFE0001FE
+	DEFAULT_VERSION                = 1
+	ACTION_MINT                    = 0x01
+	ACTION_BUY_OFFER               = 0x02
+	ACTION_SELL_OFFER              = 0x03
+	ACTION_INVOICE                 = 0x04
+	ACTION_PAYMENT                 = 0x05
+	ACTION_DELETE_BUY_OFFER        = 0x06
+	ACTION_DELETE_SELL_OFFER       = 0x07
+	ACTION_INVOICE_SIGNATURE       = 0x08
+	ACTION_TRANSFER                = 0x09
+	ACTION_BURN                    = 0x0A
+	ACTION_ALLOWLIST_ENTRY         = 0x0B
+	ACTION_DISTRIBUTION            = 0x0C
+	ACTION_DISTRIBUTION_PAYOUT     = 0x0D
+	ACTION_MINT_AMENDMENT          = 0x0E
+	ACTION_ASSET_MANAGER_ROTATION  = 0x0F
+	ACTION_MINT_OWNERSHIP_TRANSFER = 0x10
+	ACTION_BATCH_PAYMENT           = 0x11
+	ACTION_INVOICE_CANCELLATION    = 0x12
 )
 
 type MessageEnvelope struct {
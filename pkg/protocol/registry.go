@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrUnsupportedAction = errors.New("unsupported action")
+var ErrUnsupportedVersion = errors.New("unsupported action version")
+
+type ActionVersion struct {
+	Action  uint8
+	Version uint8
+}
+
+var actionNames = map[uint8]string{
+	ACTION_MINT:                    "mint",
+	ACTION_BUY_OFFER:               "buy_offer",
+	ACTION_SELL_OFFER:              "sell_offer",
+	ACTION_INVOICE:                 "invoice",
+	ACTION_PAYMENT:                 "payment",
+	ACTION_DELETE_BUY_OFFER:        "delete_buy_offer",
+	ACTION_DELETE_SELL_OFFER:       "delete_sell_offer",
+	ACTION_INVOICE_SIGNATURE:       "invoice_signature",
+	ACTION_TRANSFER:                "transfer",
+	ACTION_BURN:                    "burn",
+	ACTION_ALLOWLIST_ENTRY:         "allowlist_entry",
+	ACTION_DISTRIBUTION:            "distribution",
+	ACTION_DISTRIBUTION_PAYOUT:     "distribution_payout",
+	ACTION_MINT_AMENDMENT:          "mint_amendment",
+	ACTION_ASSET_MANAGER_ROTATION:  "asset_manager_rotation",
+	ACTION_MINT_OWNERSHIP_TRANSFER: "mint_ownership_transfer",
+	ACTION_BATCH_PAYMENT:           "batch_payment",
+	ACTION_INVOICE_CANCELLATION:    "invoice_cancellation",
+}
+
+/*
+* onChainMessages is the registry of the OP_RETURN layouts this engine can decode, keyed by (action, version).
+* A new layout is rolled out by registering it under a new version next to the existing one,
+* so transactions written with either version keep being processed.
+ */
+var onChainMessages = map[ActionVersion]func() proto.Message{
+	{ACTION_MINT, DEFAULT_VERSION}:                    func() proto.Message { return &OnChainMintMessage{} },
+	{ACTION_INVOICE, DEFAULT_VERSION}:                 func() proto.Message { return &OnChainInvoiceMessage{} },
+	{ACTION_PAYMENT, DEFAULT_VERSION}:                 func() proto.Message { return &OnChainPaymentMessage{} },
+	{ACTION_TRANSFER, DEFAULT_VERSION}:                func() proto.Message { return &OnChainTransferMessage{} },
+	{ACTION_BURN, DEFAULT_VERSION}:                    func() proto.Message { return &OnChainBurnMessage{} },
+	{ACTION_ALLOWLIST_ENTRY, DEFAULT_VERSION}:         func() proto.Message { return &OnChainAllowlistEntryMessage{} },
+	{ACTION_DISTRIBUTION, DEFAULT_VERSION}:            func() proto.Message { return &OnChainDistributionMessage{} },
+	{ACTION_DISTRIBUTION_PAYOUT, DEFAULT_VERSION}:     func() proto.Message { return &OnChainDistributionPayoutMessage{} },
+	{ACTION_MINT_AMENDMENT, DEFAULT_VERSION}:          func() proto.Message { return &OnChainMintAmendmentMessage{} },
+	{ACTION_ASSET_MANAGER_ROTATION, DEFAULT_VERSION}:  func() proto.Message { return &OnChainAssetManagerRotationMessage{} },
+	{ACTION_MINT_OWNERSHIP_TRANSFER, DEFAULT_VERSION}: func() proto.Message { return &OnChainMintOwnershipTransferMessage{} },
+	{ACTION_BATCH_PAYMENT, DEFAULT_VERSION}:           func() proto.Message { return &OnChainBatchPaymentMessage{} },
+	{ACTION_INVOICE_CANCELLATION, DEFAULT_VERSION}:    func() proto.Message { return &OnChainInvoiceCancellationMessage{} },
+}
+
+func ActionName(action uint8) string {
+	if name, ok := actionNames[action]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("unknown_%d", action)
+}
+
+// ActionByName returns the action with the name returned by ActionName.
+func ActionByName(name string) (uint8, bool) {
+	for action, actionName := range actionNames {
+		if actionName == name {
+			return action, true
+		}
+	}
+
+	return 0, false
+}
+
+// CheckActionVersion returns ErrUnsupportedAction or ErrUnsupportedVersion if the layout is not registered.
+func CheckActionVersion(action uint8, version uint8) error {
+	if _, ok := onChainMessages[ActionVersion{action, version}]; ok {
+		return nil
+	}
+
+	for registered := range onChainMessages {
+		if registered.Action == action {
+			return fmt.Errorf("%w: %s version %d", ErrUnsupportedVersion, ActionName(action), version)
+		}
+	}
+
+	return fmt.Errorf("%w: %d", ErrUnsupportedAction, action)
+}
+
+// DecodeOnChainMessage unmarshals the OP_RETURN payload with the layout registered for (action, version).
+func DecodeOnChainMessage(action uint8, version uint8, data []byte) (proto.Message, error) {
+	if err := CheckActionVersion(action, version); err != nil {
+		return nil, err
+	}
+
+	message := onChainMessages[ActionVersion{action, version}]()
+	if err := proto.Unmarshal(data, message); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// RegisteredActionVersions returns every registered (action, version) pair.
+func RegisteredActionVersions() []ActionVersion {
+	registered := make([]ActionVersion, 0, len(onChainMessages))
+	for actionVersion := range onChainMessages {
+		registered = append(registered, actionVersion)
+	}
+
+	sort.Slice(registered, func(i, j int) bool {
+		if registered[i].Action != registered[j].Action {
+			return registered[i].Action < registered[j].Action
+		}
+		return registered[i].Version < registered[j].Version
+	})
+
+	return registered
+}
+
+// SupportedVersions returns the supported versions of each on chain action, keyed by action name.
+func SupportedVersions() map[string][]int {
+	supported := map[string][]int{}
+	for _, actionVersion := range RegisteredActionVersions() {
+		name := ActionName(actionVersion.Action)
+		supported[name] = append(supported[name], int(actionVersion.Version))
+	}
+
+	return supported
+}
@@ -0,0 +1,44 @@
+package protocol_test
+
+import (
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestCheckActionVersion(t *testing.T) {
+	assert.NilError(t, protocol.CheckActionVersion(protocol.ACTION_MINT, protocol.DEFAULT_VERSION))
+
+	err := protocol.CheckActionVersion(protocol.ACTION_MINT, 2)
+	assert.Assert(t, errors.Is(err, protocol.ErrUnsupportedVersion))
+
+	err = protocol.CheckActionVersion(99, protocol.DEFAULT_VERSION)
+	assert.Assert(t, errors.Is(err, protocol.ErrUnsupportedAction))
+}
+
+func TestDecodeOnChainMessage(t *testing.T) {
+	encoded, err := proto.Marshal(&protocol.OnChainTransferMessage{ToAddress: "toAddress", Quantity: 10})
+	assert.NilError(t, err)
+
+	message, err := protocol.DecodeOnChainMessage(protocol.ACTION_TRANSFER, protocol.DEFAULT_VERSION, encoded)
+	assert.NilError(t, err)
+
+	transfer, ok := message.(*protocol.OnChainTransferMessage)
+	assert.Assert(t, ok)
+	assert.Equal(t, transfer.ToAddress, "toAddress")
+	assert.Equal(t, transfer.Quantity, int32(10))
+
+	_, err = protocol.DecodeOnChainMessage(protocol.ACTION_TRANSFER, protocol.DEFAULT_VERSION, []byte{0xff, 0xff})
+	assert.Assert(t, err != nil)
+}
+
+func TestSupportedVersions(t *testing.T) {
+	supported := protocol.SupportedVersions()
+
+	assert.DeepEqual(t, supported["payment"], []int{1})
+	_, ok := supported["buy_offer"]
+	assert.Assert(t, !ok)
+}
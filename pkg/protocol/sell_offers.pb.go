@@ -171,9 +171,10 @@ type SellOfferPayload struct {
 	OffererAddress string                 `protobuf:"bytes,1,opt,name=offerer_address,json=offererAddress,proto3" json:"offerer_address,omitempty"`
 	MintHash       string                 `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
 	Quantity       int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price          int32                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Price per fraction, in koinu
+	PriceKoinu    int64 `protobuf:"varint,5,opt,name=price_koinu,json=priceKoinu,proto3" json:"price_koinu,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SellOfferPayload) Reset() {
@@ -227,9 +228,9 @@ func (x *SellOfferPayload) GetQuantity() int32 {
 	return 0
 }
 
-func (x *SellOfferPayload) GetPrice() int32 {
+func (x *SellOfferPayload) GetPriceKoinu() int64 {
 	if x != nil {
-		return x.Price
+		return x.PriceKoinu
 	}
 	return 0
 }
@@ -371,12 +372,13 @@ const file_pkg_protocol_sell_offers_proto_rawDesc = "" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x129\n" +
 	"\apayload\x18\x03 \x01(\v2\x1f.fractalengine.SellOfferPayloadR\apayload\x129\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8a\x01\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9b\x01\n" +
 	"\x10SellOfferPayload\x12'\n" +
 	"\x0fofferer_address\x18\x01 \x01(\tR\x0eoffererAddress\x12\x1b\n" +
 	"\tmint_hash\x18\x02 \x01(\tR\bmintHash\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x05R\x05price\",\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vprice_koinu\x18\x05 \x01(\x03R\n" +
+	"priceKoinuJ\x04\b\x04\x10\x05\",\n" +
 	"\x16DeleteSellOfferMessage\x12\x12\n" +
 	"\x04hash\x18\x01 \x01(\tR\x04hash\"\xcc\x01\n" +
 	"\x1eDeleteSellOfferMessageEnvelope\x12\x12\n" +
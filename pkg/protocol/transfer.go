@@ -0,0 +1,29 @@
+package protocol
+
+import (
+	"encoding/hex"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func NewTransferTransactionEnvelope(mintHash string, toAddress string, quantity int32, action uint8) MessageEnvelope {
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	if err != nil {
+		log.Printf("Failed to decode hash: %s", err.Error())
+		return MessageEnvelope{}
+	}
+
+	message := &OnChainTransferMessage{
+		MintHash:  mintHashBytes,
+		ToAddress: toAddress,
+		Quantity:  quantity,
+	}
+
+	protoBytes, err := proto.Marshal(message)
+	if err != nil {
+		return MessageEnvelope{}
+	}
+
+	return NewMessageEnvelope(action, DEFAULT_VERSION, protoBytes)
+}
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v6.32.1
+// source: pkg/protocol/transfer.proto
+
+package protocol
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is what gets written to the OP_RETURN on the L1
+// The sender is the address proven by the transaction itself
+type OnChainTransferMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	MintHash      []byte                 `protobuf:"bytes,2,opt,name=mint_hash,json=mintHash,proto3" json:"mint_hash,omitempty"`
+	ToAddress     string                 `protobuf:"bytes,3,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnChainTransferMessage) Reset() {
+	*x = OnChainTransferMessage{}
+	mi := &file_pkg_protocol_transfer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnChainTransferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnChainTransferMessage) ProtoMessage() {}
+
+func (x *OnChainTransferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_protocol_transfer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnChainTransferMessage.ProtoReflect.Descriptor instead.
+func (*OnChainTransferMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_protocol_transfer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OnChainTransferMessage) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *OnChainTransferMessage) GetMintHash() []byte {
+	if x != nil {
+		return x.MintHash
+	}
+	return nil
+}
+
+func (x *OnChainTransferMessage) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *OnChainTransferMessage) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+var File_pkg_protocol_transfer_proto protoreflect.FileDescriptor
+
+const file_pkg_protocol_transfer_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpkg/protocol/transfer.proto\x12\rfractalengine\"\x8a\x01\n" +
+	"\x16OnChainTransferMessage\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1b\n" +
+	"\tmint_hash\x18\x02 \x01(\fR\bmintHash\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x03 \x01(\tR\ttoAddress\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantityB\x0eZ\fpkg/protocolb\x06proto3"
+
+var (
+	file_pkg_protocol_transfer_proto_rawDescOnce sync.Once
+	file_pkg_protocol_transfer_proto_rawDescData []byte
+)
+
+func file_pkg_protocol_transfer_proto_rawDescGZIP() []byte {
+	file_pkg_protocol_transfer_proto_rawDescOnce.Do(func() {
+		file_pkg_protocol_transfer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_protocol_transfer_proto_rawDesc), len(file_pkg_protocol_transfer_proto_rawDesc)))
+	})
+	return file_pkg_protocol_transfer_proto_rawDescData
+}
+
+var file_pkg_protocol_transfer_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pkg_protocol_transfer_proto_goTypes = []any{
+	(*OnChainTransferMessage)(nil), // 0: fractalengine.OnChainTransferMessage
+}
+var file_pkg_protocol_transfer_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pkg_protocol_transfer_proto_init() }
+func file_pkg_protocol_transfer_proto_init() {
+	if File_pkg_protocol_transfer_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_protocol_transfer_proto_rawDesc), len(file_pkg_protocol_transfer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pkg_protocol_transfer_proto_goTypes,
+		DependencyIndexes: file_pkg_protocol_transfer_proto_depIdxs,
+		MessageInfos:      file_pkg_protocol_transfer_proto_msgTypes,
+	}.Build()
+	File_pkg_protocol_transfer_proto = out.File
+	file_pkg_protocol_transfer_proto_goTypes = nil
+	file_pkg_protocol_transfer_proto_depIdxs = nil
+}
@@ -0,0 +1,424 @@
+package rpc
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+	"google.golang.org/protobuf/proto"
+)
+
+type AdminRoutes struct {
+	store *store.TokenisationStore
+}
+
+// HandleAdminRoutes registers the admin routes, which the server only exposes behind the admin API key.
+func HandleAdminRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
+	ar := &AdminRoutes{store: store}
+
+	mux.HandleFunc("/admin/stuck-transactions", ar.handleStuckTransactions)
+	mux.HandleFunc("/admin/stuck-transactions/{hash}/reprocess", ar.handleReprocess)
+	mux.HandleFunc("/admin/stuck-transactions/{hash}/payload", ar.handleSupplyPayload)
+	mux.HandleFunc("/admin/stuck-transactions/{hash}/ignore", ar.handleIgnore)
+	mux.HandleFunc("/admin/audit-log", ar.handleAuditLog)
+}
+
+func (ar *AdminRoutes) handleStuckTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ar.getStuckTransactions(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ar *AdminRoutes) handleReprocess(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ar.postReprocess(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ar *AdminRoutes) handleSupplyPayload(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ar.postSupplyPayload(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ar *AdminRoutes) handleIgnore(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ar.postIgnore(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (ar *AdminRoutes) handleAuditLog(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ar.getAuditLog(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get stuck on chain transactions
+// @Description	Returns the on chain transactions awaiting data or expired while waiting, oldest first
+// @Tags			admin
+// @Produce		json
+// @Param			limit	query		int		false	"Limit"
+// @Param			page	query		int		false	"Page"
+// @Success		200		{object}	GetStuckTransactionsResponse
+// @Failure		403		{object}	string
+// @Failure		500		{object}	string
+// @Router			/admin/stuck-transactions [get]
+func (ar *AdminRoutes) getStuckTransactions(w http.ResponseWriter, r *http.Request) {
+	page, limit := adminPagination(r)
+
+	transactions, err := ar.store.GetStuckOnChainTransactions(page*limit, limit)
+	if err != nil {
+		log.Println("error getting stuck transactions", err)
+		http.Error(w, "Failed to get stuck transactions", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetStuckTransactionsResponse{
+		Transactions: transactions,
+		Page:         page,
+		Limit:        limit,
+	})
+}
+
+// @Summary		Reprocess a stuck on chain transaction
+// @Description	Queues a transaction awaiting data or expired to be processed again. Expired transactions are restored and are not trimmed again.
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string				true	"Transaction hash"
+// @Param			request	body		AdminActionRequest	false	"Reason for the action"
+// @Success		200		{object}	AdminActionResponse
+// @Failure		400		{object}	string
+// @Failure		403		{object}	string
+// @Failure		404		{object}	string
+// @Failure		409		{object}	string
+// @Router			/admin/stuck-transactions/{hash}/reprocess [post]
+func (ar *AdminRoutes) postReprocess(w http.ResponseWriter, r *http.Request) {
+	txHash, request, ok := decodeAdminActionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	outcome, err := ar.store.RequeueOnChainTransaction(txHash, store.AdminAction_REPROCESS, request.Reason, r.RemoteAddr)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	log.Printf("Admin requeued transaction %s from %s", txHash, r.RemoteAddr)
+	respondJSON(w, http.StatusOK, AdminActionResponse{Transaction: outcome})
+}
+
+// @Summary		Supply the off chain payload of a stuck on chain transaction
+// @Description	Saves the mint or invoice a transaction is waiting for and queues the transaction to be processed again. The hash of the payload must match the hash committed on chain.
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string					true	"Transaction hash"
+// @Param			request	body		SupplyPayloadRequest	true	"Off chain payload"
+// @Success		200		{object}	AdminActionResponse
+// @Failure		400		{object}	string
+// @Failure		403		{object}	string
+// @Failure		404		{object}	string
+// @Failure		409		{object}	string
+// @Router			/admin/stuck-transactions/{hash}/payload [post]
+func (ar *AdminRoutes) postSupplyPayload(w http.ResponseWriter, r *http.Request) {
+	txHash := r.PathValue("hash")
+	if err := validation.ValidateHash(txHash); err != nil {
+		http.Error(w, "Invalid transaction hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request SupplyPayloadRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	outcome, err := ar.store.GetStuckOnChainTransaction(txHash)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	switch outcome.ActionType {
+	case protocol.ACTION_MINT:
+		err = validateSuppliedMint(outcome, request.Mint)
+	case protocol.ACTION_INVOICE:
+		err = validateSuppliedInvoice(outcome, request.Invoice)
+	default:
+		http.Error(w, "Payloads can only be supplied for mints and invoices", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Println("error supplying payload", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var detail string
+	if outcome.ActionType == protocol.ACTION_MINT {
+		detail = "mint " + request.Mint.Hash
+		outcome, err = ar.store.SupplyUnconfirmedMint(txHash, request.Mint, r.RemoteAddr)
+	} else {
+		detail = "invoice " + request.Invoice.Hash
+		outcome, err = ar.store.SupplyUnconfirmedInvoice(txHash, request.Invoice, r.RemoteAddr)
+	}
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	log.Printf("Admin supplied %s for transaction %s from %s", detail, txHash, r.RemoteAddr)
+	respondJSON(w, http.StatusOK, AdminActionResponse{Transaction: outcome})
+}
+
+/*
+* validateSuppliedMint holds a mint supplied by an operator to the same checks as one created through the
+* API, its field limits and the signature of its owner, and checks it is the mint anchored on chain.
+ */
+func validateSuppliedMint(outcome store.OnChainTransactionOutcome, mint *store.MintWithoutID) error {
+	if mint == nil {
+		return errors.New("mint payload is required")
+	}
+
+	message := protocol.OnChainMintMessage{}
+	if err := proto.Unmarshal(outcome.ActionData, &message); err != nil {
+		return err
+	}
+
+	request := CreateMintRequest{
+		SignedRequest: SignedRequest{
+			PublicKey:    mint.PublicKey,
+			Signature:    mint.Signature,
+			RedeemScript: mint.RedeemScript,
+			Signatures:   mint.MultisigSignatures,
+		},
+		Payload: CreateMintRequestPayload{
+			Title:                    mint.Title,
+			FractionCount:            mint.FractionCount,
+			Description:              mint.Description,
+			Tags:                     mint.Tags,
+			Metadata:                 mint.Metadata,
+			Requirements:             mint.Requirements,
+			LockupOptions:            mint.LockupOptions,
+			FeedURL:                  mint.FeedURL,
+			ContractOfSale:           mint.ContractOfSale,
+			OwnerAddress:             mint.OwnerAddress,
+			SignatureRequirementType: mint.SignatureRequirementType,
+			AssetManagers:            mint.AssetManagers,
+			MinSignatures:            mint.MinSignatures,
+		},
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	hash, err := mint.GenerateHash()
+	if err != nil {
+		return err
+	}
+
+	if hash != message.Hash {
+		return fmt.Errorf("mint hash %s does not match the on chain hash %s", hash, message.Hash)
+	}
+
+	mint.Hash = hash
+	if mint.CreatedAt.IsZero() {
+		mint.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+/*
+* validateSuppliedInvoice holds an invoice supplied by an operator to the same checks as one created through
+* the API, its field limits and the signature of its seller, and checks it is the invoice anchored on chain.
+ */
+func validateSuppliedInvoice(outcome store.OnChainTransactionOutcome, invoice *store.UnconfirmedInvoice) error {
+	if invoice == nil {
+		return errors.New("invoice payload is required")
+	}
+
+	message := protocol.OnChainInvoiceMessage{}
+	if err := proto.Unmarshal(outcome.ActionData, &message); err != nil {
+		return err
+	}
+
+	request := CreateInvoiceRequest{
+		SignedRequest: SignedRequest{
+			PublicKey:    invoice.PublicKey,
+			Signature:    invoice.Signature,
+			RedeemScript: invoice.RedeemScript,
+			Signatures:   invoice.MultisigSignatures,
+		},
+		Payload: CreateInvoiceRequestPayload{
+			PaymentAddress: invoice.PaymentAddress,
+			BuyerAddress:   invoice.BuyerAddress,
+			MintHash:       invoice.MintHash,
+			Quantity:       invoice.Quantity,
+			PriceKoinu:     invoice.PriceKoinu,
+			SellerAddress:  invoice.SellerAddress,
+			ExpiryHeight:   message.ExpiryHeight,
+		},
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	hash, err := invoice.GenerateHash()
+	if err != nil {
+		return err
+	}
+
+	onchainHash := hex.EncodeToString(message.InvoiceHash)
+	if hash != onchainHash {
+		return fmt.Errorf("invoice hash %s does not match the on chain hash %s", hash, onchainHash)
+	}
+
+	invoice.Hash = hash
+	if invoice.CreatedAt.IsZero() {
+		invoice.CreatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// @Summary		Ignore a stuck on chain transaction
+// @Description	Finishes with a transaction awaiting data or expired without processing it
+// @Tags			admin
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string				true	"Transaction hash"
+// @Param			request	body		AdminActionRequest	false	"Reason for the action"
+// @Success		200		{object}	AdminActionResponse
+// @Failure		400		{object}	string
+// @Failure		403		{object}	string
+// @Failure		404		{object}	string
+// @Failure		409		{object}	string
+// @Router			/admin/stuck-transactions/{hash}/ignore [post]
+func (ar *AdminRoutes) postIgnore(w http.ResponseWriter, r *http.Request) {
+	txHash, request, ok := decodeAdminActionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	outcome, err := ar.store.IgnoreOnChainTransaction(txHash, request.Reason, r.RemoteAddr)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	log.Printf("Admin ignored transaction %s from %s", txHash, r.RemoteAddr)
+	respondJSON(w, http.StatusOK, AdminActionResponse{Transaction: outcome})
+}
+
+// @Summary		Get the admin audit log
+// @Description	Returns the actions taken through the admin API, most recent first
+// @Tags			admin
+// @Produce		json
+// @Param			limit	query		int		false	"Limit"
+// @Param			page	query		int		false	"Page"
+// @Success		200		{object}	GetAdminAuditLogResponse
+// @Failure		403		{object}	string
+// @Failure		500		{object}	string
+// @Router			/admin/audit-log [get]
+func (ar *AdminRoutes) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	page, limit := adminPagination(r)
+
+	entries, err := ar.store.GetAdminAuditLog(page*limit, limit)
+	if err != nil {
+		log.Println("error getting admin audit log", err)
+		http.Error(w, "Failed to get audit log", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetAdminAuditLogResponse{
+		Entries: entries,
+		Page:    page,
+		Limit:   limit,
+	})
+}
+
+func adminPagination(r *http.Request) (int, int) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	return page, limit
+}
+
+// decodeAdminActionRequest reads the transaction hash and the optional request body of an admin action.
+func decodeAdminActionRequest(w http.ResponseWriter, r *http.Request) (string, AdminActionRequest, bool) {
+	var request AdminActionRequest
+
+	txHash := r.PathValue("hash")
+	if err := validation.ValidateHash(txHash); err != nil {
+		http.Error(w, "Invalid transaction hash format", http.StatusBadRequest)
+		return "", request, false
+	}
+
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return "", request, false
+		}
+	}
+
+	if err := validation.ValidateStringLength("reason", request.Reason, 500); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", request, false
+	}
+
+	return txHash, request, true
+}
+
+func respondAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+	case errors.Is(err, store.ErrOnChainTransactionNotStuck):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		log.Println("error handling admin action", err)
+		http.Error(w, "Failed to handle admin action", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,107 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestAdminSupplyInvoicePayload(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+	rpc.HandleAdminRoutes(tokenisationStore, mux)
+
+	privHex, pubHex, sellerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	payload := rpc.CreateInvoiceRequestPayload{
+		MintHash:       test_support.GenerateRandomHash(),
+		Quantity:       10,
+		PriceKoinu:     100000000,
+		BuyerAddress:   test_support.GenerateDogecoinAddress(true),
+		PaymentAddress: test_support.GenerateDogecoinAddress(true),
+		SellerAddress:  sellerAddress,
+	}
+	signature, err := doge.SignPayload(payload, privHex, pubHex)
+	assert.NilError(t, err)
+
+	invoice := store.UnconfirmedInvoice{
+		MintHash:       payload.MintHash,
+		Quantity:       payload.Quantity,
+		PriceKoinu:     payload.PriceKoinu,
+		BuyerAddress:   payload.BuyerAddress,
+		PaymentAddress: payload.PaymentAddress,
+		SellerAddress:  payload.SellerAddress,
+		PublicKey:      pubHex,
+		Signature:      signature,
+	}
+	invoiceHash, err := invoice.GenerateHash()
+	assert.NilError(t, err)
+	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+	assert.NilError(t, err)
+
+	encodedMsg, err := proto.Marshal(&protocol.OnChainInvoiceMessage{InvoiceHash: invoiceHashBytes, Quantity: 10})
+	assert.NilError(t, err)
+
+	txHash := test_support.GenerateRandomHash()
+	_, err = tokenisationStore.SaveOnChainTransaction(txHash, 1, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedMsg, invoice.SellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenisationStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = tokenisationStore.SaveAwaitingOnChainTransaction(txs[0], store.OnChainAwaiting_DATA, "invoice not gossiped")
+	assert.NilError(t, err)
+
+	stuck, err := feClient.GetStuckTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(stuck.Transactions), 1)
+	assert.Equal(t, stuck.Transactions[0].TxHash, txHash)
+
+	// A payload that is not signed by the seller is refused
+	unsigned := invoice
+	unsigned.Signature = ""
+	_, err = feClient.SupplyStuckTransactionPayload(txHash, rpc.SupplyPayloadRequest{Invoice: &unsigned})
+	assert.ErrorContains(t, err, "400")
+
+	// A payload that does not hash to the on chain hash is refused
+	otherPayload := payload
+	otherPayload.Quantity = 1000
+	otherSignature, err := doge.SignPayload(otherPayload, privHex, pubHex)
+	assert.NilError(t, err)
+	tampered := invoice
+	tampered.Quantity = otherPayload.Quantity
+	tampered.Signature = otherSignature
+	_, err = feClient.SupplyStuckTransactionPayload(txHash, rpc.SupplyPayloadRequest{Invoice: &tampered})
+	assert.ErrorContains(t, err, "does not match the on chain hash")
+
+	_, err = tokenisationStore.GetUnconfirmedInvoiceByHash(invoiceHash)
+	assert.Assert(t, err != nil)
+
+	response, err := feClient.SupplyStuckTransactionPayload(txHash, rpc.SupplyPayloadRequest{Invoice: &invoice})
+	assert.NilError(t, err)
+	assert.Equal(t, response.Transaction.Status, store.OnChainOutcome_AWAITING_DATA)
+	assert.Equal(t, response.Transaction.ReasonCode, store.OnChainAwaiting_REQUEUED)
+
+	unconfirmedInvoice, err := tokenisationStore.GetUnconfirmedInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, unconfirmedInvoice.Quantity, 10)
+
+	audit, err := feClient.GetAdminAuditLog(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(audit.Entries), 1)
+	assert.Equal(t, audit.Entries[0].Action, store.AdminAction_SUPPLY_PAYLOAD)
+	assert.Equal(t, audit.Entries[0].Detail, "invoice "+invoiceHash)
+
+	response, err = feClient.IgnoreStuckTransaction(txHash, "duplicate")
+	assert.NilError(t, err)
+	assert.Equal(t, response.Transaction.Status, store.OnChainOutcome_IGNORED)
+
+	_, err = feClient.ReprocessStuckTransaction(txHash, "")
+	assert.ErrorContains(t, err, "409")
+}
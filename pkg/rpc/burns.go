@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/dogenet"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+type BurnRoutes struct {
+	store        *store.TokenisationStore
+	gossipClient dogenet.GossipClient
+}
+
+func HandleBurnRoutes(store *store.TokenisationStore, gossipClient dogenet.GossipClient, mux *http.ServeMux) {
+	br := &BurnRoutes{store: store, gossipClient: gossipClient}
+
+	mux.HandleFunc("/burns/{hash}/signatures", br.handleCreateBurnSignature)
+	mux.HandleFunc("/burns", br.handleBurns)
+}
+
+func (br *BurnRoutes) handleBurns(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		br.postBurn(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (br *BurnRoutes) handleCreateBurnSignature(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		br.postCreateBurnSignature(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Prepares an encoded transaction body for a token burn
+// @Description	Generates an encoded transaction body that burns tokens held by the address that signs the transaction.
+// @Description	If the mint requires co-signed burns, the returned hash must be signed by the asset managers before the burn is applied.
+// @Tags			burns
+// @Accept			json
+// @Produce		json
+// @Param			request	body		CreateBurnRequest	true	"Burn request"
+// @Success		201		{object}	CreateBurnResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/burns [post]
+func (br *BurnRoutes) postBurn(w http.ResponseWriter, r *http.Request) {
+	var request CreateBurnRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	err := request.Validate()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := br.store.GetMintByHash(request.Payload.MintHash)
+	if err != nil {
+		http.Error(w, "Failed to get mint", http.StatusInternalServerError)
+		return
+	}
+
+	if mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusBadRequest)
+		return
+	}
+
+	blockHeight, _, _, err := br.store.GetChainPosition()
+	if err != nil {
+		http.Error(w, "Failed to get chain position", http.StatusInternalServerError)
+		return
+	}
+
+	available, err := br.store.GetTransferableTokenBalance(request.Payload.Address, request.Payload.MintHash, blockHeight, time.Now().Unix(), nil)
+	if err != nil {
+		http.Error(w, "Failed to get token balance", http.StatusInternalServerError)
+		return
+	}
+
+	if available < request.Payload.Quantity {
+		http.Error(w, store.ErrInsufficientTokenBalance.Error(), http.StatusBadRequest)
+		return
+	}
+
+	burnHash := ""
+	if mint.BurnSignatureRequired() {
+		burn := store.TokenBurn{
+			MintHash:  request.Payload.MintHash,
+			Address:   request.Payload.Address,
+			Quantity:  request.Payload.Quantity,
+			CreatedAt: time.Now(),
+		}
+
+		burnHash, err = burn.GenerateHash()
+		if err != nil {
+			http.Error(w, "Failed to generate burn hash", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	envelope := protocol.NewBurnTransactionEnvelope(request.Payload.MintHash, int32(request.Payload.Quantity), burnHash, protocol.ACTION_BURN)
+	encodedTransactionBody := envelope.Serialize()
+
+	respondJSON(w, http.StatusCreated, CreateBurnResponse{
+		Hash:                   burnHash,
+		EncodedTransactionBody: hex.EncodeToString(encodedTransactionBody),
+	})
+}
+
+// @Summary		Create a burn signature
+// @Description	Records an asset manager co-signature for a burn
+// @Tags			burns
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string						true	"Burn hash"
+// @Param			request	body		CreateBurnSignatureRequest	true	"Burn signature request"
+// @Success		201		{object}	CreateBurnSignatureResponse
+// @Failure		400		{object}	string
+// @Router			/burns/{hash}/signatures [post]
+func (br *BurnRoutes) postCreateBurnSignature(w http.ResponseWriter, r *http.Request) {
+	burnHash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(burnHash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request CreateBurnSignatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		log.Println("error decoding request", err)
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := br.store.GetMintByHash(request.Payload.MintHash)
+	if err != nil || mint.Id == "" {
+		log.Println("error getting mint by hash", err)
+		http.Error(w, "Could not find mint by hash", http.StatusBadRequest)
+		return
+	}
+
+	if !mint.BurnSignatureRequired() {
+		http.Error(w, "Mint does not require burn signatures", http.StatusBadRequest)
+		return
+	}
+
+	mint, err = br.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		http.Error(w, "Could not get asset managers", http.StatusInternalServerError)
+		return
+	}
+
+	newBurnSignature := &store.InvoiceSignature{
+		InvoiceHash: burnHash,
+		Signature:   request.Payload.Signature,
+		PublicKey:   request.Payload.PublicKey,
+		CreatedAt:   time.Now(),
+	}
+
+	err = newBurnSignature.ValidateBurn(mint, burnHash, request.Payload.Address, request.Payload.Quantity)
+	if err != nil {
+		log.Println("error validating signature", err)
+		http.Error(w, "Invalid signature", http.StatusBadRequest)
+		return
+	}
+
+	id, err := br.store.SaveApprovedInvoiceSignature(newBurnSignature)
+	if err != nil {
+		log.Println("error saving burn signature", err)
+		http.Error(w, "Unable to save signature", http.StatusBadRequest)
+		return
+	}
+
+	err = br.gossipClient.GossipInvoiceSignature(*newBurnSignature)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, CreateBurnSignatureResponse{
+		Id: id,
+	})
+}
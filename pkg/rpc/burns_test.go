@@ -0,0 +1,131 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestCreateBurn(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+	rpc.HandleBurnRoutes(tokenisationStore, dogenetClient, mux)
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 10}, address)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(address, mintHash, 10)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateBurn(&rpc.CreateBurnRequest{
+		Payload: rpc.CreateBurnRequestPayload{
+			MintHash: mintHash,
+			Address:  address,
+			Quantity: 4,
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, response.Hash, "")
+
+	encoded, err := hex.DecodeString(response.EncodedTransactionBody)
+	assert.NilError(t, err)
+
+	envelope := protocol.MessageEnvelope{}
+	err = envelope.Deserialize(encoded)
+	assert.NilError(t, err)
+	assert.Equal(t, envelope.Action, uint8(protocol.ACTION_BURN))
+
+	message := protocol.OnChainBurnMessage{}
+	err = proto.Unmarshal(envelope.Data, &message)
+	assert.NilError(t, err)
+	assert.Equal(t, hex.EncodeToString(message.MintHash), mintHash)
+	assert.Equal(t, message.Quantity, int32(4))
+	assert.Equal(t, len(message.BurnHash), 0)
+
+	_, err = feClient.CreateBurn(&rpc.CreateBurnRequest{
+		Payload: rpc.CreateBurnRequestPayload{
+			MintHash: mintHash,
+			Address:  address,
+			Quantity: 11,
+		},
+	})
+	assert.ErrorContains(t, err, "insufficient available token balance")
+}
+
+func TestCreateBurnWithSignature(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+	rpc.HandleBurnRoutes(tokenisationStore, dogenetClient, mux)
+
+	assetManagerPrivKey, assetManagerPubKey, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	_, err = tokenisationStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "mint1",
+		FractionCount:            10,
+		Requirements:             store.StringInterfaceMap{store.BurnRequiresSignaturesRequirement: true},
+		SignatureRequirementType: store.SignatureRequirementType_ALL_SIGNATURES,
+		AssetManagers: store.AssetManagers{
+			{Name: "asset manager", PublicKey: assetManagerPubKey, URL: "https://example.com/assetManager"},
+		},
+	}, address)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(address, mintHash, 10)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateBurn(&rpc.CreateBurnRequest{
+		Payload: rpc.CreateBurnRequestPayload{
+			MintHash: mintHash,
+			Address:  address,
+			Quantity: 4,
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, response.Hash != "")
+
+	signature, err := doge.SignPayload(store.BurnSignatureBody{
+		Hash:     response.Hash,
+		MintHash: mintHash,
+		Quantity: 4,
+		Address:  address,
+	}, assetManagerPrivKey, assetManagerPubKey)
+	assert.NilError(t, err)
+
+	_, err = feClient.CreateBurnSignature(response.Hash, &rpc.CreateBurnSignatureRequest{
+		Payload: rpc.CreateBurnSignatureRequestPayload{
+			MintHash:  mintHash,
+			Address:   address,
+			Quantity:  5,
+			Signature: signature,
+			PublicKey: assetManagerPubKey,
+		},
+	})
+	assert.ErrorContains(t, err, "Invalid signature")
+
+	_, err = feClient.CreateBurnSignature(response.Hash, &rpc.CreateBurnSignatureRequest{
+		Payload: rpc.CreateBurnSignatureRequestPayload{
+			MintHash:  mintHash,
+			Address:   address,
+			Quantity:  4,
+			Signature: signature,
+			PublicKey: assetManagerPubKey,
+		},
+	})
+	assert.NilError(t, err)
+
+	signatures, err := tokenisationStore.GetApprovedInvoiceSignatures(response.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(signatures), 1)
+	assert.Equal(t, len(dogenetClient.invoiceSignatures), 1)
+}
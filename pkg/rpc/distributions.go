@@ -0,0 +1,289 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+type DistributionRoutes struct {
+	store *store.TokenisationStore
+}
+
+func HandleDistributionRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
+	dr := &DistributionRoutes{store: store}
+
+	mux.HandleFunc("/distributions/{hash}/payout-transaction", dr.handleDistributionPayoutTransaction)
+	mux.HandleFunc("/distributions/{hash}", dr.handleDistribution)
+	mux.HandleFunc("/distributions", dr.handleDistributions)
+}
+
+func (dr *DistributionRoutes) handleDistributions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		dr.getDistributions(w, r)
+	case http.MethodPost:
+		dr.postDistribution(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (dr *DistributionRoutes) handleDistribution(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		dr.getDistribution(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (dr *DistributionRoutes) handleDistributionPayoutTransaction(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		dr.getDistributionPayoutTransaction(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Prepares an encoded transaction body for a distribution
+// @Description	Generates an encoded transaction body that publishes a pro rata distribution to the holders of a mint.
+// @Description	The transaction must be signed by the mint owner. The returned payouts are a preview based on the holders known at the record height.
+// @Tags			distributions
+// @Accept			json
+// @Produce		json
+// @Param			request	body		CreateDistributionRequest	true	"Distribution request"
+// @Success		201		{object}	CreateDistributionResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/distributions [post]
+func (dr *DistributionRoutes) postDistribution(w http.ResponseWriter, r *http.Request) {
+	var request CreateDistributionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	err := request.Validate()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := dr.store.GetMintByHash(request.Payload.MintHash)
+	if err != nil {
+		http.Error(w, "Failed to get mint", http.StatusInternalServerError)
+		return
+	}
+
+	if mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusBadRequest)
+		return
+	}
+
+	if mint.OwnerAddress != request.Payload.OwnerAddress {
+		http.Error(w, "Only the mint owner can publish a distribution", http.StatusBadRequest)
+		return
+	}
+
+	blockHeight, _, _, err := dr.store.GetChainPosition()
+	if err != nil {
+		http.Error(w, "Failed to get chain position", http.StatusInternalServerError)
+		return
+	}
+
+	if request.Payload.RecordHeight > blockHeight {
+		http.Error(w, "record_height is above the current block height", http.StatusBadRequest)
+		return
+	}
+
+	holders, err := dr.store.GetTokenHoldersAtHeight(request.Payload.MintHash, request.Payload.RecordHeight, nil)
+	if err != nil {
+		http.Error(w, "Failed to get token holders", http.StatusInternalServerError)
+		return
+	}
+
+	if len(holders) == 0 {
+		http.Error(w, store.ErrNoHolders.Error(), http.StatusBadRequest)
+		return
+	}
+
+	envelope := protocol.NewDistributionTransactionEnvelope(request.Payload.MintHash, request.Payload.TotalKoinu, request.Payload.RecordHeight, protocol.ACTION_DISTRIBUTION)
+	encodedTransactionBody := envelope.Serialize()
+
+	respondJSON(w, http.StatusCreated, CreateDistributionResponse{
+		EncodedTransactionBody: hex.EncodeToString(encodedTransactionBody),
+		Payouts:                store.ComputeDistributionPayouts("", request.Payload.TotalKoinu, holders),
+	})
+}
+
+// @Summary		Get distributions
+// @Description	Returns the distributions published on chain
+// @Tags			distributions
+// @Produce		json
+// @Param			mint_hash	query		string	false	"Mint hash"
+// @Param			limit		query		int		false	"Limit"
+// @Param			page		query		int		false	"Page"
+// @Success		200			{object}	GetDistributionsResponse
+// @Failure		400			{object}	string
+// @Failure		500			{object}	string
+// @Router			/distributions [get]
+func (dr *DistributionRoutes) getDistributions(w http.ResponseWriter, r *http.Request) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	mintHash := validation.SanitizeQueryParam(r.URL.Query().Get("mint_hash"))
+	if mintHash != "" {
+		if err := validation.ValidateHash(mintHash); err != nil {
+			http.Error(w, "Invalid mint_hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	distributions, err := dr.store.GetDistributions(mintHash, page*limit, limit)
+	if err != nil {
+		log.Println("error getting distributions", err)
+		http.Error(w, "Failed to get distributions", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetDistributionsResponse{
+		Distributions: distributions,
+		Page:          page,
+		Limit:         limit,
+	})
+}
+
+// @Summary		Get a distribution
+// @Description	Returns a distribution and its payout plan, including which holders have been paid
+// @Tags			distributions
+// @Produce		json
+// @Param			hash	path		string	true	"Distribution hash"
+// @Success		200		{object}	GetDistributionResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/distributions/{hash} [get]
+func (dr *DistributionRoutes) getDistribution(w http.ResponseWriter, r *http.Request) {
+	distribution, payouts, ok := dr.loadDistribution(w, r)
+	if !ok {
+		return
+	}
+
+	response := GetDistributionResponse{
+		Distribution: distribution,
+		Payouts:      payouts,
+	}
+
+	for _, payout := range payouts {
+		if payout.Paid() {
+			response.PaidKoinu += payout.AmountKoinu
+		} else {
+			response.UnpaidKoinu += payout.AmountKoinu
+		}
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// @Summary		Get the payout transaction of a distribution
+// @Description	Returns the encoded transaction body and the outputs paying every unpaid holder of a distribution.
+// @Description	Payouts below the dust limit have no output as the transaction would not be relayed; their total is returned as dust_koinu.
+// @Tags			distributions
+// @Produce		json
+// @Param			hash	path		string	true	"Distribution hash"
+// @Success		200		{object}	GetDistributionPayoutTransactionResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/distributions/{hash}/payout-transaction [get]
+func (dr *DistributionRoutes) getDistributionPayoutTransaction(w http.ResponseWriter, r *http.Request) {
+	distribution, payouts, ok := dr.loadDistribution(w, r)
+	if !ok {
+		return
+	}
+
+	response := GetDistributionPayoutTransactionResponse{
+		Outputs: []DistributionPayoutOutput{},
+	}
+
+	for _, payout := range payouts {
+		if payout.Paid() || payout.AmountKoinu == 0 {
+			continue
+		}
+
+		if payout.AmountKoinu < doge.DustLimitKoinu {
+			response.DustKoinu += payout.AmountKoinu
+			continue
+		}
+
+		response.Outputs = append(response.Outputs, DistributionPayoutOutput{
+			Address:     payout.Address,
+			AmountKoinu: payout.AmountKoinu,
+		})
+		response.TotalKoinu += payout.AmountKoinu
+	}
+
+	if len(response.Outputs) == 0 && response.DustKoinu > 0 {
+		http.Error(w, "Unpaid payouts are all below the dust limit", http.StatusBadRequest)
+		return
+	}
+
+	if len(response.Outputs) == 0 {
+		http.Error(w, "Distribution is fully paid", http.StatusBadRequest)
+		return
+	}
+
+	envelope := protocol.NewDistributionPayoutTransactionEnvelope(distribution.Hash, protocol.ACTION_DISTRIBUTION_PAYOUT)
+	response.EncodedTransactionBody = hex.EncodeToString(envelope.Serialize())
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func (dr *DistributionRoutes) loadDistribution(w http.ResponseWriter, r *http.Request) (store.Distribution, []store.DistributionPayout, bool) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return store.Distribution{}, nil, false
+	}
+
+	distribution, err := dr.store.GetDistribution(hash)
+	if err != nil {
+		http.Error(w, "Failed to get distribution", http.StatusInternalServerError)
+		return store.Distribution{}, nil, false
+	}
+
+	if distribution.Hash == "" {
+		http.Error(w, "Distribution not found", http.StatusNotFound)
+		return store.Distribution{}, nil, false
+	}
+
+	payouts, err := dr.store.GetDistributionPayouts(hash)
+	if err != nil {
+		http.Error(w, "Failed to get distribution payouts", http.StatusInternalServerError)
+		return store.Distribution{}, nil, false
+	}
+
+	return distribution, payouts, true
+}
@@ -0,0 +1,122 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestDistributions(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleDistributionRoutes(tokenisationStore, mux)
+
+	mintHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	holder := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 100}, owner)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(owner, mintHash, 60)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(holder, mintHash, 40)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertChainPosition(10, "blockHash10", false)
+	assert.NilError(t, err)
+
+	request := &rpc.CreateDistributionRequest{
+		Payload: rpc.CreateDistributionRequestPayload{
+			MintHash:     mintHash,
+			OwnerAddress: holder,
+			TotalKoinu:   1_000_000_000,
+			RecordHeight: 5,
+		},
+	}
+
+	_, err = feClient.CreateDistribution(request)
+	assert.ErrorContains(t, err, "mint owner")
+
+	request.Payload.OwnerAddress = owner
+	request.Payload.RecordHeight = 11
+	_, err = feClient.CreateDistribution(request)
+	assert.ErrorContains(t, err, "record_height")
+
+	request.Payload.RecordHeight = 5
+	response, err := feClient.CreateDistribution(request)
+	assert.NilError(t, err)
+	assert.Assert(t, response.EncodedTransactionBody != "")
+	assert.Equal(t, len(response.Payouts), 2)
+
+	distributionHash := support.GenerateRandomHash()
+	err = tokenisationStore.ProcessDistribution(store.OnChainTransaction{Id: "distributionTx", TxHash: distributionHash, Height: 10, BlockHash: "blockHash10", Address: owner}, mintHash, 1_000_000_000, 5)
+	assert.NilError(t, err)
+
+	distributions, err := feClient.GetDistributions(0, 10, mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(distributions.Distributions), 1)
+
+	payoutTransaction, err := feClient.GetDistributionPayoutTransaction(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, payoutTransaction.TotalKoinu, int64(1_000_000_000))
+	assert.Equal(t, len(payoutTransaction.Outputs), 2)
+
+	_, err = tokenisationStore.ProcessDistributionPayout(store.OnChainTransaction{
+		Id:         "payoutTx",
+		TxHash:     "payoutTx",
+		Height:     11,
+		ActionType: protocol.ACTION_DISTRIBUTION_PAYOUT,
+		Values:     store.KoinuValues{holder: 400_000_000},
+	}, distributionHash)
+	assert.NilError(t, err)
+
+	distribution, err := feClient.GetDistribution(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, distribution.PaidKoinu, int64(400_000_000))
+	assert.Equal(t, distribution.UnpaidKoinu, int64(600_000_000))
+
+	payoutTransaction, err = feClient.GetDistributionPayoutTransaction(distributionHash)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, payoutTransaction.Outputs, []rpc.DistributionPayoutOutput{{Address: owner, AmountKoinu: 600_000_000}})
+
+	_, err = feClient.GetDistribution(support.GenerateRandomHash())
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestDistributionPayoutTransactionSkipsDust(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleDistributionRoutes(tokenisationStore, mux)
+
+	mintHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	holder := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 100}, owner)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(owner, mintHash, 60)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(holder, mintHash, 40)
+	assert.NilError(t, err)
+
+	// The holder's share is below the dust limit so it has no output
+	distributionHash := support.GenerateRandomHash()
+	err = tokenisationStore.ProcessDistribution(store.OnChainTransaction{Id: "distributionTx", TxHash: distributionHash, Height: 10, BlockHash: "blockHash10", Address: owner}, mintHash, 2_000_000, 5)
+	assert.NilError(t, err)
+
+	payoutTransaction, err := feClient.GetDistributionPayoutTransaction(distributionHash)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, payoutTransaction.Outputs, []rpc.DistributionPayoutOutput{{Address: owner, AmountKoinu: 1_200_000}})
+	assert.Equal(t, payoutTransaction.TotalKoinu, int64(1_200_000))
+	assert.Equal(t, payoutTransaction.DustKoinu, int64(800_000))
+
+	dustHash := support.GenerateRandomHash()
+	err = tokenisationStore.ProcessDistribution(store.OnChainTransaction{Id: "dustTx", TxHash: dustHash, Height: 10, BlockHash: "blockHash10", Address: owner}, mintHash, 1_000, 5)
+	assert.NilError(t, err)
+
+	_, err = feClient.GetDistributionPayoutTransaction(dustHash)
+	assert.ErrorContains(t, err, "dust limit")
+}
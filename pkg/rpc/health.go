@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"net/http"
 
+	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 	"dogecoin.org/fractal-engine/pkg/version"
 )
@@ -28,7 +29,7 @@ func (hr *HealthRoutes) handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary		Get health
-// @Description	Returns the current and latest block height
+// @Description	Returns the current and latest block height and the message versions supported per action
 // @Tags			health
 // @Accept			json
 // @Produce		json
@@ -53,6 +54,7 @@ func (hr *HealthRoutes) getHealth(w http.ResponseWriter, _ *http.Request) {
 		Chain:              chain,
 		WalletsEnabled:     walletsEnabled,
 		Version:            version.Version,
+		SupportedVersions:  protocol.SupportedVersions(),
 	}
 
 	respondJSON(w, http.StatusOK, response)
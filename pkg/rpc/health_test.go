@@ -23,4 +23,6 @@ func TestGetHealth(t *testing.T) {
 	assert.Equal(t, healthResponse.UpdatedAt.IsZero(), false)
 	assert.Equal(t, healthResponse.Chain, "test")
 	assert.Equal(t, healthResponse.WalletsEnabled, true)
+	assert.DeepEqual(t, healthResponse.SupportedVersions["mint"], []int{1})
+	assert.DeepEqual(t, healthResponse.SupportedVersions["mint_ownership_transfer"], []int{1})
 }
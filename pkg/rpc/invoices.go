@@ -25,11 +25,131 @@ func HandleInvoiceRoutes(store *store.TokenisationStore, gossipClient dogenet.Go
 	ir := &InvoiceRoutes{store: store, gossipClient: gossipClient, cfg: cfg}
 
 	mux.HandleFunc("/invoices/{hash}/signatures", ir.handleCreateInvoiceSignature)
+	mux.HandleFunc("/invoices/{hash}/cancellation", ir.handleInvoiceCancellation)
 	mux.HandleFunc("/invoices", ir.handleInvoices)
 	mux.HandleFunc("/invoices/{address}", ir.handleInvoices)
+	mux.HandleFunc("/trade-rejections", ir.handleTradeRejections)
 
 }
 
+func (ir *InvoiceRoutes) handleTradeRejections(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ir.getTradeRejections(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get trade rejections
+// @Description	Returns invoices and payments that were rejected because they violate the requirements of the mint
+// @Tags			invoices
+// @Produce		json
+// @Param			mint_hash		query		string	false	"Mint hash"
+// @Param			invoice_hash	query		string	false	"Invoice hash"
+// @Param			limit			query		int		false	"Limit"
+// @Param			page			query		int		false	"Page"
+// @Success		200				{object}	GetTradeRejectionsResponse
+// @Failure		400				{object}	string
+// @Failure		500				{object}	string
+// @Router			/trade-rejections [get]
+func (ir *InvoiceRoutes) getTradeRejections(w http.ResponseWriter, r *http.Request) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	mintHash := validation.SanitizeQueryParam(r.URL.Query().Get("mint_hash"))
+	if mintHash != "" {
+		if err := validation.ValidateHash(mintHash); err != nil {
+			http.Error(w, "Invalid mint_hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	invoiceHash := validation.SanitizeQueryParam(r.URL.Query().Get("invoice_hash"))
+	if invoiceHash != "" {
+		if err := validation.ValidateHash(invoiceHash); err != nil {
+			http.Error(w, "Invalid invoice_hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rejections, err := ir.store.GetTradeRejections(mintHash, invoiceHash, page*limit, limit)
+	if err != nil {
+		log.Println("error getting trade rejections", err)
+		http.Error(w, "Failed to get trade rejections", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetTradeRejectionsResponse{
+		Rejections: rejections,
+		Page:       page,
+		Limit:      limit,
+	})
+}
+
+func (ir *InvoiceRoutes) handleInvoiceCancellation(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ir.postInvoiceCancellation(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Prepares an encoded transaction body for an invoice cancellation
+// @Description	Generates an encoded transaction body for cancelling an unpaid invoice, which releases its reserved fractions. The transaction must be sent by the seller of the invoice.
+// @Tags			invoices
+// @Produce		json
+// @Param			hash	path		string	true	"Invoice hash"
+// @Success		201		{object}	CreateInvoiceCancellationResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/invoices/{hash}/cancellation [post]
+func (ir *InvoiceRoutes) postInvoiceCancellation(w http.ResponseWriter, r *http.Request) {
+	invoiceHash := r.PathValue("hash")
+	if err := validation.ValidateHash(invoiceHash); err != nil {
+		http.Error(w, "Invalid invoice hash format", http.StatusBadRequest)
+		return
+	}
+
+	invoice, err := ir.store.GetInvoiceByHash(invoiceHash)
+	if err == nil {
+		if invoice.PaidKoinu > 0 {
+			http.Error(w, "Invoice has received a payment", http.StatusBadRequest)
+			return
+		}
+		if invoice.ReleaseReason != "" {
+			http.Error(w, "Invoice has been "+invoice.ReleaseReason, http.StatusBadRequest)
+			return
+		}
+	} else if _, err := ir.store.GetUnconfirmedInvoiceByHash(invoiceHash); err != nil {
+		http.Error(w, "Invoice not found", http.StatusNotFound)
+		return
+	}
+
+	envelope := protocol.NewInvoiceCancellationTransactionEnvelope(invoiceHash, protocol.ACTION_INVOICE_CANCELLATION)
+
+	respondJSON(w, http.StatusCreated, CreateInvoiceCancellationResponse{
+		InvoiceHash:            invoiceHash,
+		EncodedTransactionBody: hex.EncodeToString(envelope.Serialize()),
+	})
+}
+
 func (ir *InvoiceRoutes) handleCreateInvoiceSignature(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
@@ -77,6 +197,14 @@ func (ir *InvoiceRoutes) postCreateInvoiceSignature(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// The invoice is not anchored yet, so the latest asset managers are the ones effective for it
+	mint, err = ir.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		log.Println("error getting asset managers", err)
+		http.Error(w, "Could not get asset managers", http.StatusInternalServerError)
+		return
+	}
+
 	err = newInvoiceSignature.Validate(mint, invoice)
 	if err != nil {
 		log.Println("error validating signature", err)
@@ -130,7 +258,7 @@ func (ir *InvoiceRoutes) handleInvoices(w http.ResponseWriter, r *http.Request)
 }
 
 // @Summary		Get invoices
-// @Description	Returns a list of invoices with optional filtering by mint_hash and address
+// @Description	Returns a list of invoices with optional filtering by mint_hash and address, with the payment of each invoice waiting in the mempool if there is one
 // @Tags			invoices
 // @Accept			json
 // @Produce		json
@@ -201,8 +329,15 @@ func (ir *InvoiceRoutes) getInvoices(w http.ResponseWriter, r *http.Request) {
 		end = len(invoices)
 	}
 
+	pageInvoices, err := ir.store.WithMempoolPayments(invoices[start:end])
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Failed to get mempool payments", http.StatusInternalServerError)
+		return
+	}
+
 	response := GetInvoicesResponse{
-		Invoices: invoices[start:end],
+		Invoices: pageInvoices,
 		Total:    len(invoices),
 		Page:     page,
 		Limit:    limit,
@@ -260,16 +395,18 @@ func (ir *InvoiceRoutes) postInvoice(w http.ResponseWriter, r *http.Request) {
 	}
 
 	newInvoiceWithoutId := &store.UnconfirmedInvoice{
-		MintHash:       request.Payload.MintHash,
-		Quantity:       request.Payload.Quantity,
-		Price:          request.Payload.Price,
-		BuyerAddress:   request.Payload.BuyerAddress,
-		PaymentAddress: request.Payload.PaymentAddress,
-		CreatedAt:      time.Now(),
-		SellerAddress:  request.Payload.SellerAddress,
-		PublicKey:      request.PublicKey,
-		Signature:      request.Signature,
-		Status:         initialStatus,
+		MintHash:           request.Payload.MintHash,
+		Quantity:           request.Payload.Quantity,
+		PriceKoinu:         request.Payload.PriceKoinu,
+		BuyerAddress:       request.Payload.BuyerAddress,
+		PaymentAddress:     request.Payload.PaymentAddress,
+		CreatedAt:          time.Now(),
+		SellerAddress:      request.Payload.SellerAddress,
+		PublicKey:          request.PublicKey,
+		Signature:          request.Signature,
+		RedeemScript:       request.RedeemScript,
+		MultisigSignatures: store.MultisigSignatures(request.Signatures),
+		Status:             initialStatus,
 	}
 
 	newInvoiceWithoutId.Hash, err = newInvoiceWithoutId.GenerateHash()
@@ -292,7 +429,7 @@ func (ir *InvoiceRoutes) postInvoice(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	envelope := protocol.NewInvoiceTransactionEnvelope(newInvoiceWithoutId.Hash, newInvoiceWithoutId.MintHash, int32(newInvoiceWithoutId.Quantity), protocol.ACTION_INVOICE)
+	envelope := protocol.NewInvoiceTransactionEnvelope(newInvoiceWithoutId.Hash, newInvoiceWithoutId.MintHash, int32(newInvoiceWithoutId.Quantity), request.Payload.ExpiryHeight, protocol.ACTION_INVOICE)
 	encodedTransactionBody := envelope.Serialize()
 
 	response := CreateInvoiceResponse{
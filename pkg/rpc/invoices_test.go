@@ -28,7 +28,7 @@ func TestInvoices(t *testing.T) {
 			BuyerAddress:   MintHash,
 			MintHash:       buyOfferMintHash,
 			Quantity:       10,
-			Price:          100,
+			PriceKoinu:     100,
 			SellerAddress:  sellOfferAddress,
 		},
 	}
@@ -49,7 +49,7 @@ func TestInvoices(t *testing.T) {
 	assert.Equal(t, invoices[0].BuyerAddress, invoice.Payload.BuyerAddress)
 	assert.Equal(t, invoices[0].MintHash, invoice.Payload.MintHash)
 	assert.Equal(t, invoices[0].Quantity, invoice.Payload.Quantity)
-	assert.Equal(t, invoices[0].Price, invoice.Payload.Price)
+	assert.Equal(t, invoices[0].PriceKoinu, invoice.Payload.PriceKoinu)
 	assert.Equal(t, invoices[0].SellerAddress, invoice.Payload.SellerAddress)
 	assert.Equal(t, invoices[0].Status, "draft")
 
@@ -59,7 +59,7 @@ func TestInvoices(t *testing.T) {
 	assert.Equal(t, dogenetClient.invoices[0].BuyerAddress, invoice.Payload.BuyerAddress)
 	assert.Equal(t, dogenetClient.invoices[0].MintHash, invoice.Payload.MintHash)
 	assert.Equal(t, dogenetClient.invoices[0].Quantity, invoice.Payload.Quantity)
-	assert.Equal(t, dogenetClient.invoices[0].Price, invoice.Payload.Price)
+	assert.Equal(t, dogenetClient.invoices[0].PriceKoinu, invoice.Payload.PriceKoinu)
 	assert.Equal(t, dogenetClient.invoices[0].SellerAddress, invoice.Payload.SellerAddress)
 }
 
@@ -105,7 +105,7 @@ func TestInvoicesWithSignatureRequired(t *testing.T) {
 			BuyerAddress:   buyerAddress,
 			MintHash:       confirmedMint.Hash,
 			Quantity:       10,
-			Price:          100,
+			PriceKoinu:     100,
 			SellerAddress:  sellOfferAddress,
 		},
 	}
@@ -126,7 +126,7 @@ func TestInvoicesWithSignatureRequired(t *testing.T) {
 	assert.Equal(t, invoices[0].BuyerAddress, invoice.Payload.BuyerAddress)
 	assert.Equal(t, invoices[0].MintHash, invoice.Payload.MintHash)
 	assert.Equal(t, invoices[0].Quantity, invoice.Payload.Quantity)
-	assert.Equal(t, invoices[0].Price, invoice.Payload.Price)
+	assert.Equal(t, invoices[0].PriceKoinu, invoice.Payload.PriceKoinu)
 	assert.Equal(t, invoices[0].SellerAddress, invoice.Payload.SellerAddress)
 	assert.Equal(t, invoices[0].Status, "pending_signatures")
 
@@ -136,7 +136,7 @@ func TestInvoicesWithSignatureRequired(t *testing.T) {
 	assert.Equal(t, dogenetClient.invoices[0].BuyerAddress, invoice.Payload.BuyerAddress)
 	assert.Equal(t, dogenetClient.invoices[0].MintHash, invoice.Payload.MintHash)
 	assert.Equal(t, dogenetClient.invoices[0].Quantity, invoice.Payload.Quantity)
-	assert.Equal(t, dogenetClient.invoices[0].Price, invoice.Payload.Price)
+	assert.Equal(t, dogenetClient.invoices[0].PriceKoinu, invoice.Payload.PriceKoinu)
 	assert.Equal(t, dogenetClient.invoices[0].SellerAddress, invoice.Payload.SellerAddress)
 }
 
@@ -185,7 +185,7 @@ func TestCreateInvoiceSignature(t *testing.T) {
 		BuyerAddress:   offererAddress,
 		MintHash:       confirmedMint.Hash,
 		Quantity:       10,
-		Price:          25,
+		PriceKoinu:     25,
 		CreatedAt:      time.Now(),
 		PublicKey:      "myPublicKey",
 		SellerAddress:  sellOfferAddress,
@@ -203,7 +203,7 @@ func TestCreateInvoiceSignature(t *testing.T) {
 	invoiceBody := store.InvoiceSignatureBody{
 		Hash:           invoice.Hash,
 		MintHash:       invoice.MintHash,
-		Price:          invoice.Price,
+		PriceKoinu:     invoice.PriceKoinu,
 		Quantity:       invoice.Quantity,
 		BuyerAddress:   invoice.BuyerAddress,
 		PaymentAddress: invoice.PaymentAddress,
@@ -234,3 +234,79 @@ func TestCreateInvoiceSignature(t *testing.T) {
 
 	assert.Equal(t, savedInvoiceHash, invoice.Hash)
 }
+
+func TestGetTradeRejections(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleInvoiceRoutes(tokenisationStore, dogenetClient, mux, &config.Config{})
+
+	mintHash := support.GenerateRandomHash()
+	invoiceHash := support.GenerateRandomHash()
+
+	_, err := tokenisationStore.SaveTradeRejection(store.TradeRejection{
+		InvoiceHash:   invoiceHash,
+		MintHash:      mintHash,
+		BuyerAddress:  support.GenerateDogecoinAddress(true),
+		SellerAddress: support.GenerateDogecoinAddress(true),
+		Quantity:      10,
+		ReasonCode:    store.TradeRejection_MAX_HOLDERS_EXCEEDED,
+		Reason:        "trade would result in 3 holders, above 2",
+		BlockHeight:   5,
+	}, nil)
+	assert.NilError(t, err)
+
+	response, err := feClient.GetTradeRejections(mintHash, "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Rejections), 1)
+	assert.Equal(t, response.Rejections[0].InvoiceHash, invoiceHash)
+	assert.Equal(t, response.Rejections[0].ReasonCode, store.TradeRejection_MAX_HOLDERS_EXCEEDED)
+
+	response, err = feClient.GetTradeRejections("", support.GenerateRandomHash())
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Rejections), 0)
+}
+
+func TestCreateInvoiceRequestWithMultisigSeller(t *testing.T) {
+	var privKeys, pubKeys []string
+	for i := 0; i < 3; i++ {
+		privKey, pubKey, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+		assert.NilError(t, err)
+		privKeys = append(privKeys, privKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	redeemScript, err := doge.NewMultisigRedeemScript(2, pubKeys)
+	assert.NilError(t, err)
+
+	sellerAddress, err := doge.ScriptHashAddress(redeemScript, doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	payload := rpc.CreateInvoiceRequestPayload{
+		PaymentAddress: sellerAddress,
+		BuyerAddress:   support.GenerateDogecoinAddress(true),
+		MintHash:       support.GenerateRandomHash(),
+		Quantity:       10,
+		PriceKoinu:     100,
+		SellerAddress:  sellerAddress,
+	}
+
+	var signatures []doge.MultisigSignature
+	for i := 0; i < 2; i++ {
+		signature, err := doge.SignPayload(payload, privKeys[i], pubKeys[i])
+		assert.NilError(t, err)
+		signatures = append(signatures, doge.MultisigSignature{PublicKey: pubKeys[i], Signature: signature})
+	}
+
+	request := rpc.CreateInvoiceRequest{
+		SignedRequest: rpc.SignedRequest{RedeemScript: redeemScript, Signatures: signatures},
+		Payload:       payload,
+	}
+	assert.NilError(t, request.Validate())
+
+	request.Signatures = signatures[:1]
+	assert.ErrorContains(t, request.Validate(), "not enough multisig signatures")
+
+	request.Signatures = signatures
+	request.Payload.SellerAddress = support.GenerateDogecoinAddress(true)
+	assert.ErrorContains(t, request.Validate(), "redeem_script does not match seller_address")
+}
@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+type MempoolRoutes struct {
+	store *store.TokenisationStore
+}
+
+func HandleMempoolRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
+	mr := &MempoolRoutes{store: store}
+
+	mux.HandleFunc("/mempool-transactions", mr.handleMempoolTransactions)
+}
+
+func (mr *MempoolRoutes) handleMempoolTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		mr.getMempoolTransactions(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get mempool transactions
+// @Description	Returns the mints, invoices and payments seen in the mempool and not yet in a block, most recently seen first. They do not change balances and are cleared when they leave the mempool.
+// @Tags			mempool
+// @Produce		json
+// @Param			hash	query		string	false	"Hash of the mint or invoice"
+// @Param			subject	query		string	false	"Subject (mint, invoice, payment)"
+// @Param			address	query		string	false	"Address of the sender"
+// @Param			limit	query		int		false	"Limit"
+// @Param			page	query		int		false	"Page"
+// @Success		200		{object}	GetMempoolTransactionsResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/mempool-transactions [get]
+func (mr *MempoolRoutes) getMempoolTransactions(w http.ResponseWriter, r *http.Request) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	hash := validation.SanitizeQueryParam(r.URL.Query().Get("hash"))
+	if hash != "" {
+		if err := validation.ValidateHash(hash); err != nil {
+			http.Error(w, "Invalid hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	subject := validation.SanitizeQueryParam(r.URL.Query().Get("subject"))
+	if subject != "" && subject != store.MempoolSubject_MINT && subject != store.MempoolSubject_INVOICE && subject != store.MempoolSubject_PAYMENT {
+		http.Error(w, "Invalid subject", http.StatusBadRequest)
+		return
+	}
+
+	address := validation.SanitizeQueryParam(r.URL.Query().Get("address"))
+	if address != "" {
+		if err := validation.ValidateAddress(address); err != nil {
+			http.Error(w, "Invalid address format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	transactions, err := mr.store.GetMempoolTransactions(hash, subject, address, page*limit, limit)
+	if err != nil {
+		log.Println("error getting mempool transactions", err)
+		http.Error(w, "Failed to get mempool transactions", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetMempoolTransactionsResponse{
+		Transactions: transactions,
+		Page:         page,
+		Limit:        limit,
+	})
+}
@@ -16,7 +16,6 @@ import (
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 	"dogecoin.org/fractal-engine/pkg/validation"
-	"github.com/gorilla/mux"
 )
 
 type MintRoutes struct {
@@ -29,6 +28,11 @@ type MintRoutes struct {
 func HandleMintRoutes(store *store.TokenisationStore, gossipClient dogenet.GossipClient, mux *http.ServeMux, cfg *config.Config, dogeClient *doge.RpcClient) {
 	mr := &MintRoutes{store: store, gossipClient: gossipClient, cfg: cfg, dogeClient: dogeClient}
 
+	mux.HandleFunc("/mints/{hash}/allowlist", mr.handleMintAllowlist)
+	mux.HandleFunc("/mints/{hash}/amendments", mr.handleMintAmendments)
+	mux.HandleFunc("/mints/{hash}/asset-managers", mr.handleAssetManagers)
+	mux.HandleFunc("/mints/{hash}/owners", mr.handleMintOwners)
+	mux.HandleFunc("/mints/{hash}/owners/{transfer}/accept", mr.handleAcceptMintOwnershipTransfer)
 	mux.HandleFunc("/mints/{hash}", mr.handleMint)
 	mux.HandleFunc("/mints", mr.handleMints)
 
@@ -54,8 +58,612 @@ func (mr *MintRoutes) handleMint(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (mr *MintRoutes) handleMintAllowlist(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		mr.getMintAllowlist(w, r)
+	case http.MethodPost:
+		mr.postMintAllowlistEntry(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get the allowlist of a mint
+// @Description	Returns the effective buyer allowlist of a mint, including confirmed asset manager updates, and the updates waiting to be anchored
+// @Tags			mints
+// @Produce		json
+// @Param			hash	path		string	true	"Mint hash"
+// @Success		200		{object}	GetMintAllowlistResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/allowlist [get]
+func (mr *MintRoutes) getMintAllowlist(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	allowlist, err := mr.store.GetMintAllowlist(mint)
+	if err != nil {
+		http.Error(w, "Failed to get allowlist", http.StatusInternalServerError)
+		return
+	}
+
+	pending, err := mr.store.GetUnconfirmedMintAllowlistEntries(hash)
+	if err != nil {
+		http.Error(w, "Failed to get pending allowlist entries", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetMintAllowlistResponse{
+		MintHash:         hash,
+		AllowlistEnabled: mint.TypedRequirements().AllowlistEnabled,
+		Allowlist:        allowlist,
+		Pending:          pending,
+	})
+}
+
+// @Summary		Add or remove an allowlist entry
+// @Description	Records an allowlist update signed by one of the mint's asset managers, gossips it to peers and returns the transaction body that anchors it on chain. The update applies to trades after the anchor is confirmed
+// @Tags			mints
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string						true	"Mint hash"
+// @Param			request	body		CreateMintAllowlistEntryRequest	true	"Allowlist entry"
+// @Success		201		{object}	CreateMintAllowlistEntryResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/allowlist [post]
+func (mr *MintRoutes) postMintAllowlistEntry(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request CreateMintAllowlistEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	mint, err = mr.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		http.Error(w, "Failed to get asset managers", http.StatusInternalServerError)
+		return
+	}
+
+	entryHash, err := request.Payload.GenerateHash()
+	if err != nil {
+		http.Error(w, "Failed to generate allowlist entry hash", http.StatusInternalServerError)
+		return
+	}
+
+	entry := store.MintAllowlistEntry{
+		MintAllowlistEntryBody: request.Payload,
+		Hash:                   entryHash,
+		PublicKey:              request.PublicKey,
+		Signature:              request.Signature,
+		CreatedAt:              time.Now(),
+	}
+
+	err = store.ValidateMintAllowlistEntry(mint, entry)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = mr.store.SaveUnconfirmedMintAllowlistEntry(&entry)
+	if err != nil {
+		log.Println("error saving allowlist entry", err)
+		http.Error(w, "Unable to save allowlist entry", http.StatusInternalServerError)
+		return
+	}
+
+	err = mr.gossipClient.GossipMintAllowlistEntry(entry)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	envelope := protocol.NewAllowlistEntryTransactionEnvelope(entryHash, hash, protocol.ACTION_ALLOWLIST_ENTRY)
+
+	respondJSON(w, http.StatusCreated, CreateMintAllowlistEntryResponse{
+		Hash:                   entryHash,
+		EncodedTransactionBody: hex.EncodeToString(envelope.Serialize()),
+	})
+}
+
+func (mr *MintRoutes) handleMintAmendments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		mr.getMintAmendments(w, r)
+	case http.MethodPost:
+		mr.postMintAmendment(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get the amendments of a mint
+// @Description	Returns the confirmed amendments of a mint and the amendments waiting to be anchored on chain
+// @Tags			mints
+// @Produce		json
+// @Param			hash	path		string	true	"Mint hash"
+// @Success		200		{object}	GetMintAmendmentsResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/mints/{hash}/amendments [get]
+func (mr *MintRoutes) getMintAmendments(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	amendments, err := mr.store.GetMintAmendments(hash)
+	if err != nil {
+		http.Error(w, "Failed to get mint amendments", http.StatusInternalServerError)
+		return
+	}
+
+	pending, err := mr.store.GetUnconfirmedMintAmendments(hash)
+	if err != nil {
+		http.Error(w, "Failed to get unconfirmed mint amendments", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetMintAmendmentsResponse{
+		Amendments: amendments,
+		Pending:    pending,
+	})
+}
+
+// @Summary		Amend a mint
+// @Description	Records an amendment of the description, metadata, feed url and contract of sale of a mint signed by its owner.
+// @Description	The amendment is gossiped to peers and takes effect once the returned transaction body is confirmed on chain.
+// @Tags			mints
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string						true	"Mint hash"
+// @Param			request	body		CreateMintAmendmentRequest	true	"Mint amendment"
+// @Success		201		{object}	CreateMintAmendmentResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/amendments [post]
+func (mr *MintRoutes) postMintAmendment(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request CreateMintAmendmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	amendmentHash, err := request.Payload.GenerateHash()
+	if err != nil {
+		http.Error(w, "Failed to generate amendment hash", http.StatusInternalServerError)
+		return
+	}
+
+	amendment := store.MintAmendment{
+		MintAmendmentBody: request.Payload,
+		Hash:              amendmentHash,
+		PublicKey:         request.PublicKey,
+		Signature:         request.Signature,
+		CreatedAt:         time.Now(),
+	}
+
+	err = store.ValidateMintAmendment(mint, amendment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = mr.store.SaveUnconfirmedMintAmendment(&amendment)
+	if err != nil {
+		log.Println("error saving mint amendment", err)
+		http.Error(w, "Unable to save mint amendment", http.StatusInternalServerError)
+		return
+	}
+
+	err = mr.gossipClient.GossipMintAmendment(amendment)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	envelope := protocol.NewMintAmendmentTransactionEnvelope(amendmentHash, hash, protocol.ACTION_MINT_AMENDMENT)
+
+	respondJSON(w, http.StatusCreated, CreateMintAmendmentResponse{
+		Hash:                   amendmentHash,
+		EncodedTransactionBody: hex.EncodeToString(envelope.Serialize()),
+	})
+}
+
+func (mr *MintRoutes) handleAssetManagers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		mr.getAssetManagers(w, r)
+	case http.MethodPost:
+		mr.postAssetManagerRotation(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get the asset managers of a mint
+// @Description	Returns the current asset managers of a mint, the confirmed rotations and the rotations waiting to be anchored on chain
+// @Tags			mints
+// @Produce		json
+// @Param			hash	path		string	true	"Mint hash"
+// @Success		200		{object}	GetAssetManagersResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/asset-managers [get]
+func (mr *MintRoutes) getAssetManagers(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	mint, err = mr.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		http.Error(w, "Failed to get asset managers", http.StatusInternalServerError)
+		return
+	}
+
+	rotations, err := mr.store.GetAssetManagerRotations(hash)
+	if err != nil {
+		http.Error(w, "Failed to get asset manager rotations", http.StatusInternalServerError)
+		return
+	}
+
+	pending, err := mr.store.GetUnconfirmedAssetManagerRotations(hash)
+	if err != nil {
+		http.Error(w, "Failed to get unconfirmed asset manager rotations", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetAssetManagersResponse{
+		MintHash:      hash,
+		AssetManagers: mint.AssetManagers,
+		Rotations:     rotations,
+		Pending:       pending,
+	})
+}
+
+// @Summary		Rotate the asset managers of a mint
+// @Description	Adds, removes or replaces an asset manager. The rotation must be approved by a quorum of the current asset managers
+// @Description	(per the signature requirement of the mint). It is gossiped to peers and takes effect from the block the returned transaction body is confirmed in.
+// @Tags			mints
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string								true	"Mint hash"
+// @Param			request	body		CreateAssetManagerRotationRequest	true	"Asset manager rotation"
+// @Success		201		{object}	CreateAssetManagerRotationResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/asset-managers [post]
+func (mr *MintRoutes) postAssetManagerRotation(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request CreateAssetManagerRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	mint, err = mr.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		http.Error(w, "Failed to get asset managers", http.StatusInternalServerError)
+		return
+	}
+
+	rotationHash, err := request.Payload.GenerateHash()
+	if err != nil {
+		http.Error(w, "Failed to generate rotation hash", http.StatusInternalServerError)
+		return
+	}
+
+	rotation := store.AssetManagerRotation{
+		AssetManagerRotationBody: request.Payload,
+		Hash:                     rotationHash,
+		Approvals:                request.Approvals,
+		CreatedAt:                time.Now(),
+	}
+
+	_, err = store.ValidateAssetManagerRotation(mint, rotation)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = mr.store.SaveUnconfirmedAssetManagerRotation(&rotation)
+	if err != nil {
+		log.Println("error saving asset manager rotation", err)
+		http.Error(w, "Unable to save asset manager rotation", http.StatusInternalServerError)
+		return
+	}
+
+	err = mr.gossipClient.GossipAssetManagerRotation(rotation)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	envelope := protocol.NewAssetManagerRotationTransactionEnvelope(rotationHash, hash, protocol.ACTION_ASSET_MANAGER_ROTATION)
+
+	respondJSON(w, http.StatusCreated, CreateAssetManagerRotationResponse{
+		Hash:                   rotationHash,
+		EncodedTransactionBody: hex.EncodeToString(envelope.Serialize()),
+	})
+}
+
+func (mr *MintRoutes) handleMintOwners(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		mr.getMintOwners(w, r)
+	case http.MethodPost:
+		mr.postMintOwnershipTransfer(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (mr *MintRoutes) handleAcceptMintOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		mr.postAcceptMintOwnershipTransfer(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get the owners of a mint
+// @Description	Returns the ownership history of a mint, oldest first, and the transfers waiting to be accepted or anchored on chain
+// @Tags			mints
+// @Produce		json
+// @Param			hash	path		string	true	"Mint hash"
+// @Success		200		{object}	GetMintOwnersResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/owners [get]
+func (mr *MintRoutes) getMintOwners(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	owners, err := mr.store.GetMintOwners(mint)
+	if err != nil {
+		http.Error(w, "Failed to get mint owners", http.StatusInternalServerError)
+		return
+	}
+
+	pending, err := mr.store.GetUnconfirmedMintOwnershipTransfers(hash)
+	if err != nil {
+		http.Error(w, "Failed to get unconfirmed mint ownership transfers", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetMintOwnersResponse{
+		MintHash: hash,
+		Owners:   owners,
+		Pending:  pending,
+	})
+}
+
+// @Summary		Offer the ownership of a mint
+// @Description	Records an ownership transfer offer signed by the current owner of the mint.
+// @Description	The transfer takes effect once it is accepted by the new owner and anchored on chain by the current owner.
+// @Tags			mints
+// @Accept			json
+// @Produce		json
+// @Param			hash	path		string								true	"Mint hash"
+// @Param			request	body		CreateMintOwnershipTransferRequest	true	"Ownership transfer offer"
+// @Success		201		{object}	CreateMintOwnershipTransferResponse
+// @Failure		400		{object}	string
+// @Failure		404		{object}	string
+// @Router			/mints/{hash}/owners [post]
+func (mr *MintRoutes) postMintOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request CreateMintOwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	transferHash, err := request.Payload.GenerateHash()
+	if err != nil {
+		http.Error(w, "Failed to generate transfer hash", http.StatusInternalServerError)
+		return
+	}
+
+	transfer := store.MintOwnershipTransfer{
+		MintOwnershipTransferBody: request.Payload,
+		Hash:                      transferHash,
+		OwnerPublicKey:            request.PublicKey,
+		OwnerSignature:            request.Signature,
+		CreatedAt:                 time.Now(),
+	}
+
+	err = store.ValidateMintOwnershipTransfer(mint, transfer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = mr.store.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	if err != nil {
+		log.Println("error saving mint ownership transfer", err)
+		http.Error(w, "Unable to save mint ownership transfer", http.StatusInternalServerError)
+		return
+	}
+
+	err = mr.gossipClient.GossipMintOwnershipTransfer(transfer)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, CreateMintOwnershipTransferResponse{Hash: transferHash})
+}
+
+// @Summary		Accept the ownership of a mint
+// @Description	Records the acceptance of an ownership transfer, signed by the new owner over the transfer payload.
+// @Description	Returns the transaction body the current owner writes on chain to complete the transfer.
+// @Tags			mints
+// @Accept			json
+// @Produce		json
+// @Param			hash		path		string								true	"Mint hash"
+// @Param			transfer	path		string								true	"Transfer hash"
+// @Param			request		body		AcceptMintOwnershipTransferRequest	true	"Acceptance"
+// @Success		201			{object}	AcceptMintOwnershipTransferResponse
+// @Failure		400			{object}	string
+// @Failure		404			{object}	string
+// @Router			/mints/{hash}/owners/{transfer}/accept [post]
+func (mr *MintRoutes) postAcceptMintOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
+	if err := validation.ValidateHash(hash); err != nil {
+		http.Error(w, "Invalid hash format", http.StatusBadRequest)
+		return
+	}
+
+	transferHash := validation.SanitizeQueryParam(r.PathValue("transfer"))
+	if err := validation.ValidateHash(transferHash); err != nil {
+		http.Error(w, "Invalid transfer hash format", http.StatusBadRequest)
+		return
+	}
+
+	var request AcceptMintOwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	transfer, err := mr.store.GetUnconfirmedMintOwnershipTransfer(transferHash)
+	if err != nil || transfer.MintHash != hash {
+		http.Error(w, "Ownership transfer not found", http.StatusNotFound)
+		return
+	}
+
+	if request.PublicKey != transfer.NewOwnerPublicKey {
+		http.Error(w, "public key does not match the new owner", http.StatusBadRequest)
+		return
+	}
+
+	mint, err := mr.store.GetMintByHash(hash)
+	if err != nil || mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusNotFound)
+		return
+	}
+
+	transfer.NewOwnerSignature = request.Signature
+
+	err = store.ValidateMintOwnershipTransfer(mint, transfer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = mr.store.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	if err != nil {
+		log.Println("error saving mint ownership transfer acceptance", err)
+		http.Error(w, "Unable to save mint ownership transfer", http.StatusInternalServerError)
+		return
+	}
+
+	err = mr.gossipClient.GossipMintOwnershipTransfer(transfer)
+	if err != nil {
+		http.Error(w, "Unable to gossip", http.StatusInternalServerError)
+		return
+	}
+
+	envelope := protocol.NewMintOwnershipTransferTransactionEnvelope(transferHash, hash, protocol.ACTION_MINT_OWNERSHIP_TRANSFER)
+
+	respondJSON(w, http.StatusCreated, AcceptMintOwnershipTransferResponse{
+		Hash:                   transferHash,
+		EncodedTransactionBody: hex.EncodeToString(envelope.Serialize()),
+	})
+}
+
 func (mr *MintRoutes) getMint(w http.ResponseWriter, r *http.Request) {
-	hash := validation.SanitizeQueryParam(mux.Vars(r)["hash"])
+	hash := validation.SanitizeQueryParam(r.PathValue("hash"))
 
 	// Validate hash format
 	if err := validation.ValidateHash(hash); err != nil {
@@ -64,13 +672,37 @@ func (mr *MintRoutes) getMint(w http.ResponseWriter, r *http.Request) {
 	}
 
 	mint, err := mr.store.GetMintByHash(hash)
-	if err != nil {
+	if err != nil || mint.Id == "" {
 		http.Error(w, "Mint not found", http.StatusNotFound)
 		return
 	}
 
+	burnedSupply, err := mr.store.GetBurnedSupply(hash)
+	if err != nil {
+		http.Error(w, "Failed to get burned supply", http.StatusInternalServerError)
+		return
+	}
+
+	revisions, err := mr.store.GetMintRevisions(mint)
+	if err != nil {
+		http.Error(w, "Failed to get mint revisions", http.StatusInternalServerError)
+		return
+	}
+
+	current := revisions[len(revisions)-1]
+
+	mint, err = mr.store.WithCurrentAssetManagers(mint)
+	if err != nil {
+		http.Error(w, "Failed to get asset managers", http.StatusInternalServerError)
+		return
+	}
+
 	response := GetMintResponse{
-		Mint: mint,
+		Mint:              current.Apply(mint),
+		CirculatingSupply: mint.FractionCount - burnedSupply,
+		BurnedSupply:      burnedSupply,
+		Revision:          current.Revision,
+		Revisions:         revisions[:len(revisions)-1],
 	}
 
 	respondJSON(w, http.StatusOK, response)
@@ -218,6 +850,8 @@ func (mr *MintRoutes) postMint(w http.ResponseWriter, r *http.Request) {
 		ContractOfSale:           request.Payload.ContractOfSale,
 		AssetManagers:            request.Payload.AssetManagers,
 		MinSignatures:            request.Payload.MinSignatures,
+		RedeemScript:             request.RedeemScript,
+		MultisigSignatures:       store.MultisigSignatures(request.Signatures),
 	}
 
 	newMintWithoutId.Hash, err = newMintWithoutId.GenerateHash()
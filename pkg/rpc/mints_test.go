@@ -1,11 +1,16 @@
 package rpc_test
 
 import (
+	"encoding/hex"
 	"testing"
 
+	"dogecoin.org/fractal-engine/internal/test/support"
 	"dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
 	"gotest.tools/assert"
 )
 
@@ -74,3 +79,296 @@ func TestMints(t *testing.T) {
 	assert.DeepEqual(t, dogenetClient.mints[0].Metadata, mintRequest.Payload.Metadata)
 	assert.Equal(t, dogenetClient.mints[0].FeedURL, mintRequest.Payload.FeedURL)
 }
+
+func TestGetMintSupply(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleMintRoutes(tokenisationStore, dogenetClient, mux, &config.Config{}, doge.NewRpcClient(&config.Config{}))
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 100}, address)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(address, mintHash, 100)
+	assert.NilError(t, err)
+
+	err = tokenisationStore.ProcessBurn(store.OnChainTransaction{Id: "burnTx", TxHash: "burnTx", Height: 2, BlockHash: "blockHash2", Address: address}, mintHash, 15, "")
+	assert.NilError(t, err)
+
+	response, err := feClient.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, response.Mint.Hash, mintHash)
+	assert.Equal(t, response.BurnedSupply, 15)
+	assert.Equal(t, response.CirculatingSupply, 85)
+
+	_, err = feClient.GetMintByHash(support.GenerateRandomHash())
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestMintAllowlist(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleMintRoutes(tokenisationStore, dogenetClient, mux, &config.Config{}, doge.NewRpcClient(&config.Config{}))
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	ownerAddress := support.GenerateDogecoinAddress(true)
+	buyerAddress := support.GenerateDogecoinAddress(true)
+
+	_, err = tokenisationStore.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "mint1",
+		FractionCount: 100,
+		Requirements: store.StringInterfaceMap{
+			"allowlist_enabled": true,
+			"allowlist":         []interface{}{ownerAddress},
+		},
+		SignatureRequirementType: store.SignatureRequirementType_ONE_SIGNATURE,
+		AssetManagers:            []store.AssetManager{{Name: "Manager", PublicKey: pubHex}},
+	}, ownerAddress)
+	assert.NilError(t, err)
+
+	allowlist, err := feClient.GetMintAllowlist(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, allowlist.AllowlistEnabled, true)
+	assert.DeepEqual(t, allowlist.Allowlist, []string{ownerAddress})
+
+	payload := store.MintAllowlistEntryBody{MintHash: mintHash, Entry: buyerAddress, Allowed: true, Timestamp: 1}
+	signature, err := doge.SignPayload(payload, privHex, pubHex)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateMintAllowlistEntry(mintHash, &rpc.CreateMintAllowlistEntryRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: pubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, response.EncodedTransactionBody != "")
+	assert.Equal(t, len(dogenetClient.allowlistEntries), 1)
+
+	// The entry applies once anchored on chain
+	allowlist, err = feClient.GetMintAllowlist(mintHash)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, allowlist.Allowlist, []string{ownerAddress})
+	assert.Equal(t, len(allowlist.Pending), 1)
+	assert.Equal(t, allowlist.Pending[0].Hash, response.Hash)
+	assert.Equal(t, allowlist.Pending[0].Entry, buyerAddress)
+
+	// Only asset managers can maintain the allowlist
+	otherPrivHex, otherPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	payload.Timestamp = 2
+	payload.Allowed = false
+	signature, err = doge.SignPayload(payload, otherPrivHex, otherPubHex)
+	assert.NilError(t, err)
+
+	_, err = feClient.CreateMintAllowlistEntry(mintHash, &rpc.CreateMintAllowlistEntryRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: otherPubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.ErrorContains(t, err, "asset managers")
+}
+
+func TestCreateMintRequestValidatesRequirements(t *testing.T) {
+	request := rpc.PrepareMintRequest{
+		Payload: rpc.CreateMintRequestPayload{
+			Title:         "Test Mint",
+			Description:   "Test Description",
+			FractionCount: 100,
+			Requirements:  store.StringInterfaceMap{"max_holder_share_bps": float64(20000)},
+		},
+	}
+
+	err := request.Validate()
+	assert.ErrorContains(t, err, "invalid requirements")
+
+	request.Payload.Requirements = store.StringInterfaceMap{"burn_requires_signatures": true}
+	err = request.Validate()
+	assert.ErrorContains(t, err, "signature_requirement_type")
+
+	request.Payload.Requirements = store.StringInterfaceMap{"max_holders": float64(50), "max_holder_share_bps": float64(1000)}
+	err = request.Validate()
+	assert.NilError(t, err)
+}
+
+func TestMintAmendments(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleMintRoutes(tokenisationStore, dogenetClient, mux, &config.Config{}, doge.NewRpcClient(&config.Config{}))
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenisationStore.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "mint1",
+		Description:   "original",
+		FractionCount: 100,
+		PublicKey:     pubHex,
+	}, support.GenerateDogecoinAddress(true))
+	assert.NilError(t, err)
+
+	payload := store.MintAmendmentBody{MintHash: mintHash, Description: "amended", Timestamp: 1}
+	signature, err := doge.SignPayload(payload, privHex, pubHex)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateMintAmendment(mintHash, &rpc.CreateMintAmendmentRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: pubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, response.EncodedTransactionBody != "")
+	assert.Equal(t, len(dogenetClient.mintAmendments), 1)
+
+	amendments, err := feClient.GetMintAmendments(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(amendments.Pending), 1)
+	assert.Equal(t, len(amendments.Amendments), 0)
+
+	// Confirm the amendment on chain
+	amendmentHashBytes, _ := hex.DecodeString(response.Hash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	amendmentMsg := &protocol.OnChainMintAmendmentMessage{AmendmentHash: amendmentHashBytes, MintHash: mintHashBytes}
+	encoded, err := proto.Marshal(amendmentMsg)
+	assert.NilError(t, err)
+	id, err := tokenisationStore.SaveOnChainTransaction(support.GenerateRandomHash(), 5, "blockHash", 0, protocol.ACTION_MINT_AMENDMENT, protocol.DEFAULT_VERSION, encoded, "owner", store.KoinuValues{})
+	assert.NilError(t, err)
+	err = tokenisationStore.MatchUnconfirmedMintAmendment(store.OnChainTransaction{Id: id, TxHash: "tx", Height: 5, ActionType: protocol.ACTION_MINT_AMENDMENT, ActionData: encoded}, amendmentMsg)
+	assert.NilError(t, err)
+
+	mint, err := feClient.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.Mint.Description, "amended")
+	assert.Equal(t, mint.Mint.Hash, mintHash)
+	assert.Equal(t, mint.Revision, 1)
+	assert.Equal(t, len(mint.Revisions), 1)
+	assert.Equal(t, mint.Revisions[0].Description, "original")
+
+	// Only the owner can amend the mint
+	otherPrivHex, otherPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	payload.Timestamp = 2
+	signature, err = doge.SignPayload(payload, otherPrivHex, otherPubHex)
+	assert.NilError(t, err)
+
+	_, err = feClient.CreateMintAmendment(mintHash, &rpc.CreateMintAmendmentRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: otherPubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.ErrorContains(t, err, "mint owner")
+}
+
+func TestAssetManagerRotation(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleMintRoutes(tokenisationStore, dogenetClient, mux, &config.Config{}, doge.NewRpcClient(&config.Config{}))
+
+	firstPrivHex, firstPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	secondPrivHex, secondPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	_, newPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenisationStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "mint1",
+		FractionCount:            100,
+		SignatureRequirementType: store.SignatureRequirementType_MIN_SIGNATURES,
+		MinSignatures:            2,
+		AssetManagers:            store.AssetManagers{{Name: "first", PublicKey: firstPubHex}, {Name: "second", PublicKey: secondPubHex}},
+	}, support.GenerateDogecoinAddress(true))
+	assert.NilError(t, err)
+
+	payload := store.AssetManagerRotationBody{
+		MintHash:  mintHash,
+		Action:    store.AssetManagerRotation_ADD,
+		Manager:   &store.AssetManager{Name: "third", PublicKey: newPubHex},
+		Timestamp: 1,
+	}
+	firstSignature, err := doge.SignPayload(payload, firstPrivHex, firstPubHex)
+	assert.NilError(t, err)
+
+	// One approval is not a quorum for a mint requiring two signatures
+	_, err = feClient.CreateAssetManagerRotation(mintHash, &rpc.CreateAssetManagerRotationRequest{
+		Payload:   payload,
+		Approvals: []store.AssetManagerApproval{{PublicKey: firstPubHex, Signature: firstSignature}},
+	})
+	assert.ErrorContains(t, err, "quorum")
+
+	secondSignature, err := doge.SignPayload(payload, secondPrivHex, secondPubHex)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateAssetManagerRotation(mintHash, &rpc.CreateAssetManagerRotationRequest{
+		Payload: payload,
+		Approvals: []store.AssetManagerApproval{
+			{PublicKey: firstPubHex, Signature: firstSignature},
+			{PublicKey: secondPubHex, Signature: secondSignature},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, response.EncodedTransactionBody != "")
+	assert.Equal(t, len(dogenetClient.rotations), 1)
+
+	managers, err := feClient.GetAssetManagers(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(managers.AssetManagers), 2)
+	assert.Equal(t, len(managers.Pending), 1)
+	assert.Equal(t, managers.Pending[0].Hash, response.Hash)
+	assert.Equal(t, len(managers.Rotations), 0)
+}
+
+func TestMintOwnershipTransfer(t *testing.T) {
+	tokenisationStore, dogenetClient, mux, feClient := SetupRpcTest(t)
+
+	rpc.HandleMintRoutes(tokenisationStore, dogenetClient, mux, &config.Config{}, doge.NewRpcClient(&config.Config{}))
+
+	ownerPrivHex, ownerPubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	newPrivHex, newPubHex, newAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 100, PublicKey: ownerPubHex}, ownerAddress)
+	assert.NilError(t, err)
+
+	payload := store.MintOwnershipTransferBody{MintHash: mintHash, NewOwnerAddress: newAddress, NewOwnerPublicKey: newPubHex, Timestamp: 1}
+
+	// Only the current owner can offer the mint
+	signature, err := doge.SignPayload(payload, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+	_, err = feClient.CreateMintOwnershipTransfer(mintHash, &rpc.CreateMintOwnershipTransferRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: newPubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.ErrorContains(t, err, "mint owner")
+
+	signature, err = doge.SignPayload(payload, ownerPrivHex, ownerPubHex)
+	assert.NilError(t, err)
+	offer, err := feClient.CreateMintOwnershipTransfer(mintHash, &rpc.CreateMintOwnershipTransferRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: ownerPubHex, Signature: signature},
+		Payload:       payload,
+	})
+	assert.NilError(t, err)
+
+	acceptSignature, err := doge.SignPayload(payload, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+	accepted, err := feClient.AcceptMintOwnershipTransfer(mintHash, offer.Hash, &rpc.AcceptMintOwnershipTransferRequest{
+		SignedRequest: rpc.SignedRequest{PublicKey: newPubHex, Signature: acceptSignature},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, accepted.Hash, offer.Hash)
+	assert.Assert(t, accepted.EncodedTransactionBody != "")
+	assert.Equal(t, len(dogenetClient.ownershipTransfers), 2)
+
+	owners, err := feClient.GetMintOwners(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(owners.Owners), 1)
+	assert.Equal(t, owners.Owners[0].OwnerAddress, ownerAddress)
+	assert.Equal(t, len(owners.Pending), 1)
+	assert.Assert(t, owners.Pending[0].Accepted())
+}
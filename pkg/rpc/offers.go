@@ -222,7 +222,7 @@ func (or *OfferRoutes) postSellOffer(w http.ResponseWriter, r *http.Request) {
 		OffererAddress: request.Payload.OffererAddress,
 		MintHash:       request.Payload.MintHash,
 		Quantity:       request.Payload.Quantity,
-		Price:          request.Payload.Price,
+		PriceKoinu:     request.Payload.PriceKoinu,
 		CreatedAt:      time.Now(),
 		PublicKey:      request.PublicKey,
 		Signature:      request.Signature,
@@ -356,7 +356,7 @@ func (or *OfferRoutes) postBuyOffer(w http.ResponseWriter, r *http.Request) {
 		MintHash:       request.Payload.MintHash,
 		SellerAddress:  request.Payload.SellerAddress,
 		Quantity:       request.Payload.Quantity,
-		Price:          request.Payload.Price,
+		PriceKoinu:     request.Payload.PriceKoinu,
 		CreatedAt:      time.Now(),
 		PublicKey:      request.PublicKey,
 	}
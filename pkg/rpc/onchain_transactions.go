@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+type OnChainTransactionRoutes struct {
+	store *store.TokenisationStore
+}
+
+func HandleOnChainTransactionRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
+	oc := &OnChainTransactionRoutes{store: store}
+
+	mux.HandleFunc("/onchain-transactions", oc.handleOnChainTransactions)
+}
+
+func (oc *OnChainTransactionRoutes) handleOnChainTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		oc.getOnChainTransactions(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get on chain transactions
+// @Description	Returns what became of on chain transactions, most recent first: applied, rejected with a reason code, awaiting data (with the confirmations seen and required for their action) or expired
+// @Tags			onchain-transactions
+// @Produce		json
+// @Param			status	query		string	false	"Status (applied, rejected, awaiting_data, expired, ignored)"
+// @Param			tx_hash	query		string	false	"Transaction hash"
+// @Param			limit	query		int		false	"Limit"
+// @Param			page	query		int		false	"Page"
+// @Success		200		{object}	GetOnChainTransactionsResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/onchain-transactions [get]
+func (oc *OnChainTransactionRoutes) getOnChainTransactions(w http.ResponseWriter, r *http.Request) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	status := validation.SanitizeQueryParam(r.URL.Query().Get("status"))
+	if status != "" && !store.IsOnChainOutcome(status) {
+		http.Error(w, "Invalid status", http.StatusBadRequest)
+		return
+	}
+
+	txHash := validation.SanitizeQueryParam(r.URL.Query().Get("tx_hash"))
+
+	transactions, err := oc.store.GetOnChainTransactionOutcomes(status, txHash, page*limit, limit)
+	if err != nil {
+		log.Println("error getting onchain transactions", err)
+		http.Error(w, "Failed to get onchain transactions", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetOnChainTransactionsResponse{
+		Transactions: transactions,
+		Page:         page,
+		Limit:        limit,
+	})
+}
@@ -0,0 +1,50 @@
+package rpc_test
+
+import (
+	"testing"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestGetOnChainTransactionsByStatus(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+	rpc.HandleOnChainTransactionRoutes(tokenisationStore, mux)
+
+	_, err := tokenisationStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, []byte("mint"), "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+	_, err = tokenisationStore.SaveOnChainTransaction("txTransfer", 2, "blockHash", 1, protocol.ACTION_TRANSFER, protocol.DEFAULT_VERSION, []byte("transfer"), "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenisationStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	err = tokenisationStore.SaveAwaitingOnChainTransaction(txs[0], store.OnChainAwaiting_DATA, "mint not gossiped")
+	assert.NilError(t, err)
+	err = tokenisationStore.RejectOnChainTransaction(txs[1], store.OnChainRejection_INSUFFICIENT_BALANCE, "not enough tokens")
+	assert.NilError(t, err)
+
+	response, err := feClient.GetOnChainTransactions("", "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Transactions), 2)
+	assert.Equal(t, response.Transactions[0].TxHash, "txTransfer")
+
+	response, err = feClient.GetOnChainTransactions(store.OnChainOutcome_AWAITING_DATA, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Transactions), 1)
+	assert.Equal(t, response.Transactions[0].TxHash, "txMint")
+	assert.Equal(t, response.Transactions[0].Action, "mint")
+	assert.Equal(t, response.Transactions[0].Reason, "mint not gossiped")
+
+	response, err = feClient.GetOnChainTransactions("", "txTransfer", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Transactions), 1)
+	assert.Equal(t, response.Transactions[0].Status, store.OnChainOutcome_REJECTED)
+	assert.Equal(t, response.Transactions[0].ReasonCode, store.OnChainRejection_INSUFFICIENT_BALANCE)
+	assert.DeepEqual(t, response.Transactions[0].ActionData, []byte("transfer"))
+
+	_, err = feClient.GetOnChainTransactions("unknown", "", 0, 10)
+	assert.ErrorContains(t, err, "400")
+}
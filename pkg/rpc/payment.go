@@ -3,12 +3,15 @@ package rpc
 import (
 	"encoding/hex"
 	"encoding/json"
+	"log"
 	"net/http"
+	"strconv"
 
 	"dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/dogenet"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
 )
 
 type PaymentRoutes struct {
@@ -21,6 +24,8 @@ func HandlePaymentRoutes(store *store.TokenisationStore, gossipClient dogenet.Go
 	ir := &PaymentRoutes{store: store, gossipClient: gossipClient, cfg: cfg}
 
 	mux.HandleFunc("/payments/new", ir.handleNewPayment)
+	mux.HandleFunc("/payments/batch/new", ir.handleNewBatchPayment)
+	mux.HandleFunc("/payments/batch/outcomes", ir.handleBatchPaymentOutcomes)
 }
 
 func (ir *PaymentRoutes) handleNewPayment(w http.ResponseWriter, r *http.Request) {
@@ -55,3 +60,111 @@ func (ir *PaymentRoutes) postNewPayment(w http.ResponseWriter, r *http.Request)
 		"encoded_transaction_body": hex.EncodeToString(encodedTransactionBody),
 	})
 }
+
+func (ir *PaymentRoutes) handleNewBatchPayment(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		ir.postNewBatchPayment(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Prepares an encoded transaction body for a batch payment
+// @Description	Generates an encoded transaction body for paying several invoices in one transaction, with one output per payment address
+// @Tags			payments
+// @Accept			json
+// @Produce		json
+// @Param			request	body		CreateNewBatchPaymentRequest	true	"Batch payment request"
+// @Success		201		{object}	map[string]string
+// @Failure		400		{object}	string
+// @Router			/payments/batch/new [post]
+func (ir *PaymentRoutes) postNewBatchPayment(w http.ResponseWriter, r *http.Request) {
+	var request CreateNewBatchPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	envelope := protocol.NewBatchPaymentTransactionEnvelope(request.InvoiceHashes, protocol.ACTION_BATCH_PAYMENT)
+	encodedTransactionBody := envelope.Serialize()
+
+	respondJSON(w, http.StatusCreated, map[string]string{
+		"encoded_transaction_body": hex.EncodeToString(encodedTransactionBody),
+	})
+}
+
+func (ir *PaymentRoutes) handleBatchPaymentOutcomes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ir.getBatchPaymentOutcomes(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Get batch payment outcomes
+// @Description	Returns which invoices of batch payments were settled, partially paid or rejected, and why
+// @Tags			payments
+// @Produce		json
+// @Param			tx_hash			query		string	false	"Transaction hash"
+// @Param			invoice_hash	query		string	false	"Invoice hash"
+// @Param			limit			query		int		false	"Limit"
+// @Param			page			query		int		false	"Page"
+// @Success		200				{object}	GetBatchPaymentOutcomesResponse
+// @Failure		400				{object}	string
+// @Failure		500				{object}	string
+// @Router			/payments/batch/outcomes [get]
+func (ir *PaymentRoutes) getBatchPaymentOutcomes(w http.ResponseWriter, r *http.Request) {
+	limitStr := validation.SanitizeQueryParam(r.URL.Query().Get("limit"))
+	limit := 100
+
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= limit {
+			limit = l
+		}
+	}
+
+	pageStr := validation.SanitizeQueryParam(r.URL.Query().Get("page"))
+	page := 0
+
+	if pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 && p <= 1000 {
+			page = p
+		}
+	}
+
+	txHash := validation.SanitizeQueryParam(r.URL.Query().Get("tx_hash"))
+	if txHash != "" {
+		if err := validation.ValidateHash(txHash); err != nil {
+			http.Error(w, "Invalid tx_hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	invoiceHash := validation.SanitizeQueryParam(r.URL.Query().Get("invoice_hash"))
+	if invoiceHash != "" {
+		if err := validation.ValidateHash(invoiceHash); err != nil {
+			http.Error(w, "Invalid invoice_hash format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	outcomes, err := ir.store.GetBatchPaymentOutcomes(txHash, invoiceHash, page*limit, limit)
+	if err != nil {
+		log.Println("error getting batch payment outcomes", err)
+		http.Error(w, "Failed to get batch payment outcomes", http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, GetBatchPaymentOutcomesResponse{
+		Outcomes: outcomes,
+		Page:     page,
+		Limit:    limit,
+	})
+}
@@ -36,6 +36,17 @@ func NewRpcServer(cfg *config.Config, store *store.TokenisationStore, gossipClie
 		handler = withSecureAPI(cfg.RpcApiKey, handler)
 	}
 
+	// The admin routes are served behind their own key, which replaces the RPC API key for them
+	if cfg.AdminApiKey != "" {
+		adminMux := http.NewServeMux()
+		HandleAdminRoutes(store, adminMux)
+
+		root := http.NewServeMux()
+		root.Handle("/admin/", withSecureAPI(cfg.AdminApiKey, adminMux))
+		root.Handle("/", handler)
+		handler = root
+	}
+
 	limiter := rate.NewLimiter(rate.Limit(cfg.RateLimitPerSecond), cfg.RateLimitPerSecond*3)
 	handler = rateLimitMiddleware(limiter, handler)
 
@@ -47,6 +58,11 @@ func NewRpcServer(cfg *config.Config, store *store.TokenisationStore, gossipClie
 	HandleTokenRoutes(store, mux)
 	HandleDogeRoutes(store, dogeClient, mux)
 	HandlePaymentRoutes(store, gossipClient, mux, cfg)
+	HandleTransferRoutes(store, mux)
+	HandleBurnRoutes(store, gossipClient, mux)
+	HandleDistributionRoutes(store, mux)
+	HandleOnChainTransactionRoutes(store, mux)
+	HandleMempoolRoutes(store, mux)
 
 	server := &http.Server{
 		Addr:    cfg.RpcServerHost + ":" + cfg.RpcServerPort,
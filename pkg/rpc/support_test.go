@@ -15,11 +15,15 @@ import (
 
 type FakeGossipClient struct {
 	dogenet.GossipClient
-	buyOffers         []store.BuyOffer
-	sellOffers        []store.SellOffer
-	mints             []store.Mint
-	invoices          []store.UnconfirmedInvoice
-	invoiceSignatures []store.InvoiceSignature
+	buyOffers          []store.BuyOffer
+	sellOffers         []store.SellOffer
+	mints              []store.Mint
+	invoices           []store.UnconfirmedInvoice
+	invoiceSignatures  []store.InvoiceSignature
+	allowlistEntries   []store.MintAllowlistEntry
+	mintAmendments     []store.MintAmendment
+	rotations          []store.AssetManagerRotation
+	ownershipTransfers []store.MintOwnershipTransfer
 }
 
 func (g *FakeGossipClient) GossipBuyOffer(offer store.BuyOffer) error {
@@ -71,16 +75,40 @@ func (g *FakeGossipClient) GossipInvoiceSignature(invoiceSignature store.Invoice
 	return nil
 }
 
+func (g *FakeGossipClient) GossipMintAllowlistEntry(entry store.MintAllowlistEntry) error {
+	g.allowlistEntries = append(g.allowlistEntries, entry)
+	return nil
+}
+
+func (g *FakeGossipClient) GossipMintAmendment(amendment store.MintAmendment) error {
+	g.mintAmendments = append(g.mintAmendments, amendment)
+	return nil
+}
+
+func (g *FakeGossipClient) GossipAssetManagerRotation(rotation store.AssetManagerRotation) error {
+	g.rotations = append(g.rotations, rotation)
+	return nil
+}
+
+func (g *FakeGossipClient) GossipMintOwnershipTransfer(transfer store.MintOwnershipTransfer) error {
+	g.ownershipTransfers = append(g.ownershipTransfers, transfer)
+	return nil
+}
+
 func SetupRpcTest(t *testing.T) (*store.TokenisationStore, *FakeGossipClient, *http.ServeMux, *client.TokenisationClient) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
 
 	dogenetClient := &FakeGossipClient{
-		buyOffers:         []store.BuyOffer{},
-		sellOffers:        []store.SellOffer{},
-		mints:             []store.Mint{},
-		invoices:          []store.UnconfirmedInvoice{},
-		invoiceSignatures: []store.InvoiceSignature{},
+		buyOffers:          []store.BuyOffer{},
+		sellOffers:         []store.SellOffer{},
+		mints:              []store.Mint{},
+		invoices:           []store.UnconfirmedInvoice{},
+		invoiceSignatures:  []store.InvoiceSignature{},
+		allowlistEntries:   []store.MintAllowlistEntry{},
+		mintAmendments:     []store.MintAmendment{},
+		rotations:          []store.AssetManagerRotation{},
+		ownershipTransfers: []store.MintOwnershipTransfer{},
 	}
 
 	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixTestnet)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"dogecoin.org/fractal-engine/pkg/store"
 	"dogecoin.org/fractal-engine/pkg/validation"
@@ -18,6 +19,16 @@ func HandleTokenRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
 
 	mux.HandleFunc("/token-balances/", tr.handleTokenBalances)
 	mux.HandleFunc("/pending-token-balances/", tr.handlePendingTokenBalances)
+	mux.HandleFunc("/locked-token-balances/", tr.handleLockedTokenBalances)
+}
+
+func (tr *TokenRoutes) handleLockedTokenBalances(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		tr.getLockedTokenBalances(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
 }
 
 func (tr *TokenRoutes) handlePendingTokenBalances(w http.ResponseWriter, r *http.Request) {
@@ -150,3 +161,52 @@ func (tr *TokenRoutes) getPendingTokenBalances(w http.ResponseWriter, r *http.Re
 
 	json.NewEncoder(w).Encode(tokenBalances)
 }
+
+// @Summary		Get locked token balances
+// @Description	Returns the locked and transferable token balances for an address at the current block height, optionally filtered by mint hash
+// @Tags			Token Balances
+// @Accept			json
+// @Produce		json
+// @Param			address		path		string	true	"Address to get locked token balances for"
+// @Param			mint_hash	query		string	false	"Filter by mint hash"
+// @Success		200			{object}	GetLockedTokenBalancesResponse
+// @Failure		400			{object}	string
+// @Failure		500			{object}	string
+// @Router			/locked-token-balances/{address} [get]
+func (tr *TokenRoutes) getLockedTokenBalances(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Path[len("/locked-token-balances/"):]
+	if address == "" {
+		http.Error(w, "Address is required", http.StatusBadRequest)
+		return
+	}
+
+	blockHeight, _, _, err := tr.store.GetChainPosition()
+	if err != nil {
+		http.Error(w, "Failed to get chain position", http.StatusInternalServerError)
+		return
+	}
+
+	blockTime := time.Now().Unix()
+	mintHash := validation.SanitizeQueryParam(r.URL.Query().Get("mint_hash"))
+
+	var balances []store.LockedTokenBalance
+	if mintHash != "" {
+		balance, err := tr.store.GetLockedTokenBalance(address, mintHash, blockHeight, blockTime, nil)
+		if err != nil {
+			http.Error(w, "Failed to get tokens", http.StatusInternalServerError)
+			return
+		}
+		balances = []store.LockedTokenBalance{balance}
+	} else {
+		balances, err = tr.store.GetLockedTokenBalances(address, blockHeight, blockTime)
+		if err != nil {
+			http.Error(w, "Failed to get tokens", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, GetLockedTokenBalancesResponse{
+		BlockHeight: blockHeight,
+		Balances:    balances,
+	})
+}
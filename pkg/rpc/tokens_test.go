@@ -63,3 +63,58 @@ func TestGetTokenBalanceWithMintDetails(t *testing.T) {
 	assert.Equal(t, tokens[0].Mint.FractionCount, 10)
 
 }
+
+func TestGetLockedTokenBalances(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+	rpc.HandleTokenRoutes(tokenisationStore, mux)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{
+		Hash:          "mint1",
+		Title:         "mint1",
+		FractionCount: 10,
+		LockupOptions: store.StringInterfaceMap{"unlock_block_height": float64(100)},
+	}, "address1")
+	assert.NilError(t, err)
+
+	err = tokenisationStore.UpsertTokenBalance("address1", "mint1", 10)
+	assert.NilError(t, err)
+
+	err = tokenisationStore.UpsertChainPosition(99, "blockHash99", false)
+	assert.NilError(t, err)
+
+	response, err := feClient.GetLockedTokenBalances("address1", "")
+	assert.NilError(t, err)
+	assert.Equal(t, response.BlockHeight, int64(99))
+	assert.Equal(t, len(response.Balances), 1)
+	assert.Equal(t, response.Balances[0].Locked, 10)
+	assert.Equal(t, response.Balances[0].Transferable, 0)
+
+	err = tokenisationStore.UpsertChainPosition(100, "blockHash100", false)
+	assert.NilError(t, err)
+
+	response, err = feClient.GetLockedTokenBalances("address1", "mint1")
+	assert.NilError(t, err)
+	assert.Equal(t, len(response.Balances), 1)
+	assert.Equal(t, response.Balances[0].Locked, 0)
+	assert.Equal(t, response.Balances[0].Transferable, 10)
+}
+
+func TestCreateMintRequestValidatesLockupOptions(t *testing.T) {
+	request := rpc.PrepareMintRequest{
+		Payload: rpc.CreateMintRequestPayload{
+			Title:         "Test Mint",
+			Description:   "Test Description",
+			FractionCount: 100,
+			LockupOptions: store.StringInterfaceMap{
+				"vesting": map[string]interface{}{"cliff_blocks": float64(20), "duration_blocks": float64(10)},
+			},
+		},
+	}
+
+	err := request.Validate()
+	assert.ErrorContains(t, err, "invalid lockup_options")
+
+	request.Payload.LockupOptions = store.StringInterfaceMap{"unlock_block_height": float64(10)}
+	err = request.Validate()
+	assert.NilError(t, err)
+}
@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type TransferRoutes struct {
+	store *store.TokenisationStore
+}
+
+func HandleTransferRoutes(store *store.TokenisationStore, mux *http.ServeMux) {
+	tr := &TransferRoutes{store: store}
+
+	mux.HandleFunc("/transfers", tr.handleTransfers)
+}
+
+func (tr *TransferRoutes) handleTransfers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		tr.postTransfer(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// @Summary		Prepares an encoded transaction body for a token transfer
+// @Description	Generates an encoded transaction body that transfers tokens from the address that signs the transaction
+// @Tags			transfers
+// @Accept			json
+// @Produce		json
+// @Param			request	body		CreateTransferRequest	true	"Transfer request"
+// @Success		201		{object}	CreateTransferResponse
+// @Failure		400		{object}	string
+// @Failure		500		{object}	string
+// @Router			/transfers [post]
+func (tr *TransferRoutes) postTransfer(w http.ResponseWriter, r *http.Request) {
+	var request CreateTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	err := request.Validate()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mint, err := tr.store.GetMintByHash(request.Payload.MintHash)
+	if err != nil {
+		http.Error(w, "Failed to get mint", http.StatusInternalServerError)
+		return
+	}
+
+	if mint.Id == "" {
+		http.Error(w, "Mint not found", http.StatusBadRequest)
+		return
+	}
+
+	blockHeight, _, _, err := tr.store.GetChainPosition()
+	if err != nil {
+		http.Error(w, "Failed to get chain position", http.StatusInternalServerError)
+		return
+	}
+
+	available, err := tr.store.GetTransferableTokenBalance(request.Payload.FromAddress, request.Payload.MintHash, blockHeight, time.Now().Unix(), nil)
+	if err != nil {
+		http.Error(w, "Failed to get token balance", http.StatusInternalServerError)
+		return
+	}
+
+	if available < request.Payload.Quantity {
+		http.Error(w, store.ErrInsufficientTokenBalance.Error(), http.StatusBadRequest)
+		return
+	}
+
+	envelope := protocol.NewTransferTransactionEnvelope(request.Payload.MintHash, request.Payload.ToAddress, int32(request.Payload.Quantity), protocol.ACTION_TRANSFER)
+	encodedTransactionBody := envelope.Serialize()
+
+	respondJSON(w, http.StatusCreated, CreateTransferResponse{
+		EncodedTransactionBody: hex.EncodeToString(encodedTransactionBody),
+	})
+}
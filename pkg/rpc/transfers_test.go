@@ -0,0 +1,62 @@
+package rpc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/rpc"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestCreateTransfer(t *testing.T) {
+	tokenisationStore, _, mux, feClient := SetupRpcTest(t)
+	rpc.HandleTransferRoutes(tokenisationStore, mux)
+
+	mintHash := support.GenerateRandomHash()
+	fromAddress := support.GenerateDogecoinAddress(true)
+	toAddress := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenisationStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "mint1", FractionCount: 10}, fromAddress)
+	assert.NilError(t, err)
+	err = tokenisationStore.UpsertTokenBalance(fromAddress, mintHash, 10)
+	assert.NilError(t, err)
+
+	response, err := feClient.CreateTransfer(&rpc.CreateTransferRequest{
+		Payload: rpc.CreateTransferRequestPayload{
+			MintHash:    mintHash,
+			FromAddress: fromAddress,
+			ToAddress:   toAddress,
+			Quantity:    4,
+		},
+	})
+	assert.NilError(t, err)
+
+	encoded, err := hex.DecodeString(response.EncodedTransactionBody)
+	assert.NilError(t, err)
+
+	envelope := protocol.MessageEnvelope{}
+	err = envelope.Deserialize(encoded)
+	assert.NilError(t, err)
+	assert.Equal(t, envelope.Action, uint8(protocol.ACTION_TRANSFER))
+
+	message := protocol.OnChainTransferMessage{}
+	err = proto.Unmarshal(envelope.Data, &message)
+	assert.NilError(t, err)
+	assert.Equal(t, hex.EncodeToString(message.MintHash), mintHash)
+	assert.Equal(t, message.ToAddress, toAddress)
+	assert.Equal(t, message.Quantity, int32(4))
+
+	_, err = feClient.CreateTransfer(&rpc.CreateTransferRequest{
+		Payload: rpc.CreateTransferRequestPayload{
+			MintHash:    mintHash,
+			FromAddress: fromAddress,
+			ToAddress:   toAddress,
+			Quantity:    11,
+		},
+	})
+	assert.ErrorContains(t, err, "insufficient available token balance")
+}
@@ -10,9 +10,50 @@ import (
 	"dogecoin.org/fractal-engine/pkg/validation"
 )
 
+/*
+* SignedRequest is signed either by a single key, or by at least M keys of the M-of-N
+* multisig redeem script of a P2SH address.
+ */
 type SignedRequest struct {
-	PublicKey string `json:"public_key"`
-	Signature string `json:"signature"`
+	PublicKey    string                   `json:"public_key"`
+	Signature    string                   `json:"signature"`
+	RedeemScript string                   `json:"redeem_script,omitempty"`
+	Signatures   []doge.MultisigSignature `json:"signatures,omitempty"`
+}
+
+func (req *SignedRequest) Signer() doge.Signer {
+	return doge.Signer{
+		PublicKey:    req.PublicKey,
+		Signature:    req.Signature,
+		RedeemScript: req.RedeemScript,
+		Signatures:   req.Signatures,
+	}
+}
+
+// Verify checks the single or multisig signatures of the request over the payload.
+func (req *SignedRequest) Verify(payload interface{}) error {
+	return req.Signer().Validate(payload)
+}
+
+// validateSignerAddress checks the signer's key, or multisig redeem script, is valid and belongs to the address.
+func (req *SignedRequest) validateSignerAddress(field string, address string) error {
+	if req.RedeemScript == "" {
+		if err := validation.ValidatePublicKey(req.PublicKey); err != nil {
+			return fmt.Errorf("invalid public_key: %w", err)
+		}
+
+		return nil
+	}
+
+	if _, _, err := doge.ParseMultisigRedeemScript(req.RedeemScript); err != nil {
+		return fmt.Errorf("invalid redeem_script: %w", err)
+	}
+
+	if !doge.AddressMatchesRedeemScript(address, req.RedeemScript) {
+		return fmt.Errorf("redeem_script does not match %s", field)
+	}
+
+	return nil
 }
 
 type PrepareMintRequest struct {
@@ -73,6 +114,27 @@ func (req *PrepareMintRequest) Validate() error {
 		}
 	}
 
+	if _, err := store.ParseLockupOptions(req.Payload.LockupOptions); err != nil {
+		return fmt.Errorf("invalid lockup_options: %w", err)
+	}
+
+	if err := validateRequirements(req.Payload); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateRequirements(payload CreateMintRequestPayload) error {
+	requirements, err := store.ParseRequirements(payload.Requirements)
+	if err != nil {
+		return fmt.Errorf("invalid requirements: %w", err)
+	}
+
+	if requirements.BurnRequiresSignatures && (payload.SignatureRequirementType == "" || payload.SignatureRequirementType == store.SignatureRequirementType_NONE) {
+		return fmt.Errorf("invalid requirements: burn_requires_signatures requires a signature_requirement_type")
+	}
+
 	return nil
 }
 
@@ -102,11 +164,19 @@ func (req *CreateMintRequest) Validate() error {
 		return fmt.Errorf("invalid address: %w", err)
 	}
 
-	if err := validation.ValidatePublicKey(req.PublicKey); err != nil {
-		return fmt.Errorf("invalid public_key: %w", err)
+	if _, err := store.ParseLockupOptions(req.Payload.LockupOptions); err != nil {
+		return fmt.Errorf("invalid lockup_options: %w", err)
+	}
+
+	if err := validateRequirements(req.Payload); err != nil {
+		return err
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := req.validateSignerAddress("owner_address", req.Payload.OwnerAddress); err != nil {
+		return err
+	}
+
+	if err := req.Verify(req.Payload); err != nil {
 		return err
 	}
 
@@ -123,6 +193,11 @@ type GetTokenBalanceResponse struct {
 	Balance  int    `json:"balance"`
 }
 
+type GetLockedTokenBalancesResponse struct {
+	BlockHeight int64                      `json:"block_height"`
+	Balances    []store.LockedTokenBalance `json:"balances"`
+}
+
 type GetTokenBalanceWithMintsResponse struct {
 	Mints []store.TokenBalanceWithMint `json:"mints"`
 	Total int                          `json:"total"`
@@ -151,7 +226,7 @@ type CreateBuyOfferRequestPayload struct {
 	SellerAddress  string `json:"seller_address"`
 	MintHash       string `json:"mint_hash"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 }
 
 type DeleteBuyOfferRequest struct {
@@ -177,7 +252,7 @@ func (req *DeleteBuyOfferRequest) Validate() error {
 		return fmt.Errorf("invalid offer_hash: %w", err)
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := req.Verify(req.Payload); err != nil {
 		return err
 	}
 
@@ -189,7 +264,7 @@ func (req *DeleteSellOfferRequest) Validate() error {
 		return fmt.Errorf("invalid offer_hash: %w", err)
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := req.Verify(req.Payload); err != nil {
 		return err
 	}
 
@@ -213,11 +288,15 @@ func (req *CreateBuyOfferRequest) Validate() error {
 		return err
 	}
 
-	if err := validation.ValidatePrice("price", req.Payload.Price); err != nil {
+	if err := validation.ValidatePrice("price_koinu", req.Payload.PriceKoinu); err != nil {
 		return err
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := validation.ValidateTotalKoinu("quantity * price_koinu", req.Payload.Quantity, req.Payload.PriceKoinu); err != nil {
+		return err
+	}
+
+	if err := req.Verify(req.Payload); err != nil {
 		return err
 	}
 
@@ -233,7 +312,7 @@ type CreateSellOfferRequestPayload struct {
 	OffererAddress string `json:"offerer_address"`
 	MintHash       string `json:"mint_hash"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 }
 
 func (req *CreateSellOfferRequest) Validate() error {
@@ -249,11 +328,15 @@ func (req *CreateSellOfferRequest) Validate() error {
 		return err
 	}
 
-	if err := validation.ValidatePrice("price", req.Payload.Price); err != nil {
+	if err := validation.ValidatePrice("price_koinu", req.Payload.PriceKoinu); err != nil {
 		return err
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := validation.ValidateTotalKoinu("quantity * price_koinu", req.Payload.Quantity, req.Payload.PriceKoinu); err != nil {
+		return err
+	}
+
+	if err := req.Verify(req.Payload); err != nil {
 		return err
 	}
 
@@ -266,7 +349,11 @@ type CreateOfferResponse struct {
 }
 
 type GetMintResponse struct {
-	Mint store.Mint `json:"mint"`
+	Mint              store.Mint           `json:"mint"`
+	CirculatingSupply int                  `json:"circulating_supply"`
+	BurnedSupply      int                  `json:"burned_supply"`
+	Revision          int                  `json:"revision"`
+	Revisions         []store.MintRevision `json:"revisions"`
 }
 
 type SellOfferWithMint struct {
@@ -302,13 +389,45 @@ type CreateNewPaymentRequest struct {
 	InvoiceHash string `json:"invoice_hash"`
 }
 
+type CreateNewBatchPaymentRequest struct {
+	InvoiceHashes []string `json:"invoice_hashes"`
+}
+
+func (req *CreateNewBatchPaymentRequest) Validate() error {
+	if len(req.InvoiceHashes) == 0 {
+		return fmt.Errorf("invoice_hashes is required")
+	}
+
+	listed := map[string]bool{}
+	for _, invoiceHash := range req.InvoiceHashes {
+		if err := validation.ValidateHash(invoiceHash); err != nil {
+			return fmt.Errorf("invalid invoice_hashes: %w", err)
+		}
+
+		if listed[invoiceHash] {
+			return fmt.Errorf("invalid invoice_hashes: %s is listed more than once", invoiceHash)
+		}
+		listed[invoiceHash] = true
+	}
+
+	return nil
+}
+
+type GetBatchPaymentOutcomesResponse struct {
+	Outcomes []store.BatchPaymentOutcome `json:"outcomes"`
+	Page     int                         `json:"page"`
+	Limit    int                         `json:"limit"`
+}
+
 type CreateInvoiceRequestPayload struct {
 	PaymentAddress string `json:"payment_address"`
 	BuyerAddress   string `json:"buyer_address"`
 	MintHash       string `json:"mint_hash"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	SellerAddress  string `json:"seller_address"`
+	// Last block height a payment is accepted at, omitted for no expiry
+	ExpiryHeight int64 `json:"expiry_height,omitempty"`
 }
 
 func (req *CreateInvoiceRequest) Validate() error {
@@ -332,17 +451,358 @@ func (req *CreateInvoiceRequest) Validate() error {
 		return err
 	}
 
-	if err := validation.ValidatePrice("price", req.Payload.Price); err != nil {
+	if err := validation.ValidatePrice("price_koinu", req.Payload.PriceKoinu); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateTotalKoinu("quantity * price_koinu", req.Payload.Quantity, req.Payload.PriceKoinu); err != nil {
+		return err
+	}
+
+	if req.Payload.ExpiryHeight < 0 {
+		return fmt.Errorf("expiry_height must not be negative")
+	}
+
+	if req.RedeemScript != "" {
+		if err := req.validateSignerAddress("seller_address", req.Payload.SellerAddress); err != nil {
+			return err
+		}
+	}
+
+	if err := req.Verify(req.Payload); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type CreateTransferRequest struct {
+	Payload CreateTransferRequestPayload `json:"payload"`
+}
+
+type CreateTransferRequestPayload struct {
+	MintHash    string `json:"mint_hash"`
+	FromAddress string `json:"from_address"`
+	ToAddress   string `json:"to_address"`
+	Quantity    int    `json:"quantity"`
+}
+
+func (req *CreateTransferRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	if err := validation.ValidateAddress(req.Payload.FromAddress); err != nil {
+		return fmt.Errorf("invalid from_address: %w", err)
+	}
+
+	if err := validation.ValidateAddress(req.Payload.ToAddress); err != nil {
+		return fmt.Errorf("invalid to_address: %w", err)
+	}
+
+	if req.Payload.FromAddress == req.Payload.ToAddress {
+		return fmt.Errorf("from_address and to_address must be different")
+	}
+
+	if err := validation.ValidateQuantity("quantity", req.Payload.Quantity); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type CreateTransferResponse struct {
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type CreateBurnRequest struct {
+	Payload CreateBurnRequestPayload `json:"payload"`
+}
+
+type CreateBurnRequestPayload struct {
+	MintHash string `json:"mint_hash"`
+	Address  string `json:"address"`
+	Quantity int    `json:"quantity"`
+}
+
+func (req *CreateBurnRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	if err := validation.ValidateAddress(req.Payload.Address); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+
+	if err := validation.ValidateQuantity("quantity", req.Payload.Quantity); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type CreateBurnResponse struct {
+	Hash                   string `json:"hash,omitempty"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type CreateBurnSignatureRequest struct {
+	Payload CreateBurnSignatureRequestPayload `json:"payload"`
+}
+
+type CreateBurnSignatureRequestPayload struct {
+	MintHash  string `json:"mint_hash"`
+	Address   string `json:"address"`
+	Quantity  int    `json:"quantity"`
+	Signature string `json:"signature"`
+	PublicKey string `json:"public_key"`
+}
+
+type CreateBurnSignatureResponse struct {
+	Id string `json:"id"`
+}
+
+type GetMintAllowlistResponse struct {
+	MintHash         string                     `json:"mint_hash"`
+	AllowlistEnabled bool                       `json:"allowlist_enabled"`
+	Allowlist        []string                   `json:"allowlist"`
+	Pending          []store.MintAllowlistEntry `json:"pending"`
+}
+
+type CreateMintAllowlistEntryRequest struct {
+	SignedRequest
+	Payload store.MintAllowlistEntryBody `json:"payload"`
+}
+
+type CreateMintAllowlistEntryResponse struct {
+	Hash                   string `json:"hash"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type GetOnChainTransactionsResponse struct {
+	Transactions []store.OnChainTransactionOutcome `json:"transactions"`
+	Page         int                               `json:"page"`
+	Limit        int                               `json:"limit"`
+}
+
+type GetMempoolTransactionsResponse struct {
+	Transactions []store.MempoolTransaction `json:"transactions"`
+	Page         int                        `json:"page"`
+	Limit        int                        `json:"limit"`
+}
+
+type GetTradeRejectionsResponse struct {
+	Rejections []store.TradeRejection `json:"rejections"`
+	Page       int                    `json:"page"`
+	Limit      int                    `json:"limit"`
+}
+
+type CreateDistributionRequest struct {
+	Payload CreateDistributionRequestPayload `json:"payload"`
+}
+
+type CreateDistributionRequestPayload struct {
+	MintHash     string `json:"mint_hash"`
+	OwnerAddress string `json:"owner_address"`
+	TotalKoinu   int64  `json:"total_koinu"`
+	RecordHeight int64  `json:"record_height"`
+}
+
+func (req *CreateDistributionRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	if err := validation.ValidateAddress(req.Payload.OwnerAddress); err != nil {
+		return fmt.Errorf("invalid owner_address: %w", err)
+	}
+
+	if req.Payload.TotalKoinu <= 0 {
+		return fmt.Errorf("total_koinu must be positive")
+	}
+
+	if req.Payload.RecordHeight <= 0 {
+		return fmt.Errorf("record_height must be positive")
+	}
+
+	return nil
+}
+
+type CreateDistributionResponse struct {
+	EncodedTransactionBody string                     `json:"encoded_transaction_body"`
+	Payouts                []store.DistributionPayout `json:"payouts"`
+}
+
+type GetDistributionsResponse struct {
+	Distributions []store.Distribution `json:"distributions"`
+	Page          int                  `json:"page"`
+	Limit         int                  `json:"limit"`
+}
+
+type GetDistributionResponse struct {
+	Distribution store.Distribution         `json:"distribution"`
+	Payouts      []store.DistributionPayout `json:"payouts"`
+	PaidKoinu    int64                      `json:"paid_koinu"`
+	UnpaidKoinu  int64                      `json:"unpaid_koinu"`
+}
+
+type DistributionPayoutOutput struct {
+	Address     string `json:"address"`
+	AmountKoinu int64  `json:"amount_koinu"`
+}
+
+// DustKoinu is the total owed to unpaid holders whose payouts are below the dust limit and so have no output.
+type GetDistributionPayoutTransactionResponse struct {
+	EncodedTransactionBody string                     `json:"encoded_transaction_body"`
+	Outputs                []DistributionPayoutOutput `json:"outputs"`
+	TotalKoinu             int64                      `json:"total_koinu"`
+	DustKoinu              int64                      `json:"dust_koinu"`
+}
+
+type CreateMintAmendmentRequest struct {
+	SignedRequest
+	Payload store.MintAmendmentBody `json:"payload"`
+}
+
+func (req *CreateMintAmendmentRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	if err := validation.ValidateDescription(req.Payload.Description); err != nil {
 		return err
 	}
 
-	if err := doge.ValidateSignature(req.Payload, req.PublicKey, req.Signature); err != nil {
+	if err := validation.ValidateFeedURL(req.Payload.FeedURL); err != nil {
 		return err
 	}
 
+	if req.Payload.Metadata != nil {
+		metadataBytes, err := json.Marshal(req.Payload.Metadata)
+		if err != nil {
+			return fmt.Errorf("invalid metadata format: %w", err)
+		}
+		if err := validation.ValidateMetadataSize("metadata", metadataBytes); err != nil {
+			return err
+		}
+	}
+
+	if err := validation.ValidatePublicKey(req.PublicKey); err != nil {
+		return fmt.Errorf("invalid public_key: %w", err)
+	}
+
 	return nil
 }
 
+type CreateMintAmendmentResponse struct {
+	Hash                   string `json:"hash"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type GetMintAmendmentsResponse struct {
+	Amendments []store.MintAmendment `json:"amendments"`
+	Pending    []store.MintAmendment `json:"pending"`
+}
+
+type CreateAssetManagerRotationRequest struct {
+	Payload   store.AssetManagerRotationBody `json:"payload"`
+	Approvals []store.AssetManagerApproval   `json:"approvals"`
+}
+
+func (req *CreateAssetManagerRotationRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	switch req.Payload.Action {
+	case store.AssetManagerRotation_ADD:
+	case store.AssetManagerRotation_REMOVE, store.AssetManagerRotation_REPLACE:
+		if err := validation.ValidatePublicKey(req.Payload.PublicKey); err != nil {
+			return fmt.Errorf("invalid public_key: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid action: %s", req.Payload.Action)
+	}
+
+	if req.Payload.Action != store.AssetManagerRotation_REMOVE {
+		if req.Payload.Manager == nil {
+			return fmt.Errorf("manager is required for action: %s", req.Payload.Action)
+		}
+		if err := validation.ValidatePublicKey(req.Payload.Manager.PublicKey); err != nil {
+			return fmt.Errorf("invalid manager public_key: %w", err)
+		}
+	}
+
+	if len(req.Approvals) == 0 {
+		return fmt.Errorf("at least one asset manager approval is required")
+	}
+
+	for _, approval := range req.Approvals {
+		if err := validation.ValidatePublicKey(approval.PublicKey); err != nil {
+			return fmt.Errorf("invalid approval public_key: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type CreateAssetManagerRotationResponse struct {
+	Hash                   string `json:"hash"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type GetAssetManagersResponse struct {
+	MintHash      string                       `json:"mint_hash"`
+	AssetManagers store.AssetManagers          `json:"asset_managers"`
+	Rotations     []store.AssetManagerRotation `json:"rotations"`
+	Pending       []store.AssetManagerRotation `json:"pending"`
+}
+
+type CreateMintOwnershipTransferRequest struct {
+	SignedRequest
+	Payload store.MintOwnershipTransferBody `json:"payload"`
+}
+
+func (req *CreateMintOwnershipTransferRequest) Validate() error {
+	if err := validation.ValidateHash(req.Payload.MintHash); err != nil {
+		return fmt.Errorf("invalid mint_hash: %w", err)
+	}
+
+	if err := validation.ValidateAddress(req.Payload.NewOwnerAddress); err != nil {
+		return fmt.Errorf("invalid new_owner_address: %w", err)
+	}
+
+	if err := validation.ValidatePublicKey(req.Payload.NewOwnerPublicKey); err != nil {
+		return fmt.Errorf("invalid new_owner_public_key: %w", err)
+	}
+
+	if err := validation.ValidatePublicKey(req.PublicKey); err != nil {
+		return fmt.Errorf("invalid public_key: %w", err)
+	}
+
+	return nil
+}
+
+type CreateMintOwnershipTransferResponse struct {
+	Hash string `json:"hash"`
+}
+
+// AcceptMintOwnershipTransferRequest is signed by the new owner over the transfer payload.
+type AcceptMintOwnershipTransferRequest struct {
+	SignedRequest
+}
+
+type AcceptMintOwnershipTransferResponse struct {
+	Hash                   string `json:"hash"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
+type GetMintOwnersResponse struct {
+	MintHash string                        `json:"mint_hash"`
+	Owners   []store.MintOwner             `json:"owners"`
+	Pending  []store.MintOwnershipTransfer `json:"pending"`
+}
+
 type GetInvoicesResponse struct {
 	Invoices []store.Invoice `json:"invoices"`
 	Total    int             `json:"total"`
@@ -355,13 +815,19 @@ type CreateInvoiceResponse struct {
 	EncodedTransactionBody string `json:"encoded_transaction_body"`
 }
 
+type CreateInvoiceCancellationResponse struct {
+	InvoiceHash            string `json:"invoice_hash"`
+	EncodedTransactionBody string `json:"encoded_transaction_body"`
+}
+
 type GetHealthResponse struct {
-	CurrentBlockHeight int64     `json:"current_block_height"`
-	LatestBlockHeight  int64     `json:"latest_block_height"`
-	Chain              string    `json:"chain"`
-	WalletsEnabled     bool      `json:"wallets_enabled"`
-	UpdatedAt          time.Time `json:"updated_at"`
-	Version            string    `json:"version"`
+	CurrentBlockHeight int64            `json:"current_block_height"`
+	LatestBlockHeight  int64            `json:"latest_block_height"`
+	Chain              string           `json:"chain"`
+	WalletsEnabled     bool             `json:"wallets_enabled"`
+	UpdatedAt          time.Time        `json:"updated_at"`
+	Version            string           `json:"version"`
+	SupportedVersions  map[string][]int `json:"supported_versions"`
 }
 
 type Address struct {
@@ -377,3 +843,29 @@ type SignTxRequest struct {
 	PublicKey              string             `json:"public_key"`
 	EncodedTransactionBody string             `json:"encoded_transaction_body"`
 }
+
+type GetStuckTransactionsResponse struct {
+	Transactions []store.OnChainTransactionOutcome `json:"transactions"`
+	Page         int                               `json:"page"`
+	Limit        int                               `json:"limit"`
+}
+
+type AdminActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SupplyPayloadRequest carries the mint or invoice a stuck transaction is waiting for.
+type SupplyPayloadRequest struct {
+	Mint    *store.MintWithoutID      `json:"mint,omitempty"`
+	Invoice *store.UnconfirmedInvoice `json:"invoice,omitempty"`
+}
+
+type AdminActionResponse struct {
+	Transaction store.OnChainTransactionOutcome `json:"transaction"`
+}
+
+type GetAdminAuditLogResponse struct {
+	Entries []store.AdminAuditEntry `json:"entries"`
+	Page    int                     `json:"page"`
+	Limit   int                     `json:"limit"`
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type AllowlistEntryProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewAllowlistEntryProcessor(store *store.TokenisationStore) *AllowlistEntryProcessor {
+	return &AllowlistEntryProcessor{store: store}
+}
+
+/*
+* Allowlist entries are authorised by the signature of an asset manager effective at the block of the anchor,
+* so any address may anchor them. The anchor is kept until the entry has been gossiped;
+* invalid anchors and entries are rejected.
+ */
+func (p *AllowlistEntryProcessor) Process(tx store.OnChainTransaction, message *protocol.OnChainAllowlistEntryMessage) error {
+	entryHash := hex.EncodeToString(message.EntryHash)
+	mintHash := hex.EncodeToString(message.MintHash)
+
+	entry, err := p.store.GetUnconfirmedMintAllowlistEntry(entryHash)
+	if errors.Is(err, store.ErrMintAllowlistEntryNotFound) {
+		// Wait for the entry to be gossiped
+		return err
+	}
+
+	if err == nil {
+		err = p.validate(tx, entry, mintHash)
+	}
+
+	if err == nil {
+		err = p.store.ConfirmMintAllowlistEntry(tx, message)
+	}
+
+	if err != nil {
+		log.Println("Allowlist entry rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Confirmed allowlist entry:", tx.TxHash)
+	return nil
+}
+
+func (p *AllowlistEntryProcessor) validate(tx store.OnChainTransaction, entry store.MintAllowlistEntry, mintHash string) error {
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return err
+	}
+
+	if mint.Id == "" {
+		return fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	mint, err = p.store.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber)
+	if err != nil {
+		return err
+	}
+
+	return store.ValidateMintAllowlistEntry(mint, entry)
+}
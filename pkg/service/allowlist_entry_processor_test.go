@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestAllowlistEntryProcessorProcess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	owner := support.GenerateDogecoinAddress(true)
+	buyer := support.GenerateDogecoinAddress(true)
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "Test Mint",
+		FractionCount:            100,
+		Requirements:             store.StringInterfaceMap{"allowlist_enabled": true},
+		SignatureRequirementType: store.SignatureRequirementType_ONE_SIGNATURE,
+		AssetManagers:            []store.AssetManager{{Name: "Manager", PublicKey: pubHex}},
+	}, owner)
+	assert.NilError(t, err)
+
+	body := store.MintAllowlistEntryBody{MintHash: mintHash, Entry: buyer, Allowed: true, Timestamp: 1}
+	entryHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	entryHashBytes, _ := hex.DecodeString(entryHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainAllowlistEntryMessage{EntryHash: entryHashBytes, MintHash: mintHashBytes}
+
+	// The anchor waits until the entry has been gossiped, and may be sent by any address
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_ALLOWLIST_ENTRY, message, support.GenerateDogecoinAddress(true), store.KoinuValues{})
+	err = service.NewAllowlistEntryProcessor(tokenStore).Process(tx, message)
+	assert.Assert(t, errors.Is(err, store.ErrMintAllowlistEntryNotFound))
+
+	err = tokenStore.SaveUnconfirmedMintAllowlistEntry(&store.MintAllowlistEntry{MintAllowlistEntryBody: body, Hash: entryHash, PublicKey: pubHex, Signature: signature})
+	assert.NilError(t, err)
+
+	err = service.NewAllowlistEntryProcessor(tokenStore).Process(tx, message)
+	assert.NilError(t, err)
+
+	entries, err := tokenStore.GetMintAllowlistEntries(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].TransactionHash, tx.TxHash)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	allowlist, err := tokenStore.GetMintAllowlist(mint)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, allowlist, []string{buyer})
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
+
+func TestAllowlistEntryProcessorRejectsNonAssetManager(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	_, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	otherPrivHex, otherPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	owner := support.GenerateDogecoinAddress(true)
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "Test Mint",
+		FractionCount:            100,
+		Requirements:             store.StringInterfaceMap{"allowlist_enabled": true},
+		SignatureRequirementType: store.SignatureRequirementType_ONE_SIGNATURE,
+		AssetManagers:            []store.AssetManager{{Name: "Manager", PublicKey: pubHex}},
+	}, owner)
+	assert.NilError(t, err)
+
+	body := store.MintAllowlistEntryBody{MintHash: mintHash, Entry: support.GenerateDogecoinAddress(true), Allowed: true, Timestamp: 1}
+	entryHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, otherPrivHex, otherPubHex)
+	assert.NilError(t, err)
+	err = tokenStore.SaveUnconfirmedMintAllowlistEntry(&store.MintAllowlistEntry{MintAllowlistEntryBody: body, Hash: entryHash, PublicKey: otherPubHex, Signature: signature})
+	assert.NilError(t, err)
+
+	entryHashBytes, _ := hex.DecodeString(entryHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainAllowlistEntryMessage{EntryHash: entryHashBytes, MintHash: mintHashBytes}
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_ALLOWLIST_ENTRY, message, owner, store.KoinuValues{})
+
+	err = service.NewAllowlistEntryProcessor(tokenStore).Process(tx, message)
+	assert.ErrorContains(t, err, "asset managers")
+
+	entries, err := tokenStore.GetMintAllowlistEntries(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
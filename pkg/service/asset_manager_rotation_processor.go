@@ -0,0 +1,71 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type AssetManagerRotationProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewAssetManagerRotationProcessor(store *store.TokenisationStore) *AssetManagerRotationProcessor {
+	return &AssetManagerRotationProcessor{store: store}
+}
+
+/*
+* Rotations are authorised by the approvals of the asset managers effective at the block of the anchor,
+* so any address may anchor them. The anchor is kept until the rotation has been gossiped;
+* invalid anchors and rotations are rejected.
+ */
+func (p *AssetManagerRotationProcessor) Process(tx store.OnChainTransaction, message *protocol.OnChainAssetManagerRotationMessage) error {
+	rotationHash := hex.EncodeToString(message.RotationHash)
+	mintHash := hex.EncodeToString(message.MintHash)
+
+	rotation, err := p.store.GetUnconfirmedAssetManagerRotation(rotationHash)
+	if errors.Is(err, store.ErrAssetManagerRotationNotFound) {
+		// Wait for the rotation to be gossiped
+		return err
+	}
+
+	var assetManagers store.AssetManagers
+	if err == nil {
+		assetManagers, err = p.validate(tx, rotation, mintHash)
+	}
+
+	if err == nil {
+		err = p.store.ConfirmAssetManagerRotation(tx, message, assetManagers)
+	}
+
+	if err != nil {
+		log.Println("Asset manager rotation rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Confirmed asset manager rotation:", tx.TxHash)
+	return nil
+}
+
+func (p *AssetManagerRotationProcessor) validate(tx store.OnChainTransaction, rotation store.AssetManagerRotation, mintHash string) (store.AssetManagers, error) {
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if mint.Id == "" {
+		return nil, fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	mint, err = p.store.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ValidateAssetManagerRotation(mint, rotation)
+}
@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestAssetManagerRotationProcessorProcess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	_, replacementPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "Test Mint",
+		FractionCount:            100,
+		SignatureRequirementType: store.SignatureRequirementType_ALL_SIGNATURES,
+		AssetManagers:            store.AssetManagers{{Name: "Custodian", PublicKey: pubHex}},
+	}, support.GenerateDogecoinAddress(true))
+	assert.NilError(t, err)
+
+	body := store.AssetManagerRotationBody{
+		MintHash:  mintHash,
+		Action:    store.AssetManagerRotation_REPLACE,
+		PublicKey: pubHex,
+		Manager:   &store.AssetManager{Name: "New custodian", PublicKey: replacementPubHex},
+		Timestamp: 1,
+	}
+	rotationHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	rotationHashBytes, _ := hex.DecodeString(rotationHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainAssetManagerRotationMessage{RotationHash: rotationHashBytes, MintHash: mintHashBytes}
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_ASSET_MANAGER_ROTATION, message, support.GenerateDogecoinAddress(true), store.KoinuValues{})
+
+	// The anchor waits until the rotation has been gossiped
+	err = service.NewAssetManagerRotationProcessor(tokenStore).Process(tx, message)
+	assert.Assert(t, errors.Is(err, store.ErrAssetManagerRotationNotFound))
+
+	err = tokenStore.SaveUnconfirmedAssetManagerRotation(&store.AssetManagerRotation{
+		AssetManagerRotationBody: body,
+		Hash:                     rotationHash,
+		Approvals:                store.AssetManagerApprovals{{PublicKey: pubHex, Signature: signature}},
+	})
+	assert.NilError(t, err)
+
+	err = service.NewAssetManagerRotationProcessor(tokenStore).Process(tx, message)
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+
+	// Invoices anchored before the rotation, even in its block, are still signed by the previous manager
+	before, err := tokenStore.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber)
+	assert.NilError(t, err)
+	assert.Assert(t, before.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: pubHex}}))
+
+	after, err := tokenStore.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber+1)
+	assert.NilError(t, err)
+	assert.Assert(t, !after.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: pubHex}}))
+	assert.Assert(t, after.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: replacementPubHex}}))
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+
+	// Replaying the rotation fails as the manager it replaces has been revoked
+	err = tokenStore.SaveUnconfirmedAssetManagerRotation(&store.AssetManagerRotation{
+		AssetManagerRotationBody: body,
+		Hash:                     rotationHash,
+		Approvals:                store.AssetManagerApprovals{{PublicKey: pubHex, Signature: signature}},
+	})
+	assert.NilError(t, err)
+
+	replay := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_ASSET_MANAGER_ROTATION, message, support.GenerateDogecoinAddress(true), store.KoinuValues{})
+	replay.TransactionNumber = tx.TransactionNumber + 1
+
+	err = service.NewAssetManagerRotationProcessor(tokenStore).Process(replay, message)
+	assert.ErrorContains(t, err, "does not match any asset managers")
+
+	rotations, err := tokenStore.GetAssetManagerRotations(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rotations), 1)
+}
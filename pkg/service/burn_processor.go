@@ -0,0 +1,113 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+var errAwaitingBurnSignatures = errors.New("awaiting burn signatures")
+
+type BurnProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewBurnProcessor(store *store.TokenisationStore) *BurnProcessor {
+	return &BurnProcessor{store: store}
+}
+
+/*
+* The holder is the address proven by the on chain transaction.
+* Burns that are invalid or exceed the holder's available balance are rejected.
+* When the mint requires co-signed burns, the burn is kept until enough
+* asset manager signatures for the burn hash have been received.
+ */
+func (p *BurnProcessor) Process(tx store.OnChainTransaction, burn *protocol.OnChainBurnMessage) error {
+	mintHash := hex.EncodeToString(burn.MintHash)
+	burnHash := hex.EncodeToString(burn.BurnHash)
+
+	err := p.validate(tx, burn, mintHash, burnHash)
+	if errors.Is(err, errAwaitingBurnSignatures) {
+		log.Println("Burn waiting for signatures:", tx.TxHash)
+		return nil
+	}
+
+	if err == nil {
+		err = p.store.ProcessBurn(tx, mintHash, int(burn.Quantity), burnHash)
+		if err != nil && !errors.Is(err, store.ErrInsufficientTokenBalance) {
+			log.Println("Error processing burn:", err)
+			return err
+		}
+	}
+
+	if err != nil {
+		log.Println("Burn rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Matched burn:", tx.TxHash)
+	return nil
+}
+
+func (p *BurnProcessor) validate(tx store.OnChainTransaction, burn *protocol.OnChainBurnMessage, mintHash string, burnHash string) error {
+	if err := validation.ValidateProtobufQuantity(burn.Quantity); err != nil {
+		return err
+	}
+
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return err
+	}
+
+	if mint.Id == "" {
+		return fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	if !mint.BurnSignatureRequired() {
+		return nil
+	}
+
+	if burnHash == "" {
+		return fmt.Errorf("burn hash is required for mint: %s", mintHash)
+	}
+
+	burned, err := p.store.HasTokenBurn(burnHash)
+	if err != nil {
+		return err
+	}
+
+	if burned {
+		return fmt.Errorf("burn hash already used: %s", burnHash)
+	}
+
+	mint, err = p.store.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber)
+	if err != nil {
+		return err
+	}
+
+	signatures, err := p.store.GetApprovedInvoiceSignatures(burnHash)
+	if err != nil {
+		return err
+	}
+
+	var validSignatures []store.InvoiceSignature
+	for _, signature := range signatures {
+		if err := signature.ValidateBurn(mint, burnHash, tx.Address, int(burn.Quantity)); err != nil {
+			log.Println("Ignoring burn signature:", err)
+			continue
+		}
+		validSignatures = append(validSignatures, signature)
+	}
+
+	if !mint.HasRequiredSignatures(validSignatures) {
+		return errAwaitingBurnSignatures
+	}
+
+	return nil
+}
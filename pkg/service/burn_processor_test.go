@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func saveBurnTransaction(t *testing.T, tokenStore *store.TokenisationStore, mintHash string, address string, quantity int32, burnHash string) (store.OnChainTransaction, *protocol.OnChainBurnMessage) {
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	assert.NilError(t, err)
+
+	burnHashBytes, err := hex.DecodeString(burnHash)
+	assert.NilError(t, err)
+
+	burn := &protocol.OnChainBurnMessage{
+		MintHash: mintHashBytes,
+		Quantity: quantity,
+		BurnHash: burnHashBytes,
+	}
+	return saveOnChainTransaction(t, tokenStore, "burnTx", protocol.ACTION_BURN, burn, address, store.KoinuValues{}), burn
+}
+
+func TestBurnProcessorProcessSuccess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewBurnProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, address)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(address, mintHash, 100)
+	assert.NilError(t, err)
+
+	tx, burn := saveBurnTransaction(t, tokenStore, mintHash, address, 25, "")
+
+	err = processor.Process(tx, burn)
+	assert.NilError(t, err)
+
+	available, err := tokenStore.GetAvailableTokenBalance(address, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 75)
+
+	burned, err := tokenStore.GetBurnedSupply(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 25)
+}
+
+func TestBurnProcessorDiscardsInsufficientBalance(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewBurnProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, address)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(address, mintHash, 10)
+	assert.NilError(t, err)
+
+	tx, burn := saveBurnTransaction(t, tokenStore, mintHash, address, 25, "")
+
+	err = processor.Process(tx, burn)
+	assert.ErrorContains(t, err, "insufficient available token balance")
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
+
+func TestBurnProcessorWaitsForSignatures(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewBurnProcessor(tokenStore)
+
+	assetManagerPrivKey, assetManagerPubKey, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	address := support.GenerateDogecoinAddress(true)
+
+	mint := store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "Test Mint",
+		FractionCount:            100,
+		Requirements:             store.StringInterfaceMap{store.BurnRequiresSignaturesRequirement: true},
+		SignatureRequirementType: store.SignatureRequirementType_ONE_SIGNATURE,
+		AssetManagers: store.AssetManagers{
+			{Name: "asset manager", PublicKey: assetManagerPubKey, URL: "https://example.com/assetManager"},
+		},
+	}
+	_, err = tokenStore.SaveMint(&mint, address)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(address, mintHash, 100)
+	assert.NilError(t, err)
+
+	burnHash := support.GenerateRandomHash()
+	tx, burn := saveBurnTransaction(t, tokenStore, mintHash, address, 25, burnHash)
+
+	err = processor.Process(tx, burn)
+	assert.NilError(t, err)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+
+	burned, err := tokenStore.GetBurnedSupply(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 0)
+
+	signature, err := doge.SignPayload(store.BurnSignatureBody{
+		Hash:     burnHash,
+		MintHash: mintHash,
+		Quantity: 25,
+		Address:  address,
+	}, assetManagerPrivKey, assetManagerPubKey)
+	assert.NilError(t, err)
+
+	_, err = tokenStore.SaveApprovedInvoiceSignature(&store.InvoiceSignature{
+		InvoiceHash: burnHash,
+		Signature:   signature,
+		PublicKey:   assetManagerPubKey,
+		CreatedAt:   time.Now(),
+	})
+	assert.NilError(t, err)
+
+	err = processor.Process(tx, burn)
+	assert.NilError(t, err)
+
+	burned, err = tokenStore.GetBurnedSupply(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 25)
+}
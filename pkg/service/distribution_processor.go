@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type DistributionProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewDistributionProcessor(store *store.TokenisationStore) *DistributionProcessor {
+	return &DistributionProcessor{store: store}
+}
+
+/*
+* Distributions can only be published by the mint owner (the address proven by the
+* on chain transaction) for a record height that is not in the future.
+* Invalid distributions are rejected.
+ */
+func (p *DistributionProcessor) Process(tx store.OnChainTransaction, distribution *protocol.OnChainDistributionMessage) error {
+	mintHash := hex.EncodeToString(distribution.MintHash)
+
+	err := p.validate(tx, distribution, mintHash)
+	if err == nil {
+		err = p.store.ProcessDistribution(tx, mintHash, distribution.TotalKoinu, distribution.RecordHeight)
+	}
+
+	if err != nil {
+		log.Println("Distribution rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Matched distribution:", tx.TxHash)
+	return nil
+}
+
+func (p *DistributionProcessor) validate(tx store.OnChainTransaction, distribution *protocol.OnChainDistributionMessage, mintHash string) error {
+	if distribution.TotalKoinu <= 0 {
+		return fmt.Errorf("total_koinu must be positive: %d", distribution.TotalKoinu)
+	}
+
+	if distribution.RecordHeight <= 0 || distribution.RecordHeight > tx.Height {
+		return fmt.Errorf("record_height must be between 1 and the block height %d: %d", tx.Height, distribution.RecordHeight)
+	}
+
+	err := checkMintOwner(p.store, tx, mintHash)
+	if err != nil {
+		return err
+	}
+
+	exists, err := p.store.HasDistribution(tx.TxHash)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return fmt.Errorf("distribution already recorded: %s", tx.TxHash)
+	}
+
+	return nil
+}
+
+// checkMintOwner fails unless the transaction was sent by the current owner of the mint.
+func checkMintOwner(s *store.TokenisationStore, tx store.OnChainTransaction, mintHash string) error {
+	mint, err := s.GetMintByHash(mintHash)
+	if err != nil {
+		return err
+	}
+
+	if mint.Id == "" {
+		return fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	if mint.OwnerAddress != tx.Address {
+		return fmt.Errorf("%w: %s is not the mint owner", store.ErrSenderMismatch, tx.Address)
+	}
+
+	return nil
+}
+
+type DistributionPayoutProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewDistributionPayoutProcessor(store *store.TokenisationStore) *DistributionPayoutProcessor {
+	return &DistributionPayoutProcessor{store: store}
+}
+
+/*
+* Payouts are matched by output: each unpaid holder that received at least their
+* share in the transaction is marked as paid. A distribution may be paid out over
+* several transactions, which like the distribution must be sent by the mint owner.
+ */
+func (p *DistributionPayoutProcessor) Process(tx store.OnChainTransaction, payout *protocol.OnChainDistributionPayoutMessage) error {
+	distributionHash := hex.EncodeToString(payout.DistributionHash)
+
+	distribution, err := p.store.GetDistribution(distributionHash)
+	if err != nil {
+		return err
+	}
+
+	if distribution.Hash == "" {
+		// The distribution may not have been processed yet
+		return fmt.Errorf("distribution not found: %s", distributionHash)
+	}
+
+	err = checkMintOwner(p.store, tx, distribution.MintHash)
+	if err != nil {
+		log.Println("Distribution payout rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	paid, err := p.store.ProcessDistributionPayout(tx, distributionHash)
+	if err != nil {
+		log.Println("Error processing distribution payout:", err)
+		return err
+	}
+
+	log.Printf("Matched distribution payout: %s (%d holders paid)", tx.TxHash, paid)
+	return nil
+}
@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestDistributionProcessorProcess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	distributionHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	holder := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, owner)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(holder, mintHash, 100)
+	assert.NilError(t, err)
+
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	distribution := &protocol.OnChainDistributionMessage{
+		MintHash:     mintHashBytes,
+		TotalKoinu:   1_000_000_000,
+		RecordHeight: 5,
+	}
+	tx := saveOnChainTransaction(t, tokenStore, distributionHash, protocol.ACTION_DISTRIBUTION, distribution, owner, store.KoinuValues{})
+
+	err = service.NewDistributionProcessor(tokenStore).Process(tx, distribution)
+	assert.NilError(t, err)
+
+	payouts, err := tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(payouts), 1)
+	assert.Equal(t, payouts[0].Address, holder)
+	assert.Equal(t, payouts[0].AmountKoinu, int64(1_000_000_000))
+
+	distributionHashBytes, _ := hex.DecodeString(distributionHash)
+	payout := &protocol.OnChainDistributionPayoutMessage{DistributionHash: distributionHashBytes}
+
+	// Only the mint owner can pay out a distribution
+	spoofedTx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_DISTRIBUTION_PAYOUT, payout, holder, store.KoinuValues{holder: 1_000_000_000})
+	err = service.NewDistributionPayoutProcessor(tokenStore).Process(spoofedTx, payout)
+	assert.Assert(t, errors.Is(err, store.ErrSenderMismatch))
+
+	payouts, err = tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	assert.Assert(t, !payouts[0].Paid())
+
+	payoutTx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_DISTRIBUTION_PAYOUT, payout, owner, store.KoinuValues{holder: 1_000_000_000})
+
+	err = service.NewDistributionPayoutProcessor(tokenStore).Process(payoutTx, payout)
+	assert.NilError(t, err)
+
+	payouts, err = tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	assert.Assert(t, payouts[0].Paid())
+	assert.Equal(t, payouts[0].PaidTransactionHash, payoutTx.TxHash)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
+
+func TestDistributionProcessorDiscardsInvalidDistributions(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewDistributionProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	holder := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, owner)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(holder, mintHash, 100)
+	assert.NilError(t, err)
+
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+
+	// Not published by the owner
+	distribution := &protocol.OnChainDistributionMessage{
+		MintHash:     mintHashBytes,
+		TotalKoinu:   1_000,
+		RecordHeight: 5,
+	}
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_DISTRIBUTION, distribution, holder, store.KoinuValues{})
+	err = processor.Process(tx, distribution)
+	assert.Assert(t, errors.Is(err, store.ErrSenderMismatch))
+
+	// Record height in the future
+	distribution = &protocol.OnChainDistributionMessage{
+		MintHash:     mintHashBytes,
+		TotalKoinu:   1_000,
+		RecordHeight: 11,
+	}
+	tx = saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_DISTRIBUTION, distribution, owner, store.KoinuValues{})
+	err = processor.Process(tx, distribution)
+	assert.ErrorContains(t, err, "record_height")
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+
+	distributions, err := tokenStore.GetDistributions(mintHash, 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(distributions), 0)
+}
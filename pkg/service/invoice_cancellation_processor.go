@@ -0,0 +1,96 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type InvoiceCancellationProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewInvoiceCancellationProcessor(store *store.TokenisationStore) *InvoiceCancellationProcessor {
+	return &InvoiceCancellationProcessor{store: store}
+}
+
+/*
+* The seller is the address proven by the on chain transaction and must match the seller of the invoice.
+* The cancellation is kept until the invoice has been gossiped, and while a payment made before it is
+* waiting for its confirmations. Invoices that have received a payment or were already released are
+* not cancelled; the transaction is rejected.
+ */
+func (p *InvoiceCancellationProcessor) Process(tx store.OnChainTransaction, cancellation *protocol.OnChainInvoiceCancellationMessage) error {
+	invoiceHash := hex.EncodeToString(cancellation.InvoiceHash)
+
+	sellerAddress, mintHash, paidKoinu, err := p.getInvoice(invoiceHash)
+	if err != nil {
+		return err
+	}
+
+	if sellerAddress != tx.Address {
+		err = fmt.Errorf("%w: invoice %s is sold by %s, cancelled by %s", store.ErrSenderMismatch, invoiceHash, sellerAddress, tx.Address)
+		log.Println("Invoice cancellation rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_SENDER_MISMATCH, err.Error())
+	}
+
+	if paidKoinu > 0 {
+		err = fmt.Errorf("%w: invoice %s has received %d koinu", store.ErrInvoicePaid, invoiceHash, paidKoinu)
+		log.Println("Invoice cancellation rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_INVOICE_PAID, err.Error())
+	}
+
+	release, err := p.store.GetInvoiceRelease(invoiceHash)
+	if err == nil {
+		reasonCode := store.OnChainRejection_INVOICE_EXPIRED
+		if release.Reason == store.InvoiceRelease_CANCELLED {
+			reasonCode = store.OnChainRejection_INVOICE_CANCELLED
+		}
+
+		log.Printf("Invoice cancellation rejected: invoice %s was %s at height %d", invoiceHash, release.Reason, release.BlockHeight)
+		return p.store.RejectOnChainTransaction(tx, reasonCode, fmt.Sprintf("invoice %s was %s at height %d", invoiceHash, release.Reason, release.BlockHeight))
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.Println("Error getting invoice release:", err)
+		return err
+	}
+
+	pendingPayments, err := p.store.GetPendingPaymentHeights()
+	if err != nil {
+		log.Println("Error getting pending payments:", err)
+		return err
+	}
+
+	if height, ok := pendingPayments[invoiceHash]; ok && height <= tx.Height {
+		return fmt.Errorf("invoice %s has a payment at height %d waiting to be processed", invoiceHash, height)
+	}
+
+	err = p.store.CancelInvoice(tx, invoiceHash, mintHash)
+	if err != nil {
+		log.Println("Error cancelling invoice:", err)
+		return err
+	}
+
+	log.Println("Cancelled invoice:", invoiceHash)
+	return nil
+}
+
+// getInvoice returns the seller, mint and amount paid of the invoice, preferring the confirmed invoice.
+func (p *InvoiceCancellationProcessor) getInvoice(invoiceHash string) (string, string, int64, error) {
+	invoice, err := p.store.GetInvoiceByHash(invoiceHash)
+	if err == nil {
+		return invoice.SellerAddress, invoice.MintHash, invoice.PaidKoinu, nil
+	}
+
+	unconfirmedInvoice, err := p.store.GetUnconfirmedInvoiceByHash(invoiceHash)
+	if err == nil {
+		return unconfirmedInvoice.SellerAddress, unconfirmedInvoice.MintHash, 0, nil
+	}
+
+	return "", "", 0, fmt.Errorf("%w: %s", store.ErrInvoiceNotFound, invoiceHash)
+}
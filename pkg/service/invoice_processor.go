@@ -1,14 +1,16 @@
 package service
 
 import (
+	"database/sql"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"log"
 	"strings"
 
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 	"dogecoin.org/fractal-engine/pkg/validation"
-	"google.golang.org/protobuf/proto"
 )
 
 type InvoiceProcessor struct {
@@ -27,27 +29,35 @@ func NewInvoiceProcessor(store *store.TokenisationStore) *InvoiceProcessor {
 * If so, create a pending token balance
 * If not, remove onchain transaction (discard invoice)
  */
-func (p *InvoiceProcessor) Process(tx store.OnChainTransaction) error {
-	invoice := protocol.OnChainInvoiceMessage{}
-	err := proto.Unmarshal(tx.ActionData, &invoice)
-	if err != nil {
-		log.Println("Error unmarshalling invoice:", err)
-		return err
-	}
-
+func (p *InvoiceProcessor) Process(tx store.OnChainTransaction, invoice *protocol.OnChainInvoiceMessage) error {
 	// Validate protobuf content
 	if err := validation.ValidateProtobufQuantity(invoice.Quantity); err != nil {
 		log.Printf("Invalid quantity in protobuf: %v", err)
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	rejected, err := p.checkSender(tx, invoice)
+	if err != nil || rejected {
 		return err
 	}
 
-	hasPendingTokenBalance, err := p.EnsurePendingTokenBalance(tx)
+	rejected, err = p.checkOpen(tx, invoice)
+	if err != nil || rejected {
+		return err
+	}
+
+	rejected, err = p.checkTradeRequirements(tx, invoice)
+	if err != nil || rejected {
+		return err
+	}
+
+	hasPendingTokenBalance, err := p.EnsurePendingTokenBalance(tx, invoice)
 	if err != nil {
 		return err
 	}
 
 	if !hasPendingTokenBalance {
-		log.Println("Invoice discarded, not enough availability")
+		log.Println("Invoice rejected, not enough availability")
 		return nil
 	}
 
@@ -58,7 +68,14 @@ func (p *InvoiceProcessor) Process(tx store.OnChainTransaction) error {
 	}
 
 	// Check if signatures are required and if the number of signatures is correct
+	// Signatures are counted against the asset managers effective at the position of the invoice in the chain
 	if mint.SignatureRequired() {
+		mint, err = p.store.WithAssetManagersAt(mint, tx.Height, tx.TransactionNumber)
+		if err != nil {
+			log.Println("Error getting asset managers:", err)
+			return err
+		}
+
 		signatures, err := p.store.GetApprovedInvoiceSignatures(hex.EncodeToString(invoice.InvoiceHash))
 		if err != nil {
 			log.Println("Error getting invoice signatures:", err)
@@ -72,12 +89,12 @@ func (p *InvoiceProcessor) Process(tx store.OnChainTransaction) error {
 	}
 
 	// Try to match confirmed invoice first
-	if p.store.MatchInvoice(tx) {
+	if p.store.MatchInvoice(tx, invoice) {
 		return nil
 	}
 
 	// Try to match unconfirmed invoice (already transaction-safe)
-	err = p.store.MatchUnconfirmedInvoice(tx)
+	err = p.store.MatchUnconfirmedInvoice(tx, invoice)
 	if err == nil {
 		log.Println("Matched invoice:", tx.TxHash)
 	} else {
@@ -92,14 +109,117 @@ func (p *InvoiceProcessor) Process(tx store.OnChainTransaction) error {
 	return err
 }
 
-func (p *InvoiceProcessor) EnsurePendingTokenBalance(tx store.OnChainTransaction) (bool, error) {
-	invoice := protocol.OnChainInvoiceMessage{}
-	err := proto.Unmarshal(tx.ActionData, &invoice)
+/*
+* Check the invoice was anchored by its seller, the fractions are reserved from the sender's balance.
+* The seller is only known once the invoice has been gossiped; a reservation made before then by
+* a sender that turns out not to be the seller is released when the transaction is rejected.
+ */
+func (p *InvoiceProcessor) checkSender(tx store.OnChainTransaction, invoice *protocol.OnChainInvoiceMessage) (bool, error) {
+	invoiceHash := hex.EncodeToString(invoice.InvoiceHash)
+	mintHash := hex.EncodeToString(invoice.MintHash)
+
+	sellerAddress, _ := p.getInvoiceParties(invoiceHash)
+	if sellerAddress == "" || sellerAddress == tx.Address {
+		return false, nil
+	}
+
+	pendingTokenBalance, err := p.store.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	if err == nil && pendingTokenBalance.OwnerAddress == tx.Address {
+		err = p.store.RemovePendingTokenBalance(invoiceHash, mintHash)
+		if err != nil {
+			log.Println("Error removing pending token balance:", err)
+			return false, err
+		}
+	}
+
+	err = fmt.Errorf("%w: invoice %s is sold by %s, sent by %s", store.ErrSenderMismatch, invoiceHash, sellerAddress, tx.Address)
+	log.Println("Invoice rejected:", err)
+	return true, p.store.RejectOnChainTransaction(tx, store.OnChainRejection_SENDER_MISMATCH, err.Error())
+}
+
+/*
+* Check the invoice can still be reserved: it must not expire before the block it is anchored in,
+* and must not have been released already, which happens when the seller cancels it or it expires
+* before its details are gossiped.
+ */
+func (p *InvoiceProcessor) checkOpen(tx store.OnChainTransaction, invoice *protocol.OnChainInvoiceMessage) (bool, error) {
+	invoiceHash := hex.EncodeToString(invoice.InvoiceHash)
+
+	if invoice.ExpiryHeight > 0 && invoice.ExpiryHeight < tx.Height {
+		err := fmt.Errorf("%w: invoice %s expires at height %d, before it was anchored at %d", store.ErrInvoiceExpired, invoiceHash, invoice.ExpiryHeight, tx.Height)
+		log.Println("Invoice rejected:", err)
+		return true, p.store.RejectOnChainTransaction(tx, store.OnChainRejection_INVOICE_EXPIRED, err.Error())
+	}
+
+	release, err := p.store.GetInvoiceRelease(invoiceHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
 	if err != nil {
-		log.Println("Error unmarshalling invoice:", err)
+		log.Println("Error getting invoice release:", err)
 		return false, err
 	}
 
+	reasonCode := store.OnChainRejection_INVOICE_EXPIRED
+	if release.Reason == store.InvoiceRelease_CANCELLED {
+		reasonCode = store.OnChainRejection_INVOICE_CANCELLED
+	}
+
+	log.Printf("Invoice rejected: invoice %s was %s at height %d", invoiceHash, release.Reason, release.BlockHeight)
+	return true, p.store.RejectOnChainTransaction(tx, reasonCode, fmt.Sprintf("invoice %s was %s at height %d", invoiceHash, release.Reason, release.BlockHeight))
+}
+
+func (p *InvoiceProcessor) getInvoiceParties(invoiceHash string) (string, string) {
+	unconfirmedInvoice, err := p.store.GetUnconfirmedInvoiceByHash(invoiceHash)
+	if err == nil {
+		return unconfirmedInvoice.SellerAddress, unconfirmedInvoice.BuyerAddress
+	}
+
+	confirmedInvoice, err := p.store.GetInvoiceByHash(invoiceHash)
+	if err == nil {
+		return confirmedInvoice.SellerAddress, confirmedInvoice.BuyerAddress
+	}
+
+	return "", ""
+}
+
+/*
+* Check the mint requirements (allowlist, holder caps) against the buyer of the invoice.
+* The buyer is only known once the invoice has been gossiped; if it is not known yet
+* the requirements are checked again when the payment is processed.
+* Violations are recorded as trade rejections and the onchain transaction is rejected with the violation code.
+ */
+func (p *InvoiceProcessor) checkTradeRequirements(tx store.OnChainTransaction, invoice *protocol.OnChainInvoiceMessage) (bool, error) {
+	invoiceHash := hex.EncodeToString(invoice.InvoiceHash)
+	mintHash := hex.EncodeToString(invoice.MintHash)
+
+	_, buyerAddress := p.getInvoiceParties(invoiceHash)
+	if buyerAddress == "" {
+		return false, nil
+	}
+
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		log.Println("Error getting mint:", err)
+		return false, err
+	}
+
+	err = p.store.CheckTradeRequirements(mint, tx.Address, buyerAddress, int(invoice.Quantity), tx)
+	if err == nil {
+		return false, nil
+	}
+
+	var violation *store.TradeViolation
+	if !errors.As(err, &violation) {
+		log.Println("Error checking trade requirements:", err)
+		return false, err
+	}
+
+	log.Println("Invoice rejected:", violation)
+	return true, p.store.RejectTrade(tx, invoiceHash, mintHash, tx.Address, buyerAddress, int(invoice.Quantity), violation)
+}
+
+func (p *InvoiceProcessor) EnsurePendingTokenBalance(tx store.OnChainTransaction, invoice *protocol.OnChainInvoiceMessage) (bool, error) {
 	// Start transaction for atomic operations
 	dbTx, err := p.store.DB.Begin()
 	if err != nil {
@@ -115,30 +235,18 @@ func (p *InvoiceProcessor) EnsurePendingTokenBalance(tx store.OnChainTransaction
 		return true, nil
 	}
 
-	tokenBalances, err := p.store.GetTokenBalances(tx.Address, hex.EncodeToString(invoice.MintHash))
+	// Locked fractions (see store.LockupOptions) cannot be reserved
+	tokenBalanceAvailable, err := p.store.GetTransferableTokenBalance(tx.Address, hex.EncodeToString(invoice.MintHash), tx.Height, tx.EffectiveBlockTime(), dbTx)
 	if err != nil {
-		log.Println("Error getting token balance:", err)
+		log.Println("Error getting transferable token balance:", err)
 		return false, err
 	}
 
-	pendingTokenBalanceTotal, err := p.store.GetPendingTokenBalanceTotalForMintAndOwner(hex.EncodeToString(invoice.MintHash), tx.Address)
-	if err != nil {
-		log.Println("Error getting pending token balance total:", err)
-		return false, err
-	}
-
-	totalTokenBalance := 0
-	for _, tokenBalance := range tokenBalances {
-		totalTokenBalance += tokenBalance.Quantity
-	}
-
-	tokenBalanceAvailable := totalTokenBalance - pendingTokenBalanceTotal
-
 	if tokenBalanceAvailable >= int(invoice.Quantity) {
 		log.Println("Token balance is enough")
 
 		// Use transaction-aware UpsertPendingTokenBalance
-		err = p.store.UpsertPendingTokenBalanceWithTx(hex.EncodeToString(invoice.InvoiceHash), hex.EncodeToString(invoice.MintHash), int(invoice.Quantity), tx.Id, tx.Address, dbTx)
+		err = p.store.UpsertPendingTokenBalanceWithTx(hex.EncodeToString(invoice.InvoiceHash), hex.EncodeToString(invoice.MintHash), int(invoice.Quantity), tx.Id, tx.Address, tx.Height, tx.BlockHash, invoice.ExpiryHeight, dbTx)
 		if err != nil {
 			log.Println("Error inserting pending token balance:", err)
 			return false, err
@@ -154,14 +262,15 @@ func (p *InvoiceProcessor) EnsurePendingTokenBalance(tx store.OnChainTransaction
 	} else {
 		log.Println("Token balance is not enough")
 
-		// Remove onchain transaction within same transaction
-		_, err = dbTx.Exec("DELETE FROM onchain_transactions WHERE id = $1", tx.Id)
+		// Reject onchain transaction within same transaction
+		reason := fmt.Errorf("%w: seller %s has %d available, invoice %s reserves %d", store.ErrInsufficientTokenBalance, tx.Address, tokenBalanceAvailable, hex.EncodeToString(invoice.InvoiceHash), invoice.Quantity)
+		err = p.store.RejectOnChainTransactionWithTx(dbTx, tx, store.OnChainRejection_INSUFFICIENT_BALANCE, reason.Error())
 		if err != nil {
-			log.Println("Error removing onchain transaction:", err)
+			log.Println("Error rejecting onchain transaction:", err)
 			return false, err
 		}
 
-		// Commit the removal
+		// Commit the rejection
 		err = dbTx.Commit()
 		if err != nil {
 			return false, err
@@ -54,7 +54,7 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -64,7 +64,7 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 	mintTx := findInvoiceTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Create unconfirmed invoice
@@ -74,7 +74,7 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 		BuyerAddress:   support.GenerateDogecoinAddress(true),
 		MintHash:       mintHash,
 		Quantity:       int(quantity),
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  sellerAddress,
 	})
@@ -92,8 +92,8 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 		Quantity:    quantity,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -103,7 +103,7 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 	assert.Assert(t, invoiceTx != nil)
 
 	// Test Process
-	err = processor.Process(*invoiceTx)
+	err = processor.Process(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 
 	// Verify pending token balance was created
@@ -117,28 +117,6 @@ func TestInvoiceProcessorProcessSuccess(t *testing.T) {
 	assert.Equal(t, mintHash, pendingBalance.MintHash)
 }
 
-func TestInvoiceProcessorProcessInvalidProtobuf(t *testing.T) {
-	tokenStore := test_support.SetupTestDB()
-	processor := service.NewInvoiceProcessor(tokenStore)
-
-	// Create transaction with invalid protobuf data
-	invalidTx := store.OnChainTransaction{
-		Id:         "invalidTx",
-		TxHash:     "invalidTxHash",
-		ActionType: protocol.ACTION_INVOICE,
-		ActionData: []byte("invalid protobuf data"),
-		Address:    support.GenerateDogecoinAddress(true),
-		Values: map[string]interface{}{
-			"address": 50,
-		},
-	}
-
-	// Process should handle invalid protobuf gracefully
-	// The address check will fail since we can't unmarshal the data
-	err := processor.Process(invalidTx)
-	assert.Assert(t, err != nil, "Should fail with invalid protobuf")
-}
-
 func TestInvoiceProcessorProcessInsufficientTokenBalance(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	processor := service.NewInvoiceProcessor(tokenStore)
@@ -162,7 +140,7 @@ func TestInvoiceProcessorProcessInsufficientTokenBalance(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -172,7 +150,7 @@ func TestInvoiceProcessorProcessInsufficientTokenBalance(t *testing.T) {
 	mintTx := findInvoiceTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Create invoice transaction requesting more tokens than available
@@ -187,8 +165,8 @@ func TestInvoiceProcessorProcessInsufficientTokenBalance(t *testing.T) {
 		Quantity:    quantity,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -198,7 +176,7 @@ func TestInvoiceProcessorProcessInsufficientTokenBalance(t *testing.T) {
 	assert.Assert(t, invoiceTx != nil)
 
 	// Test Process - should succeed but not create pending balance
-	err = processor.Process(*invoiceTx)
+	err = processor.Process(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 
 	// Verify no pending token balance was created
@@ -238,7 +216,7 @@ func TestInvoiceProcessorProcessExistingPendingBalance(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -248,7 +226,7 @@ func TestInvoiceProcessorProcessExistingPendingBalance(t *testing.T) {
 	mintTx := findInvoiceTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Create existing pending token balance
@@ -267,8 +245,8 @@ func TestInvoiceProcessorProcessExistingPendingBalance(t *testing.T) {
 		Quantity:    quantity,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -278,7 +256,7 @@ func TestInvoiceProcessorProcessExistingPendingBalance(t *testing.T) {
 	assert.Assert(t, invoiceTx != nil)
 
 	// Test Process - should succeed and not create duplicate pending balance
-	err = processor.Process(*invoiceTx)
+	err = processor.Process(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 
 	// Verify pending token balance still exists with original values
@@ -316,7 +294,7 @@ func TestInvoiceProcessorProcessPartialTokenBalance(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -326,7 +304,7 @@ func TestInvoiceProcessorProcessPartialTokenBalance(t *testing.T) {
 	mintTx := findInvoiceTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Create existing pending balance (50 tokens)
@@ -345,8 +323,8 @@ func TestInvoiceProcessorProcessPartialTokenBalance(t *testing.T) {
 		Quantity:    quantity,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -356,7 +334,7 @@ func TestInvoiceProcessorProcessPartialTokenBalance(t *testing.T) {
 	assert.Assert(t, invoiceTx != nil)
 
 	// Test Process - should succeed but not create pending balance due to insufficient available tokens
-	err = processor.Process(*invoiceTx)
+	err = processor.Process(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 
 	// Verify no new pending token balance was created
@@ -401,7 +379,7 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceSuccess(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -411,7 +389,7 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceSuccess(t *testing.T) {
 	mintTx := findInvoiceTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Create invoice transaction
@@ -432,11 +410,11 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceSuccess(t *testing.T) {
 		ActionType: protocol.ACTION_INVOICE,
 		ActionData: encodedInvoiceMsg,
 		Address:    sellerAddress,
-		Values:     map[string]interface{}{sellerAddress: quantity},
+		Values:     store.KoinuValues{sellerAddress: int64(quantity)},
 	}
 
 	// Test EnsurePendingTokenBalance
-	hasPending, err := processor.EnsurePendingTokenBalance(invoiceTx)
+	hasPending, err := processor.EnsurePendingTokenBalance(invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 	assert.Assert(t, hasPending, "Should have pending token balance")
 
@@ -470,8 +448,8 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceInsufficientBalance(t *testing
 		Quantity:    quantity,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -481,7 +459,7 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceInsufficientBalance(t *testing
 	assert.Assert(t, invoiceTx != nil)
 
 	// Test EnsurePendingTokenBalance
-	hasPending, err := processor.EnsurePendingTokenBalance(*invoiceTx)
+	hasPending, err := processor.EnsurePendingTokenBalance(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 	assert.Assert(t, !hasPending, "Should not have pending token balance")
 
@@ -490,4 +468,196 @@ func TestInvoiceProcessorEnsurePendingTokenBalanceInsufficientBalance(t *testing
 	assert.NilError(t, err)
 	removedTx := findInvoiceTransactionById(txsAfter, invoiceTxId)
 	assert.Assert(t, removedTx == nil, "Transaction should be removed")
+
+	outcome, err := tokenStore.GetOnChainTransactionOutcome("invoiceTx")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_REJECTED)
+	assert.Equal(t, outcome.ReasonCode, store.OnChainRejection_INSUFFICIENT_BALANCE)
+	assert.DeepEqual(t, outcome.ActionData, encodedInvoiceMsg)
+}
+
+func TestInvoiceProcessorEnsurePendingTokenBalanceLockedFractions(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	processor := service.NewInvoiceProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	sellerAddress := support.GenerateDogecoinAddress(true)
+	invoiceHash := support.GenerateRandomHash()
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		LockupOptions: store.StringInterfaceMap{"unlock_block_height": float64(10)},
+	}, sellerAddress)
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertTokenBalance(sellerAddress, mintHash, 100)
+	assert.NilError(t, err)
+
+	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+	assert.NilError(t, err)
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	assert.NilError(t, err)
+
+	invoiceMsg := &protocol.OnChainInvoiceMessage{
+		InvoiceHash: invoiceHashBytes,
+		MintHash:    mintHashBytes,
+		Quantity:    10,
+	}
+	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
+	invoiceTxId, err := tokenStore.SaveOnChainTransactionWithBlockTime("invoiceTx", 2, "blockHash", time.Now().Unix(), 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	invoiceTx := findInvoiceTransactionById(txs, invoiceTxId)
+	assert.Assert(t, invoiceTx != nil)
+
+	hasPending, err := processor.EnsurePendingTokenBalance(*invoiceTx, invoiceMsg)
+	assert.NilError(t, err)
+	assert.Assert(t, !hasPending, "Locked fractions should not be reserved")
+
+	_, err = tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.ErrorContains(t, err, "no pending token balance found")
+}
+
+func TestInvoiceProcessorRejectsTradeViolatingRequirements(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	processor := service.NewInvoiceProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	sellerAddress := support.GenerateDogecoinAddress(true)
+	buyerAddress := support.GenerateDogecoinAddress(true)
+	invoiceHash := support.GenerateRandomHash()
+	quantity := int32(50)
+
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		Requirements: store.StringInterfaceMap{
+			"allowlist_enabled": true,
+			"allowlist":         []interface{}{sellerAddress},
+		},
+		TransactionHash: "mintTx",
+	})
+	assert.NilError(t, err)
+
+	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
+	encodedMintMsg, _ := proto.Marshal(mintMsg)
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.NilError(t, tokenStore.MatchUnconfirmedMint(*findInvoiceTransactionById(txs, mintTxId), mintMsg))
+
+	_, err = tokenStore.SaveUnconfirmedInvoice(&store.UnconfirmedInvoice{
+		Hash:           invoiceHash,
+		PaymentAddress: sellerAddress,
+		BuyerAddress:   buyerAddress,
+		MintHash:       mintHash,
+		Quantity:       int(quantity),
+		PriceKoinu:     100,
+		CreatedAt:      time.Now(),
+		SellerAddress:  sellerAddress,
+	})
+	assert.NilError(t, err)
+
+	invoiceHashBytes, _ := hex.DecodeString(invoiceHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	invoiceMsg := &protocol.OnChainInvoiceMessage{
+		InvoiceHash: invoiceHashBytes,
+		MintHash:    mintHashBytes,
+		Quantity:    quantity,
+	}
+	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	err = processor.Process(*findInvoiceTransactionById(txs, invoiceTxId), invoiceMsg)
+	assert.NilError(t, err)
+
+	// No reservation is made and the rejection is recorded
+	tx, _ := tokenStore.DB.Begin()
+	pendingBalance, _ := tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, tx)
+	tx.Rollback()
+	assert.Equal(t, "", pendingBalance.InvoiceHash)
+
+	rejections, err := tokenStore.GetTradeRejections("", invoiceHash, 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(rejections))
+	assert.Equal(t, store.TradeRejection_NOT_ALLOWLISTED, rejections[0].ReasonCode)
+	assert.Equal(t, buyerAddress, rejections[0].BuyerAddress)
+	assert.Equal(t, "invoiceTx", rejections[0].TransactionHash)
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Assert(t, findInvoiceTransactionById(txs, invoiceTxId) == nil)
+}
+
+func TestInvoiceProcessorRejectsInvoiceNotSentBySeller(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	processor := service.NewInvoiceProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	sellerAddress := support.GenerateDogecoinAddress(true)
+	senderAddress := support.GenerateDogecoinAddress(true)
+	invoiceHash := support.GenerateRandomHash()
+	quantity := int32(50)
+
+	// The sender holds fractions of the mint too, so the reservation itself would succeed
+	assert.NilError(t, tokenStore.UpsertTokenBalance(senderAddress, mintHash, 100))
+
+	_, err := tokenStore.SaveUnconfirmedInvoice(&store.UnconfirmedInvoice{
+		Hash:           invoiceHash,
+		PaymentAddress: sellerAddress,
+		BuyerAddress:   support.GenerateDogecoinAddress(true),
+		MintHash:       mintHash,
+		Quantity:       int(quantity),
+		PriceKoinu:     100,
+		CreatedAt:      time.Now(),
+		SellerAddress:  sellerAddress,
+	})
+	assert.NilError(t, err)
+
+	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+	assert.NilError(t, err)
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	assert.NilError(t, err)
+
+	invoiceMsg := &protocol.OnChainInvoiceMessage{
+		InvoiceHash: invoiceHashBytes,
+		MintHash:    mintHashBytes,
+		Quantity:    quantity,
+	}
+	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, senderAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
+	})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	invoiceTx := findInvoiceTransactionById(txs, invoiceTxId)
+	assert.Assert(t, invoiceTx != nil)
+
+	err = processor.Process(*invoiceTx, invoiceMsg)
+	assert.NilError(t, err)
+
+	_, err = tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.ErrorContains(t, err, "no pending token balance found")
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 0)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_SENDER_MISMATCH)
 }
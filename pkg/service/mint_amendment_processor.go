@@ -0,0 +1,69 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type MintAmendmentProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewMintAmendmentProcessor(store *store.TokenisationStore) *MintAmendmentProcessor {
+	return &MintAmendmentProcessor{store: store}
+}
+
+/*
+* Amendments are anchored by the mint owner (the address proven by the on chain transaction).
+* The anchor is kept until the signed amendment has been gossiped; invalid anchors
+* and amendments are rejected.
+ */
+func (p *MintAmendmentProcessor) Process(tx store.OnChainTransaction, message *protocol.OnChainMintAmendmentMessage) error {
+	amendmentHash := hex.EncodeToString(message.AmendmentHash)
+	mintHash := hex.EncodeToString(message.MintHash)
+
+	amendment, err := p.store.GetUnconfirmedMintAmendment(amendmentHash)
+	if errors.Is(err, store.ErrMintAmendmentNotFound) {
+		// Wait for the amendment to be gossiped
+		return err
+	}
+
+	if err == nil {
+		err = p.validate(tx, amendment, mintHash)
+	}
+
+	if err == nil {
+		err = p.store.MatchUnconfirmedMintAmendment(tx, message)
+	}
+
+	if err != nil {
+		log.Println("Mint amendment rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Matched mint amendment:", tx.TxHash)
+	return nil
+}
+
+func (p *MintAmendmentProcessor) validate(tx store.OnChainTransaction, amendment store.MintAmendment, mintHash string) error {
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return err
+	}
+
+	if mint.Id == "" {
+		return fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	if mint.OwnerAddress != tx.Address {
+		return fmt.Errorf("amendment sender %s is not the mint owner", tx.Address)
+	}
+
+	return store.ValidateMintAmendment(mint, amendment)
+}
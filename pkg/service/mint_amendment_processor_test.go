@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestMintAmendmentProcessorProcess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	owner := support.GenerateDogecoinAddress(true)
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", Description: "original", FractionCount: 100, PublicKey: pubHex}, owner)
+	assert.NilError(t, err)
+
+	body := store.MintAmendmentBody{MintHash: mintHash, Description: "amended", FeedURL: "https://example.com/feed", Timestamp: 1}
+	amendmentHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	amendmentHashBytes, _ := hex.DecodeString(amendmentHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainMintAmendmentMessage{AmendmentHash: amendmentHashBytes, MintHash: mintHashBytes}
+
+	// The anchor waits until the amendment has been gossiped
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_MINT_AMENDMENT, message, owner, store.KoinuValues{})
+	err = service.NewMintAmendmentProcessor(tokenStore).Process(tx, message)
+	assert.Assert(t, errors.Is(err, store.ErrMintAmendmentNotFound))
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+
+	err = tokenStore.SaveUnconfirmedMintAmendment(&store.MintAmendment{MintAmendmentBody: body, Hash: amendmentHash, PublicKey: pubHex, Signature: signature})
+	assert.NilError(t, err)
+
+	err = service.NewMintAmendmentProcessor(tokenStore).Process(tx, message)
+	assert.NilError(t, err)
+
+	amendments, err := tokenStore.GetMintAmendments(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(amendments), 1)
+	assert.Equal(t, amendments[0].Revision, 1)
+	assert.Equal(t, amendments[0].TransactionHash, tx.TxHash)
+
+	count, err = tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
+
+func TestMintAmendmentProcessorRejectsNonOwnerSender(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	owner := support.GenerateDogecoinAddress(true)
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100, PublicKey: pubHex}, owner)
+	assert.NilError(t, err)
+
+	body := store.MintAmendmentBody{MintHash: mintHash, Description: "amended", Timestamp: 1}
+	amendmentHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+	err = tokenStore.SaveUnconfirmedMintAmendment(&store.MintAmendment{MintAmendmentBody: body, Hash: amendmentHash, PublicKey: pubHex, Signature: signature})
+	assert.NilError(t, err)
+
+	amendmentHashBytes, _ := hex.DecodeString(amendmentHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainMintAmendmentMessage{AmendmentHash: amendmentHashBytes, MintHash: mintHashBytes}
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_MINT_AMENDMENT, message, support.GenerateDogecoinAddress(true), store.KoinuValues{})
+
+	err = service.NewMintAmendmentProcessor(tokenStore).Process(tx, message)
+	assert.ErrorContains(t, err, "not the mint owner")
+
+	amendments, err := tokenStore.GetMintAmendments(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(amendments), 0)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
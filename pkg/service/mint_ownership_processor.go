@@ -0,0 +1,74 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+type MintOwnershipTransferProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewMintOwnershipTransferProcessor(store *store.TokenisationStore) *MintOwnershipTransferProcessor {
+	return &MintOwnershipTransferProcessor{store: store}
+}
+
+/*
+* Ownership transfers are anchored by the current mint owner (the address proven by the on chain transaction).
+* The anchor is kept until the transfer has been gossiped and accepted by the new owner;
+* invalid anchors and transfers are rejected.
+ */
+func (p *MintOwnershipTransferProcessor) Process(tx store.OnChainTransaction, message *protocol.OnChainMintOwnershipTransferMessage) error {
+	transferHash := hex.EncodeToString(message.TransferHash)
+	mintHash := hex.EncodeToString(message.MintHash)
+
+	transfer, err := p.store.GetUnconfirmedMintOwnershipTransfer(transferHash)
+	if errors.Is(err, store.ErrMintOwnershipTransferNotFound) {
+		// Wait for the transfer to be gossiped
+		return err
+	}
+
+	if err == nil && !transfer.Accepted() {
+		// Wait for the new owner to accept the transfer
+		return fmt.Errorf("%w: %s", store.ErrMintOwnershipTransferNotAccepted, transferHash)
+	}
+
+	if err == nil {
+		err = p.validate(tx, transfer, mintHash)
+	}
+
+	if err == nil {
+		err = p.store.ConfirmMintOwnershipTransfer(tx, message)
+	}
+
+	if err != nil {
+		log.Println("Mint ownership transfer rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Transferred mint ownership:", tx.TxHash)
+	return nil
+}
+
+func (p *MintOwnershipTransferProcessor) validate(tx store.OnChainTransaction, transfer store.MintOwnershipTransfer, mintHash string) error {
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return err
+	}
+
+	if mint.Id == "" {
+		return fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	if mint.OwnerAddress != tx.Address {
+		return fmt.Errorf("ownership transfer sender %s is not the mint owner", tx.Address)
+	}
+
+	return store.ValidateMintOwnershipTransfer(mint, transfer)
+}
@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestMintOwnershipTransferProcessorProcess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	ownerPrivHex, ownerPubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	newPrivHex, newPubHex, newAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100, PublicKey: ownerPubHex}, ownerAddress)
+	assert.NilError(t, err)
+
+	body := store.MintOwnershipTransferBody{MintHash: mintHash, NewOwnerAddress: newAddress, NewOwnerPublicKey: newPubHex, Timestamp: 1}
+	transferHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	ownerSignature, err := doge.SignPayload(body, ownerPrivHex, ownerPubHex)
+	assert.NilError(t, err)
+
+	transfer := store.MintOwnershipTransfer{MintOwnershipTransferBody: body, Hash: transferHash, OwnerPublicKey: ownerPubHex, OwnerSignature: ownerSignature}
+	err = tokenStore.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	assert.NilError(t, err)
+
+	transferHashBytes, _ := hex.DecodeString(transferHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainMintOwnershipTransferMessage{TransferHash: transferHashBytes, MintHash: mintHashBytes}
+
+	// The anchor waits until the new owner has accepted
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_MINT_OWNERSHIP_TRANSFER, message, ownerAddress, store.KoinuValues{})
+	err = service.NewMintOwnershipTransferProcessor(tokenStore).Process(tx, message)
+	assert.Assert(t, errors.Is(err, store.ErrMintOwnershipTransferNotAccepted))
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 1)
+
+	transfer.NewOwnerSignature, err = doge.SignPayload(body, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+	err = tokenStore.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	assert.NilError(t, err)
+
+	err = service.NewMintOwnershipTransferProcessor(tokenStore).Process(tx, message)
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.OwnerAddress, newAddress)
+	assert.Equal(t, mint.PublicKey, newPubHex)
+}
+
+func TestMintOwnershipTransferProcessorRejectsNonOwnerSender(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	ownerPrivHex, ownerPubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	newPrivHex, newPubHex, newAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100, PublicKey: ownerPubHex}, ownerAddress)
+	assert.NilError(t, err)
+
+	body := store.MintOwnershipTransferBody{MintHash: mintHash, NewOwnerAddress: newAddress, NewOwnerPublicKey: newPubHex, Timestamp: 1}
+	transferHash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	ownerSignature, err := doge.SignPayload(body, ownerPrivHex, ownerPubHex)
+	assert.NilError(t, err)
+	newOwnerSignature, err := doge.SignPayload(body, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+
+	err = tokenStore.SaveUnconfirmedMintOwnershipTransfer(&store.MintOwnershipTransfer{MintOwnershipTransferBody: body, Hash: transferHash, OwnerPublicKey: ownerPubHex, OwnerSignature: ownerSignature, NewOwnerSignature: newOwnerSignature})
+	assert.NilError(t, err)
+
+	transferHashBytes, _ := hex.DecodeString(transferHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+
+	// The new owner cannot anchor the transfer on behalf of the current owner
+	message := &protocol.OnChainMintOwnershipTransferMessage{TransferHash: transferHashBytes, MintHash: mintHashBytes}
+	tx := saveOnChainTransaction(t, tokenStore, support.GenerateRandomHash(), protocol.ACTION_MINT_OWNERSHIP_TRANSFER, message, newAddress, store.KoinuValues{})
+
+	err = service.NewMintOwnershipTransferProcessor(tokenStore).Process(tx, message)
+	assert.ErrorContains(t, err, "not the mint owner")
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.OwnerAddress, ownerAddress)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
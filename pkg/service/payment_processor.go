@@ -1,58 +1,135 @@
 package service
 
 import (
-	"fmt"
+	"errors"
 	"log"
 
-	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 )
 
-const MIN_CONFIRMATIONS_REQUIRED = 6
-
 type PaymentProcessor struct {
-	store      *store.TokenisationStore
-	dogeClient *doge.RpcClient
+	store *store.TokenisationStore
 }
 
-func NewPaymentProcessor(store *store.TokenisationStore, dogeClient *doge.RpcClient) *PaymentProcessor {
-	return &PaymentProcessor{store: store, dogeClient: dogeClient}
+func NewPaymentProcessor(store *store.TokenisationStore) *PaymentProcessor {
+	return &PaymentProcessor{store: store}
 }
 
-func (p *PaymentProcessor) Process(tx store.OnChainTransaction) error {
-	invoice, err := p.store.MatchPayment(tx)
+func (p *PaymentProcessor) Process(tx store.OnChainTransaction, payment *protocol.OnChainPaymentMessage) error {
+	invoice, err := p.store.MatchPayment(tx, payment)
+	if errors.Is(err, store.ErrSenderMismatch) {
+		log.Println("Payment rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_SENDER_MISMATCH, err.Error())
+	}
+	if errors.Is(err, store.ErrPaymentMismatch) {
+		log.Println("Payment rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_PAYMENT_MISMATCH, err.Error())
+	}
+	if errors.Is(err, store.ErrInvoiceExpired) {
+		log.Println("Payment rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_INVOICE_EXPIRED, err.Error())
+	}
+	if errors.Is(err, store.ErrInvoiceCancelled) {
+		log.Println("Payment rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_INVOICE_CANCELLED, err.Error())
+	}
 	if err != nil {
 		log.Println("Match Payment", err)
 		return err
 	}
 
-	if tx.BlockHash == "" {
-		blockHash, err := p.dogeClient.GetBlockHash(int(tx.Height))
+	// The seller's balance may have changed since the invoice was reserved, so the requirements are checked at settlement
+	if invoice.SettledBy(tx.Values[invoice.PayableAddress()]) {
+		violation, err := p.checkTradeRequirements(tx, invoice)
 		if err != nil {
-			log.Println("GetBlockHash", err)
 			return err
 		}
 
-		tx.BlockHash = blockHash
+		if violation != nil {
+			log.Println("Payment rejected:", violation)
+			return p.store.RejectTrade(tx, invoice.Hash, invoice.MintHash, invoice.SellerAddress, invoice.BuyerAddress, invoice.Quantity, violation)
+		}
 	}
 
-	blockHeader, err := p.dogeClient.GetBlockHeader(tx.BlockHash)
+	err = p.store.ProcessPayment(tx, invoice)
 	if err != nil {
-		log.Println("GetBlockHeader", err)
+		log.Println("ProcessPayment:", err)
 		return err
 	}
 
-	if blockHeader.Confirmations < MIN_CONFIRMATIONS_REQUIRED {
-		log.Println("Minimum confirmations not met:", err)
-		return fmt.Errorf("Minimum confirmations not met: %d < %d", blockHeader.Confirmations, MIN_CONFIRMATIONS_REQUIRED)
+	log.Printf("Matched payment %s of %d koinu to invoice %s", tx.TxHash, tx.Values[invoice.PayableAddress()], invoice.Hash)
+	return nil
+}
+
+/*
+* ProcessBatch settles each invoice of a batch payment on its own: an invoice that is rejected,
+* whether for its seller's output or its trade requirements, leaves the others to be paid.
+* The outcome of every invoice is recorded with the batch.
+ */
+func (p *PaymentProcessor) ProcessBatch(tx store.OnChainTransaction, batch *protocol.OnChainBatchPaymentMessage) error {
+	items, err := p.store.MatchBatchPayment(tx, batch)
+	if errors.Is(err, store.ErrPaymentMismatch) {
+		log.Println("Batch payment rejected:", err)
+		return p.store.RejectOnChainTransaction(tx, store.OnChainRejection_PAYMENT_MISMATCH, err.Error())
+	}
+	if err != nil {
+		log.Println("Match Batch Payment", err)
+		return err
 	}
 
-	err = p.store.ProcessPayment(tx, invoice)
+	for idx := range items {
+		item := &items[idx]
+		if item.Rejected() || !item.Invoice.SettledBy(item.AmountKoinu) {
+			continue
+		}
+
+		violation, err := p.checkTradeRequirements(tx, item.Invoice)
+		if err != nil {
+			return err
+		}
+
+		if violation != nil {
+			item.Reject(violation.Code, violation.Reason)
+			item.Violation = violation
+		}
+	}
+
+	err = p.store.ProcessBatchPayment(tx, items)
 	if err != nil {
-		log.Println("ProcessPayment:", err)
+		log.Println("ProcessBatchPayment:", err)
 		return err
 	}
 
-	log.Println("Matched payment:", tx.TxHash)
+	for _, item := range items {
+		if item.Rejected() {
+			log.Printf("Batch payment %s rejected for invoice %s: %s", tx.TxHash, item.InvoiceHash, item.Reason)
+		} else {
+			log.Printf("Matched batch payment %s of %d koinu to invoice %s", tx.TxHash, item.AmountKoinu, item.InvoiceHash)
+		}
+	}
+
 	return nil
 }
+
+// checkTradeRequirements returns the violation if settling the invoice would break the mint's trade requirements.
+func (p *PaymentProcessor) checkTradeRequirements(tx store.OnChainTransaction, invoice store.Invoice) (*store.TradeViolation, error) {
+	mint, err := p.store.GetMintByHash(invoice.MintHash)
+	if err != nil {
+		log.Println("GetMintByHash", err)
+		return nil, err
+	}
+
+	err = p.store.CheckTradeRequirements(mint, invoice.SellerAddress, invoice.BuyerAddress, invoice.Quantity, tx)
+	if err != nil {
+		var violation *store.TradeViolation
+		if !errors.As(err, &violation) {
+			log.Println("CheckTradeRequirements", err)
+			return nil, err
+		}
+
+		return violation, nil
+	}
+
+	return nil, nil
+}
@@ -1,92 +1,444 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/doge"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
 )
 
+// PROCESS_BATCH_SIZE is the number of transactions read per query while processing.
+const PROCESS_BATCH_SIZE = 100
+
+// PROCESS_RETRY_INTERVAL is how often transactions waiting for off chain data are retried when no block arrives.
+const PROCESS_RETRY_INTERVAL = 10 * time.Second
+
+/*
+* AWAITING_DATA_EXPIRY_BLOCKS is how many blocks are followed past a transaction waiting for off chain data
+* before it expires. The depth is fixed so every node that has not received the data passes it at the same block.
+ */
+const AWAITING_DATA_EXPIRY_BLOCKS = 1440
+
+// ErrAwaitingConfirmations is returned while a transaction has fewer confirmations than its action requires.
+var ErrAwaitingConfirmations = errors.New("awaiting confirmations")
+
+// ErrAwaitingData is returned while a transaction waits for off chain data, such as a gossiped invoice.
+var ErrAwaitingData = errors.New("awaiting off chain data")
+
 type FractalEngineProcessor struct {
 	store      *store.TokenisationStore
 	dogeClient *doge.RpcClient
+	cfg        *config.Config
+	handlers   map[protocol.ActionVersion]ActionHandler
 	Running    bool
+	blocks     chan struct{}
+	context    context.Context
+	cancel     context.CancelFunc
+}
+
+/*
+* ActionHandler processes an on chain transaction of the (action, version) it is registered for,
+* given its payload decoded with the layout registered in protocol for the same (action, version).
+ */
+type ActionHandler func(tx store.OnChainTransaction, message proto.Message) error
+
+// handle adapts a processor of one message layout to an ActionHandler.
+func handle[M proto.Message](process func(tx store.OnChainTransaction, message M) error) ActionHandler {
+	return func(tx store.OnChainTransaction, message proto.Message) error {
+		typed, ok := message.(M)
+		if !ok {
+			return fmt.Errorf("unexpected %T payload for %s version %d", message, protocol.ActionName(tx.ActionType), tx.ActionVersion)
+		}
+
+		return process(tx, typed)
+	}
+}
+
+func NewFractalEngineProcessor(store *store.TokenisationStore, dogeClient *doge.RpcClient, cfg *config.Config) *FractalEngineProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &FractalEngineProcessor{store: store, dogeClient: dogeClient, cfg: cfg, blocks: make(chan struct{}, 1), context: ctx, cancel: cancel}
+	p.registerHandlers()
+	return p
+}
+
+/*
+* registerHandlers maps every (action, version) the engine processes to its handler.
+* Handlers for a new message layout are registered under the new version next to the
+* existing ones, so both layouts are processed while the new one rolls out.
+ */
+func (p *FractalEngineProcessor) registerHandlers() {
+	p.handlers = map[protocol.ActionVersion]ActionHandler{
+		{Action: protocol.ACTION_MINT, Version: protocol.DEFAULT_VERSION}:                    handle(p.processMint),
+		{Action: protocol.ACTION_PAYMENT, Version: protocol.DEFAULT_VERSION}:                 handle(NewPaymentProcessor(p.store).Process),
+		{Action: protocol.ACTION_BATCH_PAYMENT, Version: protocol.DEFAULT_VERSION}:           handle(NewPaymentProcessor(p.store).ProcessBatch),
+		{Action: protocol.ACTION_INVOICE, Version: protocol.DEFAULT_VERSION}:                 handle(NewInvoiceProcessor(p.store).Process),
+		{Action: protocol.ACTION_INVOICE_CANCELLATION, Version: protocol.DEFAULT_VERSION}:    handle(NewInvoiceCancellationProcessor(p.store).Process),
+		{Action: protocol.ACTION_TRANSFER, Version: protocol.DEFAULT_VERSION}:                handle(NewTransferProcessor(p.store).Process),
+		{Action: protocol.ACTION_BURN, Version: protocol.DEFAULT_VERSION}:                    handle(NewBurnProcessor(p.store).Process),
+		{Action: protocol.ACTION_ALLOWLIST_ENTRY, Version: protocol.DEFAULT_VERSION}:         handle(NewAllowlistEntryProcessor(p.store).Process),
+		{Action: protocol.ACTION_DISTRIBUTION, Version: protocol.DEFAULT_VERSION}:            handle(NewDistributionProcessor(p.store).Process),
+		{Action: protocol.ACTION_DISTRIBUTION_PAYOUT, Version: protocol.DEFAULT_VERSION}:     handle(NewDistributionPayoutProcessor(p.store).Process),
+		{Action: protocol.ACTION_MINT_AMENDMENT, Version: protocol.DEFAULT_VERSION}:          handle(NewMintAmendmentProcessor(p.store).Process),
+		{Action: protocol.ACTION_ASSET_MANAGER_ROTATION, Version: protocol.DEFAULT_VERSION}:  handle(NewAssetManagerRotationProcessor(p.store).Process),
+		{Action: protocol.ACTION_MINT_OWNERSHIP_TRANSFER, Version: protocol.DEFAULT_VERSION}: handle(NewMintOwnershipTransferProcessor(p.store).Process),
+	}
 }
 
-func NewFractalEngineProcessor(store *store.TokenisationStore, dogeClient *doge.RpcClient) *FractalEngineProcessor {
-	return &FractalEngineProcessor{store: store, dogeClient: dogeClient}
+// Handles reports whether a handler is registered for the (action, version).
+func (p *FractalEngineProcessor) Handles(action uint8, version uint8) bool {
+	_, ok := p.handlers[protocol.ActionVersion{Action: action, Version: version}]
+	return ok
 }
 
+/*
+* Process runs one pass over the transactions waiting to be processed, in (block_height, transaction_number) order.
+* The pass stops at the first transaction still waiting for confirmations or off chain data: applying the
+* transactions after it first would settle trades against balances, allowlists and asset managers that it has
+* yet to change, and nodes that received gossip at different times would disagree. A transaction waiting for
+* off chain data expires once AWAITING_DATA_EXPIRY_BLOCKS blocks have been followed past it. Paging by position
+* means transactions removed during the pass do not shift the ones after them. Invoices are expired at each block
+* boundary before the transactions of the block are applied, so releases take effect at the same point on every node.
+ */
 func (p *FractalEngineProcessor) Process() error {
-	offset := 0
-	limit := 100
+	var blockHeight int64 = -1
+	transactionNumber := -1
 
 	for {
-		txs, err := p.store.GetOnChainTransactions(offset, limit)
+		txs, err := p.store.GetOnChainTransactionsAfter(blockHeight, transactionNumber, PROCESS_BATCH_SIZE)
 		if err != nil {
 			return err
 		}
 
 		if len(txs) == 0 {
-			break
+			// Every block followed so far has been processed
+			chainHeight, _, _, err := p.store.GetChainPosition()
+			if err != nil {
+				return err
+			}
+
+			return p.expireInvoices(chainHeight)
 		}
 
 		for _, tx := range txs {
-			fmt.Println("Processing transaction:", tx.TxHash)
-
-			if tx.ActionType == protocol.ACTION_MINT {
-				if p.store.MatchMint(tx) {
-					continue
-				}
-				err = p.store.MatchUnconfirmedMint(tx)
-				if err == nil {
-					log.Println("Matched mint:", tx.TxHash)
-				}
-			} else if tx.ActionType == protocol.ACTION_PAYMENT {
-				paymentProcessor := NewPaymentProcessor(p.store, p.dogeClient)
-				err = paymentProcessor.Process(tx)
-				if err != nil {
-					log.Println("Error processing payment:", err)
-				}
-			} else if tx.ActionType == protocol.ACTION_INVOICE {
-				invoiceProcessor := NewInvoiceProcessor(p.store)
-				err = invoiceProcessor.Process(tx)
+			if tx.Height > blockHeight {
+				err = p.expireInvoices(tx.Height)
 				if err != nil {
-					log.Println("Error processing invoice:", err)
+					return err
 				}
+			}
 
+			err = p.ProcessTransaction(tx)
+			if errors.Is(err, ErrAwaitingConfirmations) {
+				log.Printf("Holding transactions after %s until it is confirmed: %v", tx.TxHash, err)
+				return nil
 			}
+			if errors.Is(err, ErrAwaitingData) {
+				expired, expireErr := p.expireAwaitingData(tx, err)
+				if expireErr != nil {
+					return expireErr
+				}
+				if !expired {
+					log.Printf("Holding transactions after %s until its data arrives: %v", tx.TxHash, err)
+					return nil
+				}
+			} else if err != nil {
+				log.Printf("Error processing %s %s: %v", protocol.ActionName(tx.ActionType), tx.TxHash, err)
+			}
+
+			blockHeight, transactionNumber = tx.Height, tx.TransactionNumber
+		}
+	}
+}
+
+/*
+* ProcessTransaction processes the transaction exactly once and records its outcome. A transaction that
+* already has a final outcome is a copy ingested again, for instance after a restart without a persisted
+* chain position, and is removed without being processed. While the transaction waits for confirmations or
+* off chain data it is recorded as awaiting_data with the reason, and ErrAwaitingConfirmations or ErrAwaitingData
+* is returned; once its handler has removed it, it is recorded as applied, or rejected with the error the handler returned.
+ */
+func (p *FractalEngineProcessor) ProcessTransaction(tx store.OnChainTransaction) error {
+	outcome, err := p.store.GetOnChainTransactionOutcome(tx.TxHash)
+	if err == nil && outcome.IsFinal() {
+		log.Printf("Removing transaction %s, already %s", tx.TxHash, outcome.Status)
+		return p.store.RemoveOnChainTransaction(tx.Id)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	handlerErr := p.dispatch(tx)
+
+	pending, err := p.store.HasOnChainTransaction(tx.Id)
+	if err != nil {
+		return err
+	}
+
+	if pending {
+		err = p.recordAwaiting(tx, handlerErr)
+		if err != nil {
+			log.Println("Error recording onchain transaction outcome:", err)
+			return err
+		}
+
+		if errors.Is(handlerErr, ErrAwaitingConfirmations) {
+			return handlerErr
+		}
+		if handlerErr != nil {
+			return fmt.Errorf("%w: %w", ErrAwaitingData, handlerErr)
+		}
+
+		return ErrAwaitingData
+	}
+
+	// A rejection has recorded its outcome with the transaction, which is kept
+	if handlerErr != nil {
+		err = p.store.SaveOnChainTransactionOutcome(tx, store.OnChainOutcome_REJECTED, rejectionCode(handlerErr), handlerErr.Error())
+	} else {
+		err = p.store.SaveOnChainTransactionOutcome(tx, store.OnChainOutcome_APPLIED, "", "")
+	}
+	if err != nil {
+		log.Println("Error recording onchain transaction outcome:", err)
+		return err
+	}
+
+	return handlerErr
+}
+
+// recordAwaiting records why the transaction was passed over, so it can be followed through the API.
+func (p *FractalEngineProcessor) recordAwaiting(tx store.OnChainTransaction, handlerErr error) error {
+	reasonCode := store.OnChainAwaiting_DATA
+	reason := "awaiting off chain data"
+
+	if errors.Is(handlerErr, ErrAwaitingConfirmations) {
+		reasonCode = store.OnChainAwaiting_CONFIRMATIONS
+	}
+	if handlerErr != nil {
+		reason = handlerErr.Error()
+	}
+
+	return p.store.SaveAwaitingOnChainTransaction(tx, reasonCode, reason)
+}
+
+/*
+* expireInvoices releases the reservations of invoices whose expiry height is below the block height. Payments
+* made up to the expiry height are in earlier blocks, so they have been processed, or hold the pass while they
+* wait for their confirmations.
+ */
+func (p *FractalEngineProcessor) expireInvoices(blockHeight int64) error {
+	expired, err := p.store.ExpireInvoices(blockHeight)
+	if err != nil {
+		log.Println("Error expiring invoices:", err)
+		return err
+	}
+
+	if expired > 0 {
+		log.Printf("Expired %d invoices below height %d", expired, blockHeight)
+	}
+
+	return nil
+}
+
+/*
+* expireAwaitingData records a transaction still waiting for off chain data as expired once the chain has been
+* followed AWAITING_DATA_EXPIRY_BLOCKS blocks past it, so the transactions after it are no longer held.
+ */
+func (p *FractalEngineProcessor) expireAwaitingData(tx store.OnChainTransaction, reason error) (bool, error) {
+	chainHeight, _, _, err := p.store.GetChainPosition()
+	if err != nil {
+		return false, err
+	}
+
+	if chainHeight < tx.Height+AWAITING_DATA_EXPIRY_BLOCKS {
+		return false, nil
+	}
+
+	log.Printf("Expiring transaction %s: %v", tx.TxHash, reason)
+
+	err = p.store.ExpireOnChainTransaction(tx, fmt.Sprintf("not resolved within %d blocks: %v", AWAITING_DATA_EXPIRY_BLOCKS, reason))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+/*
+* dispatch hands the transaction and its decoded payload to the handler registered for its (action, version).
+* Transactions with an action or version the engine does not support, or whose payload does not
+* decode with the registered layout, are recorded as rejected rather than left to be retried.
+* Transactions are only handed to their handler once they have the confirmations their action requires.
+ */
+func (p *FractalEngineProcessor) dispatch(tx store.OnChainTransaction) error {
+	handler, ok := p.handlers[protocol.ActionVersion{Action: tx.ActionType, Version: tx.ActionVersion}]
+	if !ok {
+		err := protocol.CheckActionVersion(tx.ActionType, tx.ActionVersion)
+		if err == nil {
+			err = fmt.Errorf("%w: no handler for %s version %d", protocol.ErrUnsupportedVersion, protocol.ActionName(tx.ActionType), tx.ActionVersion)
+		}
+
+		reasonCode := store.OnChainRejection_UNSUPPORTED_ACTION
+		if errors.Is(err, protocol.ErrUnsupportedVersion) {
+			reasonCode = store.OnChainRejection_UNSUPPORTED_VERSION
 		}
 
-		offset += limit
+		return p.reject(tx, reasonCode, err)
+	}
 
-		time.Sleep(5 * time.Second)
+	message, err := protocol.DecodeOnChainMessage(tx.ActionType, tx.ActionVersion, tx.ActionData)
+	if err != nil {
+		return p.reject(tx, store.OnChainRejection_MALFORMED, err)
+	}
+
+	if err := p.checkConfirmations(&tx); err != nil {
+		return err
+	}
+
+	return handler(tx, message)
+}
+
+/*
+* checkConfirmations returns ErrAwaitingConfirmations until the block of the transaction is buried under
+* the confirmation depth configured for its action. The confirmations seen are recorded on the transaction
+* so its progress can be followed through the API.
+ */
+func (p *FractalEngineProcessor) checkConfirmations(tx *store.OnChainTransaction) error {
+	requiredConfirmations := p.cfg.ConfirmationDepth(tx.ActionType)
+	if requiredConfirmations <= 0 {
+		return nil
+	}
+
+	if tx.BlockHash == "" {
+		blockHash, err := p.dogeClient.GetBlockHash(int(tx.Height))
+		if err != nil {
+			log.Println("GetBlockHash", err)
+			return err
+		}
+
+		tx.BlockHash = blockHash
+	}
+
+	blockHeader, err := p.dogeClient.GetBlockHeader(tx.BlockHash)
+	if err != nil {
+		log.Println("GetBlockHeader", err)
+		return err
+	}
+
+	confirmations := max(int(blockHeader.Confirmations), 0)
+
+	err = p.store.SetOnChainTransactionConfirmations(tx.Id, confirmations, requiredConfirmations)
+	if err != nil {
+		log.Println("Error recording confirmations:", err)
+		return err
+	}
+
+	if confirmations < requiredConfirmations {
+		return fmt.Errorf("%w: %s has %d/%d confirmations", ErrAwaitingConfirmations, tx.TxHash, confirmations, requiredConfirmations)
 	}
 
 	return nil
 }
 
+func (p *FractalEngineProcessor) reject(tx store.OnChainTransaction, reasonCode string, reason error) error {
+	log.Printf("Rejecting transaction %s: %v", tx.TxHash, reason)
+
+	err := p.store.RejectOnChainTransaction(tx, reasonCode, reason.Error())
+	if err != nil {
+		log.Println("Error rejecting onchain transaction:", err)
+		return err
+	}
+
+	return reason
+}
+
+/*
+* rejectionCode returns the reason code for a transaction that failed the checks of its handler.
+* Failures without a more specific code are recorded as INVALID.
+ */
+func rejectionCode(err error) string {
+	switch {
+	case errors.Is(err, store.ErrInsufficientTokenBalance):
+		return store.OnChainRejection_INSUFFICIENT_BALANCE
+	case errors.Is(err, store.ErrSenderMismatch):
+		return store.OnChainRejection_SENDER_MISMATCH
+	default:
+		return store.OnChainRejection_INVALID
+	}
+}
+
+// rejectInvalid records a transaction that failed the checks of its handler as rejected and returns the failure.
+func rejectInvalid(s *store.TokenisationStore, tx store.OnChainTransaction, reason error) error {
+	err := s.RejectOnChainTransaction(tx, rejectionCode(reason), reason.Error())
+	if err != nil {
+		log.Println("Error rejecting onchain transaction:", err)
+		return err
+	}
+
+	return reason
+}
+
+func (p *FractalEngineProcessor) processMint(tx store.OnChainTransaction, mint *protocol.OnChainMintMessage) error {
+	if p.store.MatchMint(tx, mint) {
+		return nil
+	}
+
+	err := p.store.MatchUnconfirmedMint(tx, mint)
+	if errors.Is(err, store.ErrSenderMismatch) {
+		return p.reject(tx, store.OnChainRejection_SENDER_MISMATCH, err)
+	}
+	if err == nil {
+		log.Println("Matched mint:", tx.TxHash)
+	}
+
+	return err
+}
+
+/*
+* Start processes the waiting transactions each time the follower hands over a block, and at
+* PROCESS_RETRY_INTERVAL for transactions waiting for off chain data such as gossiped invoices.
+ */
 func (p *FractalEngineProcessor) Start() {
 	p.Running = true
 
-	for {
-		if !p.Running {
-			break
-		}
+	ticker := time.NewTicker(PROCESS_RETRY_INTERVAL)
+	defer ticker.Stop()
 
+	for {
 		err := p.Process()
 		if err != nil {
 			log.Println("Error processing:", err)
 		}
 
-		time.Sleep(3 * time.Second)
+		select {
+		case <-p.context.Done():
+			return
+		case <-p.blocks:
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+* HandleBlock is called by the follower once the transactions of a block have been saved. Blocks handed over
+* while a pass is running are taken up by the next pass, which processes every block saved so far in order.
+ */
+func (p *FractalEngineProcessor) HandleBlock(blockHeight int64) {
+	select {
+	case p.blocks <- struct{}{}:
+	default:
 	}
 }
 
 func (p *FractalEngineProcessor) Stop() {
 	fmt.Println("Stopping processor")
+	p.cancel()
 	p.Running = false
 }
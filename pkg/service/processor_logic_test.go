@@ -2,11 +2,13 @@ package service_test
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 
 	"dogecoin.org/fractal-engine/internal/test/support"
 	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/service"
 	"dogecoin.org/fractal-engine/pkg/store"
@@ -18,7 +20,7 @@ func TestProcessEmptyDatabase(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Process with empty database should complete without error
 	err := processor.Process()
@@ -31,7 +33,7 @@ func TestProcessMintTransactionMatched(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Create a mint that will be matched
 	mintHash := support.GenerateRandomHash()
@@ -54,7 +56,7 @@ func TestProcessMintTransactionMatched(t *testing.T) {
 	}
 
 	// Create the on-chain transaction
-	_, err = tokenStore.SaveOnChainTransaction("txHash001", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txHash001", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{
 		"ownerAddress": 100,
 	})
 	if err != nil {
@@ -90,7 +92,7 @@ func TestProcessMintTransactionNotMatched(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Create an on-chain mint transaction without unconfirmed mint
 	mintHash := support.GenerateRandomHash()
@@ -99,7 +101,7 @@ func TestProcessMintTransactionNotMatched(t *testing.T) {
 	}
 	encodedMsg, _ := proto.Marshal(mintMsg)
 
-	_, err := tokenStore.SaveOnChainTransaction("txHash002", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", map[string]interface{}{
+	_, err := tokenStore.SaveOnChainTransaction("txHash002", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{
 		"ownerAddress": 100,
 	})
 	if err != nil {
@@ -127,7 +129,7 @@ func TestProcessPaymentTransaction(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 	buyerAddress := support.GenerateDogecoinAddress(true)
 	sellerAddress := support.GenerateDogecoinAddress(true)
 
@@ -151,7 +153,7 @@ func TestProcessPaymentTransaction(t *testing.T) {
 	// Create and process mint transaction
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	if err != nil {
@@ -166,7 +168,7 @@ func TestProcessPaymentTransaction(t *testing.T) {
 		BuyerAddress:   buyerAddress,
 		MintHash:       mintHash,
 		Quantity:       50,
-		Price:          100,
+		PriceKoinu:     100,
 		SellerAddress:  sellerAddress,
 	})
 	if err != nil {
@@ -186,7 +188,7 @@ func TestProcessPaymentTransaction(t *testing.T) {
 	}
 
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	_, err = tokenStore.SaveOnChainTransaction("txInvoice", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txInvoice", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 50,
 	})
 	if err != nil {
@@ -199,7 +201,7 @@ func TestProcessPaymentTransaction(t *testing.T) {
 		Hash: invoiceHash,
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
-	_, err = tokenStore.SaveOnChainTransaction("txPayment", 3, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txPayment", 3, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		sellerAddress: 5000,
 	})
 	if err != nil {
@@ -231,7 +233,7 @@ func TestProcessInvoiceTransaction(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Setup: Create a mint first
 	mintHash := support.GenerateRandomHash()
@@ -253,7 +255,7 @@ func TestProcessInvoiceTransaction(t *testing.T) {
 	// Create and process mint transaction
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, ownerAddress, map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, ownerAddress, store.KoinuValues{
 		ownerAddress: 100,
 	})
 	if err != nil {
@@ -274,7 +276,7 @@ func TestProcessInvoiceTransaction(t *testing.T) {
 		Quantity:    30,
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	_, err = tokenStore.SaveOnChainTransaction("txInvoice", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, ownerAddress, map[string]interface{}{
+	_, err = tokenStore.SaveOnChainTransaction("txInvoice", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, ownerAddress, store.KoinuValues{
 		ownerAddress: 30,
 	})
 	if err != nil {
@@ -305,7 +307,7 @@ func TestProcessPagination(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Create 150 transactions to test pagination (limit is 100)
 	for i := 0; i < 150; i++ {
@@ -314,7 +316,7 @@ func TestProcessPagination(t *testing.T) {
 		encodedMsg, _ := proto.Marshal(mintMsg)
 
 		txHash := fmt.Sprintf("tx%d", i)
-		_, err := tokenStore.SaveOnChainTransaction(txHash, int64(i+1), "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", map[string]interface{}{
+		_, err := tokenStore.SaveOnChainTransaction(txHash, int64(i+1), "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{
 			"ownerAddress": 1,
 		})
 		if err != nil {
@@ -343,10 +345,10 @@ func TestProcessUnknownActionType(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
-	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
 
 	// Create transaction with unknown action type (use a valid uint8 value)
-	_, err := tokenStore.SaveOnChainTransaction("txUnknown", 1, "blockHash", 1, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", map[string]interface{}{
+	_, err := tokenStore.SaveOnChainTransaction("txUnknown", 1, "blockHash", 1, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", store.KoinuValues{
 		"ownerAddress": 0,
 	})
 	if err != nil {
@@ -359,3 +361,311 @@ func TestProcessUnknownActionType(t *testing.T) {
 		t.Fatalf("Expected no error with unknown action type, got: %v", err)
 	}
 }
+
+func TestProcessorHandlesRegisteredActionVersions(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	for _, actionVersion := range protocol.RegisteredActionVersions() {
+		assert.Assert(t, processor.Handles(actionVersion.Action, actionVersion.Version), "no handler for %s version %d", protocol.ActionName(actionVersion.Action), actionVersion.Version)
+	}
+}
+
+func TestProcessRecordsUnsupportedVersion(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	encodedMsg, _ := proto.Marshal(&protocol.OnChainMintMessage{Hash: support.GenerateRandomHash()})
+	_, err := tokenStore.SaveOnChainTransaction("txFutureMint", 5, "blockHash", 1, protocol.ACTION_MINT, 2, encodedMsg, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 1)
+
+	err = processor.ProcessTransaction(txs[0])
+	assert.Assert(t, errors.Is(err, protocol.ErrUnsupportedVersion))
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 0)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].TxHash, "txFutureMint")
+	assert.Equal(t, rejections[0].ActionType, uint8(protocol.ACTION_MINT))
+	assert.Equal(t, rejections[0].ActionVersion, uint8(2))
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_UNSUPPORTED_VERSION)
+}
+
+func TestProcessRecordsUnsupportedAction(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	_, err := tokenStore.SaveOnChainTransaction("txUnknown", 1, "blockHash", 1, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_UNSUPPORTED_ACTION)
+}
+
+func TestProcessRecordsMalformedPayload(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	_, err := tokenStore.SaveOnChainTransaction("txMalformed", 1, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, []byte("invalid protobuf data"), "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	// The payload is decoded once, before the invoice processor is handed it
+	err = processor.ProcessTransaction(txs[0])
+	assert.Assert(t, err != nil)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_MALFORMED)
+}
+
+func TestProcessWaitsForConfirmationDepthOfAction(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	// The test node reports 7 confirmations for every block
+	cfg := config.NewConfig()
+	cfg.ConfirmationDepths[protocol.ACTION_MINT] = 8
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, cfg)
+
+	mintHash := support.GenerateRandomHash()
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+	})
+	assert.NilError(t, err)
+
+	encodedMsg, _ := proto.Marshal(&protocol.OnChainMintMessage{Hash: mintHash})
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	err = processor.ProcessTransaction(txs[0])
+	assert.Assert(t, errors.Is(err, service.ErrAwaitingConfirmations))
+
+	awaiting, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_AWAITING_DATA, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(awaiting), 1)
+	assert.Equal(t, awaiting[0].Action, "mint")
+	assert.Equal(t, awaiting[0].ReasonCode, store.OnChainAwaiting_CONFIRMATIONS)
+	assert.Equal(t, awaiting[0].Confirmations, 7)
+	assert.Equal(t, awaiting[0].RequiredConfirmations, 8)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.Id, "")
+
+	cfg.ConfirmationDepths[protocol.ACTION_MINT] = 7
+
+	err = processor.ProcessTransaction(txs[0])
+	assert.NilError(t, err)
+
+	mint, err = tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.Hash, mintHash)
+
+	outcome, err := tokenStore.GetOnChainTransactionOutcome("txMint")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_APPLIED)
+}
+
+func TestProcessHoldsLaterTransactionsUntilConfirmed(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	// The test node reports 7 confirmations for every block
+	cfg := config.NewConfig()
+	cfg.ConfirmationDepths[protocol.ACTION_MINT] = 8
+	cfg.ConfirmationDepths[protocol.ACTION_TRANSFER] = 0
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, cfg)
+
+	owner := support.GenerateDogecoinAddress(true)
+	buyer := support.GenerateDogecoinAddress(true)
+	mintHash := support.GenerateRandomHash()
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+	})
+	assert.NilError(t, err)
+
+	mintMsg, _ := proto.Marshal(&protocol.OnChainMintMessage{Hash: mintHash})
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash1", 0, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, mintMsg, owner, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	transferMsg, _ := proto.Marshal(&protocol.OnChainTransferMessage{MintHash: mintHashBytes, ToAddress: buyer, Quantity: 10})
+	_, err = tokenStore.SaveOnChainTransaction("txTransfer", 2, "blockHash2", 0, protocol.ACTION_TRANSFER, protocol.DEFAULT_VERSION, transferMsg, owner, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	// The transfer needs no confirmations, but is not processed before the mint it spends
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	_, err = tokenStore.GetOnChainTransactionOutcome("txTransfer")
+	assert.Assert(t, err != nil)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 2)
+
+	cfg.ConfirmationDepths[protocol.ACTION_MINT] = 7
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	outcome, err := tokenStore.GetOnChainTransactionOutcome("txMint")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_APPLIED)
+
+	outcome, err = tokenStore.GetOnChainTransactionOutcome("txTransfer")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_APPLIED)
+
+	balances, err := tokenStore.GetTokenBalances(buyer, mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(balances), 1)
+	assert.Equal(t, balances[0].Quantity, 10)
+}
+
+func TestProcessFinishesEveryTransactionInOnePass(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	// Every transaction is removed as it is rejected, which must not shift the ones after it
+	for i := 0; i < 250; i++ {
+		_, err := tokenStore.SaveOnChainTransaction(fmt.Sprintf("tx%d", i), int64(i/10+1), "blockHash", i%10, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", store.KoinuValues{})
+		assert.NilError(t, err)
+	}
+
+	err := processor.Process()
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 300)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 0)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 300)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 250)
+}
+
+func TestProcessRecordsOutcomeAndProcessesOnce(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	mintHash := support.GenerateRandomHash()
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+	})
+	assert.NilError(t, err)
+
+	encodedMsg, _ := proto.Marshal(&protocol.OnChainMintMessage{Hash: mintHash})
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+	_, err = tokenStore.SaveOnChainTransaction("txUnknown", 1, "blockHash", 2, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	outcome, err := tokenStore.GetOnChainTransactionOutcome("txMint")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_APPLIED)
+
+	outcome, err = tokenStore.GetOnChainTransactionOutcome("txUnknown")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_REJECTED)
+	assert.Equal(t, outcome.ReasonCode, store.OnChainRejection_UNSUPPORTED_ACTION)
+
+	// The same transaction ingested again is removed without being applied twice
+	_, err = tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 0)
+
+	mints, err := tokenStore.GetMints(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(mints), 1)
+}
+
+func TestProcessHoldsLaterTransactionsUntilDataArrivesOrExpires(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, rpcClient, config.NewConfig())
+
+	// The mint has not been gossiped, so the unsupported action after it is not processed yet
+	encodedMsg, _ := proto.Marshal(&protocol.OnChainMintMessage{Hash: support.GenerateRandomHash()})
+	_, err := tokenStore.SaveOnChainTransaction("txMint", 1, "blockHash1", 0, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMsg, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+	_, err = tokenStore.SaveOnChainTransaction("txUnknown", 2, "blockHash2", 0, 99, protocol.DEFAULT_VERSION, []byte{}, "ownerAddress", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertChainPosition(1+service.AWAITING_DATA_EXPIRY_BLOCKS-1, "tipHash", false)
+	assert.NilError(t, err)
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	outcome, err := tokenStore.GetOnChainTransactionOutcome("txMint")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_AWAITING_DATA)
+
+	_, err = tokenStore.GetOnChainTransactionOutcome("txUnknown")
+	assert.Assert(t, err != nil)
+
+	// Once the chain has been followed far enough past it, every node expires the mint at the same block
+	err = tokenStore.UpsertChainPosition(1+service.AWAITING_DATA_EXPIRY_BLOCKS, "tipHash", false)
+	assert.NilError(t, err)
+
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	outcome, err = tokenStore.GetOnChainTransactionOutcome("txMint")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_EXPIRED)
+	assert.Equal(t, outcome.ReasonCode, store.OnChainAwaiting_DATA)
+
+	outcome, err = tokenStore.GetOnChainTransactionOutcome("txUnknown")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_REJECTED)
+}
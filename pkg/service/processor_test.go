@@ -2,11 +2,13 @@ package service_test
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
 	"dogecoin.org/fractal-engine/internal/test/support"
 	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/config"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/service"
 	"dogecoin.org/fractal-engine/pkg/store"
@@ -22,7 +24,7 @@ func TestMintMatch(t *testing.T) {
 
 	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
 
-	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
 	processor.Process()
 
 	AssertUnconfirmedMintCreation(t, hash, tokenisationStore)
@@ -34,7 +36,7 @@ func TestInvoiceMatch(t *testing.T) {
 
 	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
 
-	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
 	processor.Process()
 
 	AssertUnconfirmedMintCreation(t, hash, tokenisationStore)
@@ -71,7 +73,7 @@ func TestInvoiceMatchEarlierBlockHeightAndTransactionNumber(t *testing.T) {
 
 	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
 
-	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
 	processor.Process()
 
 	AssertUnconfirmedMintCreation(t, hash, tokenisationStore)
@@ -92,13 +94,13 @@ func TestInvoiceMatchEarlierBlockHeightAndTransactionNumber(t *testing.T) {
 	AssertPendingTokenBalance(t, invoiceHash3, hash, 88, tokenisationStore)
 }
 
-func TestPaymentIsLessThanExpected(t *testing.T) {
+func TestPartialPaymentsAccumulateUntilInvoiceIsPaid(t *testing.T) {
 	tokenisationStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
 	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
 
-	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
 	processor.Process()
 
 	AssertUnconfirmedMintCreation(t, hash, tokenisationStore)
@@ -107,6 +109,7 @@ func TestPaymentIsLessThanExpected(t *testing.T) {
 	invoiceHash := support.GenerateRandomHash()
 	txHash2 := support.GenerateDogecoinAddress(true)
 	txHash3 := support.GenerateDogecoinAddress(true)
+	txHash4 := support.GenerateDogecoinAddress(true)
 
 	CreateOnChainInvoiceMessage(t, txHash2, 3, 1, ownerAddress, invoiceHash, hash, 50, tokenisationStore)
 	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, invoiceHash, hash, 50, tokenisationStore)
@@ -115,36 +118,234 @@ func TestPaymentIsLessThanExpected(t *testing.T) {
 
 	AssertPendingTokenBalance(t, invoiceHash, hash, 50, tokenisationStore)
 
-	CreateOnChainPaymentMessage(t, txHash3, invoiceHash, buyerAddress, ownerAddress, 1, 1, 49, tokenisationStore)
+	CreateOnChainPaymentMessage(t, txHash3, invoiceHash, buyerAddress, ownerAddress, 1, 1, 50*100-1, tokenisationStore)
 	processor.Process()
 
 	AssertTokenBalance(t, buyerAddress, hash, 0, tokenisationStore)
 	AssertTokenBalance(t, ownerAddress, hash, 100, tokenisationStore)
+
+	invoice, err := tokenisationStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.PaidKoinu, int64(4999))
+	assert.Equal(t, invoice.PaymentStatus, store.InvoicePaymentStatus_PARTIALLY_PAID)
+
+	// The second payment overshoots the remaining koinu
+	CreateOnChainPaymentMessage(t, txHash4, invoiceHash, buyerAddress, ownerAddress, 2, 1, 11, tokenisationStore)
+	processor.Process()
+
+	AssertTokenBalance(t, buyerAddress, hash, 50, tokenisationStore)
+	AssertTokenBalance(t, ownerAddress, hash, 50, tokenisationStore)
+
+	invoice, err = tokenisationStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.PaidKoinu, int64(5010))
+	assert.Equal(t, invoice.SurplusKoinu, int64(10))
+	assert.Equal(t, invoice.PaymentStatus, store.InvoicePaymentStatus_OVERPAID)
+	assert.Assert(t, invoice.PaidAt.Valid)
 }
 
-func TestInvoiceTimesOutAfter14BlockDays(t *testing.T) {
+func TestPaymentToAnotherAddressIsRejected(t *testing.T) {
 	tokenisationStore := test_support.SetupTestDB()
 	rpcClient := support.NewTestDogeClient(t)
 
 	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
 
-	invoiceTimeoutProcessor := service.NewInvoiceTimeoutProcessor(tokenisationStore)
-	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient)
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
 	processor.Process()
 
-	AssertUnconfirmedMintCreation(t, hash, tokenisationStore)
-
+	buyerAddress := support.GenerateDogecoinAddress(true)
 	invoiceHash := support.GenerateRandomHash()
 	txHash2 := support.GenerateDogecoinAddress(true)
+	txHash3 := support.GenerateDogecoinAddress(true)
 
 	CreateOnChainInvoiceMessage(t, txHash2, 3, 1, ownerAddress, invoiceHash, hash, 50, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, invoiceHash, hash, 50, tokenisationStore)
+
+	processor.Process()
+
+	CreateOnChainPaymentMessage(t, txHash3, invoiceHash, buyerAddress, support.GenerateDogecoinAddress(true), 1, 1, 50*100, tokenisationStore)
+	processor.Process()
+
+	AssertTokenBalance(t, buyerAddress, hash, 0, tokenisationStore)
+
+	rejected, err := tokenisationStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejected), 1)
+	assert.Equal(t, rejected[0].TxHash, txHash3)
+	assert.Equal(t, rejected[0].ReasonCode, store.OnChainRejection_PAYMENT_MISMATCH)
+	assert.Assert(t, strings.Contains(rejected[0].Reason, "paid nothing to "+ownerAddress))
+}
+
+func TestBatchPaymentSettlesEachInvoiceIndependently(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
+
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
+	processor.Process()
+
+	buyerAddress := support.GenerateDogecoinAddress(true)
+	otherBuyerAddress := support.GenerateDogecoinAddress(true)
+	settledInvoiceHash := support.GenerateRandomHash()
+	partialInvoiceHash := support.GenerateRandomHash()
+	otherInvoiceHash := support.GenerateRandomHash()
+	paymentTxHash := support.GenerateDogecoinAddress(true)
+
+	CreateOnChainInvoiceMessage(t, support.GenerateDogecoinAddress(true), 3, 1, ownerAddress, settledInvoiceHash, hash, 30, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, settledInvoiceHash, hash, 30, tokenisationStore)
+	CreateOnChainInvoiceMessage(t, support.GenerateDogecoinAddress(true), 3, 2, ownerAddress, partialInvoiceHash, hash, 20, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, partialInvoiceHash, hash, 20, tokenisationStore)
+	CreateOnChainInvoiceMessage(t, support.GenerateDogecoinAddress(true), 3, 3, ownerAddress, otherInvoiceHash, hash, 10, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, otherBuyerAddress, otherInvoiceHash, hash, 10, tokenisationStore)
+
+	processor.Process()
+
+	// The seller's output covers the first invoice and all but one koinu of the second
+	CreateOnChainBatchPaymentMessage(t, paymentTxHash, []string{settledInvoiceHash, partialInvoiceHash, otherInvoiceHash}, buyerAddress, store.KoinuValues{
+		ownerAddress: 30*100 + 20*100 - 1,
+	}, 1, 1, tokenisationStore)
+	processor.Process()
+
+	AssertTokenBalance(t, buyerAddress, hash, 30, tokenisationStore)
+	AssertPendingTokenBalance(t, partialInvoiceHash, hash, 20, tokenisationStore)
+	AssertPendingTokenBalance(t, otherInvoiceHash, hash, 10, tokenisationStore)
+
+	partialInvoice, err := tokenisationStore.GetInvoiceByHash(partialInvoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, partialInvoice.PaidKoinu, int64(1999))
+	assert.Equal(t, partialInvoice.PaymentStatus, store.InvoicePaymentStatus_PARTIALLY_PAID)
+
+	outcomes, err := tokenisationStore.GetBatchPaymentOutcomes(paymentTxHash, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(outcomes), 3)
+
+	assert.Equal(t, outcomes[0].InvoiceHash, settledInvoiceHash)
+	assert.Equal(t, outcomes[0].Status, store.BatchPaymentStatus_SETTLED)
+	assert.Equal(t, outcomes[0].AmountKoinu, int64(3000))
+
+	assert.Equal(t, outcomes[1].InvoiceHash, partialInvoiceHash)
+	assert.Equal(t, outcomes[1].Status, store.BatchPaymentStatus_PARTIALLY_PAID)
+	assert.Equal(t, outcomes[1].AmountKoinu, int64(1999))
+
+	assert.Equal(t, outcomes[2].InvoiceHash, otherInvoiceHash)
+	assert.Equal(t, outcomes[2].Status, store.BatchPaymentStatus_REJECTED)
+	assert.Equal(t, outcomes[2].ReasonCode, store.OnChainRejection_SENDER_MISMATCH)
+}
+
+func TestInvoiceExpiryReleasesReservationAndRefusesLatePayment(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
+
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
+	processor.Process()
+
+	buyerAddress := support.GenerateDogecoinAddress(true)
+	invoiceHash := support.GenerateRandomHash()
+
+	CreateOnChainInvoiceMessageWithExpiry(t, support.GenerateDogecoinAddress(true), 3, 1, ownerAddress, invoiceHash, hash, 50, 5, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, invoiceHash, hash, 50, tokenisationStore)
+	processor.Process()
+
+	AssertPendingTokenBalance(t, invoiceHash, hash, 50, tokenisationStore)
+
+	// The reservation is held until the chain has been followed past the expiry height
+	assert.NilError(t, tokenisationStore.UpsertChainPosition(5, "blockHash", false))
+	assert.NilError(t, processor.Process())
+	AssertPendingTokenBalance(t, invoiceHash, hash, 50, tokenisationStore)
+
+	assert.NilError(t, tokenisationStore.UpsertChainPosition(7, "blockHash", false))
+	assert.NilError(t, processor.Process())
+	AssertNoPendingTokenBalance(t, invoiceHash, hash, tokenisationStore)
+
+	invoice, err := tokenisationStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.ReleaseReason, store.InvoiceRelease_EXPIRED)
+
+	CreateOnChainPaymentMessage(t, support.GenerateDogecoinAddress(true), invoiceHash, buyerAddress, ownerAddress, 6, 1, 50*100, tokenisationStore)
+	processor.Process()
+
+	AssertTokenBalance(t, ownerAddress, hash, 100, tokenisationStore)
+
+	rejections, err := tokenisationStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_INVOICE_EXPIRED)
+}
+
+func TestInvoiceCancellationBySellerReleasesReservation(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
+
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
+	processor.Process()
+
+	buyerAddress := support.GenerateDogecoinAddress(true)
+	invoiceHash := support.GenerateRandomHash()
+
+	CreateOnChainInvoiceMessage(t, support.GenerateDogecoinAddress(true), 3, 1, ownerAddress, invoiceHash, hash, 50, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, buyerAddress, invoiceHash, hash, 50, tokenisationStore)
+	processor.Process()
+
+	// Only the seller can cancel the invoice
+	CreateOnChainInvoiceCancellationMessage(t, support.GenerateDogecoinAddress(true), 4, 1, buyerAddress, invoiceHash, tokenisationStore)
 	processor.Process()
 
 	AssertPendingTokenBalance(t, invoiceHash, hash, 50, tokenisationStore)
 
-	invoiceTimeoutProcessor.Process(4)
+	CreateOnChainInvoiceCancellationMessage(t, support.GenerateDogecoinAddress(true), 4, 2, ownerAddress, invoiceHash, tokenisationStore)
+	processor.Process()
+
+	AssertNoPendingTokenBalance(t, invoiceHash, hash, tokenisationStore)
+
+	invoice, err := tokenisationStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.ReleaseReason, store.InvoiceRelease_CANCELLED)
+
+	CreateOnChainPaymentMessage(t, support.GenerateDogecoinAddress(true), invoiceHash, buyerAddress, ownerAddress, 5, 1, 50*100, tokenisationStore)
+	processor.Process()
+
+	AssertTokenBalance(t, ownerAddress, hash, 100, tokenisationStore)
+
+	rejections, err := tokenisationStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 2)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_INVOICE_CANCELLED)
+	assert.Equal(t, rejections[1].ReasonCode, store.OnChainRejection_SENDER_MISMATCH)
+}
+
+func TestInvoiceExpiresBeforeTheTransactionsOfTheNextBlock(t *testing.T) {
+	tokenisationStore := test_support.SetupTestDB()
+	rpcClient := support.NewTestDogeClient(t)
+
+	hash := CreateUnconfirmedMint(t, support.GenerateRandomHash(), tokenisationStore)
+
+	processor := service.NewFractalEngineProcessor(tokenisationStore, rpcClient, config.NewConfig())
+	processor.Process()
+
+	invoiceHash := support.GenerateRandomHash()
+	CreateOnChainInvoiceMessageWithExpiry(t, support.GenerateDogecoinAddress(true), 3, 1, ownerAddress, invoiceHash, hash, 50, 5, tokenisationStore)
+	SaveUnconfirmedInvoice(t, ownerAddress, support.GenerateDogecoinAddress(true), invoiceHash, hash, 50, tokenisationStore)
+
+	// The transfer spends the reserved fractions, which are released before its block is applied
+	mintHashBytes, err := hex.DecodeString(hash)
+	assert.NilError(t, err)
+	buyerAddress := support.GenerateDogecoinAddress(true)
+	transfer := &protocol.OnChainTransferMessage{MintHash: mintHashBytes, ToAddress: buyerAddress, Quantity: 80}
+	saveOnChainTransaction(t, tokenisationStore, "transferTx", protocol.ACTION_TRANSFER, transfer, ownerAddress, store.KoinuValues{})
+
+	assert.NilError(t, processor.Process())
 
 	AssertNoPendingTokenBalance(t, invoiceHash, hash, tokenisationStore)
+	AssertTokenBalance(t, buyerAddress, hash, 80, tokenisationStore)
+
+	release, err := tokenisationStore.GetInvoiceRelease(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, release.BlockHeight, int64(6))
 }
 
 func AssertNoPendingTokenBalance(t *testing.T, invoiceHash string, mintHash string, tokenisationStore *store.TokenisationStore) {
@@ -189,7 +390,7 @@ func AssertTokenBalance(t *testing.T, s, hash string, i int, tokenisationStore *
 	assert.Equal(t, i, totalQuantity)
 }
 
-func CreateOnChainPaymentMessage(t *testing.T, trxnHash string, invoiceHash string, buyerAddress string, sellerAddress string, blockHeight int64, trxnNo int, value float64, tokenisationStore *store.TokenisationStore) {
+func CreateOnChainPaymentMessage(t *testing.T, trxnHash string, invoiceHash string, buyerAddress string, sellerAddress string, blockHeight int64, trxnNo int, value int64, tokenisationStore *store.TokenisationStore) {
 	message3 := protocol.OnChainPaymentMessage{
 		Hash: invoiceHash,
 	}
@@ -199,7 +400,7 @@ func CreateOnChainPaymentMessage(t *testing.T, trxnHash string, invoiceHash stri
 		t.Fatalf("Failed to marshal message: %v", err)
 	}
 
-	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedMessage3, buyerAddress, map[string]interface{}{
+	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedMessage3, buyerAddress, store.KoinuValues{
 		sellerAddress: value,
 	})
 	if err != nil {
@@ -207,6 +408,44 @@ func CreateOnChainPaymentMessage(t *testing.T, trxnHash string, invoiceHash stri
 	}
 }
 
+func CreateOnChainBatchPaymentMessage(t *testing.T, trxnHash string, invoiceHashes []string, buyerAddress string, values store.KoinuValues, blockHeight int64, trxnNo int, tokenisationStore *store.TokenisationStore) {
+	message := protocol.OnChainBatchPaymentMessage{}
+	for _, invoiceHash := range invoiceHashes {
+		invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+		if err != nil {
+			t.Fatalf("Failed to decode invoice hash: %v", err)
+		}
+		message.InvoiceHashes = append(message.InvoiceHashes, invoiceHashBytes)
+	}
+
+	encodedMessage, err := proto.Marshal(&message)
+	if err != nil {
+		t.Fatalf("Failed to marshal message: %v", err)
+	}
+
+	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_BATCH_PAYMENT, protocol.DEFAULT_VERSION, encodedMessage, buyerAddress, values)
+	if err != nil {
+		t.Fatalf("Failed to save on chain transaction: %v", err)
+	}
+}
+
+func CreateOnChainInvoiceCancellationMessage(t *testing.T, trxnHash string, blockHeight int64, trxnNo int, senderAddress string, invoiceHash string, tokenisationStore *store.TokenisationStore) {
+	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
+	assert.NilError(t, err)
+
+	encodedMessage, err := proto.Marshal(&protocol.OnChainInvoiceCancellationMessage{
+		InvoiceHash: invoiceHashBytes,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal message: %v", err)
+	}
+
+	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_INVOICE_CANCELLATION, protocol.DEFAULT_VERSION, encodedMessage, senderAddress, store.KoinuValues{})
+	if err != nil {
+		t.Fatalf("Failed to save on chain transaction: %v", err)
+	}
+}
+
 func SaveUnconfirmedInvoice(t *testing.T, ownerAddress string, buyerAddress string, invoiceHash string, mintHash string, quantity int, tokenisationStore *store.TokenisationStore) {
 	_, err := tokenisationStore.SaveUnconfirmedInvoice(&store.UnconfirmedInvoice{
 		Hash:           invoiceHash,
@@ -214,7 +453,7 @@ func SaveUnconfirmedInvoice(t *testing.T, ownerAddress string, buyerAddress stri
 		BuyerAddress:   buyerAddress,
 		MintHash:       mintHash,
 		Quantity:       quantity,
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  ownerAddress,
 	})
@@ -226,23 +465,28 @@ func SaveUnconfirmedInvoice(t *testing.T, ownerAddress string, buyerAddress stri
 }
 
 func CreateOnChainInvoiceMessage(t *testing.T, trxnHash string, blockHeight int64, trxnNo int, ownerAddress string, invoiceHash string, mintHash string, quantity int, tokenisationStore *store.TokenisationStore) {
+	CreateOnChainInvoiceMessageWithExpiry(t, trxnHash, blockHeight, trxnNo, ownerAddress, invoiceHash, mintHash, quantity, 0, tokenisationStore)
+}
+
+func CreateOnChainInvoiceMessageWithExpiry(t *testing.T, trxnHash string, blockHeight int64, trxnNo int, ownerAddress string, invoiceHash string, mintHash string, quantity int, expiryHeight int64, tokenisationStore *store.TokenisationStore) {
 	invoiceHashBytes, err := hex.DecodeString(invoiceHash)
 	assert.NilError(t, err)
 	mintHashBytes, err := hex.DecodeString(mintHash)
 	assert.NilError(t, err)
 
 	message := &protocol.OnChainInvoiceMessage{
-		InvoiceHash: invoiceHashBytes,
-		MintHash:    mintHashBytes,
-		Quantity:    int32(quantity),
+		InvoiceHash:  invoiceHashBytes,
+		MintHash:     mintHashBytes,
+		Quantity:     int32(quantity),
+		ExpiryHeight: expiryHeight,
 	}
 	encodedMessage, err := proto.Marshal(message)
 	if err != nil {
 		t.Fatalf("Failed to marshal message: %v", err)
 	}
 
-	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedMessage, ownerAddress, map[string]interface{}{
-		ownerAddress: quantity,
+	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, blockHeight, "blockHash", trxnNo, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedMessage, ownerAddress, store.KoinuValues{
+		ownerAddress: int64(quantity),
 	})
 	if err != nil {
 		t.Fatalf("Failed to save on chain transaction: %v", err)
@@ -297,7 +541,7 @@ func CreateUnconfirmedMint(t *testing.T, trxnHash string, tokenisationStore *sto
 		t.Fatalf("Failed to marshal message: %v", err)
 	}
 
-	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMessage, ownerAddress, map[string]interface{}{
+	_, err = tokenisationStore.SaveOnChainTransaction(trxnHash, 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMessage, ownerAddress, store.KoinuValues{
 		ownerAddress: 100,
 	})
 	if err != nil {
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/followerer"
+	"dogecoin.org/fractal-engine/pkg/store"
+)
+
+// REINDEX_PROGRESS_INTERVAL is how often a reindex reports how far it has got.
+const REINDEX_PROGRESS_INTERVAL = 10 * time.Second
+
+// ReindexNode tells a reindex where the chain of the node ends and which block is at a height.
+type ReindexNode interface {
+	GetBlockCount() (int64, error)
+	GetBlockHash(blockHeight int) (string, error)
+}
+
+/*
+* Reindexer rebuilds the state derived from the chain above a block height, for instance once a processing
+* bug is fixed. Derived state above the height is rolled back, which moves confirmed mints, invoices and
+* other off chain payloads back to their unconfirmed tables, and the blocks up to the tip of the node are
+* replayed through the follower and processor.
+ */
+type Reindexer struct {
+	store            *store.TokenisationStore
+	node             ReindexNode
+	follower         *followerer.DogeFollower
+	processor        *FractalEngineProcessor
+	progressInterval time.Duration
+}
+
+func NewReindexer(cfg *config.Config, tokenStore *store.TokenisationStore) *Reindexer {
+	// The chain position is kept as blocks are replayed, so the engine resumes where the reindex ended
+	followerCfg := *cfg
+	followerCfg.PersistFollower = true
+
+	dogeClient := doge.NewRpcClient(cfg)
+	follower := followerer.NewFollower(&followerCfg, tokenStore)
+	processor := NewFractalEngineProcessor(tokenStore, dogeClient, cfg)
+
+	return NewReindexerWithFollower(tokenStore, dogeClient, follower, processor)
+}
+
+func NewReindexerWithFollower(tokenStore *store.TokenisationStore, node ReindexNode, follower *followerer.DogeFollower, processor *FractalEngineProcessor) *Reindexer {
+	return &Reindexer{store: tokenStore, node: node, follower: follower, processor: processor, progressInterval: REINDEX_PROGRESS_INTERVAL}
+}
+
+/*
+* Run rolls the derived state and chain position back to fromHeight and replays the blocks above it, up to the
+* tip of the node when the reindex started. Each block is processed before the next one is followed, so
+* transactions are processed in chain order as they were first time round.
+ */
+func (r *Reindexer) Run(fromHeight int64) error {
+	tip, err := r.node.GetBlockCount()
+	if err != nil {
+		return err
+	}
+
+	if fromHeight < 0 || fromHeight > tip {
+		return fmt.Errorf("from height %d is not between 0 and the tip of the node, %d", fromHeight, tip)
+	}
+
+	blockHash, err := r.node.GetBlockHash(int(fromHeight))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Reindexing blocks %d to %d\n", fromHeight+1, tip)
+
+	err = r.store.RollbackToChainPosition(fromHeight, blockHash, false)
+	if err != nil {
+		return err
+	}
+
+	var height atomic.Int64
+	height.Store(fromHeight)
+
+	done := make(chan struct{})
+	var once sync.Once
+
+	r.follower.OnBlock(func(blockHeight int64) {
+		err := r.processor.Process()
+		if err != nil {
+			log.Println("Error processing:", err)
+		}
+
+		height.Store(blockHeight)
+		if blockHeight >= tip {
+			once.Do(func() { close(done) })
+		}
+	})
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- r.follower.Start()
+	}()
+
+	ticker := time.NewTicker(r.progressInterval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		select {
+		case <-done:
+			r.follower.Stop()
+			log.Printf("Reindexed blocks %d to %d in %s\n", fromHeight+1, tip, time.Since(started).Round(time.Second))
+			return nil
+
+		case err := <-stopped:
+			if err == nil {
+				err = errors.New("follower stopped")
+			}
+			return err
+
+		case <-ticker.C:
+			current := height.Load()
+			progress := 100.0
+			if tip > fromHeight {
+				progress = float64(current-fromHeight) / float64(tip-fromHeight) * 100
+			}
+			log.Printf("Reindexed to block %d of %d (%.1f%%)\n", current, tip, progress)
+		}
+	}
+}
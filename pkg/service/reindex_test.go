@@ -0,0 +1,132 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/config"
+	"dogecoin.org/fractal-engine/pkg/followerer"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"github.com/dogecoinfoundation/chainfollower/pkg/chainfollower"
+	"github.com/dogecoinfoundation/chainfollower/pkg/messages"
+	"github.com/dogecoinfoundation/chainfollower/pkg/state"
+	"github.com/dogecoinfoundation/chainfollower/pkg/types"
+	"github.com/shopspring/decimal"
+	"gotest.tools/assert"
+)
+
+type FakeReindexNode struct {
+	Tip    int64
+	Hashes map[int]string
+}
+
+func (n *FakeReindexNode) GetBlockCount() (int64, error) {
+	return n.Tip, nil
+}
+
+func (n *FakeReindexNode) GetBlockHash(blockHeight int) (string, error) {
+	return n.Hashes[blockHeight], nil
+}
+
+// ReplayingChainFollower hands over its blocks once started, as the chain follower does when resuming from a chain position.
+type ReplayingChainFollower struct {
+	chainfollower.ChainFollowerInterface
+	Blocks  []*types.Block
+	StartAt *state.ChainPos
+}
+
+func (f *ReplayingChainFollower) Start(chainPos *state.ChainPos) chan messages.Message {
+	f.StartAt = chainPos
+	messageChan := make(chan messages.Message)
+	go func() {
+		for _, block := range f.Blocks {
+			messageChan <- messages.BlockMessage{Block: block, ChainPos: &state.ChainPos{BlockHash: block.Hash, BlockHeight: block.Height}}
+		}
+	}()
+	return messageChan
+}
+
+func (f *ReplayingChainFollower) Stop() {}
+
+type FakeFundingSource struct {
+	Address string
+}
+
+func (s *FakeFundingSource) GetRawTransaction(txId string) (*types.RawTxn, error) {
+	if txId != "FundingTX" {
+		return nil, fmt.Errorf("no such transaction: %s", txId)
+	}
+
+	return &types.RawTxn{TxID: txId, Hash: txId, VOut: []types.RawTxnVOut{{
+		N:            0,
+		ScriptPubKey: types.RawTxnScriptPubKey{Type: "pubkeyhash", Addresses: []string{s.Address}},
+		Value:        decimal.NewFromInt(1000),
+	}}}, nil
+}
+
+func TestReindexReplaysBlocksAboveHeight(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+	ownerAddress := test_support.GenerateDogecoinAddress(true)
+	mintHash := test_support.GenerateRandomHash()
+
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		Description:   "Test Description",
+		FractionCount: 100,
+	})
+	assert.NilError(t, err)
+
+	envelope := protocol.NewMintTransactionEnvelope(mintHash, protocol.ACTION_MINT)
+	_, err = tokenStore.SaveOnChainTransaction("mintTx", 5, "blockHash5", 0, envelope.Action, envelope.Version, envelope.Data, ownerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	processor := service.NewFractalEngineProcessor(tokenStore, test_support.NewTestDogeClient(t), config.NewConfig())
+	err = processor.Process()
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertChainPosition(6, "blockHash6", false)
+	assert.NilError(t, err)
+
+	chainFollower := &ReplayingChainFollower{Blocks: []*types.Block{
+		{Hash: "blockHash4", Height: 4},
+		{Hash: "blockHash5", Height: 5, Tx: []types.RawTxn{{
+			Hash: "mintTx",
+			VIn:  []types.RawTxnVIn{{TxID: "FundingTX", VOut: 0}},
+			VOut: []types.RawTxnVOut{{
+				ScriptPubKey: types.RawTxnScriptPubKey{Type: "nulldata", Asm: "OP_RETURN " + hex.EncodeToString(envelope.Serialize())},
+			}},
+		}}},
+		{Hash: "blockHash6", Height: 6},
+	}}
+
+	follower := followerer.NewFollowerWithCustomChainFollower(&config.Config{PersistFollower: true}, tokenStore, chainFollower, &FakeFundingSource{Address: ownerAddress})
+	node := &FakeReindexNode{Tip: 6, Hashes: map[int]string{4: "blockHash4"}}
+
+	err = service.NewReindexerWithFollower(tokenStore, node, follower, processor).Run(4)
+	assert.NilError(t, err)
+
+	assert.Equal(t, chainFollower.StartAt.BlockHeight, int64(4))
+	assert.Equal(t, chainFollower.StartAt.BlockHash, "blockHash4")
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Assert(t, mint.Id != "")
+
+	balances, err := tokenStore.GetTokenBalances(ownerAddress, mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(balances), 1)
+	assert.Equal(t, balances[0].Quantity, 100)
+
+	blockHeight, blockHash, _, err := tokenStore.GetChainPosition()
+	assert.NilError(t, err)
+	assert.Equal(t, blockHeight, int64(6))
+	assert.Equal(t, blockHash, "blockHash6")
+
+	err = service.NewReindexerWithFollower(tokenStore, node, follower, processor).Run(7)
+	assert.ErrorContains(t, err, "not between 0 and the tip")
+}
@@ -21,28 +21,50 @@ type TokenisationService struct {
 	DogeNetClient  *dogenet.DogeNetClient
 	DogeClient     *doge.RpcClient
 	Follower       *followerer.DogeFollower
+	MempoolWatcher *followerer.MempoolWatcher
 	TrimmerService *TrimmerService
 	Processor      *FractalEngineProcessor
 	HealthService  *health.HealthService
+	ZmqSubscriber  *doge.ZmqSubscriber
 }
 
 func NewTokenisationService(cfg *config.Config, dogenetClient *dogenet.DogeNetClient, tokenStore *store.TokenisationStore) *TokenisationService {
 	dogeClient := doge.NewRpcClient(cfg)
 	follower := followerer.NewFollower(cfg, tokenStore)
 
-	trimmerService := NewTrimmerService(20160, 100, tokenStore, dogeClient)
-	processor := NewFractalEngineProcessor(tokenStore, dogeClient)
+	trimmerService := NewTrimmerService(100, tokenStore)
+	processor := NewFractalEngineProcessor(tokenStore, dogeClient, cfg)
+	follower.OnBlock(processor.HandleBlock)
 	healthService := health.NewHealthService(dogeClient, tokenStore)
 
+	var mempoolWatcher *followerer.MempoolWatcher
+	if cfg.MempoolPollInterval > 0 {
+		mempoolWatcher = followerer.NewMempoolWatcher(cfg, tokenStore)
+	}
+
+	// Services poll the node unless it publishes notifications
+	var zmqSubscriber *doge.ZmqSubscriber
+	if cfg.DogeZmqAddress != "" {
+		zmqSubscriber = doge.NewZmqSubscriber(cfg.DogeZmqAddress)
+		follower.WakeOn(zmqSubscriber.BlockSignal())
+		trimmerService.WakeOn(zmqSubscriber.BlockSignal())
+		healthService.WakeOn(zmqSubscriber.BlockSignal())
+		if mempoolWatcher != nil {
+			mempoolWatcher.WakeOn(zmqSubscriber.TransactionSignal())
+		}
+	}
+
 	return &TokenisationService{
 		RpcServer:      rpc.NewRpcServer(cfg, tokenStore, dogenetClient, dogeClient),
 		Store:          tokenStore,
 		DogeNetClient:  dogenetClient,
 		DogeClient:     dogeClient,
 		Follower:       follower,
+		MempoolWatcher: mempoolWatcher,
 		TrimmerService: trimmerService,
 		Processor:      processor,
 		HealthService:  healthService,
+		ZmqSubscriber:  zmqSubscriber,
 	}
 }
 
@@ -70,11 +92,24 @@ func (s *TokenisationService) Run() {
 
 	log.Println("Migration successful")
 
+	if s.ZmqSubscriber != nil {
+		go s.ZmqSubscriber.Start()
+	}
+
 	go s.HealthService.Start()
 	go s.RpcServer.Start()
-	go s.Follower.Start()
+	go func() {
+		err := s.Follower.Start()
+		if err != nil {
+			log.Fatalf("Failed to start follower: %v", err)
+		}
+	}()
 	go s.TrimmerService.Start()
 	go s.Processor.Start()
+
+	if s.MempoolWatcher != nil {
+		go s.MempoolWatcher.Start()
+	}
 }
 
 func (s *TokenisationService) waitForFollower() {
@@ -105,9 +140,15 @@ func (s *TokenisationService) WaitForRunning() {
 }
 
 func (s *TokenisationService) Stop() {
+	if s.ZmqSubscriber != nil {
+		s.ZmqSubscriber.Stop()
+	}
 	s.HealthService.Stop()
 	s.Processor.Stop()
 	s.Follower.Stop()
+	if s.MempoolWatcher != nil {
+		s.MempoolWatcher.Stop()
+	}
 	s.Store.Close()
 	s.RpcServer.Stop()
 	s.TrimmerService.Stop()
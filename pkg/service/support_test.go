@@ -0,0 +1,30 @@
+package service_test
+
+import (
+	"testing"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func saveOnChainTransaction(t *testing.T, tokenStore *store.TokenisationStore, txHash string, action uint8, message proto.Message, address string, values store.KoinuValues) store.OnChainTransaction {
+	encoded, err := proto.Marshal(message)
+	assert.NilError(t, err)
+
+	id, err := tokenStore.SaveOnChainTransaction(txHash, 10, "blockHash10", 0, action, protocol.DEFAULT_VERSION, encoded, address, values)
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	for _, tx := range txs {
+		if tx.Id == id {
+			return tx
+		}
+	}
+
+	t.Fatal("on chain transaction not found")
+	return store.OnChainTransaction{}
+}
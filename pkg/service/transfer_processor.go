@@ -0,0 +1,86 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"dogecoin.org/fractal-engine/pkg/validation"
+)
+
+type TransferProcessor struct {
+	store *store.TokenisationStore
+}
+
+func NewTransferProcessor(store *store.TokenisationStore) *TransferProcessor {
+	return &TransferProcessor{store: store}
+}
+
+/*
+* The sender is the address proven by the on chain transaction.
+* Transfers that are invalid, break the requirements of the mint, or exceed the sender's
+* available balance (balance minus pending invoice reservations) are rejected.
+ */
+func (p *TransferProcessor) Process(tx store.OnChainTransaction, transfer *protocol.OnChainTransferMessage) error {
+	mintHash := hex.EncodeToString(transfer.MintHash)
+
+	mint, err := p.validate(tx, transfer, mintHash)
+	if err == nil {
+		// Transfers are held to the same allowlist and holder limits as invoiced trades
+		err = p.store.CheckTradeRequirements(mint, tx.Address, transfer.ToAddress, int(transfer.Quantity), tx)
+
+		var violation *store.TradeViolation
+		if errors.As(err, &violation) {
+			log.Println("Transfer rejected:", violation)
+			return p.store.RejectTrade(tx, "", mintHash, tx.Address, transfer.ToAddress, int(transfer.Quantity), violation)
+		}
+
+		if err != nil {
+			log.Println("Error checking trade requirements:", err)
+			return err
+		}
+
+		err = p.store.ProcessTransfer(tx, mintHash, transfer.ToAddress, int(transfer.Quantity))
+		if err != nil && !errors.Is(err, store.ErrInsufficientTokenBalance) {
+			log.Println("Error processing transfer:", err)
+			return err
+		}
+	}
+
+	if err != nil {
+		log.Println("Transfer rejected:", err)
+
+		return rejectInvalid(p.store, tx, err)
+	}
+
+	log.Println("Matched transfer:", tx.TxHash)
+	return nil
+}
+
+func (p *TransferProcessor) validate(tx store.OnChainTransaction, transfer *protocol.OnChainTransferMessage, mintHash string) (store.Mint, error) {
+	if err := validation.ValidateProtobufQuantity(transfer.Quantity); err != nil {
+		return store.Mint{}, err
+	}
+
+	if err := validation.ValidateAddress(transfer.ToAddress); err != nil {
+		return store.Mint{}, fmt.Errorf("invalid to_address: %w", err)
+	}
+
+	if transfer.ToAddress == tx.Address {
+		return store.Mint{}, fmt.Errorf("sender and recipient are the same address: %s", tx.Address)
+	}
+
+	mint, err := p.store.GetMintByHash(mintHash)
+	if err != nil {
+		return store.Mint{}, err
+	}
+
+	if mint.Id == "" {
+		return store.Mint{}, fmt.Errorf("mint not found: %s", mintHash)
+	}
+
+	return mint, nil
+}
@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/service"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func saveTransferTransaction(t *testing.T, tokenStore *store.TokenisationStore, mintHash string, fromAddress string, toAddress string, quantity int32) (store.OnChainTransaction, *protocol.OnChainTransferMessage) {
+	mintHashBytes, err := hex.DecodeString(mintHash)
+	assert.NilError(t, err)
+
+	transfer := &protocol.OnChainTransferMessage{
+		MintHash:  mintHashBytes,
+		ToAddress: toAddress,
+		Quantity:  quantity,
+	}
+	return saveOnChainTransaction(t, tokenStore, "transferTx", protocol.ACTION_TRANSFER, transfer, fromAddress, store.KoinuValues{}), transfer
+}
+
+func TestTransferProcessorProcessSuccess(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewTransferProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	fromAddress := support.GenerateDogecoinAddress(true)
+	toAddress := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, fromAddress)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(fromAddress, mintHash, 100)
+	assert.NilError(t, err)
+
+	tx, transfer := saveTransferTransaction(t, tokenStore, mintHash, fromAddress, toAddress, 25)
+
+	err = processor.Process(tx, transfer)
+	assert.NilError(t, err)
+
+	available, err := tokenStore.GetAvailableTokenBalance(toAddress, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 25)
+
+	available, err = tokenStore.GetAvailableTokenBalance(fromAddress, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 75)
+}
+
+func TestTransferProcessorDiscardsInsufficientBalance(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewTransferProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	fromAddress := support.GenerateDogecoinAddress(true)
+	toAddress := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Test Mint", FractionCount: 100}, fromAddress)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(fromAddress, mintHash, 100)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertPendingTokenBalance(support.GenerateRandomHash(), mintHash, 90, "invoiceTx", fromAddress)
+	assert.NilError(t, err)
+
+	tx, transfer := saveTransferTransaction(t, tokenStore, mintHash, fromAddress, toAddress, 25)
+
+	err = processor.Process(tx, transfer)
+	assert.ErrorContains(t, err, "insufficient available token balance")
+
+	available, err := tokenStore.GetAvailableTokenBalance(toAddress, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 0)
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+
+	rejections, err := tokenStore.GetOnChainTransactionOutcomes(store.OnChainOutcome_REJECTED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.OnChainRejection_INSUFFICIENT_BALANCE)
+}
+
+func TestTransferProcessorRejectsRecipientNotOnAllowlist(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+	processor := service.NewTransferProcessor(tokenStore)
+
+	mintHash := support.GenerateRandomHash()
+	fromAddress := support.GenerateDogecoinAddress(true)
+	toAddress := support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		Requirements: store.StringInterfaceMap{
+			"allowlist_enabled": true,
+			"allowlist":         []interface{}{fromAddress},
+		},
+	}, fromAddress)
+	assert.NilError(t, err)
+	err = tokenStore.UpsertTokenBalance(fromAddress, mintHash, 100)
+	assert.NilError(t, err)
+
+	tx, transfer := saveTransferTransaction(t, tokenStore, mintHash, fromAddress, toAddress, 25)
+
+	err = processor.Process(tx, transfer)
+	assert.NilError(t, err)
+
+	available, err := tokenStore.GetAvailableTokenBalance(toAddress, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 0)
+
+	available, err = tokenStore.GetAvailableTokenBalance(fromAddress, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 100)
+
+	rejections, err := tokenStore.GetTradeRejections(mintHash, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rejections), 1)
+	assert.Equal(t, rejections[0].ReasonCode, store.TradeRejection_NOT_ALLOWLISTED)
+	assert.Equal(t, rejections[0].BuyerAddress, toAddress)
+	assert.Equal(t, rejections[0].ActionType, uint8(protocol.ACTION_TRANSFER))
+
+	count, err := tokenStore.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+}
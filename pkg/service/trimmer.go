@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -9,56 +10,38 @@ import (
 	"dogecoin.org/fractal-engine/pkg/store"
 )
 
+/*
+* TrimmerService removes unconfirmed mints that were never anchored on chain. On chain transactions and invoice
+* reservations are expired by the processor, in chain order.
+ */
 type TrimmerService struct {
-	blocksToKeep            int
-	unconfirmedMintsToKeep  int
-	store                   *store.TokenisationStore
-	dogeClient              *doge.RpcClient
-	running                 bool
-	invoiceTimeoutProcessor *InvoiceTimeoutProcessor
+	unconfirmedMintsToKeep int
+	store                  *store.TokenisationStore
+	running                bool
+	blocks                 doge.Signal
+	interval               time.Duration
 }
 
-func NewTrimmerService(blocksToKeep int, unconfirmedMintsToKeep int, store *store.TokenisationStore, dogeClient *doge.RpcClient) *TrimmerService {
-	return &TrimmerService{blocksToKeep: blocksToKeep, unconfirmedMintsToKeep: unconfirmedMintsToKeep, store: store, dogeClient: dogeClient, running: false, invoiceTimeoutProcessor: NewInvoiceTimeoutProcessor(store)}
+func NewTrimmerService(unconfirmedMintsToKeep int, store *store.TokenisationStore) *TrimmerService {
+	return &TrimmerService{unconfirmedMintsToKeep: unconfirmedMintsToKeep, store: store, running: false, interval: 10 * time.Second}
+}
+
+// WakeOn trims when the signal fires for a new block, polling less often.
+func (t *TrimmerService) WakeOn(blocks doge.Signal) {
+	t.blocks = blocks
+	t.interval = doge.NOTIFIED_POLL_INTERVAL
 }
 
 func (t *TrimmerService) Start() {
 	t.running = true
 
 	for {
-		bestBlockHash, err := t.dogeClient.GetBestBlockHash()
-		if err != nil {
-			log.Println("Error getting best block hash:", err)
-			time.Sleep(10 * time.Second)
-			continue
-		}
-
-		blockHeader, err := t.dogeClient.GetBlockHeader(bestBlockHash)
-		if err != nil {
-			log.Println("Error getting block header:", err)
-			time.Sleep(10 * time.Second)
-			continue
-		}
-
-		latestBlockHeight := int(blockHeader.Height)
-		oldestBlockHeight := latestBlockHeight - t.blocksToKeep
-
-		err = t.invoiceTimeoutProcessor.Process(oldestBlockHeight)
-		if err != nil {
-			log.Println("Error processing invoice timeout:", err)
-		}
-
-		err = t.store.TrimOldUnconfirmedMints(t.unconfirmedMintsToKeep)
+		err := t.store.TrimOldUnconfirmedMints(t.unconfirmedMintsToKeep)
 		if err != nil {
 			log.Println("Error trimming unconfirmed mints:", err)
 		}
 
-		err = t.store.TrimOldOnChainTransactions(oldestBlockHeight)
-		if err != nil {
-			log.Println("Error trimming on chain transactions:", err)
-		}
-
-		time.Sleep(10 * time.Second)
+		t.blocks.Wait(context.Background(), t.interval)
 
 		if !t.running {
 			break
@@ -4,7 +4,6 @@ import (
 	"testing"
 	"time"
 
-	"dogecoin.org/fractal-engine/internal/test/support"
 	test_support "dogecoin.org/fractal-engine/internal/test/support"
 	"dogecoin.org/fractal-engine/pkg/service"
 	"dogecoin.org/fractal-engine/pkg/store"
@@ -14,9 +13,7 @@ import (
 func TestTrimmerServiceForOnChainTransactions(t *testing.T) {
 	tokenisationStore := test_support.SetupTestDB()
 
-	rpcClient := support.NewTestDogeClient(t)
-
-	value := map[string]interface{}{
+	value := store.KoinuValues{
 		"0000000000000000000000000000000000000000000000000000000000000000": 100,
 	}
 
@@ -53,17 +50,18 @@ func TestTrimmerServiceForOnChainTransactions(t *testing.T) {
 	}
 	assert.Equal(t, 4, len(mintCount))
 
-	trimmerService := service.NewTrimmerService(14, 2, tokenisationStore, rpcClient)
+	trimmerService := service.NewTrimmerService(2, tokenisationStore)
 	go trimmerService.Start()
 
 	time.Sleep(2 * time.Second)
 
+	// On chain transactions are left to the processor, which expires them in chain order
 	count, err = tokenisationStore.GetOnChainTransactions(0, 100)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	assert.Equal(t, 3, len(count))
+	assert.Equal(t, 6, len(count))
 
 	mintCount, err = tokenisationStore.GetUnconfirmedMints(0, 100)
 	if err != nil {
@@ -0,0 +1,230 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AdminAction_REPROCESS      = "reprocess"
+	AdminAction_SUPPLY_PAYLOAD = "supply_payload"
+	AdminAction_IGNORE         = "ignore"
+)
+
+const (
+	OnChainAwaiting_REQUEUED = "REQUEUED"
+	OnChainIgnored_OPERATOR  = "IGNORED_BY_OPERATOR"
+)
+
+// ErrOnChainTransactionNotStuck is returned when an operator acts on a transaction that has been finished with.
+var ErrOnChainTransactionNotStuck = errors.New("onchain transaction is not awaiting data or expired")
+
+// AdminAuditEntry records an action taken through the admin API.
+type AdminAuditEntry struct {
+	Id            string    `json:"id"`
+	Action        string    `json:"action"`
+	TxHash        string    `json:"tx_hash"`
+	Detail        string    `json:"detail"`
+	RemoteAddress string    `json:"remote_address"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// GetStuckOnChainTransactions returns the transactions awaiting data or expired while waiting, oldest first.
+func (s *TokenisationStore) GetStuckOnChainTransactions(offset int, limit int) ([]OnChainTransactionOutcome, error) {
+	rows, err := s.DB.Query(`
+	SELECT `+onChainTransactionOutcomeColumns+`
+	FROM onchain_transaction_outcomes
+	WHERE outcome = 'awaiting_data' OR outcome = 'expired'
+	ORDER BY block_height ASC, transaction_number ASC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outcomes := []OnChainTransactionOutcome{}
+	for rows.Next() {
+		outcome, err := scanOnChainTransactionOutcome(rows)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, rows.Err()
+}
+
+// GetStuckOnChainTransaction returns the outcome of a transaction an operator can act on.
+func (s *TokenisationStore) GetStuckOnChainTransaction(txHash string) (OnChainTransactionOutcome, error) {
+	outcome, err := s.GetOnChainTransactionOutcome(txHash)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	if !outcome.IsStuck() {
+		return OnChainTransactionOutcome{}, fmt.Errorf("%w: %s is %s", ErrOnChainTransactionNotStuck, txHash, outcome.Status)
+	}
+
+	return outcome, nil
+}
+
+/*
+* RequeueOnChainTransaction hands a stuck transaction back to the processor, which processes it on its next
+* pass. An expired transaction is restored from its outcome. The transaction is kept from being trimmed
+* again, and the action is written to the audit log.
+ */
+func (s *TokenisationStore) RequeueOnChainTransaction(txHash string, action string, detail string, remoteAddress string) (OnChainTransactionOutcome, error) {
+	return s.requeueOnChainTransaction(txHash, action, detail, remoteAddress, nil)
+}
+
+/*
+* SupplyUnconfirmedMint saves a mint supplied by an operator for a transaction waiting on its data and
+* requeues the transaction, so the mint is only kept if the transaction is handed back to the processor.
+ */
+func (s *TokenisationStore) SupplyUnconfirmedMint(txHash string, mint *MintWithoutID, remoteAddress string) (OnChainTransactionOutcome, error) {
+	return s.requeueOnChainTransaction(txHash, AdminAction_SUPPLY_PAYLOAD, "mint "+mint.Hash, remoteAddress, func(tx *sql.Tx) error {
+		_, err := s.SaveUnconfirmedMintWithTx(mint, tx)
+		return err
+	})
+}
+
+/*
+* SupplyUnconfirmedInvoice saves an invoice supplied by an operator for a transaction waiting on its data and
+* requeues the transaction, so the invoice is only kept if the transaction is handed back to the processor.
+ */
+func (s *TokenisationStore) SupplyUnconfirmedInvoice(txHash string, invoice *UnconfirmedInvoice, remoteAddress string) (OnChainTransactionOutcome, error) {
+	return s.requeueOnChainTransaction(txHash, AdminAction_SUPPLY_PAYLOAD, "invoice "+invoice.Hash, remoteAddress, func(tx *sql.Tx) error {
+		_, err := s.SaveUnconfirmedInvoiceWithTx(invoice, tx)
+		return err
+	})
+}
+
+func (s *TokenisationStore) requeueOnChainTransaction(txHash string, action string, detail string, remoteAddress string, savePayload func(tx *sql.Tx) error) (OnChainTransactionOutcome, error) {
+	outcome, err := s.GetStuckOnChainTransaction(txHash)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+	defer tx.Rollback()
+
+	if savePayload != nil {
+		err = savePayload(tx)
+		if err != nil {
+			return OnChainTransactionOutcome{}, err
+		}
+	}
+
+	if outcome.Status == OnChainOutcome_EXPIRED {
+		_, err = tx.Exec(`
+		INSERT INTO onchain_transactions (id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time, values_unit, requeued)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'koinu', TRUE)
+		`, uuid.New().String(), outcome.TxHash, outcome.Height, outcome.BlockHash, outcome.TransactionNumber, outcome.ActionType, outcome.ActionVersion, outcome.ActionData, outcome.Address, outcome.Values, outcome.BlockTime)
+	} else {
+		_, err = tx.Exec("UPDATE onchain_transactions SET requeued = TRUE WHERE tx_hash = $1", txHash)
+	}
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = setOnChainTransactionOutcome(tx, txHash, OnChainOutcome_AWAITING_DATA, OnChainAwaiting_REQUEUED, "queued again by an operator")
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = saveAdminAuditEntry(tx, action, txHash, detail, remoteAddress)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	return s.GetOnChainTransactionOutcome(txHash)
+}
+
+// IgnoreOnChainTransaction finishes with a stuck transaction without processing it, writing the action to the audit log.
+func (s *TokenisationStore) IgnoreOnChainTransaction(txHash string, reason string, remoteAddress string) (OnChainTransactionOutcome, error) {
+	_, err := s.GetStuckOnChainTransaction(txHash)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE tx_hash = $1", txHash)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = setOnChainTransactionOutcome(tx, txHash, OnChainOutcome_IGNORED, OnChainIgnored_OPERATOR, reason)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = saveAdminAuditEntry(tx, AdminAction_IGNORE, txHash, reason, remoteAddress)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	return s.GetOnChainTransactionOutcome(txHash)
+}
+
+func setOnChainTransactionOutcome(tx *sql.Tx, txHash string, outcome string, reasonCode string, reason string) error {
+	_, err := tx.Exec(`
+	UPDATE onchain_transaction_outcomes SET outcome = $1, reason_code = $2, reason = $3, processed_at = $4 WHERE tx_hash = $5
+	`, outcome, reasonCode, reason, time.Now().UTC(), txHash)
+	return err
+}
+
+func saveAdminAuditEntry(tx *sql.Tx, action string, txHash string, detail string, remoteAddress string) error {
+	_, err := tx.Exec(`
+	INSERT INTO admin_audit_log (id, action, tx_hash, detail, remote_address, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	`, uuid.New().String(), action, txHash, detail, remoteAddress, time.Now().UTC())
+	return err
+}
+
+// GetAdminAuditLog returns the actions taken through the admin API, most recent first.
+func (s *TokenisationStore) GetAdminAuditLog(offset int, limit int) ([]AdminAuditEntry, error) {
+	rows, err := s.DB.Query(`
+	SELECT id, action, tx_hash, detail, remote_address, created_at
+	FROM admin_audit_log
+	ORDER BY created_at DESC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []AdminAuditEntry{}
+	for rows.Next() {
+		var entry AdminAuditEntry
+		if err := rows.Scan(&entry.Id, &entry.Action, &entry.TxHash, &entry.Detail, &entry.RemoteAddress, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
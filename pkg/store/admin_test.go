@@ -0,0 +1,122 @@
+package store_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestRequeueExpiredOnChainTransaction(t *testing.T) {
+	db := support.SetupTestDB()
+
+	_, err := db.SaveOnChainTransactionWithBlockTime("tx1", 100, "blockHash", 1700000000, 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{"addr2": 500})
+	assert.NilError(t, err)
+
+	err = db.TrimOldOnChainTransactions(250)
+	assert.NilError(t, err)
+
+	outcome, err := db.RequeueOnChainTransaction("tx1", store.AdminAction_REPROCESS, "mint arrived late", "127.0.0.1:1234")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_AWAITING_DATA)
+	assert.Equal(t, outcome.ReasonCode, store.OnChainAwaiting_REQUEUED)
+
+	// The transaction is restored as it was seen on chain and is not trimmed again
+	err = db.TrimOldOnChainTransactions(250)
+	assert.NilError(t, err)
+
+	transactions, err := db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions), 1)
+	assert.Equal(t, transactions[0].TxHash, "tx1")
+	assert.Equal(t, transactions[0].BlockTime, int64(1700000000))
+	assert.DeepEqual(t, transactions[0].ActionData, []byte("data1"))
+	assert.DeepEqual(t, transactions[0].Values, store.KoinuValues{"addr2": 500})
+
+	entries, err := db.GetAdminAuditLog(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Action, store.AdminAction_REPROCESS)
+	assert.Equal(t, entries[0].TxHash, "tx1")
+	assert.Equal(t, entries[0].Detail, "mint arrived late")
+	assert.Equal(t, entries[0].RemoteAddress, "127.0.0.1:1234")
+}
+
+func TestIgnoreAwaitingOnChainTransaction(t *testing.T) {
+	db := support.SetupTestDB()
+
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	transactions, err := db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = db.SaveAwaitingOnChainTransaction(transactions[0], store.OnChainAwaiting_DATA, "mint not gossiped")
+	assert.NilError(t, err)
+
+	stuck, err := db.GetStuckOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(stuck), 1)
+
+	outcome, err := db.IgnoreOnChainTransaction("tx1", "mint withdrawn", "127.0.0.1:1234")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_IGNORED)
+	assert.Equal(t, outcome.Reason, "mint withdrawn")
+
+	transactions, err = db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions), 0)
+
+	// Transactions that have been finished with cannot be acted on again
+	_, err = db.RequeueOnChainTransaction("tx1", store.AdminAction_REPROCESS, "", "127.0.0.1:1234")
+	assert.Assert(t, errors.Is(err, store.ErrOnChainTransactionNotStuck))
+
+	_, err = db.IgnoreOnChainTransaction("unknown", "", "127.0.0.1:1234")
+	assert.Assert(t, errors.Is(err, sql.ErrNoRows))
+
+	entries, err := db.GetAdminAuditLog(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Action, store.AdminAction_IGNORE)
+}
+
+func TestSupplyUnconfirmedInvoice(t *testing.T) {
+	db := support.SetupTestDB()
+
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	transactions, err := db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = db.SaveAwaitingOnChainTransaction(transactions[0], store.OnChainAwaiting_DATA, "invoice not gossiped")
+	assert.NilError(t, err)
+
+	invoice := store.UnconfirmedInvoice{Hash: "invoice1", MintHash: "mint1", Quantity: 10, PriceKoinu: 100}
+	outcome, err := db.SupplyUnconfirmedInvoice("tx1", &invoice, "127.0.0.1:1234")
+	assert.NilError(t, err)
+	assert.Equal(t, outcome.Status, store.OnChainOutcome_AWAITING_DATA)
+	assert.Equal(t, outcome.ReasonCode, store.OnChainAwaiting_REQUEUED)
+
+	saved, err := db.GetUnconfirmedInvoiceByHash("invoice1")
+	assert.NilError(t, err)
+	assert.Equal(t, saved.Quantity, 10)
+
+	entries, err := db.GetAdminAuditLog(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 1)
+	assert.Equal(t, entries[0].Action, store.AdminAction_SUPPLY_PAYLOAD)
+	assert.Equal(t, entries[0].Detail, "invoice invoice1")
+
+	// Nothing is saved for a transaction that has been finished with
+	_, err = db.IgnoreOnChainTransaction("tx1", "duplicate", "127.0.0.1:1234")
+	assert.NilError(t, err)
+
+	other := store.UnconfirmedInvoice{Hash: "invoice2", MintHash: "mint1", Quantity: 10, PriceKoinu: 100}
+	_, err = db.SupplyUnconfirmedInvoice("tx1", &other, "127.0.0.1:1234")
+	assert.Assert(t, errors.Is(err, store.ErrOnChainTransactionNotStuck))
+
+	_, err = db.GetUnconfirmedInvoiceByHash("invoice2")
+	assert.Assert(t, err != nil)
+}
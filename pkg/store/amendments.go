@@ -0,0 +1,296 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+var ErrMintAmendmentNotFound = errors.New("no unconfirmed mint amendment found")
+
+/*
+* MintAmendmentBody is the payload the mint owner signs to amend the mutable fields of a mint.
+* An amendment replaces all of the mutable fields. The identity of the mint
+* (MintWithoutID.GenerateHash) is not affected.
+ */
+type MintAmendmentBody struct {
+	MintHash       string             `json:"mint_hash"`
+	Description    string             `json:"description"`
+	Metadata       StringInterfaceMap `json:"metadata"`
+	FeedURL        string             `json:"feed_url"`
+	ContractOfSale string             `json:"contract_of_sale"`
+	Timestamp      int64              `json:"timestamp"`
+}
+
+type MintAmendment struct {
+	MintAmendmentBody
+	Hash            string    `json:"hash"`
+	PublicKey       string    `json:"public_key"`
+	Signature       string    `json:"signature"`
+	Revision        int       `json:"revision"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	BlockHeight     int64     `json:"block_height,omitempty"`
+	BlockHash       string    `json:"block_hash,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// MintRevision is a version of the mutable fields of a mint. Revision 0 is the original mint.
+type MintRevision struct {
+	Revision        int                `json:"revision"`
+	AmendmentHash   string             `json:"amendment_hash,omitempty"`
+	Description     string             `json:"description"`
+	Metadata        StringInterfaceMap `json:"metadata"`
+	FeedURL         string             `json:"feed_url"`
+	ContractOfSale  string             `json:"contract_of_sale"`
+	TransactionHash string             `json:"transaction_hash"`
+	BlockHeight     int64              `json:"block_height"`
+}
+
+func (b *MintAmendmentBody) GenerateHash() (string, error) {
+	jsonBytes, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(jsonBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ValidateMintAmendment checks that the amendment is for the mint and signed by its owner.
+func ValidateMintAmendment(mint Mint, amendment MintAmendment) error {
+	if amendment.MintHash != mint.Hash {
+		return fmt.Errorf("amendment is not for mint: %s", mint.Hash)
+	}
+
+	hash, err := amendment.GenerateHash()
+	if err != nil {
+		return err
+	}
+
+	if hash != amendment.Hash {
+		return fmt.Errorf("amendment hash does not match payload: %s", amendment.Hash)
+	}
+
+	if amendment.PublicKey != mint.PublicKey {
+		return fmt.Errorf("public key does not match the mint owner")
+	}
+
+	if err := doge.ValidateSignature(amendment.MintAmendmentBody, amendment.PublicKey, amendment.Signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return nil
+}
+
+// Apply returns the mint with the amended fields.
+func (r MintRevision) Apply(mint Mint) Mint {
+	mint.Description = r.Description
+	mint.Metadata = r.Metadata
+	mint.FeedURL = r.FeedURL
+	mint.ContractOfSale = r.ContractOfSale
+	return mint
+}
+
+// amendmentMetadata keeps a nil metadata map as JSON null so the amendment hash survives storage.
+func amendmentMetadata(metadata StringInterfaceMap) (string, error) {
+	metadataBytes, err := json.Marshal(map[string]interface{}(metadata))
+	if err != nil {
+		return "", err
+	}
+
+	return string(metadataBytes), nil
+}
+
+func (s *TokenisationStore) SaveUnconfirmedMintAmendment(amendment *MintAmendment) error {
+	metadata, err := amendmentMetadata(amendment.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`
+	INSERT INTO unconfirmed_mint_amendments (hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (hash) DO NOTHING
+	`, amendment.Hash, amendment.MintHash, amendment.Description, metadata, amendment.FeedURL, amendment.ContractOfSale, amendment.Timestamp, amendment.PublicKey, amendment.Signature, time.Now())
+
+	return err
+}
+
+func scanMintAmendment(scanner interface{ Scan(...interface{}) error }, confirmed bool) (MintAmendment, error) {
+	var amendment MintAmendment
+	var err error
+	if confirmed {
+		err = scanner.Scan(&amendment.Hash, &amendment.MintHash, &amendment.Description, &amendment.Metadata, &amendment.FeedURL, &amendment.ContractOfSale, &amendment.Timestamp, &amendment.PublicKey, &amendment.Signature, &amendment.CreatedAt, &amendment.Revision, &amendment.TransactionHash, &amendment.BlockHeight, &amendment.BlockHash)
+	} else {
+		err = scanner.Scan(&amendment.Hash, &amendment.MintHash, &amendment.Description, &amendment.Metadata, &amendment.FeedURL, &amendment.ContractOfSale, &amendment.Timestamp, &amendment.PublicKey, &amendment.Signature, &amendment.CreatedAt)
+	}
+
+	return amendment, err
+}
+
+// GetUnconfirmedMintAmendment returns ErrMintAmendmentNotFound if the amendment is unknown.
+func (s *TokenisationStore) GetUnconfirmedMintAmendment(hash string) (MintAmendment, error) {
+	row := s.DB.QueryRow(`
+	SELECT hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_amendments WHERE hash = $1
+	`, hash)
+
+	amendment, err := scanMintAmendment(row, false)
+	if err == sql.ErrNoRows {
+		return MintAmendment{}, fmt.Errorf("%w: %s", ErrMintAmendmentNotFound, hash)
+	}
+
+	return amendment, err
+}
+
+func (s *TokenisationStore) GetUnconfirmedMintAmendments(mintHash string) ([]MintAmendment, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_amendments WHERE mint_hash = $1 ORDER BY timestamp
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amendments := []MintAmendment{}
+	for rows.Next() {
+		amendment, err := scanMintAmendment(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		amendments = append(amendments, amendment)
+	}
+
+	return amendments, rows.Err()
+}
+
+// GetMintAmendments returns the confirmed amendments of a mint, oldest first.
+func (s *TokenisationStore) GetMintAmendments(mintHash string) ([]MintAmendment, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at, revision, transaction_hash, block_height, block_hash
+	FROM mint_amendments WHERE mint_hash = $1 ORDER BY revision
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amendments := []MintAmendment{}
+	for rows.Next() {
+		amendment, err := scanMintAmendment(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		amendments = append(amendments, amendment)
+	}
+
+	return amendments, rows.Err()
+}
+
+// GetMintRevisions returns every version of the mutable fields of the mint, oldest first.
+func (s *TokenisationStore) GetMintRevisions(mint Mint) ([]MintRevision, error) {
+	amendments, err := s.GetMintAmendments(mint.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []MintRevision{{
+		Revision:        0,
+		Description:     mint.Description,
+		Metadata:        mint.Metadata,
+		FeedURL:         mint.FeedURL,
+		ContractOfSale:  mint.ContractOfSale,
+		TransactionHash: mint.TransactionHash,
+		BlockHeight:     mint.BlockHeight,
+	}}
+
+	for _, amendment := range amendments {
+		revisions = append(revisions, MintRevision{
+			Revision:        amendment.Revision,
+			AmendmentHash:   amendment.Hash,
+			Description:     amendment.Description,
+			Metadata:        amendment.Metadata,
+			FeedURL:         amendment.FeedURL,
+			ContractOfSale:  amendment.ContractOfSale,
+			TransactionHash: amendment.TransactionHash,
+			BlockHeight:     amendment.BlockHeight,
+		})
+	}
+
+	return revisions, nil
+}
+
+/*
+* MatchUnconfirmedMintAmendment confirms the gossiped amendment anchored by the on chain transaction.
+* Returns ErrMintAmendmentNotFound if the amendment has not been gossiped yet.
+ */
+func (s *TokenisationStore) MatchUnconfirmedMintAmendment(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainMintAmendmentMessage) error {
+	if onchainTransaction.ActionType != protocol.ACTION_MINT_AMENDMENT {
+		return fmt.Errorf("action type is not mint amendment: %d", onchainTransaction.ActionType)
+	}
+
+	amendmentHash := hex.EncodeToString(onchainMessage.AmendmentHash)
+	mintHash := hex.EncodeToString(onchainMessage.MintHash)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRow(`
+	SELECT hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_amendments WHERE hash = $1 AND mint_hash = $2
+	`, amendmentHash, mintHash)
+
+	amendment, err := scanMintAmendment(row, false)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrMintAmendmentNotFound, amendmentHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	metadata, err := amendmentMetadata(amendment.Metadata)
+	if err != nil {
+		return err
+	}
+
+	var revision int
+	err = tx.QueryRow("SELECT COALESCE(MAX(revision), 0) + 1 FROM mint_amendments WHERE mint_hash = $1", mintHash).Scan(&revision)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO mint_amendments (hash, mint_hash, revision, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, transaction_hash, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, amendment.Hash, amendment.MintHash, revision, amendment.Description, metadata, amendment.FeedURL, amendment.ContractOfSale, amendment.Timestamp, amendment.PublicKey, amendment.Signature, onchainTransaction.TxHash, onchainTransaction.Height, onchainTransaction.BlockHash, amendment.CreatedAt)
+	if err != nil {
+		log.Println("Error saving mint amendment:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM unconfirmed_mint_amendments WHERE hash = $1", amendment.Hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
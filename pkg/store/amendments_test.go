@@ -0,0 +1,116 @@
+package store_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func saveSignedMintAmendment(t *testing.T, tokenStore *store.TokenisationStore, privHex string, pubHex string, body store.MintAmendmentBody) store.MintAmendment {
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	amendment := store.MintAmendment{MintAmendmentBody: body, Hash: hash, PublicKey: pubHex, Signature: signature}
+	err = tokenStore.SaveUnconfirmedMintAmendment(&amendment)
+	assert.NilError(t, err)
+
+	return amendment
+}
+
+func anchorMintAmendment(t *testing.T, tokenStore *store.TokenisationStore, amendment store.MintAmendment, height int64) (store.OnChainTransaction, *protocol.OnChainMintAmendmentMessage) {
+	amendmentHash, _ := hex.DecodeString(amendment.Hash)
+	mintHash, _ := hex.DecodeString(amendment.MintHash)
+
+	message := &protocol.OnChainMintAmendmentMessage{AmendmentHash: amendmentHash, MintHash: mintHash}
+	encoded, err := proto.Marshal(message)
+	assert.NilError(t, err)
+
+	id, err := tokenStore.SaveOnChainTransaction(support.GenerateRandomHash(), height, "blockHash", 0, protocol.ACTION_MINT_AMENDMENT, protocol.DEFAULT_VERSION, encoded, "owner", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 100)
+	assert.NilError(t, err)
+	for _, tx := range txs {
+		if tx.Id == id {
+			return tx, message
+		}
+	}
+
+	t.Fatal("mint amendment transaction not found")
+	return store.OnChainTransaction{}, nil
+}
+
+func TestMintAmendmentRevisions(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintWithoutID := &store.MintWithoutID{Title: "Mint", Description: "original", FractionCount: 10, PublicKey: pubHex, BlockHeight: 1}
+	mintHash, err := mintWithoutID.GenerateHash()
+	assert.NilError(t, err)
+	mintWithoutID.Hash = mintHash
+
+	_, err = tokenStore.SaveMint(mintWithoutID, "owner")
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+
+	first := saveSignedMintAmendment(t, tokenStore, privHex, pubHex, store.MintAmendmentBody{MintHash: mintHash, Description: "first", Timestamp: 1})
+	second := saveSignedMintAmendment(t, tokenStore, privHex, pubHex, store.MintAmendmentBody{MintHash: mintHash, Description: "second", Timestamp: 2})
+	assert.NilError(t, store.ValidateMintAmendment(mint, first))
+
+	assert.NilError(t, tokenStore.MatchUnconfirmedMintAmendment(anchorMintAmendment(t, tokenStore, first, 5)))
+	assert.NilError(t, tokenStore.MatchUnconfirmedMintAmendment(anchorMintAmendment(t, tokenStore, second, 6)))
+
+	revisions, err := tokenStore.GetMintRevisions(mint)
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 3)
+	assert.Equal(t, revisions[0].Description, "original")
+	assert.Equal(t, revisions[1].Revision, 1)
+	assert.Equal(t, revisions[1].AmendmentHash, first.Hash)
+	assert.Equal(t, revisions[2].Revision, 2)
+	assert.Equal(t, revisions[2].Apply(mint).Description, "second")
+	assert.Equal(t, revisions[2].Apply(mint).Hash, mintHash)
+
+	// Rolling back the second anchor returns the amendment to the unconfirmed pool
+	err = tokenStore.RollbackToBlockHeight(5)
+	assert.NilError(t, err)
+
+	revisions, err = tokenStore.GetMintRevisions(mint)
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 2)
+
+	pending, err := tokenStore.GetUnconfirmedMintAmendments(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(pending), 1)
+	assert.Equal(t, pending[0].Hash, second.Hash)
+}
+
+func TestValidateMintAmendmentRejectsOtherSigner(t *testing.T) {
+	_, ownerPubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mint := store.Mint{MintWithoutID: store.MintWithoutID{Hash: support.GenerateRandomHash(), PublicKey: ownerPubHex}}
+
+	body := store.MintAmendmentBody{MintHash: mint.Hash, Description: "changed", Timestamp: 1}
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	err = store.ValidateMintAmendment(mint, store.MintAmendment{MintAmendmentBody: body, Hash: hash, PublicKey: pubHex, Signature: signature})
+	assert.ErrorContains(t, err, "mint owner")
+}
@@ -0,0 +1,383 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+var ErrAssetManagerRotationNotFound = errors.New("no unconfirmed asset manager rotation found")
+
+const (
+	AssetManagerRotation_ADD     = "add"
+	AssetManagerRotation_REMOVE  = "remove"
+	AssetManagerRotation_REPLACE = "replace"
+)
+
+/*
+* AssetManagerRotationBody is the payload a quorum of the current asset managers signs to change the manager set.
+* PublicKey is the manager being removed or replaced, Manager is the manager being added.
+ */
+type AssetManagerRotationBody struct {
+	MintHash  string        `json:"mint_hash"`
+	Action    string        `json:"action"`
+	PublicKey string        `json:"public_key,omitempty"`
+	Manager   *AssetManager `json:"manager,omitempty"`
+	Timestamp int64         `json:"timestamp"`
+}
+
+type AssetManagerApproval struct {
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+type AssetManagerApprovals []AssetManagerApproval
+
+func (a AssetManagerApprovals) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (a *AssetManagerApprovals) Scan(src interface{}) error {
+	var source []byte
+	switch src := src.(type) {
+	case string:
+		source = []byte(src)
+	case []byte:
+		source = src
+	case nil:
+		*a = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type: %T", src)
+	}
+	return json.Unmarshal(source, a)
+}
+
+type AssetManagerRotation struct {
+	AssetManagerRotationBody
+	Hash      string                `json:"hash"`
+	Approvals AssetManagerApprovals `json:"approvals"`
+	// AssetManagers is the manager set once the rotation is confirmed
+	AssetManagers     AssetManagers `json:"asset_managers,omitempty"`
+	TransactionHash   string        `json:"transaction_hash,omitempty"`
+	TransactionNumber int           `json:"transaction_number,omitempty"`
+	BlockHeight       int64         `json:"block_height,omitempty"`
+	BlockHash         string        `json:"block_hash,omitempty"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+func (b *AssetManagerRotationBody) GenerateHash() (string, error) {
+	jsonBytes, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(jsonBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Apply returns the manager set after the rotation.
+func (b *AssetManagerRotationBody) Apply(assetManagers AssetManagers) (AssetManagers, error) {
+	rotated := AssetManagers{}
+
+	switch b.Action {
+	case AssetManagerRotation_ADD:
+		if b.Manager == nil || b.Manager.PublicKey == "" {
+			return nil, fmt.Errorf("manager is required to add an asset manager")
+		}
+		if assetManagers.Contains(b.Manager.PublicKey) {
+			return nil, fmt.Errorf("asset manager already exists: %s", b.Manager.PublicKey)
+		}
+		rotated = append(rotated, assetManagers...)
+		rotated = append(rotated, *b.Manager)
+	case AssetManagerRotation_REMOVE, AssetManagerRotation_REPLACE:
+		if !assetManagers.Contains(b.PublicKey) {
+			return nil, fmt.Errorf("public key does not match any asset managers: %s", b.PublicKey)
+		}
+		if b.Action == AssetManagerRotation_REPLACE {
+			if b.Manager == nil || b.Manager.PublicKey == "" {
+				return nil, fmt.Errorf("manager is required to replace an asset manager")
+			}
+			if b.Manager.PublicKey != b.PublicKey && assetManagers.Contains(b.Manager.PublicKey) {
+				return nil, fmt.Errorf("asset manager already exists: %s", b.Manager.PublicKey)
+			}
+		}
+		for _, assetManager := range assetManagers {
+			if assetManager.PublicKey != b.PublicKey {
+				rotated = append(rotated, assetManager)
+			} else if b.Action == AssetManagerRotation_REPLACE {
+				rotated = append(rotated, *b.Manager)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown asset manager rotation action: %s", b.Action)
+	}
+
+	if len(rotated) == 0 {
+		return nil, fmt.Errorf("a mint requiring signatures must keep at least one asset manager")
+	}
+
+	return rotated, nil
+}
+
+/*
+* ValidateAssetManagerRotation checks that the rotation is approved by a quorum of the asset managers of the mint
+* (per SignatureRequirementType / MinSignatures) and returns the resulting manager set.
+* The mint must carry the manager set effective where the rotation is evaluated (see WithAssetManagersAt).
+ */
+func ValidateAssetManagerRotation(mint Mint, rotation AssetManagerRotation) (AssetManagers, error) {
+	if rotation.MintHash != mint.Hash {
+		return nil, fmt.Errorf("rotation is not for mint: %s", mint.Hash)
+	}
+
+	if !mint.SignatureRequired() {
+		return nil, fmt.Errorf("mint does not require asset manager signatures: %s", mint.Hash)
+	}
+
+	hash, err := rotation.GenerateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	if hash != rotation.Hash {
+		return nil, fmt.Errorf("rotation hash does not match payload: %s", rotation.Hash)
+	}
+
+	publicKeys := []string{}
+	for _, approval := range rotation.Approvals {
+		if !mint.AssetManagers.Contains(approval.PublicKey) {
+			return nil, fmt.Errorf("public key does not match any asset managers: %s", approval.PublicKey)
+		}
+
+		if err := doge.ValidateSignature(rotation.AssetManagerRotationBody, approval.PublicKey, approval.Signature); err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+
+		publicKeys = append(publicKeys, approval.PublicKey)
+	}
+
+	if !mint.hasRequiredSigners(publicKeys) {
+		return nil, fmt.Errorf("rotation is not approved by a quorum of asset managers")
+	}
+
+	rotated, err := rotation.Apply(mint.AssetManagers)
+	if err != nil {
+		return nil, err
+	}
+
+	if mint.SignatureRequirementType == SignatureRequirementType_MIN_SIGNATURES && len(rotated) < mint.MinSignatures {
+		return nil, fmt.Errorf("mint requires %d signatures but would only have %d asset managers", mint.MinSignatures, len(rotated))
+	}
+
+	return rotated, nil
+}
+
+/*
+* GetAssetManagersAt returns the asset managers effective for the on chain transaction at blockHeight and
+* transactionNumber: the managers after the last rotation confirmed before it in chain order.
+* Mints without confirmed rotations keep the managers they were minted with.
+ */
+func (s *TokenisationStore) GetAssetManagersAt(mint Mint, blockHeight int64, transactionNumber int) (AssetManagers, error) {
+	var assetManagers AssetManagers
+	err := s.DB.QueryRow(`
+	SELECT asset_managers FROM asset_manager_rotations
+	WHERE mint_hash = $1 AND (block_height < $2 OR (block_height = $2 AND transaction_number < $3))
+	ORDER BY block_height DESC, transaction_number DESC
+	LIMIT 1
+	`, mint.Hash, blockHeight, transactionNumber).Scan(&assetManagers)
+	if err == sql.ErrNoRows {
+		return mint.AssetManagers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return assetManagers, nil
+}
+
+// WithAssetManagersAt returns the mint with the asset managers effective for the transaction at blockHeight and transactionNumber.
+func (s *TokenisationStore) WithAssetManagersAt(mint Mint, blockHeight int64, transactionNumber int) (Mint, error) {
+	assetManagers, err := s.GetAssetManagersAt(mint, blockHeight, transactionNumber)
+	if err != nil {
+		return Mint{}, err
+	}
+
+	mint.AssetManagers = assetManagers
+	return mint, nil
+}
+
+// WithCurrentAssetManagers returns the mint with the asset managers after every confirmed rotation.
+func (s *TokenisationStore) WithCurrentAssetManagers(mint Mint) (Mint, error) {
+	return s.WithAssetManagersAt(mint, math.MaxInt64, math.MaxInt32)
+}
+
+func (s *TokenisationStore) SaveUnconfirmedAssetManagerRotation(rotation *AssetManagerRotation) error {
+	manager, err := json.Marshal(rotation.Manager)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.Exec(`
+	INSERT INTO unconfirmed_asset_manager_rotations (hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (hash) DO NOTHING
+	`, rotation.Hash, rotation.MintHash, rotation.Action, rotation.PublicKey, string(manager), rotation.Timestamp, rotation.Approvals, time.Now())
+
+	return err
+}
+
+func scanAssetManagerRotation(scanner interface{ Scan(...interface{}) error }, confirmed bool) (AssetManagerRotation, error) {
+	var rotation AssetManagerRotation
+	var manager sql.NullString
+	var err error
+	if confirmed {
+		err = scanner.Scan(&rotation.Hash, &rotation.MintHash, &rotation.Action, &rotation.PublicKey, &manager, &rotation.Timestamp, &rotation.Approvals, &rotation.CreatedAt, &rotation.AssetManagers, &rotation.TransactionHash, &rotation.TransactionNumber, &rotation.BlockHeight, &rotation.BlockHash)
+	} else {
+		err = scanner.Scan(&rotation.Hash, &rotation.MintHash, &rotation.Action, &rotation.PublicKey, &manager, &rotation.Timestamp, &rotation.Approvals, &rotation.CreatedAt)
+	}
+	if err != nil {
+		return AssetManagerRotation{}, err
+	}
+
+	if manager.Valid && manager.String != "" {
+		err = json.Unmarshal([]byte(manager.String), &rotation.Manager)
+	}
+
+	return rotation, err
+}
+
+// GetUnconfirmedAssetManagerRotation returns ErrAssetManagerRotationNotFound if the rotation is unknown.
+func (s *TokenisationStore) GetUnconfirmedAssetManagerRotation(hash string) (AssetManagerRotation, error) {
+	row := s.DB.QueryRow(`
+	SELECT hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at
+	FROM unconfirmed_asset_manager_rotations WHERE hash = $1
+	`, hash)
+
+	rotation, err := scanAssetManagerRotation(row, false)
+	if err == sql.ErrNoRows {
+		return AssetManagerRotation{}, fmt.Errorf("%w: %s", ErrAssetManagerRotationNotFound, hash)
+	}
+
+	return rotation, err
+}
+
+func (s *TokenisationStore) GetUnconfirmedAssetManagerRotations(mintHash string) ([]AssetManagerRotation, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at
+	FROM unconfirmed_asset_manager_rotations WHERE mint_hash = $1 ORDER BY timestamp
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotations := []AssetManagerRotation{}
+	for rows.Next() {
+		rotation, err := scanAssetManagerRotation(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, rotation)
+	}
+
+	return rotations, rows.Err()
+}
+
+// GetAssetManagerRotations returns the confirmed rotations of the mint in chain order.
+func (s *TokenisationStore) GetAssetManagerRotations(mintHash string) ([]AssetManagerRotation, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at, asset_managers, transaction_hash, transaction_number, block_height, block_hash
+	FROM asset_manager_rotations WHERE mint_hash = $1 ORDER BY block_height, transaction_number
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rotations := []AssetManagerRotation{}
+	for rows.Next() {
+		rotation, err := scanAssetManagerRotation(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, rotation)
+	}
+
+	return rotations, rows.Err()
+}
+
+/*
+* ConfirmAssetManagerRotation records the gossiped rotation anchored by the on chain transaction
+* with the resulting manager set, effective from the block of the transaction.
+ */
+func (s *TokenisationStore) ConfirmAssetManagerRotation(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainAssetManagerRotationMessage, assetManagers AssetManagers) error {
+	if onchainTransaction.ActionType != protocol.ACTION_ASSET_MANAGER_ROTATION {
+		return fmt.Errorf("action type is not asset manager rotation: %d", onchainTransaction.ActionType)
+	}
+
+	rotationHash := hex.EncodeToString(onchainMessage.RotationHash)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRow(`
+	SELECT hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at
+	FROM unconfirmed_asset_manager_rotations WHERE hash = $1
+	`, rotationHash)
+
+	rotation, err := scanAssetManagerRotation(row, false)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrAssetManagerRotationNotFound, rotationHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	manager, err := json.Marshal(rotation.Manager)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO asset_manager_rotations (hash, mint_hash, action, public_key, manager, timestamp, approvals, asset_managers, transaction_hash, transaction_number, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, rotation.Hash, rotation.MintHash, rotation.Action, rotation.PublicKey, string(manager), rotation.Timestamp, rotation.Approvals, assetManagers, onchainTransaction.TxHash, onchainTransaction.TransactionNumber, onchainTransaction.Height, onchainTransaction.BlockHash, rotation.CreatedAt)
+	if err != nil {
+		log.Println("Error saving asset manager rotation:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM unconfirmed_asset_manager_rotations WHERE hash = $1", rotation.Hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
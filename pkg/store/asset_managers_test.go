@@ -0,0 +1,160 @@
+package store_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+type testManager struct {
+	privHex string
+	pubHex  string
+}
+
+func newTestManager(t *testing.T) testManager {
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	return testManager{privHex: privHex, pubHex: pubHex}
+}
+
+func signedRotation(t *testing.T, body store.AssetManagerRotationBody, approvers ...testManager) store.AssetManagerRotation {
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+
+	rotation := store.AssetManagerRotation{AssetManagerRotationBody: body, Hash: hash}
+	for _, approver := range approvers {
+		signature, err := doge.SignPayload(body, approver.privHex, approver.pubHex)
+		assert.NilError(t, err)
+		rotation.Approvals = append(rotation.Approvals, store.AssetManagerApproval{PublicKey: approver.pubHex, Signature: signature})
+	}
+
+	return rotation
+}
+
+func TestAssetManagerRotationApply(t *testing.T) {
+	managers := store.AssetManagers{{Name: "a", PublicKey: "a"}, {Name: "b", PublicKey: "b"}}
+
+	body := store.AssetManagerRotationBody{Action: store.AssetManagerRotation_REPLACE, PublicKey: "a", Manager: &store.AssetManager{Name: "c", PublicKey: "c"}}
+	rotated, err := body.Apply(managers)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, rotated, store.AssetManagers{{Name: "c", PublicKey: "c"}, {Name: "b", PublicKey: "b"}})
+
+	body = store.AssetManagerRotationBody{Action: store.AssetManagerRotation_ADD, Manager: &store.AssetManager{PublicKey: "b"}}
+	_, err = body.Apply(managers)
+	assert.ErrorContains(t, err, "already exists")
+
+	body = store.AssetManagerRotationBody{Action: store.AssetManagerRotation_REMOVE, PublicKey: "a"}
+	rotated, err = body.Apply(rotated[1:])
+	assert.ErrorContains(t, err, "does not match")
+	assert.Assert(t, rotated == nil)
+
+	body = store.AssetManagerRotationBody{Action: store.AssetManagerRotation_REMOVE, PublicKey: "b"}
+	_, err = body.Apply(store.AssetManagers{{PublicKey: "b"}})
+	assert.ErrorContains(t, err, "at least one asset manager")
+}
+
+func TestValidateAssetManagerRotationRequiresQuorum(t *testing.T) {
+	first := newTestManager(t)
+	second := newTestManager(t)
+	third := newTestManager(t)
+	replacement := newTestManager(t)
+
+	mint := store.Mint{MintWithoutID: store.MintWithoutID{
+		Hash:                     support.GenerateRandomHash(),
+		SignatureRequirementType: store.SignatureRequirementType_MIN_SIGNATURES,
+		MinSignatures:            2,
+		AssetManagers:            store.AssetManagers{{PublicKey: first.pubHex}, {PublicKey: second.pubHex}, {PublicKey: third.pubHex}},
+	}}
+
+	body := store.AssetManagerRotationBody{MintHash: mint.Hash, Action: store.AssetManagerRotation_REPLACE, PublicKey: third.pubHex, Manager: &store.AssetManager{PublicKey: replacement.pubHex}, Timestamp: 1}
+
+	_, err := store.ValidateAssetManagerRotation(mint, signedRotation(t, body, first))
+	assert.ErrorContains(t, err, "quorum")
+
+	// The same manager approving twice does not make a quorum
+	_, err = store.ValidateAssetManagerRotation(mint, signedRotation(t, body, first, first))
+	assert.ErrorContains(t, err, "quorum")
+
+	_, err = store.ValidateAssetManagerRotation(mint, signedRotation(t, body, first, replacement))
+	assert.ErrorContains(t, err, "does not match any asset managers")
+
+	rotated, err := store.ValidateAssetManagerRotation(mint, signedRotation(t, body, first, second))
+	assert.NilError(t, err)
+	assert.Equal(t, len(rotated), 3)
+	assert.Assert(t, rotated.Contains(replacement.pubHex))
+	assert.Assert(t, !rotated.Contains(third.pubHex))
+
+	// Removing a manager may not leave fewer managers than required signatures
+	remove := store.AssetManagerRotationBody{MintHash: mint.Hash, Action: store.AssetManagerRotation_REMOVE, PublicKey: third.pubHex, Timestamp: 2}
+	mint.MinSignatures = 3
+	_, err = store.ValidateAssetManagerRotation(mint, signedRotation(t, remove, first, second, third))
+	assert.ErrorContains(t, err, "requires 3 signatures")
+}
+
+func TestAssetManagersAt(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	original := newTestManager(t)
+	replacement := newTestManager(t)
+
+	mintHash := support.GenerateRandomHash()
+	_, err := tokenStore.SaveMint(&store.MintWithoutID{
+		Hash:                     mintHash,
+		Title:                    "Mint",
+		FractionCount:            10,
+		SignatureRequirementType: store.SignatureRequirementType_ONE_SIGNATURE,
+		AssetManagers:            store.AssetManagers{{PublicKey: original.pubHex}},
+	}, "owner")
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+
+	rotation := signedRotation(t, store.AssetManagerRotationBody{MintHash: mintHash, Action: store.AssetManagerRotation_REPLACE, PublicKey: original.pubHex, Manager: &store.AssetManager{PublicKey: replacement.pubHex}, Timestamp: 1}, original)
+	rotated, err := store.ValidateAssetManagerRotation(mint, rotation)
+	assert.NilError(t, err)
+	err = tokenStore.SaveUnconfirmedAssetManagerRotation(&rotation)
+	assert.NilError(t, err)
+
+	rotationHash, _ := hex.DecodeString(rotation.Hash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainAssetManagerRotationMessage{RotationHash: rotationHash, MintHash: mintHashBytes}
+	encoded, err := proto.Marshal(message)
+	assert.NilError(t, err)
+	id, err := tokenStore.SaveOnChainTransaction(support.GenerateRandomHash(), 20, "blockHash20", 0, protocol.ACTION_ASSET_MANAGER_ROTATION, protocol.DEFAULT_VERSION, encoded, "anyone", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = tokenStore.ConfirmAssetManagerRotation(store.OnChainTransaction{Id: id, TxHash: "tx", Height: 20, BlockHash: "blockHash20", ActionType: protocol.ACTION_ASSET_MANAGER_ROTATION, ActionData: encoded}, message, rotated)
+	assert.NilError(t, err)
+
+	// The rotation applies to the transactions after it, including later ones in its block
+	before, err := tokenStore.WithAssetManagersAt(mint, 20, 0)
+	assert.NilError(t, err)
+	assert.Assert(t, before.AssetManagers.Contains(original.pubHex))
+	assert.Assert(t, before.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: original.pubHex}}))
+
+	after, err := tokenStore.WithAssetManagersAt(mint, 20, 1)
+	assert.NilError(t, err)
+	assert.Assert(t, !after.AssetManagers.Contains(original.pubHex))
+	assert.Assert(t, !after.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: original.pubHex}}))
+	assert.Assert(t, after.HasRequiredSignatures([]store.InvoiceSignature{{PublicKey: replacement.pubHex}}))
+
+	// Rolling back the rotation restores the original managers and returns the rotation to the unconfirmed pool
+	err = tokenStore.RollbackToBlockHeight(19)
+	assert.NilError(t, err)
+
+	current, err := tokenStore.WithCurrentAssetManagers(mint)
+	assert.NilError(t, err)
+	assert.Assert(t, current.AssetManagers.Contains(original.pubHex))
+
+	pending, err := tokenStore.GetUnconfirmedAssetManagerRotation(rotation.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, pending.Manager.PublicKey, replacement.pubHex)
+	assert.Equal(t, len(pending.Approvals), 1)
+}
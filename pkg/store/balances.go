@@ -24,24 +24,25 @@ func (s *TokenisationStore) UpsertTokenBalance(address, mintHash string, quantit
 }
 
 func (s *TokenisationStore) UpsertPendingTokenBalance(invoiceHash, mintHash string, quantity int, onchainTransactionId string, ownerAddress string) error {
-	return s.UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash, quantity, onchainTransactionId, ownerAddress, nil)
+	return s.UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash, quantity, onchainTransactionId, ownerAddress, 0, "", 0, nil)
 }
 
-func (s *TokenisationStore) UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash string, quantity int, onchainTransactionId string, ownerAddress string, tx *sql.Tx) error {
+// UpsertPendingTokenBalanceWithTx reserves the fractions of an invoice, released at the expiry height unless it is 0.
+func (s *TokenisationStore) UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash string, quantity int, onchainTransactionId string, ownerAddress string, blockHeight int64, blockHash string, expiryHeight int64, tx *sql.Tx) error {
 	log.Println("Upserting pending token balance:", invoiceHash, mintHash, quantity, onchainTransactionId, ownerAddress)
 
 	query := `
-	INSERT INTO pending_token_balances (invoice_hash, mint_hash, quantity, onchain_transaction_id, created_at, owner_address)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	INSERT INTO pending_token_balances (invoice_hash, mint_hash, quantity, onchain_transaction_id, created_at, owner_address, block_height, block_hash, expiry_height)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	ON CONFLICT (invoice_hash, mint_hash)
 	DO UPDATE SET quantity = $3
 	`
 
 	var err error
 	if tx != nil {
-		_, err = tx.Exec(query, invoiceHash, mintHash, quantity, onchainTransactionId, time.Now(), ownerAddress)
+		_, err = tx.Exec(query, invoiceHash, mintHash, quantity, onchainTransactionId, time.Now(), ownerAddress, blockHeight, blockHash, expiryHeight)
 	} else {
-		_, err = s.DB.Exec(query, invoiceHash, mintHash, quantity, onchainTransactionId, time.Now(), ownerAddress)
+		_, err = s.DB.Exec(query, invoiceHash, mintHash, quantity, onchainTransactionId, time.Now(), ownerAddress, blockHeight, blockHash, expiryHeight)
 	}
 
 	return err
@@ -301,28 +302,26 @@ func (s *TokenisationStore) GetPendingTokenBalances(address string, mintHash str
 	return tokenBalances, nil
 }
 
-func (s *TokenisationStore) UpsertTokenBalanceWithTransaction(address, mintHash string, quantity int, tx *sql.Tx) error {
-	log.Println("Upserting token balance with transaction:", address, mintHash, quantity)
+// UpsertTokenBalanceWithTransaction records a balance change attributed to the block
+// that caused it, so it can be undone if that block is rolled back.
+func (s *TokenisationStore) UpsertTokenBalanceWithTransaction(address, mintHash string, quantity int, blockHeight int64, blockHash string, tx *sql.Tx) error {
+	log.Println("Upserting token balance with transaction:", address, mintHash, quantity, blockHeight)
 
 	_, err := tx.Exec(`
-	INSERT INTO token_balances (address, mint_hash, quantity, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5)
-	`, address, mintHash, quantity, time.Now(), time.Now())
+	INSERT INTO token_balances (address, mint_hash, quantity, created_at, updated_at, block_height, block_hash)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, address, mintHash, quantity, time.Now(), time.Now(), blockHeight, blockHash)
 
 	return err
 }
 
-func (s *TokenisationStore) MovePendingToTokenBalance(pendingTokenBalance PendingTokenBalance, buyerAddress string, tx *sql.Tx) error {
-	_, err := tx.Exec(`
-	INSERT INTO token_balances (address, mint_hash, quantity, created_at, updated_at)
-	VALUES ($1, $2, $3, $4, $5)
-	`, buyerAddress, pendingTokenBalance.MintHash, pendingTokenBalance.Quantity, time.Now(), time.Now())
-
+func (s *TokenisationStore) MovePendingToTokenBalance(pendingTokenBalance PendingTokenBalance, buyerAddress string, blockHeight int64, blockHash string, tx *sql.Tx) error {
+	err := s.UpsertTokenBalanceWithTransaction(buyerAddress, pendingTokenBalance.MintHash, pendingTokenBalance.Quantity, blockHeight, blockHash, tx)
 	if err != nil {
 		return err
 	}
 
-	err = s.UpsertTokenBalanceWithTransaction(pendingTokenBalance.OwnerAddress, pendingTokenBalance.MintHash, -pendingTokenBalance.Quantity, tx)
+	err = s.UpsertTokenBalanceWithTransaction(pendingTokenBalance.OwnerAddress, pendingTokenBalance.MintHash, -pendingTokenBalance.Quantity, blockHeight, blockHash, tx)
 	if err != nil {
 		return err
 	}
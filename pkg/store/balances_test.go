@@ -207,7 +207,7 @@ func TestUpsertTokenBalanceWithTransaction(t *testing.T) {
 	defer tx.Rollback()
 
 	// Insert a token balance within transaction
-	err = db.UpsertTokenBalanceWithTransaction("address1", "mintHash1", 100, tx)
+	err = db.UpsertTokenBalanceWithTransaction("address1", "mintHash1", 100, 1, "blockHash", tx)
 	assert.NilError(t, err)
 
 	// Commit the transaction
@@ -241,7 +241,7 @@ func TestMovePendingToTokenBalance(t *testing.T) {
 	assert.NilError(t, err)
 
 	// Move pending to token balance
-	err = db.MovePendingToTokenBalance(pendingBalance, "buyer1", tx)
+	err = db.MovePendingToTokenBalance(pendingBalance, "buyer1", 1, "blockHash", tx)
 	assert.NilError(t, err)
 
 	// Commit the transaction
@@ -357,7 +357,7 @@ func TestTransactionRollback(t *testing.T) {
 	assert.NilError(t, err)
 
 	// Perform operations within transaction
-	err = db.MovePendingToTokenBalance(pendingBalance, "buyer1", tx)
+	err = db.MovePendingToTokenBalance(pendingBalance, "buyer1", 1, "blockHash", tx)
 	assert.NilError(t, err)
 
 	// Rollback the transaction
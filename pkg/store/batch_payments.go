@@ -0,0 +1,242 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+const (
+	BatchPaymentStatus_SETTLED        = "settled"
+	BatchPaymentStatus_PARTIALLY_PAID = "partially_paid"
+	BatchPaymentStatus_REJECTED       = "rejected"
+
+	BatchPaymentRejection_DUPLICATE_INVOICE = "DUPLICATE_INVOICE"
+)
+
+// BatchPaymentItem is one invoice of a batch payment with the koinu allocated to it, or why it was rejected.
+type BatchPaymentItem struct {
+	InvoiceHash string
+	Invoice     Invoice
+	AmountKoinu int64
+	ReasonCode  string
+	Reason      string
+	Violation   *TradeViolation
+}
+
+func (i *BatchPaymentItem) Rejected() bool {
+	return i.ReasonCode != ""
+}
+
+func (i *BatchPaymentItem) Reject(reasonCode string, reason string) {
+	i.ReasonCode = reasonCode
+	i.Reason = reason
+	i.AmountKoinu = 0
+}
+
+// BatchPaymentOutcome records what a batch payment did to one of its invoices.
+type BatchPaymentOutcome struct {
+	TxHash      string    `json:"tx_hash"`
+	InvoiceHash string    `json:"invoice_hash"`
+	Position    int       `json:"position"`
+	Status      string    `json:"status"`
+	AmountKoinu int64     `json:"amount_koinu"`
+	ReasonCode  string    `json:"reason_code"`
+	Reason      string    `json:"reason"`
+	BlockHeight int64     `json:"block_height"`
+	BlockHash   string    `json:"block_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+/*
+* MatchBatchPayment matches every invoice of a batch payment independently. The koinu paid to each
+* payment address are allocated to its invoices in the order they are listed, each taking what it
+* still owes and the last one taking any surplus. Invoices bought by another address, listed twice,
+* paid after their expiry or cancellation, or left with nothing are rejected on their own without
+* affecting the rest of the batch.
+* An invoice that is not confirmed yet fails the whole match so the batch is retried later.
+ */
+func (s *TokenisationStore) MatchBatchPayment(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainBatchPaymentMessage) ([]BatchPaymentItem, error) {
+	if onchainTransaction.ActionType != protocol.ACTION_BATCH_PAYMENT {
+		return nil, fmt.Errorf("action type is not batch payment: %d", onchainTransaction.ActionType)
+	}
+
+	if len(onchainMessage.InvoiceHashes) == 0 {
+		return nil, fmt.Errorf("%w: batch payment %s lists no invoices", ErrPaymentMismatch, onchainTransaction.TxHash)
+	}
+
+	items := make([]BatchPaymentItem, len(onchainMessage.InvoiceHashes))
+	listed := map[string]bool{}
+	lastForAddress := map[string]int{}
+	remaining := map[string]int64{}
+
+	for idx, invoiceHashBytes := range onchainMessage.InvoiceHashes {
+		item := &items[idx]
+		item.InvoiceHash = hex.EncodeToString(invoiceHashBytes)
+
+		if listed[item.InvoiceHash] {
+			item.Reject(BatchPaymentRejection_DUPLICATE_INVOICE, fmt.Sprintf("invoice %s is listed more than once", item.InvoiceHash))
+			continue
+		}
+		listed[item.InvoiceHash] = true
+
+		invoice, err := s.getPayableInvoice(item.InvoiceHash)
+		if err != nil {
+			return nil, err
+		}
+		item.Invoice = invoice
+
+		if onchainTransaction.Address != invoice.BuyerAddress {
+			item.Reject(OnChainRejection_SENDER_MISMATCH, fmt.Sprintf("invoice %s is bought by %s, paid by %s", invoice.Hash, invoice.BuyerAddress, onchainTransaction.Address))
+			continue
+		}
+
+		err = s.CheckPaymentOnTime(invoice, onchainTransaction.Height)
+		if errors.Is(err, ErrInvoiceExpired) {
+			item.Reject(OnChainRejection_INVOICE_EXPIRED, err.Error())
+			continue
+		}
+		if errors.Is(err, ErrInvoiceCancelled) {
+			item.Reject(OnChainRejection_INVOICE_CANCELLED, err.Error())
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		paymentAddress := invoice.PayableAddress()
+		if _, ok := remaining[paymentAddress]; !ok {
+			remaining[paymentAddress] = onchainTransaction.Values[paymentAddress]
+		}
+
+		item.AmountKoinu = min(max(invoice.TotalKoinu()-invoice.PaidKoinu, 0), remaining[paymentAddress])
+		remaining[paymentAddress] -= item.AmountKoinu
+		lastForAddress[paymentAddress] = idx
+	}
+
+	for paymentAddress, idx := range lastForAddress {
+		items[idx].AmountKoinu += remaining[paymentAddress]
+	}
+
+	for idx := range items {
+		item := &items[idx]
+		if !item.Rejected() && item.AmountKoinu <= 0 {
+			item.Reject(OnChainRejection_PAYMENT_MISMATCH, fmt.Sprintf("%s paid nothing to %s, the payment address of invoice %s", onchainTransaction.TxHash, item.Invoice.PayableAddress(), item.InvoiceHash))
+		}
+	}
+
+	return items, nil
+}
+
+// ProcessBatchPayment applies the payment of every accepted invoice and records the outcome of each, in one transaction.
+func (s *TokenisationStore) ProcessBatchPayment(onchainTransaction OnChainTransaction, items []BatchPaymentItem) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for position, item := range items {
+		outcome := BatchPaymentOutcome{
+			TxHash:      onchainTransaction.TxHash,
+			InvoiceHash: item.InvoiceHash,
+			Position:    position,
+			AmountKoinu: item.AmountKoinu,
+			ReasonCode:  item.ReasonCode,
+			Reason:      item.Reason,
+			BlockHeight: onchainTransaction.Height,
+			BlockHash:   onchainTransaction.BlockHash,
+		}
+
+		switch {
+		case item.Rejected():
+			outcome.Status = BatchPaymentStatus_REJECTED
+		case item.Invoice.PaidAt.Valid || item.Invoice.SettledBy(item.AmountKoinu):
+			outcome.Status = BatchPaymentStatus_SETTLED
+		default:
+			outcome.Status = BatchPaymentStatus_PARTIALLY_PAID
+		}
+
+		if item.Violation != nil {
+			_, err = s.SaveTradeRejection(TradeRejection{
+				InvoiceHash:     item.Invoice.Hash,
+				MintHash:        item.Invoice.MintHash,
+				BuyerAddress:    item.Invoice.BuyerAddress,
+				SellerAddress:   item.Invoice.SellerAddress,
+				Quantity:        item.Invoice.Quantity,
+				ReasonCode:      item.Violation.Code,
+				Reason:          item.Violation.Reason,
+				ActionType:      onchainTransaction.ActionType,
+				TransactionHash: onchainTransaction.TxHash,
+				BlockHeight:     onchainTransaction.Height,
+				BlockHash:       onchainTransaction.BlockHash,
+			}, tx)
+			if err != nil {
+				log.Println("Error saving trade rejection:", err)
+				return err
+			}
+		}
+
+		if !item.Rejected() {
+			err = s.applyPayment(onchainTransaction, item.Invoice, item.AmountKoinu, tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = s.saveBatchPaymentOutcome(outcome, tx)
+		if err != nil {
+			log.Println("Error saving batch payment outcome:", err)
+			return err
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *TokenisationStore) saveBatchPaymentOutcome(outcome BatchPaymentOutcome, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	INSERT INTO batch_payment_outcomes (tx_hash, invoice_hash, position, status, amount_koinu, reason_code, reason, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, outcome.TxHash, outcome.InvoiceHash, outcome.Position, outcome.Status, outcome.AmountKoinu, outcome.ReasonCode, outcome.Reason, outcome.BlockHeight, outcome.BlockHash, time.Now().UTC())
+
+	return err
+}
+
+// GetBatchPaymentOutcomes returns the per invoice outcomes of batch payments, filtered by transaction and invoice when given.
+func (s *TokenisationStore) GetBatchPaymentOutcomes(txHash string, invoiceHash string, offset int, limit int) ([]BatchPaymentOutcome, error) {
+	rows, err := s.DB.Query(`
+	SELECT tx_hash, invoice_hash, position, status, amount_koinu, reason_code, reason, block_height, block_hash, created_at
+	FROM batch_payment_outcomes
+	WHERE ($1 = '' OR tx_hash = $1) AND ($2 = '' OR invoice_hash = $2)
+	ORDER BY block_height DESC, tx_hash, position
+	LIMIT $3 OFFSET $4
+	`, txHash, invoiceHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outcomes := []BatchPaymentOutcome{}
+	for rows.Next() {
+		var outcome BatchPaymentOutcome
+		if err := rows.Scan(&outcome.TxHash, &outcome.InvoiceHash, &outcome.Position, &outcome.Status, &outcome.AmountKoinu, &outcome.ReasonCode, &outcome.Reason, &outcome.BlockHeight, &outcome.BlockHash, &outcome.CreatedAt); err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, rows.Err()
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProcessBurn removes tokens from the sender of the on chain transaction and records the burned supply.
+func (s *TokenisationStore) ProcessBurn(onchainTransaction OnChainTransaction, mintHash string, quantity int, burnHash string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	available, err := s.GetTransferableTokenBalance(onchainTransaction.Address, mintHash, onchainTransaction.Height, onchainTransaction.EffectiveBlockTime(), tx)
+	if err != nil {
+		log.Println("Error getting transferable token balance:", err)
+		return err
+	}
+
+	if available < quantity {
+		return fmt.Errorf("%w: %d < %d", ErrInsufficientTokenBalance, available, quantity)
+	}
+
+	err = s.UpsertTokenBalanceWithTransaction(onchainTransaction.Address, mintHash, -quantity, onchainTransaction.Height, onchainTransaction.BlockHash, tx)
+	if err != nil {
+		log.Println("Error debiting token balance:", err)
+		return err
+	}
+
+	var nullableBurnHash sql.NullString
+	if burnHash != "" {
+		nullableBurnHash = sql.NullString{String: burnHash, Valid: true}
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO token_burns (id, mint_hash, address, quantity, burn_hash, transaction_hash, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, uuid.New().String(), mintHash, onchainTransaction.Address, quantity, nullableBurnHash, onchainTransaction.TxHash, onchainTransaction.Height, onchainTransaction.BlockHash, time.Now())
+	if err != nil {
+		log.Println("Error recording token burn:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *TokenisationStore) GetBurnedSupply(mintHash string) (int, error) {
+	var burned int
+	err := s.DB.QueryRow("SELECT COALESCE(SUM(quantity), 0) FROM token_burns WHERE mint_hash = $1", mintHash).Scan(&burned)
+	if err != nil {
+		return 0, err
+	}
+
+	return burned, nil
+}
+
+func (s *TokenisationStore) HasTokenBurn(burnHash string) (bool, error) {
+	var count int
+	err := s.DB.QueryRow("SELECT COUNT(*) FROM token_burns WHERE burn_hash = $1", burnHash).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *TokenisationStore) GetTokenBurns(mintHash string) ([]TokenBurn, error) {
+	rows, err := s.DB.Query(`
+	SELECT id, mint_hash, address, quantity, COALESCE(burn_hash, ''), transaction_hash, block_height, block_hash, created_at
+	FROM token_burns WHERE mint_hash = $1 ORDER BY block_height ASC
+	`, mintHash)
+	if err != nil {
+		return []TokenBurn{}, err
+	}
+	defer rows.Close()
+
+	burns := []TokenBurn{}
+	for rows.Next() {
+		var burn TokenBurn
+		if err := rows.Scan(&burn.Id, &burn.MintHash, &burn.Address, &burn.Quantity, &burn.BurnHash, &burn.TransactionHash, &burn.BlockHeight, &burn.BlockHash, &burn.CreatedAt); err != nil {
+			return []TokenBurn{}, err
+		}
+		burns = append(burns, burn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []TokenBurn{}, err
+	}
+
+	return burns, nil
+}
@@ -0,0 +1,115 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestProcessBurn(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	id, err := db.SaveOnChainTransaction("burnTx", 10, "blockHash10", 0, protocol.ACTION_BURN, protocol.DEFAULT_VERSION, []byte{}, "owner1", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = db.ProcessBurn(store.OnChainTransaction{Id: id, TxHash: "burnTx", Height: 10, BlockHash: "blockHash10", Address: "owner1"}, "mintHash1", 30, "")
+	assert.NilError(t, err)
+
+	available, err := db.GetAvailableTokenBalance("owner1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 70)
+
+	burned, err := db.GetBurnedSupply("mintHash1")
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 30)
+
+	burns, err := db.GetTokenBurns("mintHash1")
+	assert.NilError(t, err)
+	assert.Equal(t, len(burns), 1)
+	assert.Equal(t, burns[0].Address, "owner1")
+	assert.Equal(t, burns[0].TransactionHash, "burnTx")
+
+	count, err := db.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+
+	// The burn is attributed to its block and is undone by a rollback
+	err = db.RollbackToBlockHeight(9)
+	assert.NilError(t, err)
+
+	burned, err = db.GetBurnedSupply("mintHash1")
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 0)
+
+	available, err = db.GetAvailableTokenBalance("owner1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 100)
+}
+
+func TestProcessBurnRespectsPendingBalances(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	err = db.UpsertPendingTokenBalance("invoice1", "mintHash1", 80, "onchainTx1", "owner1")
+	assert.NilError(t, err)
+
+	err = db.ProcessBurn(store.OnChainTransaction{Id: "burnTx", Height: 10, Address: "owner1"}, "mintHash1", 40, "")
+	assert.Assert(t, errors.Is(err, store.ErrInsufficientTokenBalance))
+
+	burned, err := db.GetBurnedSupply("mintHash1")
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 0)
+}
+
+func TestProcessBurnRespectsLockups(t *testing.T) {
+	db := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	_, err := db.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		LockupOptions: store.StringInterfaceMap{"unlock_block_height": float64(50)},
+	}, "owner1")
+	assert.NilError(t, err)
+
+	creditTokenBalance(t, db, "owner1", mintHash, 100, 1)
+
+	// Locked fractions cannot be burned until they unlock
+	err = db.ProcessBurn(store.OnChainTransaction{Id: "burnTx", Height: 49, BlockTime: 1, Address: "owner1"}, mintHash, 10, "")
+	assert.Assert(t, errors.Is(err, store.ErrInsufficientTokenBalance))
+
+	burned, err := db.GetBurnedSupply(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, burned, 0)
+
+	err = db.ProcessBurn(store.OnChainTransaction{Id: "burnTx", Height: 50, BlockTime: 1, Address: "owner1"}, mintHash, 10, "")
+	assert.NilError(t, err)
+}
+
+func TestHasTokenBurn(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	err = db.ProcessBurn(store.OnChainTransaction{Id: "burnTx", TxHash: "burnTx", Height: 10, BlockHash: "blockHash10", Address: "owner1"}, "mintHash1", 10, "burnHash1")
+	assert.NilError(t, err)
+
+	burned, err := db.HasTokenBurn("burnHash1")
+	assert.NilError(t, err)
+	assert.Assert(t, burned)
+
+	burned, err = db.HasTokenBurn("burnHash2")
+	assert.NilError(t, err)
+	assert.Assert(t, !burned)
+}
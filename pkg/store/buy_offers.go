@@ -10,12 +10,12 @@ import (
 func (s *TokenisationStore) SaveBuyOffer(d *BuyOfferWithoutID) (string, error) {
 	id := uuid.New().String()
 
-	log.Println("SaveBuyOffer", d.OffererAddress, d.SellerAddress, d.Hash, d.MintHash, d.Quantity, d.Price, d.CreatedAt, d.PublicKey, d.Signature)
+	log.Println("SaveBuyOffer", d.OffererAddress, d.SellerAddress, d.Hash, d.MintHash, d.Quantity, d.PriceKoinu, d.CreatedAt, d.PublicKey, d.Signature)
 
 	_, err := s.DB.Exec(`
-	INSERT INTO buy_offers (id, offerer_address, seller_address, hash, mint_hash, quantity, price, created_at, public_key, signature)
+	INSERT INTO buy_offers (id, offerer_address, seller_address, hash, mint_hash, quantity, price_koinu, created_at, public_key, signature)
 	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`, id, d.OffererAddress, d.SellerAddress, d.Hash, d.MintHash, d.Quantity, d.Price, d.CreatedAt, d.PublicKey, d.Signature)
+	`, id, d.OffererAddress, d.SellerAddress, d.Hash, d.MintHash, d.Quantity, d.PriceKoinu, d.CreatedAt, d.PublicKey, d.Signature)
 
 	return id, err
 }
@@ -39,9 +39,9 @@ func (s *TokenisationStore) GetBuyOffersByMintAndSellerAddress(offset int, limit
 	log.Println("GetBuyOffersByMintAndSellerAddress", mintHash, sellerAddress)
 
 	if sellerAddress == "" {
-		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, seller_address, hash, mint_hash, quantity, price, public_key, signature FROM buy_offers WHERE mint_hash = $1 LIMIT $2 OFFSET $3", mintHash, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, seller_address, hash, mint_hash, quantity, price_koinu, public_key, signature FROM buy_offers WHERE mint_hash = $1 LIMIT $2 OFFSET $3", mintHash, limit, offset)
 	} else {
-		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, seller_address, hash, mint_hash, quantity, price, public_key, signature FROM buy_offers WHERE mint_hash = $1 AND seller_address = $2 LIMIT $3 OFFSET $4", mintHash, sellerAddress, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, seller_address, hash, mint_hash, quantity, price_koinu, public_key, signature FROM buy_offers WHERE mint_hash = $1 AND seller_address = $2 LIMIT $3 OFFSET $4", mintHash, sellerAddress, limit, offset)
 	}
 
 	if err != nil {
@@ -53,7 +53,7 @@ func (s *TokenisationStore) GetBuyOffersByMintAndSellerAddress(offset int, limit
 
 	for rows.Next() {
 		var offer BuyOffer
-		if err := rows.Scan(&offer.Id, &offer.CreatedAt, &offer.OffererAddress, &offer.SellerAddress, &offer.Hash, &offer.MintHash, &offer.Quantity, &offer.Price, &offer.PublicKey, &offer.Signature); err != nil {
+		if err := rows.Scan(&offer.Id, &offer.CreatedAt, &offer.OffererAddress, &offer.SellerAddress, &offer.Hash, &offer.MintHash, &offer.Quantity, &offer.PriceKoinu, &offer.PublicKey, &offer.Signature); err != nil {
 			return nil, err
 		}
 
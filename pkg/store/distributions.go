@@ -0,0 +1,308 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var ErrNoHolders = errors.New("no holders at record height")
+
+/*
+* A Distribution shares TotalKoinu between the holders of a mint in proportion to
+* the fractions they held at RecordHeight. The hash is the hash of the transaction
+* that published the distribution.
+ */
+type Distribution struct {
+	Hash           string    `json:"hash"`
+	MintHash       string    `json:"mint_hash"`
+	OwnerAddress   string    `json:"owner_address"`
+	TotalKoinu     int64     `json:"total_koinu"`
+	RecordHeight   int64     `json:"record_height"`
+	TotalFractions int       `json:"total_fractions"`
+	BlockHeight    int64     `json:"block_height"`
+	BlockHash      string    `json:"block_hash"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type DistributionPayout struct {
+	DistributionHash    string `json:"distribution_hash"`
+	Address             string `json:"address"`
+	Fractions           int    `json:"fractions"`
+	AmountKoinu         int64  `json:"amount_koinu"`
+	PaidTransactionHash string `json:"paid_transaction_hash,omitempty"`
+	PaidBlockHeight     int64  `json:"paid_block_height,omitempty"`
+}
+
+func (p DistributionPayout) Paid() bool {
+	return p.PaidTransactionHash != ""
+}
+
+type TokenHolder struct {
+	Address  string `json:"address"`
+	Quantity int    `json:"quantity"`
+}
+
+// GetTokenHoldersAtHeight returns the holders of a mint from the token balance ledger as of blockHeight.
+func (s *TokenisationStore) GetTokenHoldersAtHeight(mintHash string, blockHeight int64, tx *sql.Tx) ([]TokenHolder, error) {
+	query := `
+	SELECT address, SUM(quantity) FROM token_balances
+	WHERE mint_hash = $1 AND COALESCE(block_height, 0) <= $2
+	GROUP BY address
+	HAVING SUM(quantity) > 0
+	ORDER BY address
+	`
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, mintHash, blockHeight)
+	} else {
+		rows, err = s.DB.Query(query, mintHash, blockHeight)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holders := []TokenHolder{}
+	for rows.Next() {
+		var holder TokenHolder
+		if err := rows.Scan(&holder.Address, &holder.Quantity); err != nil {
+			return nil, err
+		}
+		holders = append(holders, holder)
+	}
+
+	return holders, rows.Err()
+}
+
+/*
+* ComputeDistributionPayouts shares totalKoinu pro rata between the holders.
+* Shares are rounded down and the koinu left over are handed out one at a time
+* by largest remainder (ties broken by address), so the payouts always add up to totalKoinu.
+ */
+func ComputeDistributionPayouts(distributionHash string, totalKoinu int64, holders []TokenHolder) []DistributionPayout {
+	totalFractions := big.NewInt(0)
+	for _, holder := range holders {
+		totalFractions.Add(totalFractions, big.NewInt(int64(holder.Quantity)))
+	}
+
+	if totalFractions.Sign() == 0 {
+		return []DistributionPayout{}
+	}
+
+	payouts := make([]DistributionPayout, len(holders))
+	remainders := make([]*big.Int, len(holders))
+	allocated := int64(0)
+
+	for i, holder := range holders {
+		share := new(big.Int).Mul(big.NewInt(totalKoinu), big.NewInt(int64(holder.Quantity)))
+		remainder := new(big.Int)
+		share.QuoRem(share, totalFractions, remainder)
+
+		payouts[i] = DistributionPayout{
+			DistributionHash: distributionHash,
+			Address:          holder.Address,
+			Fractions:        holder.Quantity,
+			AmountKoinu:      share.Int64(),
+		}
+		remainders[i] = remainder
+		allocated += share.Int64()
+	}
+
+	order := make([]int, len(holders))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		cmp := remainders[order[a]].Cmp(remainders[order[b]])
+		if cmp != 0 {
+			return cmp > 0
+		}
+		return payouts[order[a]].Address < payouts[order[b]].Address
+	})
+
+	for i := 0; allocated < totalKoinu; i++ {
+		payouts[order[i%len(order)]].AmountKoinu++
+		allocated++
+	}
+
+	return payouts
+}
+
+// ProcessDistribution snapshots the holders at the record height and stores the payout plan.
+func (s *TokenisationStore) ProcessDistribution(onchainTransaction OnChainTransaction, mintHash string, totalKoinu int64, recordHeight int64) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	holders, err := s.GetTokenHoldersAtHeight(mintHash, recordHeight, tx)
+	if err != nil {
+		log.Println("Error getting token holders:", err)
+		return err
+	}
+
+	if len(holders) == 0 {
+		return ErrNoHolders
+	}
+
+	payouts := ComputeDistributionPayouts(onchainTransaction.TxHash, totalKoinu, holders)
+
+	totalFractions := 0
+	for _, payout := range payouts {
+		totalFractions += payout.Fractions
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO distributions (hash, mint_hash, owner_address, total_koinu, record_height, total_fractions, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, onchainTransaction.TxHash, mintHash, onchainTransaction.Address, totalKoinu, recordHeight, totalFractions, onchainTransaction.Height, onchainTransaction.BlockHash, time.Now())
+	if err != nil {
+		log.Println("Error inserting distribution:", err)
+		return err
+	}
+
+	for _, payout := range payouts {
+		_, err = tx.Exec(`
+		INSERT INTO distribution_payouts (distribution_hash, address, fractions, amount_koinu)
+		VALUES ($1, $2, $3, $4)
+		`, payout.DistributionHash, payout.Address, payout.Fractions, payout.AmountKoinu)
+		if err != nil {
+			log.Println("Error inserting distribution payout:", err)
+			return err
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+* ProcessDistributionPayout marks every unpaid payout of the distribution whose holder
+* received at least the payout amount in the transaction outputs. It returns the number
+* of payouts marked as paid.
+ */
+func (s *TokenisationStore) ProcessDistributionPayout(onchainTransaction OnChainTransaction, distributionHash string) (int, error) {
+	payouts, err := s.GetDistributionPayouts(distributionHash)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	paid := 0
+	for _, payout := range payouts {
+		if payout.Paid() {
+			continue
+		}
+
+		if onchainTransaction.Values[payout.Address] < payout.AmountKoinu {
+			continue
+		}
+
+		_, err = tx.Exec(`
+		UPDATE distribution_payouts SET paid_transaction_hash = $1, paid_block_height = $2, paid_block_hash = $3
+		WHERE distribution_hash = $4 AND address = $5
+		`, onchainTransaction.TxHash, onchainTransaction.Height, onchainTransaction.BlockHash, distributionHash, payout.Address)
+		if err != nil {
+			log.Println("Error marking payout as paid:", err)
+			return 0, err
+		}
+		paid++
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return 0, err
+	}
+
+	return paid, tx.Commit()
+}
+
+// GetDistribution returns an empty Distribution (Hash == "") when not found.
+func (s *TokenisationStore) GetDistribution(hash string) (Distribution, error) {
+	var distribution Distribution
+	err := s.DB.QueryRow(`
+	SELECT hash, mint_hash, owner_address, total_koinu, record_height, total_fractions, block_height, block_hash, created_at
+	FROM distributions WHERE hash = $1
+	`, hash).Scan(&distribution.Hash, &distribution.MintHash, &distribution.OwnerAddress, &distribution.TotalKoinu, &distribution.RecordHeight, &distribution.TotalFractions, &distribution.BlockHeight, &distribution.BlockHash, &distribution.CreatedAt)
+	if err == sql.ErrNoRows {
+		return Distribution{}, nil
+	}
+
+	return distribution, err
+}
+
+func (s *TokenisationStore) GetDistributions(mintHash string, offset int, limit int) ([]Distribution, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, owner_address, total_koinu, record_height, total_fractions, block_height, block_hash, created_at
+	FROM distributions WHERE ($1 = '' OR mint_hash = $1)
+	ORDER BY block_height DESC
+	LIMIT $2 OFFSET $3
+	`, mintHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distributions := []Distribution{}
+	for rows.Next() {
+		var distribution Distribution
+		if err := rows.Scan(&distribution.Hash, &distribution.MintHash, &distribution.OwnerAddress, &distribution.TotalKoinu, &distribution.RecordHeight, &distribution.TotalFractions, &distribution.BlockHeight, &distribution.BlockHash, &distribution.CreatedAt); err != nil {
+			return nil, err
+		}
+		distributions = append(distributions, distribution)
+	}
+
+	return distributions, rows.Err()
+}
+
+func (s *TokenisationStore) GetDistributionPayouts(distributionHash string) ([]DistributionPayout, error) {
+	rows, err := s.DB.Query(`
+	SELECT distribution_hash, address, fractions, amount_koinu, COALESCE(paid_transaction_hash, ''), COALESCE(paid_block_height, 0)
+	FROM distribution_payouts WHERE distribution_hash = $1
+	ORDER BY address
+	`, distributionHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payouts := []DistributionPayout{}
+	for rows.Next() {
+		var payout DistributionPayout
+		if err := rows.Scan(&payout.DistributionHash, &payout.Address, &payout.Fractions, &payout.AmountKoinu, &payout.PaidTransactionHash, &payout.PaidBlockHeight); err != nil {
+			return nil, err
+		}
+		payouts = append(payouts, payout)
+	}
+
+	return payouts, rows.Err()
+}
+
+func (s *TokenisationStore) HasDistribution(hash string) (bool, error) {
+	var count int
+	err := s.DB.QueryRow("SELECT COUNT(*) FROM distributions WHERE hash = $1", hash).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check distribution: %w", err)
+	}
+
+	return count > 0, nil
+}
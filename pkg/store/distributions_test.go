@@ -0,0 +1,137 @@
+package store_test
+
+import (
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestComputeDistributionPayouts(t *testing.T) {
+	holders := []store.TokenHolder{
+		{Address: "a", Quantity: 1},
+		{Address: "b", Quantity: 1},
+		{Address: "c", Quantity: 1},
+	}
+
+	payouts := store.ComputeDistributionPayouts("hash", 100, holders)
+	assert.Equal(t, len(payouts), 3)
+	assert.Equal(t, payouts[0].AmountKoinu, int64(34))
+	assert.Equal(t, payouts[1].AmountKoinu, int64(33))
+	assert.Equal(t, payouts[2].AmountKoinu, int64(33))
+
+	// Large amounts do not overflow
+	holders = []store.TokenHolder{
+		{Address: "a", Quantity: 750_000_000},
+		{Address: "b", Quantity: 250_000_000},
+	}
+
+	payouts = store.ComputeDistributionPayouts("hash", 10_000_000_000_000_000, holders)
+	assert.Equal(t, payouts[0].AmountKoinu, int64(7_500_000_000_000_000))
+	assert.Equal(t, payouts[1].AmountKoinu, int64(2_500_000_000_000_000))
+
+	assert.Equal(t, len(store.ComputeDistributionPayouts("hash", 100, []store.TokenHolder{})), 0)
+}
+
+func TestGetTokenHoldersAtHeight(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	buyer := support.GenerateDogecoinAddress(true)
+
+	creditTokenBalance(t, tokenStore, owner, mintHash, 100, 1)
+	creditTokenBalance(t, tokenStore, owner, mintHash, -40, 5)
+	creditTokenBalance(t, tokenStore, buyer, mintHash, 40, 5)
+
+	holders, err := tokenStore.GetTokenHoldersAtHeight(mintHash, 4, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, holders, []store.TokenHolder{{Address: owner, Quantity: 100}})
+
+	holders, err = tokenStore.GetTokenHoldersAtHeight(mintHash, 5, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(holders), 2)
+}
+
+func TestProcessDistributionAndPayout(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	distributionHash := support.GenerateRandomHash()
+	owner := support.GenerateDogecoinAddress(true)
+	buyer := support.GenerateDogecoinAddress(true)
+
+	creditTokenBalance(t, tokenStore, owner, mintHash, 75, 1)
+	creditTokenBalance(t, tokenStore, buyer, mintHash, 25, 1)
+	// Acquired after the record height
+	creditTokenBalance(t, tokenStore, support.GenerateDogecoinAddress(true), mintHash, 10, 8)
+
+	err := tokenStore.ProcessDistribution(store.OnChainTransaction{Id: "distributionTx", TxHash: distributionHash, Height: 10, BlockHash: "blockHash10", Address: owner}, mintHash, 400_000_000, 5)
+	assert.NilError(t, err)
+
+	distribution, err := tokenStore.GetDistribution(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, distribution.TotalFractions, 100)
+	assert.Equal(t, distribution.OwnerAddress, owner)
+
+	payouts, err := tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(payouts), 2)
+
+	amounts := map[string]int64{}
+	for _, payout := range payouts {
+		amounts[payout.Address] = payout.AmountKoinu
+	}
+	assert.Equal(t, amounts[owner], int64(300_000_000))
+	assert.Equal(t, amounts[buyer], int64(100_000_000))
+
+	// The buyer is underpaid, the owner is paid through the change output
+	payoutTx := store.OnChainTransaction{
+		Id:         "payoutTx",
+		TxHash:     "payoutTx",
+		Height:     11,
+		BlockHash:  "blockHash11",
+		ActionType: protocol.ACTION_DISTRIBUTION_PAYOUT,
+		Values:     store.KoinuValues{owner: 500_000_000, buyer: 50_000_000},
+	}
+	paid, err := tokenStore.ProcessDistributionPayout(payoutTx, distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, paid, 1)
+
+	payoutTx.TxHash = "payoutTx2"
+	payoutTx.Height = 12
+	payoutTx.Values = store.KoinuValues{buyer: 100_000_000}
+	paid, err = tokenStore.ProcessDistributionPayout(payoutTx, distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, paid, 1)
+
+	payouts, err = tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	for _, payout := range payouts {
+		assert.Assert(t, payout.Paid())
+	}
+
+	// Rolling back the second payout marks the buyer unpaid again
+	err = tokenStore.RollbackToBlockHeight(11)
+	assert.NilError(t, err)
+
+	payouts, err = tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	for _, payout := range payouts {
+		assert.Equal(t, payout.Paid(), payout.Address == owner)
+	}
+
+	// Rolling back the distribution removes it
+	err = tokenStore.RollbackToBlockHeight(9)
+	assert.NilError(t, err)
+
+	distribution, err = tokenStore.GetDistribution(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, distribution.Hash, "")
+
+	payouts, err = tokenStore.GetDistributionPayouts(distributionHash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(payouts), 0)
+}
@@ -0,0 +1,228 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	InvoiceRelease_EXPIRED   = "expired"
+	InvoiceRelease_CANCELLED = "cancelled"
+)
+
+// ErrInvoiceExpired is returned when a payment is made after the invoice's expiry height.
+var ErrInvoiceExpired = errors.New("invoice has expired")
+
+// ErrInvoiceCancelled is returned when a payment is made after the seller cancelled the invoice.
+var ErrInvoiceCancelled = errors.New("invoice has been cancelled")
+
+// ErrInvoicePaid is returned when the seller cancels an invoice that has already been settled.
+var ErrInvoicePaid = errors.New("invoice has been paid")
+
+// InvoiceRelease records the reservation of an invoice released at its expiry or by the seller.
+type InvoiceRelease struct {
+	InvoiceHash          string    `json:"invoice_hash"`
+	MintHash             string    `json:"mint_hash"`
+	Reason               string    `json:"reason"`
+	TxHash               string    `json:"tx_hash"`
+	OwnerAddress         string    `json:"owner_address"`
+	Quantity             int       `json:"quantity"`
+	OnchainTransactionId string    `json:"onchain_transaction_id"`
+	ExpiryHeight         int64     `json:"expiry_height"`
+	BlockHeight          int64     `json:"block_height"`
+	BlockHash            string    `json:"block_hash"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// GetInvoiceRelease returns the release of the invoice, or sql.ErrNoRows while its reservation is held.
+func (s *TokenisationStore) GetInvoiceRelease(invoiceHash string) (InvoiceRelease, error) {
+	row := s.DB.QueryRow(`
+	SELECT invoice_hash, mint_hash, reason, tx_hash, owner_address, quantity, onchain_transaction_id, expiry_height, block_height, block_hash, created_at
+	FROM invoice_releases WHERE invoice_hash = $1
+	`, invoiceHash)
+
+	var release InvoiceRelease
+	err := row.Scan(&release.InvoiceHash, &release.MintHash, &release.Reason, &release.TxHash, &release.OwnerAddress, &release.Quantity, &release.OnchainTransactionId, &release.ExpiryHeight, &release.BlockHeight, &release.BlockHash, &release.CreatedAt)
+	if err != nil {
+		return InvoiceRelease{}, err
+	}
+
+	return release, nil
+}
+
+// CheckPaymentOnTime returns ErrInvoiceExpired or ErrInvoiceCancelled for a payment made at a height after the invoice was closed.
+func (s *TokenisationStore) CheckPaymentOnTime(invoice Invoice, height int64) error {
+	if invoice.ExpiryHeight > 0 && height > invoice.ExpiryHeight {
+		return fmt.Errorf("%w: invoice %s expired at height %d, paid at %d", ErrInvoiceExpired, invoice.Hash, invoice.ExpiryHeight, height)
+	}
+
+	release, err := s.GetInvoiceRelease(invoice.Hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if release.Reason == InvoiceRelease_CANCELLED && height > release.BlockHeight {
+		return fmt.Errorf("%w: invoice %s was cancelled at height %d, paid at %d", ErrInvoiceCancelled, invoice.Hash, release.BlockHeight, height)
+	}
+
+	return nil
+}
+
+// GetPendingPaymentHeights returns, for every invoice with a payment waiting to be processed, the lowest height it was paid at.
+func (s *TokenisationStore) GetPendingPaymentHeights() (map[string]int64, error) {
+	rows, err := s.DB.Query("SELECT action_type, action_data, block_height FROM onchain_transactions WHERE action_type IN ($1, $2)", protocol.ACTION_PAYMENT, protocol.ACTION_BATCH_PAYMENT)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	heights := map[string]int64{}
+	paid := func(invoiceHash string, height int64) {
+		if lowest, ok := heights[invoiceHash]; !ok || height < lowest {
+			heights[invoiceHash] = height
+		}
+	}
+
+	for rows.Next() {
+		var actionType uint8
+		var actionData []byte
+		var height int64
+		if err := rows.Scan(&actionType, &actionData, &height); err != nil {
+			return nil, err
+		}
+
+		// Payments that do not decode are rejected by the processor and hold nothing back
+		if actionType == protocol.ACTION_PAYMENT {
+			var message protocol.OnChainPaymentMessage
+			if proto.Unmarshal(actionData, &message) == nil {
+				paid(message.Hash, height)
+			}
+			continue
+		}
+
+		var message protocol.OnChainBatchPaymentMessage
+		if proto.Unmarshal(actionData, &message) == nil {
+			for _, invoiceHash := range message.InvoiceHashes {
+				paid(hex.EncodeToString(invoiceHash), height)
+			}
+		}
+	}
+
+	return heights, rows.Err()
+}
+
+/*
+* CancelInvoice releases the reservation of the invoice on behalf of its seller and discards the
+* cancellation transaction. Payments made up to the height of the cancellation still count.
+ */
+func (s *TokenisationStore) CancelInvoice(onchainTransaction OnChainTransaction, invoiceHash string, mintHash string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+	INSERT INTO invoice_releases (invoice_hash, mint_hash, reason, tx_hash, owner_address, quantity, onchain_transaction_id, expiry_height, reserved_block_height, reserved_block_hash, block_height, block_hash, created_at)
+	SELECT $1, $2, $3, $4, COALESCE(MAX(p.owner_address), $5), COALESCE(MAX(p.quantity), 0), COALESCE(MAX(p.onchain_transaction_id), ''), COALESCE(MAX(p.expiry_height), 0), MAX(p.block_height), MAX(p.block_hash), $6, $7, $8
+	FROM pending_token_balances p WHERE p.invoice_hash = $1 AND p.mint_hash = $2
+	`, invoiceHash, mintHash, InvoiceRelease_CANCELLED, onchainTransaction.TxHash, onchainTransaction.Address, onchainTransaction.Height, onchainTransaction.BlockHash, time.Now().UTC())
+	if err != nil {
+		log.Println("Error saving invoice release:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM pending_token_balances WHERE invoice_hash = $1 AND mint_hash = $2", invoiceHash, mintHash)
+	if err != nil {
+		log.Println("Error removing pending token balance:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+* ExpireInvoices releases the reservations of unpaid invoices whose expiry height is below the height given,
+* which should be a height the chain has been followed past. An invoice with a payment made in time that is
+* still waiting for its confirmations keeps its reservation until that payment is processed.
+ */
+func (s *TokenisationStore) ExpireInvoices(belowHeight int64) (int, error) {
+	pendingPayments, err := s.GetPendingPaymentHeights()
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := s.DB.Query("SELECT invoice_hash, mint_hash, expiry_height FROM pending_token_balances WHERE expiry_height > 0 AND expiry_height < $1", belowHeight)
+	if err != nil {
+		return 0, err
+	}
+
+	type reservation struct {
+		invoiceHash  string
+		mintHash     string
+		expiryHeight int64
+	}
+
+	expired := []reservation{}
+	for rows.Next() {
+		var r reservation
+		if err := rows.Scan(&r.invoiceHash, &r.mintHash, &r.expiryHeight); err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		if height, ok := pendingPayments[r.invoiceHash]; ok && height <= r.expiryHeight {
+			continue
+		}
+
+		expired = append(expired, r)
+	}
+	rows.Close()
+
+	if len(expired) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	for _, r := range expired {
+		_, err = tx.Exec(`
+		INSERT INTO invoice_releases (invoice_hash, mint_hash, reason, tx_hash, owner_address, quantity, onchain_transaction_id, expiry_height, reserved_block_height, reserved_block_hash, block_height, block_hash, created_at)
+		SELECT invoice_hash, mint_hash, $1, '', owner_address, quantity, onchain_transaction_id, expiry_height, block_height, block_hash, expiry_height + 1, '', $2
+		FROM pending_token_balances WHERE invoice_hash = $3 AND mint_hash = $4
+		ON CONFLICT (invoice_hash) DO NOTHING
+		`, InvoiceRelease_EXPIRED, time.Now().UTC(), r.invoiceHash, r.mintHash)
+		if err != nil {
+			log.Println("Error saving invoice release:", err)
+			return 0, err
+		}
+
+		_, err = tx.Exec("DELETE FROM pending_token_balances WHERE invoice_hash = $1 AND mint_hash = $2", r.invoiceHash, r.mintHash)
+		if err != nil {
+			log.Println("Error removing expired pending token balance:", err)
+			return 0, err
+		}
+	}
+
+	return len(expired), tx.Commit()
+}
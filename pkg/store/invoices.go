@@ -7,15 +7,18 @@ import (
 
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/proto"
 )
 
+// invoiceReleaseReasonColumn selects why the invoice's reservation was released, empty while it is held.
+const invoiceReleaseReasonColumn = "COALESCE((SELECT r.reason FROM invoice_releases r WHERE r.invoice_hash = invoices.hash), '')"
+
 func (s *TokenisationStore) ChooseInvoice() (Invoice, error) {
-	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, paid_at FROM invoices WHERE hash IN (SELECT hash FROM invoices ORDER BY RANDOM() LIMIT 1)")
+	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, paid_at, paid_koinu, expiry_height, redeem_script, multisig_signatures, " + invoiceReleaseReasonColumn + " FROM invoices WHERE hash IN (SELECT hash FROM invoices ORDER BY RANDOM() LIMIT 1)")
 	var invoice Invoice
-	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt); err != nil {
+	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt, &invoice.PaidKoinu, &invoice.ExpiryHeight, &invoice.RedeemScript, &invoice.MultisigSignatures, &invoice.ReleaseReason); err != nil {
 		return Invoice{}, err
 	}
+	invoice.setPaymentState()
 	return invoice, nil
 }
 
@@ -29,25 +32,26 @@ func (s *TokenisationStore) ChooseInvoiceSignature() (InvoiceSignature, error) {
 }
 
 func (s *TokenisationStore) GetInvoiceByHash(hash string) (Invoice, error) {
-	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, paid_at FROM invoices WHERE hash = $1", hash)
+	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, paid_at, paid_koinu, expiry_height, redeem_script, multisig_signatures, "+invoiceReleaseReasonColumn+" FROM invoices WHERE hash = $1", hash)
 	var invoice Invoice
-	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt); err != nil {
+	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt, &invoice.PaidKoinu, &invoice.ExpiryHeight, &invoice.RedeemScript, &invoice.MultisigSignatures, &invoice.ReleaseReason); err != nil {
 		return Invoice{}, err
 	}
+	invoice.setPaymentState()
 	return invoice, nil
 }
 
 func (s *TokenisationStore) GetUnconfirmedInvoiceByHash(hash string) (UnconfirmedInvoice, error) {
-	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature FROM unconfirmed_invoices WHERE hash = $1", hash)
+	row := s.DB.QueryRow("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, redeem_script, multisig_signatures FROM unconfirmed_invoices WHERE hash = $1", hash)
 	var invoice UnconfirmedInvoice
-	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature); err != nil {
+	if err := row.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.RedeemScript, &invoice.MultisigSignatures); err != nil {
 		return UnconfirmedInvoice{}, err
 	}
 	return invoice, nil
 }
 
 func (s *TokenisationStore) GetInvoicesForMe(offset int, limit int, myAddress string) ([]Invoice, error) {
-	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, paid_at FROM invoices WHERE (buyer_address = $1 OR seller_address = $1) LIMIT $2 OFFSET $3", myAddress, limit, offset)
+	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, paid_at, paid_koinu, expiry_height, redeem_script, multisig_signatures, "+invoiceReleaseReasonColumn+" FROM invoices WHERE (buyer_address = $1 OR seller_address = $1) LIMIT $2 OFFSET $3", myAddress, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -57,10 +61,11 @@ func (s *TokenisationStore) GetInvoicesForMe(offset int, limit int, myAddress st
 
 	for rows.Next() {
 		var invoice Invoice
-		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt); err != nil {
+		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt, &invoice.PaidKoinu, &invoice.ExpiryHeight, &invoice.RedeemScript, &invoice.MultisigSignatures, &invoice.ReleaseReason); err != nil {
 			return nil, err
 		}
 
+		invoice.setPaymentState()
 		invoices = append(invoices, invoice)
 	}
 
@@ -72,7 +77,7 @@ func (s *TokenisationStore) GetInvoicesForMe(offset int, limit int, myAddress st
 }
 
 func (s *TokenisationStore) GetInvoices(offset int, limit int, mintHash string, offererAddress string) ([]Invoice, error) {
-	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, paid_at FROM invoices WHERE mint_hash = $1 AND (buyer_address = $2 OR seller_address = $2) LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
+	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, paid_at, paid_koinu, expiry_height, redeem_script, multisig_signatures, "+invoiceReleaseReasonColumn+" FROM invoices WHERE mint_hash = $1 AND (buyer_address = $2 OR seller_address = $2) LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -82,10 +87,11 @@ func (s *TokenisationStore) GetInvoices(offset int, limit int, mintHash string,
 
 	for rows.Next() {
 		var invoice Invoice
-		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt); err != nil {
+		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.PaidAt, &invoice.PaidKoinu, &invoice.ExpiryHeight, &invoice.RedeemScript, &invoice.MultisigSignatures, &invoice.ReleaseReason); err != nil {
 			return nil, err
 		}
 
+		invoice.setPaymentState()
 		invoices = append(invoices, invoice)
 	}
 
@@ -104,7 +110,7 @@ func (s *TokenisationStore) CountUnconfirmedInvoices(mintHash string, offererAdd
 }
 
 func (s *TokenisationStore) GetUnconfirmedInvoices(offset int, limit int, mintHash string, offererAddress string) ([]UnconfirmedInvoice, error) {
-	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, status FROM unconfirmed_invoices WHERE mint_hash = $1 AND buyer_address = $2 LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
+	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, status FROM unconfirmed_invoices WHERE mint_hash = $1 AND buyer_address = $2 LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +120,7 @@ func (s *TokenisationStore) GetUnconfirmedInvoices(offset int, limit int, mintHa
 
 	for rows.Next() {
 		var invoice UnconfirmedInvoice
-		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.Status); err != nil {
+		if err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PublicKey, &invoice.Signature, &invoice.Status); err != nil {
 			return nil, err
 		}
 
@@ -129,12 +135,23 @@ func (s *TokenisationStore) GetUnconfirmedInvoices(offset int, limit int, mintHa
 }
 
 func (s *TokenisationStore) SaveUnconfirmedInvoice(invoice *UnconfirmedInvoice) (string, error) {
+	return s.SaveUnconfirmedInvoiceWithTx(invoice, nil)
+}
+
+func (s *TokenisationStore) SaveUnconfirmedInvoiceWithTx(invoice *UnconfirmedInvoice, tx *sql.Tx) (string, error) {
 	id := uuid.New().String()
 
-	_, err := s.DB.Exec(`
-	INSERT INTO unconfirmed_invoices (id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, status)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	`, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.Price, invoice.CreatedAt, invoice.SellerAddress, invoice.PublicKey, invoice.Signature, invoice.Status)
+	query := `
+	INSERT INTO unconfirmed_invoices (id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, status, redeem_script, multisig_signatures)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	var err error
+	if tx != nil {
+		_, err = tx.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.PriceKoinu, invoice.CreatedAt, invoice.SellerAddress, invoice.PublicKey, invoice.Signature, invoice.Status, invoice.RedeemScript, invoice.MultisigSignatures)
+	} else {
+		_, err = s.DB.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.PriceKoinu, invoice.CreatedAt, invoice.SellerAddress, invoice.PublicKey, invoice.Signature, invoice.Status, invoice.RedeemScript, invoice.MultisigSignatures)
+	}
 
 	return id, err
 }
@@ -147,31 +164,25 @@ func (s *TokenisationStore) SaveInvoiceWithTx(invoice *Invoice, tx *sql.Tx) (str
 	id := uuid.New().String()
 
 	query := `
-	INSERT INTO invoices (id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address, block_height, transaction_hash, public_key, signature)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	INSERT INTO invoices (id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, block_height, transaction_hash, public_key, signature, block_hash, expiry_height, redeem_script, multisig_signatures)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 	`
 
 	var err error
 	if tx != nil {
-		_, err = tx.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.Price, invoice.CreatedAt, invoice.SellerAddress, invoice.BlockHeight, invoice.TransactionHash, invoice.PublicKey, invoice.Signature)
+		_, err = tx.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.PriceKoinu, invoice.CreatedAt, invoice.SellerAddress, invoice.BlockHeight, invoice.TransactionHash, invoice.PublicKey, invoice.Signature, invoice.BlockHash, invoice.ExpiryHeight, invoice.RedeemScript, invoice.MultisigSignatures)
 	} else {
-		_, err = s.DB.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.Price, invoice.CreatedAt, invoice.SellerAddress, invoice.BlockHeight, invoice.TransactionHash, invoice.PublicKey, invoice.Signature)
+		_, err = s.DB.Exec(query, id, invoice.Hash, invoice.PaymentAddress, invoice.BuyerAddress, invoice.MintHash, invoice.Quantity, invoice.PriceKoinu, invoice.CreatedAt, invoice.SellerAddress, invoice.BlockHeight, invoice.TransactionHash, invoice.PublicKey, invoice.Signature, invoice.BlockHash, invoice.ExpiryHeight, invoice.RedeemScript, invoice.MultisigSignatures)
 	}
 
 	return id, err
 }
 
-func (s *TokenisationStore) MatchInvoice(onchainTransaction OnChainTransaction) bool {
+func (s *TokenisationStore) MatchInvoice(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainInvoiceMessage) bool {
 	if onchainTransaction.ActionType != protocol.ACTION_INVOICE {
 		return false
 	}
 
-	var onchainMessage protocol.OnChainInvoiceMessage
-	err := proto.Unmarshal(onchainTransaction.ActionData, &onchainMessage)
-	if err != nil {
-		return false
-	}
-
 	rows, err := s.DB.Query("SELECT hash, transaction_hash FROM invoices WHERE transaction_hash = $1 and block_height = $2 and hash = $3", onchainTransaction.TxHash, onchainTransaction.Height, hex.EncodeToString(onchainMessage.InvoiceHash))
 	if err != nil {
 		return false
@@ -190,17 +201,11 @@ func (s *TokenisationStore) MatchInvoice(onchainTransaction OnChainTransaction)
 	return exists
 }
 
-func (s *TokenisationStore) MatchUnconfirmedInvoice(onchainTransaction OnChainTransaction) error {
+func (s *TokenisationStore) MatchUnconfirmedInvoice(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainInvoiceMessage) error {
 	if onchainTransaction.ActionType != protocol.ACTION_INVOICE {
 		return fmt.Errorf("action type is not invoice: %d", onchainTransaction.ActionType)
 	}
 
-	var onchainMessage protocol.OnChainInvoiceMessage
-	err := proto.Unmarshal(onchainTransaction.ActionData, &onchainMessage)
-	if err != nil {
-		return err
-	}
-
 	// Start transaction for atomic operations
 	tx, err := s.DB.Begin()
 	if err != nil {
@@ -208,7 +213,7 @@ func (s *TokenisationStore) MatchUnconfirmedInvoice(onchainTransaction OnChainTr
 	}
 	defer tx.Rollback()
 
-	rows, err := tx.Query("SELECT id, hash, buyer_address, mint_hash, quantity, price, created_at, seller_address, public_key, signature, status FROM unconfirmed_invoices WHERE hash = $1", hex.EncodeToString(onchainMessage.InvoiceHash))
+	rows, err := tx.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, public_key, signature, status, redeem_script, multisig_signatures FROM unconfirmed_invoices WHERE hash = $1", hex.EncodeToString(onchainMessage.InvoiceHash))
 	if err != nil {
 		return err
 	}
@@ -216,7 +221,7 @@ func (s *TokenisationStore) MatchUnconfirmedInvoice(onchainTransaction OnChainTr
 	var unconfirmedInvoice UnconfirmedInvoice
 	if rows.Next() {
 		if err := rows.Scan(
-			&unconfirmedInvoice.Id, &unconfirmedInvoice.Hash, &unconfirmedInvoice.BuyerAddress, &unconfirmedInvoice.MintHash, &unconfirmedInvoice.Quantity, &unconfirmedInvoice.Price, &unconfirmedInvoice.CreatedAt, &unconfirmedInvoice.SellerAddress, &unconfirmedInvoice.PublicKey, &unconfirmedInvoice.Signature, &unconfirmedInvoice.Status); err != nil {
+			&unconfirmedInvoice.Id, &unconfirmedInvoice.Hash, &unconfirmedInvoice.PaymentAddress, &unconfirmedInvoice.BuyerAddress, &unconfirmedInvoice.MintHash, &unconfirmedInvoice.Quantity, &unconfirmedInvoice.PriceKoinu, &unconfirmedInvoice.CreatedAt, &unconfirmedInvoice.SellerAddress, &unconfirmedInvoice.PublicKey, &unconfirmedInvoice.Signature, &unconfirmedInvoice.Status, &unconfirmedInvoice.RedeemScript, &unconfirmedInvoice.MultisigSignatures); err != nil {
 			return err
 		}
 	} else {
@@ -237,18 +242,22 @@ func (s *TokenisationStore) MatchUnconfirmedInvoice(onchainTransaction OnChainTr
 
 	// Use transaction-aware SaveInvoice
 	id, err := s.SaveInvoiceWithTx(&Invoice{
-		Hash:            unconfirmedInvoice.Hash,
-		PaymentAddress:  unconfirmedInvoice.PaymentAddress,
-		BuyerAddress:    unconfirmedInvoice.BuyerAddress,
-		MintHash:        unconfirmedInvoice.MintHash,
-		Quantity:        unconfirmedInvoice.Quantity,
-		Price:           unconfirmedInvoice.Price,
-		CreatedAt:       unconfirmedInvoice.CreatedAt,
-		SellerAddress:   unconfirmedInvoice.SellerAddress,
-		PublicKey:       unconfirmedInvoice.PublicKey,
-		Signature:       unconfirmedInvoice.Signature,
-		BlockHeight:     onchainTransaction.Height,
-		TransactionHash: onchainTransaction.TxHash,
+		Hash:               unconfirmedInvoice.Hash,
+		PaymentAddress:     unconfirmedInvoice.PaymentAddress,
+		BuyerAddress:       unconfirmedInvoice.BuyerAddress,
+		MintHash:           unconfirmedInvoice.MintHash,
+		Quantity:           unconfirmedInvoice.Quantity,
+		PriceKoinu:         unconfirmedInvoice.PriceKoinu,
+		CreatedAt:          unconfirmedInvoice.CreatedAt,
+		SellerAddress:      unconfirmedInvoice.SellerAddress,
+		PublicKey:          unconfirmedInvoice.PublicKey,
+		Signature:          unconfirmedInvoice.Signature,
+		BlockHeight:        onchainTransaction.Height,
+		BlockHash:          onchainTransaction.BlockHash,
+		TransactionHash:    onchainTransaction.TxHash,
+		ExpiryHeight:       onchainMessage.ExpiryHeight,
+		RedeemScript:       unconfirmedInvoice.RedeemScript,
+		MultisigSignatures: unconfirmedInvoice.MultisigSignatures,
 	}, tx)
 
 	if err != nil {
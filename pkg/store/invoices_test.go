@@ -23,7 +23,7 @@ func TestSaveAndGetInvoices(t *testing.T) {
 		BuyerAddress:   offererAddress,
 		MintHash:       "myMintHash",
 		Quantity:       10,
-		Price:          25,
+		PriceKoinu:     25,
 		CreatedAt:      time.Now(),
 		PublicKey:      "myPublicKey",
 		SellerAddress:  sellOfferAddress,
@@ -47,6 +47,6 @@ func TestSaveAndGetInvoices(t *testing.T) {
 	assert.Equal(t, invoices[0].BuyerAddress, invoice.BuyerAddress, "failed to match invoice offerer address")
 	assert.Equal(t, invoices[0].MintHash, invoice.MintHash, "failed to match invoice buy offer mint hash")
 	assert.Equal(t, invoices[0].Quantity, invoice.Quantity, "failed to match invoice buy offer quantity")
-	assert.Equal(t, invoices[0].Price, invoice.Price, "failed to match invoice buy offer price")
+	assert.Equal(t, invoices[0].PriceKoinu, invoice.PriceKoinu, "failed to match invoice buy offer price")
 	assert.Equal(t, invoices[0].PublicKey, invoice.PublicKey, "failed to match invoice public key")
 }
@@ -0,0 +1,271 @@
+package store
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+)
+
+/*
+* LockupOptions is the typed schema of MintWithoutID.LockupOptions.
+* All fractions are locked until the unlock block height and unlock timestamp have passed.
+* After that, vesting (if set) releases each credit a holder receives linearly over
+* duration_blocks, starting cliff_blocks after the block the credit was received in.
+ */
+type LockupOptions struct {
+	UnlockBlockHeight int64           `json:"unlock_block_height,omitempty"`
+	UnlockTimestamp   int64           `json:"unlock_timestamp,omitempty"`
+	Vesting           *VestingOptions `json:"vesting,omitempty"`
+}
+
+type VestingOptions struct {
+	CliffBlocks    int64 `json:"cliff_blocks"`
+	DurationBlocks int64 `json:"duration_blocks"`
+}
+
+// TokenCredit is a positive token balance entry and the block it was received in.
+type TokenCredit struct {
+	Quantity    int   `json:"quantity"`
+	BlockHeight int64 `json:"block_height"`
+}
+
+type LockedTokenBalance struct {
+	MintHash     string `json:"mint_hash"`
+	Address      string `json:"address"`
+	Balance      int    `json:"balance"`
+	Pending      int    `json:"pending"`
+	Locked       int    `json:"locked"`
+	Transferable int    `json:"transferable"`
+}
+
+func ParseLockupOptions(options StringInterfaceMap) (LockupOptions, error) {
+	var lockupOptions LockupOptions
+	if len(options) == 0 {
+		return lockupOptions, nil
+	}
+
+	jsonBytes, err := json.Marshal(options)
+	if err != nil {
+		return LockupOptions{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&lockupOptions); err != nil {
+		return LockupOptions{}, err
+	}
+
+	if err := lockupOptions.Validate(); err != nil {
+		return LockupOptions{}, err
+	}
+
+	return lockupOptions, nil
+}
+
+func (l LockupOptions) Validate() error {
+	if l.UnlockBlockHeight < 0 {
+		return errors.New("unlock_block_height must not be negative")
+	}
+
+	if l.UnlockTimestamp < 0 {
+		return errors.New("unlock_timestamp must not be negative")
+	}
+
+	if l.Vesting != nil {
+		if l.Vesting.DurationBlocks <= 0 {
+			return errors.New("vesting duration_blocks must be greater than 0")
+		}
+
+		if l.Vesting.CliffBlocks < 0 || l.Vesting.CliffBlocks > l.Vesting.DurationBlocks {
+			return errors.New("vesting cliff_blocks must be between 0 and duration_blocks")
+		}
+	}
+
+	return nil
+}
+
+func (l LockupOptions) IsEmpty() bool {
+	return l.UnlockBlockHeight == 0 && l.UnlockTimestamp == 0 && l.Vesting == nil
+}
+
+// LockedQuantity returns how much of balance is locked at the given block height and block time.
+func (l LockupOptions) LockedQuantity(credits []TokenCredit, balance int, blockHeight int64, blockTime int64) int {
+	if balance <= 0 {
+		return 0
+	}
+
+	if blockHeight < l.UnlockBlockHeight || blockTime < l.UnlockTimestamp {
+		return balance
+	}
+
+	if l.Vesting == nil {
+		return 0
+	}
+
+	unvested := 0
+	for _, credit := range credits {
+		unvested += credit.Quantity - l.Vesting.vestedQuantity(credit, blockHeight)
+	}
+
+	// Tokens that have left the address can only have been vested ones
+	return min(unvested, balance)
+}
+
+func (v *VestingOptions) vestedQuantity(credit TokenCredit, blockHeight int64) int {
+	elapsed := blockHeight - credit.BlockHeight
+	if elapsed < v.CliffBlocks {
+		return 0
+	}
+
+	if elapsed >= v.DurationBlocks {
+		return credit.Quantity
+	}
+
+	return int(int64(credit.Quantity) * elapsed / v.DurationBlocks)
+}
+
+func (s *TokenisationStore) GetMintLockupOptions(mintHash string, tx *sql.Tx) (LockupOptions, error) {
+	query := "SELECT lockup_options FROM mints WHERE hash = $1"
+
+	var options StringInterfaceMap
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(query, mintHash).Scan(&options)
+	} else {
+		err = s.DB.QueryRow(query, mintHash).Scan(&options)
+	}
+
+	if err == sql.ErrNoRows {
+		return LockupOptions{}, nil
+	}
+
+	if err != nil {
+		return LockupOptions{}, err
+	}
+
+	// Mints that predate the typed schema may carry free form lockup options, which are not enforced
+	lockupOptions, err := ParseLockupOptions(options)
+	if err != nil {
+		log.Println("Ignoring lockup options for mint", mintHash+":", err)
+		return LockupOptions{}, nil
+	}
+
+	return lockupOptions, nil
+}
+
+func (s *TokenisationStore) GetTokenCredits(address string, mintHash string, tx *sql.Tx) ([]TokenCredit, error) {
+	query := "SELECT quantity, COALESCE(block_height, 0) FROM token_balances WHERE address = $1 AND mint_hash = $2 AND quantity > 0"
+
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(query, address, mintHash)
+	} else {
+		rows, err = s.DB.Query(query, address, mintHash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credits []TokenCredit
+	for rows.Next() {
+		var credit TokenCredit
+		if err := rows.Scan(&credit.Quantity, &credit.BlockHeight); err != nil {
+			return nil, err
+		}
+		credits = append(credits, credit)
+	}
+
+	return credits, rows.Err()
+}
+
+// GetLockedTokenBalance splits the balance of an address into pending, locked and transferable fractions
+// at the given block height and block time.
+func (s *TokenisationStore) GetLockedTokenBalance(address string, mintHash string, blockHeight int64, blockTime int64, tx *sql.Tx) (LockedTokenBalance, error) {
+	lockedBalance := LockedTokenBalance{MintHash: mintHash, Address: address}
+
+	balanceQuery := "SELECT COALESCE(SUM(quantity), 0) FROM token_balances WHERE address = $1 AND mint_hash = $2"
+	pendingQuery := "SELECT COALESCE(SUM(quantity), 0) FROM pending_token_balances WHERE owner_address = $1 AND mint_hash = $2"
+
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(balanceQuery, address, mintHash).Scan(&lockedBalance.Balance)
+		if err == nil {
+			err = tx.QueryRow(pendingQuery, address, mintHash).Scan(&lockedBalance.Pending)
+		}
+	} else {
+		err = s.DB.QueryRow(balanceQuery, address, mintHash).Scan(&lockedBalance.Balance)
+		if err == nil {
+			err = s.DB.QueryRow(pendingQuery, address, mintHash).Scan(&lockedBalance.Pending)
+		}
+	}
+
+	if err != nil {
+		return LockedTokenBalance{}, err
+	}
+
+	lockupOptions, err := s.GetMintLockupOptions(mintHash, tx)
+	if err != nil {
+		return LockedTokenBalance{}, err
+	}
+
+	if !lockupOptions.IsEmpty() {
+		credits, err := s.GetTokenCredits(address, mintHash, tx)
+		if err != nil {
+			return LockedTokenBalance{}, err
+		}
+
+		lockedBalance.Locked = lockupOptions.LockedQuantity(credits, lockedBalance.Balance, blockHeight, blockTime)
+	}
+
+	lockedBalance.Transferable = max(lockedBalance.Balance-lockedBalance.Locked-lockedBalance.Pending, 0)
+
+	return lockedBalance, nil
+}
+
+// GetTransferableTokenBalance returns the balance of an address that is neither reserved by pending invoices nor locked.
+func (s *TokenisationStore) GetTransferableTokenBalance(address string, mintHash string, blockHeight int64, blockTime int64, tx *sql.Tx) (int, error) {
+	lockedBalance, err := s.GetLockedTokenBalance(address, mintHash, blockHeight, blockTime, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return lockedBalance.Transferable, nil
+}
+
+func (s *TokenisationStore) GetLockedTokenBalances(address string, blockHeight int64, blockTime int64) ([]LockedTokenBalance, error) {
+	rows, err := s.DB.Query("SELECT DISTINCT mint_hash FROM token_balances WHERE address = $1 ORDER BY mint_hash", address)
+	if err != nil {
+		return nil, err
+	}
+
+	var mintHashes []string
+	for rows.Next() {
+		var mintHash string
+		if err := rows.Scan(&mintHash); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		mintHashes = append(mintHashes, mintHash)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	lockedBalances := []LockedTokenBalance{}
+	for _, mintHash := range mintHashes {
+		lockedBalance, err := s.GetLockedTokenBalance(address, mintHash, blockHeight, blockTime, nil)
+		if err != nil {
+			return nil, err
+		}
+		lockedBalances = append(lockedBalances, lockedBalance)
+	}
+
+	return lockedBalances, nil
+}
@@ -0,0 +1,130 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func creditTokenBalance(t *testing.T, db *store.TokenisationStore, address string, mintHash string, quantity int, blockHeight int64) {
+	tx, err := db.DB.Begin()
+	assert.NilError(t, err)
+
+	err = db.UpsertTokenBalanceWithTransaction(address, mintHash, quantity, blockHeight, "blockHash", tx)
+	assert.NilError(t, err)
+	assert.NilError(t, tx.Commit())
+}
+
+func TestParseLockupOptions(t *testing.T) {
+	lockupOptions, err := store.ParseLockupOptions(store.StringInterfaceMap{})
+	assert.NilError(t, err)
+	assert.Assert(t, lockupOptions.IsEmpty())
+
+	lockupOptions, err = store.ParseLockupOptions(store.StringInterfaceMap{
+		"unlock_block_height": float64(100),
+		"vesting": map[string]interface{}{
+			"cliff_blocks":    float64(10),
+			"duration_blocks": float64(50),
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, lockupOptions.UnlockBlockHeight, int64(100))
+	assert.Equal(t, lockupOptions.Vesting.CliffBlocks, int64(10))
+	assert.Equal(t, lockupOptions.Vesting.DurationBlocks, int64(50))
+
+	_, err = store.ParseLockupOptions(store.StringInterfaceMap{"lockup": "option"})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = store.ParseLockupOptions(store.StringInterfaceMap{"unlock_block_height": "soon"})
+	assert.Assert(t, err != nil)
+
+	_, err = store.ParseLockupOptions(store.StringInterfaceMap{
+		"vesting": map[string]interface{}{"cliff_blocks": float64(60), "duration_blocks": float64(50)},
+	})
+	assert.ErrorContains(t, err, "cliff_blocks")
+
+	_, err = store.ParseLockupOptions(store.StringInterfaceMap{
+		"vesting": map[string]interface{}{"cliff_blocks": float64(0), "duration_blocks": float64(0)},
+	})
+	assert.ErrorContains(t, err, "duration_blocks")
+}
+
+func TestLockedQuantity(t *testing.T) {
+	credits := []store.TokenCredit{{Quantity: 100, BlockHeight: 10}}
+
+	lockup := store.LockupOptions{UnlockBlockHeight: 20}
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 19, 0), 100)
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 20, 0), 0)
+
+	lockup = store.LockupOptions{UnlockTimestamp: 1000}
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 50, 999), 100)
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 50, 1000), 0)
+
+	lockup = store.LockupOptions{Vesting: &store.VestingOptions{CliffBlocks: 10, DurationBlocks: 40}}
+	// Before the cliff nothing has vested
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 19, 0), 100)
+	// At the cliff vesting is linear from the credit block
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 20, 0), 75)
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 30, 0), 50)
+	assert.Equal(t, lockup.LockedQuantity(credits, 100, 50, 0), 0)
+	// Spent tokens come out of the vested portion
+	assert.Equal(t, lockup.LockedQuantity(credits, 60, 30, 0), 50)
+	assert.Equal(t, lockup.LockedQuantity(credits, 40, 30, 0), 40)
+}
+
+func TestGetLockedTokenBalance(t *testing.T) {
+	db := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	_, err := db.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		LockupOptions: store.StringInterfaceMap{
+			"vesting": map[string]interface{}{"cliff_blocks": float64(0), "duration_blocks": float64(10)},
+		},
+	}, "owner1")
+	assert.NilError(t, err)
+
+	creditTokenBalance(t, db, "owner1", mintHash, 100, 10)
+
+	err = db.UpsertPendingTokenBalance("invoice1", mintHash, 20, "onchainTx1", "owner1")
+	assert.NilError(t, err)
+
+	balance, err := db.GetLockedTokenBalance("owner1", mintHash, 15, 0, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Balance, 100)
+	assert.Equal(t, balance.Pending, 20)
+	assert.Equal(t, balance.Locked, 50)
+	assert.Equal(t, balance.Transferable, 30)
+
+	balances, err := db.GetLockedTokenBalances("owner1", 20, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(balances), 1)
+	assert.Equal(t, balances[0].Locked, 0)
+	assert.Equal(t, balances[0].Transferable, 80)
+}
+
+func TestProcessTransferRespectsLockups(t *testing.T) {
+	db := support.SetupTestDB()
+
+	mintHash := support.GenerateRandomHash()
+	_, err := db.SaveMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		FractionCount: 100,
+		LockupOptions: store.StringInterfaceMap{"unlock_block_height": float64(50)},
+	}, "owner1")
+	assert.NilError(t, err)
+
+	creditTokenBalance(t, db, "owner1", mintHash, 100, 1)
+
+	err = db.ProcessTransfer(store.OnChainTransaction{Id: "transferTx", Height: 49, BlockTime: 1, Address: "owner1"}, mintHash, "recipient1", 10)
+	assert.Assert(t, errors.Is(err, store.ErrInsufficientTokenBalance))
+
+	err = db.ProcessTransfer(store.OnChainTransaction{Id: "transferTx", Height: 50, BlockTime: 1, Address: "owner1"}, mintHash, "recipient1", 10)
+	assert.NilError(t, err)
+}
@@ -0,0 +1,172 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+const (
+	MempoolSubject_MINT    = "mint"
+	MempoolSubject_INVOICE = "invoice"
+	MempoolSubject_PAYMENT = "payment"
+)
+
+/*
+* MempoolTransaction is a fractal transaction seen in the mempool of the node, for the mint or invoice
+* it refers to: a mint, an invoice, or a payment of an invoice. It is only shown until the transaction
+* leaves the mempool, whether it was mined, evicted or double spent, and never changes balances.
+ */
+type MempoolTransaction struct {
+	TxHash        string      `json:"tx_hash"`
+	Hash          string      `json:"hash"`
+	Subject       string      `json:"subject"`
+	ActionType    uint8       `json:"action_type"`
+	Action        string      `json:"action"`
+	ActionVersion uint8       `json:"action_version"`
+	Address       string      `json:"address"`
+	Values        KoinuValues `json:"values"`
+	FirstSeenAt   time.Time   `json:"first_seen_at"`
+}
+
+// SaveMempoolTransactions records the transactions seen in the mempool, keeping when each was first seen.
+func (s *TokenisationStore) SaveMempoolTransactions(transactions []MempoolTransaction) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, transaction := range transactions {
+		_, err = tx.Exec(`
+		INSERT INTO mempool_transactions (tx_hash, hash, subject, action_type, action_version, address, "values", first_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tx_hash, hash) DO NOTHING
+		`, transaction.TxHash, transaction.Hash, transaction.Subject, transaction.ActionType, transaction.ActionVersion, transaction.Address, transaction.Values, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMempoolTxHashes returns the hashes of the transactions recorded as being in the mempool.
+func (s *TokenisationStore) GetMempoolTxHashes() ([]string, error) {
+	rows, err := s.DB.Query("SELECT DISTINCT tx_hash FROM mempool_transactions")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txHashes := []string{}
+	for rows.Next() {
+		var txHash string
+		if err := rows.Scan(&txHash); err != nil {
+			return nil, err
+		}
+		txHashes = append(txHashes, txHash)
+	}
+
+	return txHashes, rows.Err()
+}
+
+// ClearMempoolTransactions removes transactions that have left the mempool.
+func (s *TokenisationStore) ClearMempoolTransactions(txHashes []string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, txHash := range txHashes {
+		_, err = tx.Exec("DELETE FROM mempool_transactions WHERE tx_hash = $1", txHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/*
+* GetMempoolTransactions returns the transactions in the mempool, most recently seen first.
+* An empty hash (of a mint or invoice), subject or address matches every transaction.
+ */
+func (s *TokenisationStore) GetMempoolTransactions(hash string, subject string, address string, offset int, limit int) ([]MempoolTransaction, error) {
+	rows, err := s.DB.Query(`
+	SELECT tx_hash, hash, subject, action_type, action_version, address, "values", first_seen_at
+	FROM mempool_transactions
+	WHERE ($1 = '' OR hash = $1) AND ($2 = '' OR subject = $2) AND ($3 = '' OR address = $3)
+	ORDER BY first_seen_at DESC, tx_hash ASC
+	LIMIT $4 OFFSET $5
+	`, hash, subject, address, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []MempoolTransaction{}
+	for rows.Next() {
+		var transaction MempoolTransaction
+		err := rows.Scan(&transaction.TxHash, &transaction.Hash, &transaction.Subject, &transaction.ActionType, &transaction.ActionVersion, &transaction.Address, &transaction.Values, &transaction.FirstSeenAt)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction.Action = protocol.ActionName(transaction.ActionType)
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+/*
+* WithMempoolPayments sets the transaction paying each invoice that is waiting in the mempool, if there is one,
+* the first seen where there are several. The payments of all the invoices are read in one query.
+ */
+func (s *TokenisationStore) WithMempoolPayments(invoices []Invoice) ([]Invoice, error) {
+	if len(invoices) == 0 {
+		return invoices, nil
+	}
+
+	args := []interface{}{MempoolSubject_PAYMENT}
+	placeholders := []string{}
+	for _, invoice := range invoices {
+		args = append(args, invoice.Hash)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	rows, err := s.DB.Query(`
+	SELECT hash, tx_hash FROM mempool_transactions
+	WHERE subject = $1 AND hash IN (`+strings.Join(placeholders, ", ")+`)
+	ORDER BY first_seen_at ASC, tx_hash ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txHashes := map[string]string{}
+	for rows.Next() {
+		var hash, txHash string
+		if err := rows.Scan(&hash, &txHash); err != nil {
+			return nil, err
+		}
+
+		if _, ok := txHashes[hash]; !ok {
+			txHashes[hash] = txHash
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for idx := range invoices {
+		invoices[idx].MempoolPaymentTxHash = txHashes[invoices[idx].Hash]
+	}
+
+	return invoices, nil
+}
@@ -8,18 +8,17 @@ import (
 
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"github.com/google/uuid"
-	"google.golang.org/protobuf/proto"
 )
 
 func (s *TokenisationStore) GetMintByHash(hash string) (Mint, error) {
-	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM mints WHERE hash = $1", hash)
+	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM mints WHERE hash = $1", hash)
 	if err != nil {
 		return Mint{}, err
 	}
 
 	var m Mint
 	if rows.Next() {
-		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 			return Mint{}, err
 		}
 	}
@@ -30,7 +29,7 @@ func (s *TokenisationStore) GetMintByHash(hash string) (Mint, error) {
 }
 
 func (s *TokenisationStore) GetMintsByPublicKey(offset int, limit int, publicKey string, includeUnconfirmed bool) ([]Mint, error) {
-	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM mints WHERE public_key = $1 and transaction_hash is not null LIMIT $2 OFFSET $3", publicKey, limit, offset)
+	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM mints WHERE public_key = $1 and transaction_hash is not null LIMIT $2 OFFSET $3", publicKey, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +37,7 @@ func (s *TokenisationStore) GetMintsByPublicKey(offset int, limit int, publicKey
 	var mints []Mint
 	for rows.Next() {
 		var m Mint
-		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 			return nil, err
 		}
 		mints = append(mints, m)
@@ -50,7 +49,7 @@ func (s *TokenisationStore) GetMintsByPublicKey(offset int, limit int, publicKey
 	rows.Close()
 
 	if includeUnconfirmed {
-		rows, err = s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM unconfirmed_mints WHERE public_key = $1 LIMIT $2 OFFSET $3", publicKey, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM unconfirmed_mints WHERE public_key = $1 LIMIT $2 OFFSET $3", publicKey, limit, offset)
 		if err != nil {
 			return nil, err
 		}
@@ -58,7 +57,7 @@ func (s *TokenisationStore) GetMintsByPublicKey(offset int, limit int, publicKey
 
 		for rows.Next() {
 			var m Mint
-			if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+			if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 				return nil, err
 			}
 			mints = append(mints, m)
@@ -74,7 +73,7 @@ func (s *TokenisationStore) GetMintsByPublicKey(offset int, limit int, publicKey
 }
 
 func (s *TokenisationStore) GetMintsByAddress(offset int, limit int, address string, includeUnconfirmed bool) ([]Mint, error) {
-	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM mints WHERE owner_address = $1 LIMIT $2 OFFSET $3", address, limit, offset)
+	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM mints WHERE owner_address = $1 LIMIT $2 OFFSET $3", address, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +81,7 @@ func (s *TokenisationStore) GetMintsByAddress(offset int, limit int, address str
 	var mints []Mint
 	for rows.Next() {
 		var m Mint
-		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 			return nil, err
 		}
 		mints = append(mints, m)
@@ -94,7 +93,7 @@ func (s *TokenisationStore) GetMintsByAddress(offset int, limit int, address str
 	rows.Close()
 
 	if includeUnconfirmed {
-		rows, err = s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM unconfirmed_mints WHERE owner_address = $1 LIMIT $2 OFFSET $3", address, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM unconfirmed_mints WHERE owner_address = $1 LIMIT $2 OFFSET $3", address, limit, offset)
 		if err != nil {
 			return nil, err
 		}
@@ -102,7 +101,7 @@ func (s *TokenisationStore) GetMintsByAddress(offset int, limit int, address str
 
 		for rows.Next() {
 			var m Mint
-			if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+			if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 				return nil, err
 			}
 			mints = append(mints, m)
@@ -118,16 +117,16 @@ func (s *TokenisationStore) GetMintsByAddress(offset int, limit int, address str
 }
 
 func (s *TokenisationStore) ChooseMint() (Mint, error) {
-	row := s.DB.QueryRow("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM mints WHERE hash IN (SELECT hash FROM mints ORDER BY RANDOM() LIMIT 1)")
+	row := s.DB.QueryRow("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM mints WHERE hash IN (SELECT hash FROM mints ORDER BY RANDOM() LIMIT 1)")
 	var m Mint
-	if err := row.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+	if err := row.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 		return Mint{}, err
 	}
 	return m, nil
 }
 
 func (s *TokenisationStore) GetMints(offset int, limit int) ([]Mint, error) {
-	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM mints LIMIT $1 OFFSET $2", limit, offset)
+	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, owner_address, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM mints LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +135,7 @@ func (s *TokenisationStore) GetMints(offset int, limit int) ([]Mint, error) {
 	var mints []Mint
 	for rows.Next() {
 		var m Mint
-		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.OwnerAddress, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 			return nil, err
 		}
 		mints = append(mints, m)
@@ -149,7 +148,7 @@ func (s *TokenisationStore) GetMints(offset int, limit int) ([]Mint, error) {
 }
 
 func (s *TokenisationStore) GetUnconfirmedMints(offset int, limit int) ([]Mint, error) {
-	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM unconfirmed_mints LIMIT $1 OFFSET $2", limit, offset)
+	rows, err := s.DB.Query("SELECT id, created_at, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM unconfirmed_mints LIMIT $1 OFFSET $2", limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +157,7 @@ func (s *TokenisationStore) GetUnconfirmedMints(offset int, limit int) ([]Mint,
 	var mints []Mint
 	for rows.Next() {
 		var m Mint
-		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures); err != nil {
+		if err := rows.Scan(&m.Id, &m.CreatedAt, &m.Title, &m.Description, &m.FractionCount, &m.Tags, &m.Metadata, &m.Hash, &m.TransactionHash, &m.Requirements, &m.LockupOptions, &m.FeedURL, &m.PublicKey, &m.ContractOfSale, &m.SignatureRequirementType, &m.AssetManagers, &m.MinSignatures, &m.RedeemScript, &m.MultisigSignatures); err != nil {
 			return nil, err
 		}
 		mints = append(mints, m)
@@ -203,14 +202,14 @@ func (s *TokenisationStore) SaveMintWithTx(mint *MintWithoutID, ownerAddress str
 	}
 
 	query := `
-	INSERT INTO mints (id, title, description, fraction_count, tags, metadata, hash, requirements, lockup_options, feed_url, owner_address, public_key, block_height, transaction_hash, contract_of_sale, signature_requirement_type, asset_managers, min_signatures)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	INSERT INTO mints (id, title, description, fraction_count, tags, metadata, hash, requirements, lockup_options, feed_url, owner_address, public_key, block_height, transaction_hash, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, block_hash, redeem_script, multisig_signatures)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
 	`
 
 	if tx != nil {
-		_, err = tx.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, ownerAddress, mint.PublicKey, mint.BlockHeight, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures)
+		_, err = tx.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, ownerAddress, mint.PublicKey, mint.BlockHeight, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures, mint.BlockHash, mint.RedeemScript, mint.MultisigSignatures)
 	} else {
-		_, err = s.DB.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, ownerAddress, mint.PublicKey, mint.BlockHeight, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures)
+		_, err = s.DB.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, ownerAddress, mint.PublicKey, mint.BlockHeight, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures, mint.BlockHash, mint.RedeemScript, mint.MultisigSignatures)
 	}
 
 	return id, err
@@ -226,6 +225,10 @@ func (s *TokenisationStore) TrimOldUnconfirmedMints(limit int) error {
 }
 
 func (s *TokenisationStore) SaveUnconfirmedMint(mint *MintWithoutID) (string, error) {
+	return s.SaveUnconfirmedMintWithTx(mint, nil)
+}
+
+func (s *TokenisationStore) SaveUnconfirmedMintWithTx(mint *MintWithoutID, tx *sql.Tx) (string, error) {
 	fmt.Println("Saving unconfirmed mint:", mint.Hash)
 
 	id := uuid.New().String()
@@ -255,26 +258,26 @@ func (s *TokenisationStore) SaveUnconfirmedMint(mint *MintWithoutID) (string, er
 		return "", err
 	}
 
-	_, err = s.DB.Exec(`
-	INSERT INTO unconfirmed_mints (id, title, description, fraction_count, tags, metadata, hash, requirements, lockup_options, feed_url, public_key, owner_address, transaction_hash, contract_of_sale, signature_requirement_type, asset_managers, min_signatures)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
-	`, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, mint.PublicKey, mint.OwnerAddress, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures)
+	query := `
+	INSERT INTO unconfirmed_mints (id, title, description, fraction_count, tags, metadata, hash, requirements, lockup_options, feed_url, public_key, owner_address, transaction_hash, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`
+
+	if tx != nil {
+		_, err = tx.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, mint.PublicKey, mint.OwnerAddress, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures, mint.RedeemScript, mint.MultisigSignatures)
+	} else {
+		_, err = s.DB.Exec(query, id, mint.Title, mint.Description, mint.FractionCount, string(tags), string(metadata), mint.Hash, string(requirements), string(lockupOptions), mint.FeedURL, mint.PublicKey, mint.OwnerAddress, mint.TransactionHash, string(contractOfSale), mint.SignatureRequirementType, mint.AssetManagers, mint.MinSignatures, mint.RedeemScript, mint.MultisigSignatures)
+	}
 	log.Println("err:", err)
 
 	return id, err
 }
 
-func (s *TokenisationStore) MatchMint(onchainTransaction OnChainTransaction) bool {
+func (s *TokenisationStore) MatchMint(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainMintMessage) bool {
 	if onchainTransaction.ActionType != protocol.ACTION_MINT {
 		return false
 	}
 
-	var onchainMessage protocol.OnChainMintMessage
-	err := proto.Unmarshal(onchainTransaction.ActionData, &onchainMessage)
-	if err != nil {
-		return false
-	}
-
 	if onchainMessage.Hash != onchainTransaction.TxHash {
 		return false
 	}
@@ -297,18 +300,12 @@ func (s *TokenisationStore) MatchMint(onchainTransaction OnChainTransaction) boo
 	return exists
 }
 
-func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTransaction) error {
+func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainMintMessage) error {
 
 	if onchainTransaction.ActionType != protocol.ACTION_MINT {
 		return fmt.Errorf("action type is not mint: %d", onchainTransaction.ActionType)
 	}
 
-	var onchainMessage protocol.OnChainMintMessage
-	err := proto.Unmarshal(onchainTransaction.ActionData, &onchainMessage)
-	if err != nil {
-		return err
-	}
-
 	// Start transaction for atomic operations
 	tx, err := s.DB.Begin()
 	if err != nil {
@@ -316,7 +313,7 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 	}
 	defer tx.Rollback()
 
-	rows, err := tx.Query("SELECT id, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, public_key, contract_of_sale, signature_requirement_type, asset_managers, min_signatures FROM unconfirmed_mints WHERE hash = $1", onchainMessage.Hash)
+	rows, err := tx.Query("SELECT id, title, description, fraction_count, tags, metadata, hash, transaction_hash, requirements, lockup_options, feed_url, public_key, owner_address, contract_of_sale, signature_requirement_type, asset_managers, min_signatures, redeem_script, multisig_signatures FROM unconfirmed_mints WHERE hash = $1", onchainMessage.Hash)
 	if err != nil {
 		return err
 	}
@@ -329,7 +326,7 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 			&unconfirmedMint.Id, &unconfirmedMint.Title, &unconfirmedMint.Description,
 			&unconfirmedMint.FractionCount, &unconfirmedMint.Tags, &unconfirmedMint.Metadata,
 			&unconfirmedMint.Hash, &unconfirmedMint.TransactionHash, &unconfirmedMint.Requirements,
-			&unconfirmedMint.LockupOptions, &unconfirmedMint.FeedURL, &unconfirmedMint.PublicKey, &unconfirmedMint.ContractOfSale, &unconfirmedMint.SignatureRequirementType, &unconfirmedMint.AssetManagers, &unconfirmedMint.MinSignatures); err != nil {
+			&unconfirmedMint.LockupOptions, &unconfirmedMint.FeedURL, &unconfirmedMint.PublicKey, &unconfirmedMint.OwnerAddress, &unconfirmedMint.ContractOfSale, &unconfirmedMint.SignatureRequirementType, &unconfirmedMint.AssetManagers, &unconfirmedMint.MinSignatures, &unconfirmedMint.RedeemScript, &unconfirmedMint.MultisigSignatures); err != nil {
 			return err
 		}
 	} else {
@@ -338,6 +335,14 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 
 	rows.Close()
 
+	// The mint is only anchored by the holder of the key, or multisig redeem script, that signed it
+	// (the RPC and gossip both require one)
+	signer := unconfirmedMint.Signer()
+	senderMismatch := (signer.PublicKey != "" || signer.IsMultisig()) && !signer.MatchesAddress(onchainTransaction.Address)
+	if senderMismatch || (unconfirmedMint.OwnerAddress != "" && unconfirmedMint.OwnerAddress != onchainTransaction.Address) {
+		return fmt.Errorf("%w: mint %s is owned by %s, sent by %s", ErrSenderMismatch, unconfirmedMint.Hash, unconfirmedMint.OwnerAddress, onchainTransaction.Address)
+	}
+
 	// Use transaction-aware SaveMint
 	id, err := s.SaveMintWithTx(&MintWithoutID{
 		Hash:                     unconfirmedMint.Hash,
@@ -348,6 +353,7 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 		Metadata:                 unconfirmedMint.Metadata,
 		TransactionHash:          onchainTransaction.TxHash,
 		BlockHeight:              onchainTransaction.Height,
+		BlockHash:                onchainTransaction.BlockHash,
 		CreatedAt:                unconfirmedMint.CreatedAt,
 		Requirements:             unconfirmedMint.Requirements,
 		LockupOptions:            unconfirmedMint.LockupOptions,
@@ -358,6 +364,8 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 		SignatureRequirementType: unconfirmedMint.SignatureRequirementType,
 		AssetManagers:            unconfirmedMint.AssetManagers,
 		MinSignatures:            unconfirmedMint.MinSignatures,
+		RedeemScript:             unconfirmedMint.RedeemScript,
+		MultisigSignatures:       unconfirmedMint.MultisigSignatures,
 	}, onchainTransaction.Address, tx)
 
 	if err != nil {
@@ -367,7 +375,7 @@ func (s *TokenisationStore) MatchUnconfirmedMint(onchainTransaction OnChainTrans
 	log.Println("Saved mint:", id)
 
 	// Use transaction-aware UpsertTokenBalance
-	err = s.UpsertTokenBalanceWithTransaction(onchainTransaction.Address, unconfirmedMint.Hash, unconfirmedMint.FractionCount, tx)
+	err = s.UpsertTokenBalanceWithTransaction(onchainTransaction.Address, unconfirmedMint.Hash, unconfirmedMint.FractionCount, onchainTransaction.Height, onchainTransaction.BlockHash, tx)
 	if err != nil {
 		log.Println("error upserting token balance", err)
 		return err
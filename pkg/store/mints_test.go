@@ -1,9 +1,11 @@
 package store_test
 
 import (
+	"errors"
 	"testing"
 
 	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
 	"dogecoin.org/fractal-engine/pkg/protocol"
 	"dogecoin.org/fractal-engine/pkg/store"
 	"google.golang.org/protobuf/proto"
@@ -316,7 +318,7 @@ func TestMatchMint(t *testing.T) {
 	assert.NilError(t, err)
 
 	// Save onchain transaction
-	txId, err := db.SaveOnChainTransaction("matchTxHash", 1000, "blockHash", 1, protocol.ACTION_MINT, 1, actionData, "addr", map[string]interface{}{
+	txId, err := db.SaveOnChainTransaction("matchTxHash", 1000, "blockHash", 1, protocol.ACTION_MINT, 1, actionData, "addr", store.KoinuValues{
 		"addr": 0,
 	})
 	assert.NilError(t, err)
@@ -330,14 +332,14 @@ func TestMatchMint(t *testing.T) {
 		ActionVersion: 1,
 		ActionData:    actionData,
 		Address:       "addr",
-		Values: map[string]interface{}{
+		Values: store.KoinuValues{
 			"addr": 0,
 		},
 		TransactionNumber: 1,
 	}
 
 	// Test matching
-	matched := db.MatchMint(onchainTx)
+	matched := db.MatchMint(onchainTx, onchainMsg)
 	assert.Assert(t, matched)
 
 	// Verify the onchain transaction was deleted
@@ -349,6 +351,9 @@ func TestMatchMint(t *testing.T) {
 func TestMatchUnconfirmedMint(t *testing.T) {
 	db := support.SetupTestDB()
 
+	_, pubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
 	// Save an unconfirmed mint
 	unconfirmedMint := &store.MintWithoutID{
 		Hash:          "unconfMatchHash",
@@ -360,9 +365,9 @@ func TestMatchUnconfirmedMint(t *testing.T) {
 		Requirements:  store.StringInterfaceMap{"req": "test"},
 		LockupOptions: store.StringInterfaceMap{"lockup": "test"},
 		FeedURL:       "https://example.com",
-		PublicKey:     "pubKeyMatch",
+		PublicKey:     pubHex,
 	}
-	_, err := db.SaveUnconfirmedMint(unconfirmedMint)
+	_, err = db.SaveUnconfirmedMint(unconfirmedMint)
 	assert.NilError(t, err)
 
 	// Create matching onchain message
@@ -373,7 +378,7 @@ func TestMatchUnconfirmedMint(t *testing.T) {
 	assert.NilError(t, err)
 
 	// Save onchain transaction
-	txId, err := db.SaveOnChainTransaction("confirmTxHash", 2000, "blockHash", 1, protocol.ACTION_MINT, 1, actionData, "confirmedAddr", map[string]interface{}{
+	txId, err := db.SaveOnChainTransaction("confirmTxHash", 2000, "blockHash", 1, protocol.ACTION_MINT, 1, actionData, ownerAddress, store.KoinuValues{
 		"addr": 0,
 	})
 	assert.NilError(t, err)
@@ -386,15 +391,15 @@ func TestMatchUnconfirmedMint(t *testing.T) {
 		ActionType:    protocol.ACTION_MINT,
 		ActionVersion: 1,
 		ActionData:    actionData,
-		Address:       "confirmedAddr",
-		Values: map[string]interface{}{
+		Address:       ownerAddress,
+		Values: store.KoinuValues{
 			"addr": 0,
 		},
 		TransactionNumber: 1,
 	}
 
 	// Match the unconfirmed mint
-	err = db.MatchUnconfirmedMint(onchainTx)
+	err = db.MatchUnconfirmedMint(onchainTx, onchainMsg)
 	assert.NilError(t, err)
 
 	// Verify the mint is now confirmed
@@ -403,7 +408,7 @@ func TestMatchUnconfirmedMint(t *testing.T) {
 	assert.Equal(t, confirmedMint.Hash, "unconfMatchHash")
 	assert.Equal(t, confirmedMint.TransactionHash, "confirmTxHash")
 	// Note: BlockHeight is not returned by GetMintByHash query
-	assert.Equal(t, confirmedMint.OwnerAddress, "confirmedAddr")
+	assert.Equal(t, confirmedMint.OwnerAddress, ownerAddress)
 
 	// Verify the unconfirmed mint was deleted
 	unconfirmedMints, err := db.GetUnconfirmedMints(0, 10)
@@ -416,6 +421,51 @@ func TestMatchUnconfirmedMint(t *testing.T) {
 	assert.Equal(t, len(transactions), 0)
 }
 
+func TestMatchUnconfirmedMintRejectsSenderWithoutMintKey(t *testing.T) {
+	db := support.SetupTestDB()
+
+	_, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	_, _, otherAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	_, err = db.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          "spoofedMintHash",
+		Title:         "Spoofed Mint",
+		FractionCount: 500,
+		PublicKey:     pubHex,
+	})
+	assert.NilError(t, err)
+
+	onchainMsg := &protocol.OnChainMintMessage{Hash: "spoofedMintHash"}
+	actionData, err := proto.Marshal(onchainMsg)
+	assert.NilError(t, err)
+
+	txId, err := db.SaveOnChainTransaction("spoofTxHash", 2000, "blockHash", 1, protocol.ACTION_MINT, 1, actionData, otherAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = db.MatchUnconfirmedMint(store.OnChainTransaction{
+		Id:            txId,
+		TxHash:        "spoofTxHash",
+		Height:        2000,
+		ActionType:    protocol.ACTION_MINT,
+		ActionVersion: 1,
+		ActionData:    actionData,
+		Address:       otherAddress,
+	}, onchainMsg)
+	assert.Assert(t, errors.Is(err, store.ErrSenderMismatch))
+
+	// The mint stays unconfirmed so its owner can still anchor it
+	unconfirmedMints, err := db.GetUnconfirmedMints(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unconfirmedMints), 1)
+
+	confirmedMint, err := db.GetMintByHash("spoofedMintHash")
+	assert.NilError(t, err)
+	assert.Equal(t, confirmedMint.Id, "")
+}
+
 func TestClearMints(t *testing.T) {
 	db := support.SetupTestDB()
 
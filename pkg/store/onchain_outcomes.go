@@ -0,0 +1,285 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+const (
+	OnChainOutcome_APPLIED       = "applied"
+	OnChainOutcome_REJECTED      = "rejected"
+	OnChainOutcome_AWAITING_DATA = "awaiting_data"
+	OnChainOutcome_EXPIRED       = "expired"
+	OnChainOutcome_IGNORED       = "ignored"
+)
+
+const (
+	OnChainAwaiting_CONFIRMATIONS = "AWAITING_CONFIRMATIONS"
+	OnChainAwaiting_DATA          = "AWAITING_DATA"
+)
+
+// OnChainOutcomes are the statuses an on chain transaction can be listed by.
+var OnChainOutcomes = []string{OnChainOutcome_APPLIED, OnChainOutcome_REJECTED, OnChainOutcome_AWAITING_DATA, OnChainOutcome_EXPIRED, OnChainOutcome_IGNORED}
+
+/*
+* OnChainTransactionOutcome records what became of an on chain transaction. Transactions waiting for
+* confirmations or off chain data are awaiting_data until they are applied, rejected, or expire when they
+* are trimmed, or an operator ignores them. The payload is kept for transactions that were not applied.
+ */
+type OnChainTransactionOutcome struct {
+	TxHash                string      `json:"tx_hash"`
+	Height                int64       `json:"height"`
+	BlockHash             string      `json:"block_hash"`
+	TransactionNumber     int         `json:"transaction_number"`
+	ActionType            uint8       `json:"action_type"`
+	Action                string      `json:"action"`
+	ActionVersion         uint8       `json:"action_version"`
+	ActionData            []byte      `json:"action_data,omitempty"`
+	Address               string      `json:"address"`
+	Values                KoinuValues `json:"values,omitempty"`
+	BlockTime             int64       `json:"block_time,omitempty"`
+	Status                string      `json:"status"`
+	ReasonCode            string      `json:"reason_code"`
+	Reason                string      `json:"reason"`
+	Confirmations         int         `json:"confirmations"`
+	RequiredConfirmations int         `json:"required_confirmations"`
+	ProcessedAt           time.Time   `json:"processed_at"`
+}
+
+// IsStuck reports whether the transaction is waiting, or expired while waiting, so an operator can act on it.
+func (o OnChainTransactionOutcome) IsStuck() bool {
+	return o.Status == OnChainOutcome_AWAITING_DATA || o.Status == OnChainOutcome_EXPIRED
+}
+
+// IsFinal reports whether the transaction has been finished with.
+func (o OnChainTransactionOutcome) IsFinal() bool {
+	return o.Status != OnChainOutcome_AWAITING_DATA
+}
+
+// IsOnChainOutcome reports whether the status is one transactions can be listed by.
+func IsOnChainOutcome(status string) bool {
+	for _, outcome := range OnChainOutcomes {
+		if outcome == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+* SaveOnChainTransactionOutcome records the outcome of a transaction the processor has finished with.
+* An awaiting_data outcome is replaced, while the first final outcome recorded for a transaction is kept,
+* so an outcome recorded together with the effects of the transaction (such as a rejection) is not overwritten.
+ */
+func (s *TokenisationStore) SaveOnChainTransactionOutcome(onchainTransaction OnChainTransaction, outcome string, reasonCode string, reason string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = saveOnChainTransactionOutcomeWithTx(tx, onchainTransaction, outcome, reasonCode, reason)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+* SaveAwaitingOnChainTransaction records why a transaction is still waiting to be processed, with the
+* confirmations last seen for it. The reason is replaced each time the transaction is passed over.
+ */
+func (s *TokenisationStore) SaveAwaitingOnChainTransaction(onchainTransaction OnChainTransaction, reasonCode string, reason string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var confirmations, requiredConfirmations int
+	err = tx.QueryRow("SELECT confirmations, required_confirmations FROM onchain_transactions WHERE id = $1", onchainTransaction.Id).Scan(&confirmations, &requiredConfirmations)
+	if err != nil {
+		return err
+	}
+
+	err = upsertOnChainTransactionOutcome(tx, onchainTransaction, OnChainOutcome_AWAITING_DATA, reasonCode, reason, confirmations, requiredConfirmations)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveOnChainTransactionOutcomeWithTx(tx *sql.Tx, onchainTransaction OnChainTransaction, outcome string, reasonCode string, reason string) error {
+	var confirmations, requiredConfirmations int
+	err := tx.QueryRow("SELECT confirmations, required_confirmations FROM onchain_transaction_outcomes WHERE tx_hash = $1", onchainTransaction.TxHash).Scan(&confirmations, &requiredConfirmations)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	return upsertOnChainTransactionOutcome(tx, onchainTransaction, outcome, reasonCode, reason, confirmations, requiredConfirmations)
+}
+
+func upsertOnChainTransactionOutcome(tx *sql.Tx, onchainTransaction OnChainTransaction, outcome string, reasonCode string, reason string, confirmations int, requiredConfirmations int) error {
+	var actionData []byte
+	var values KoinuValues
+	var blockTime int64
+	if outcome != OnChainOutcome_APPLIED {
+		actionData = onchainTransaction.ActionData
+		values = onchainTransaction.Values
+		blockTime = onchainTransaction.BlockTime
+	}
+
+	_, err := tx.Exec(`
+	INSERT INTO onchain_transaction_outcomes (tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time, outcome, reason_code, reason, confirmations, required_confirmations, processed_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	ON CONFLICT (tx_hash) DO UPDATE SET
+		block_height = excluded.block_height,
+		block_hash = excluded.block_hash,
+		transaction_number = excluded.transaction_number,
+		action_data = excluded.action_data,
+		"values" = excluded."values",
+		block_time = excluded.block_time,
+		outcome = excluded.outcome,
+		reason_code = excluded.reason_code,
+		reason = excluded.reason,
+		confirmations = excluded.confirmations,
+		required_confirmations = excluded.required_confirmations,
+		processed_at = excluded.processed_at
+	WHERE onchain_transaction_outcomes.outcome = 'awaiting_data'
+	`, onchainTransaction.TxHash, onchainTransaction.Height, onchainTransaction.BlockHash, onchainTransaction.TransactionNumber, onchainTransaction.ActionType, onchainTransaction.ActionVersion, actionData, onchainTransaction.Address, values, blockTime, outcome, reasonCode, reason, confirmations, requiredConfirmations, time.Now().UTC())
+	return err
+}
+
+const onChainTransactionOutcomeColumns = `tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time, outcome, reason_code, reason, confirmations, required_confirmations, processed_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOnChainTransactionOutcome(row rowScanner) (OnChainTransactionOutcome, error) {
+	var outcome OnChainTransactionOutcome
+	err := row.Scan(&outcome.TxHash, &outcome.Height, &outcome.BlockHash, &outcome.TransactionNumber, &outcome.ActionType, &outcome.ActionVersion, &outcome.ActionData, &outcome.Address, &outcome.Values, &outcome.BlockTime, &outcome.Status, &outcome.ReasonCode, &outcome.Reason, &outcome.Confirmations, &outcome.RequiredConfirmations, &outcome.ProcessedAt)
+	if err != nil {
+		return OnChainTransactionOutcome{}, err
+	}
+
+	outcome.Action = protocol.ActionName(outcome.ActionType)
+	return outcome, nil
+}
+
+// GetOnChainTransactionOutcome returns the outcome of the transaction, or sql.ErrNoRows while it has not been processed.
+func (s *TokenisationStore) GetOnChainTransactionOutcome(txHash string) (OnChainTransactionOutcome, error) {
+	row := s.DB.QueryRow(`SELECT `+onChainTransactionOutcomeColumns+` FROM onchain_transaction_outcomes WHERE tx_hash = $1`, txHash)
+	return scanOnChainTransactionOutcome(row)
+}
+
+/*
+* GetOnChainTransactionOutcomes returns the outcomes of on chain transactions, most recent first.
+* An empty status or transaction hash matches every transaction.
+ */
+func (s *TokenisationStore) GetOnChainTransactionOutcomes(status string, txHash string, offset int, limit int) ([]OnChainTransactionOutcome, error) {
+	rows, err := s.DB.Query(`
+	SELECT `+onChainTransactionOutcomeColumns+`
+	FROM onchain_transaction_outcomes
+	WHERE ($1 = '' OR outcome = $1) AND ($2 = '' OR tx_hash = $2)
+	ORDER BY block_height DESC, transaction_number DESC
+	LIMIT $3 OFFSET $4
+	`, status, txHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	outcomes := []OnChainTransactionOutcome{}
+	for rows.Next() {
+		outcome, err := scanOnChainTransactionOutcome(rows)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, rows.Err()
+}
+
+/*
+* TrimOldOnChainTransactions removes the transactions below the block height that are still waiting to be
+* processed, recording them as expired with the reason they were waiting for, if one was recorded.
+* Transactions an operator has queued again are kept.
+ */
+func (s *TokenisationStore) TrimOldOnChainTransactions(blockHeight int) error {
+	transactions, err := s.GetOldOnchainTransactions(blockHeight)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, transaction := range transactions {
+		reasonCode := OnChainAwaiting_DATA
+		reason := fmt.Sprintf("not processed before block height %d", blockHeight)
+
+		var awaitingCode, awaitingReason string
+		err = tx.QueryRow("SELECT reason_code, reason FROM onchain_transaction_outcomes WHERE tx_hash = $1 AND outcome = 'awaiting_data'", transaction.TxHash).Scan(&awaitingCode, &awaitingReason)
+		if err == nil {
+			reasonCode = awaitingCode
+			reason = fmt.Sprintf("%s: %s", reason, awaitingReason)
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		err = saveOnChainTransactionOutcomeWithTx(tx, transaction, OnChainOutcome_EXPIRED, reasonCode, reason)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE block_height < $1 AND requeued = FALSE", blockHeight)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExpireOnChainTransaction removes a transaction whose off chain data never arrived, recording it as expired with the reason.
+func (s *TokenisationStore) ExpireOnChainTransaction(onchainTransaction OnChainTransaction, reason string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = saveOnChainTransactionOutcomeWithTx(tx, onchainTransaction, OnChainOutcome_EXPIRED, OnChainAwaiting_DATA, reason)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// HasOnChainTransaction reports whether the transaction is still waiting to be processed.
+func (s *TokenisationStore) HasOnChainTransaction(id string) (bool, error) {
+	var count int
+	err := s.DB.QueryRow("SELECT COUNT(*) FROM onchain_transactions WHERE id = $1", id).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
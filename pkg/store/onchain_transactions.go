@@ -1,12 +1,31 @@
 package store
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+const (
+	OnChainRejection_UNSUPPORTED_ACTION   = "UNSUPPORTED_ACTION"
+	OnChainRejection_UNSUPPORTED_VERSION  = "UNSUPPORTED_VERSION"
+	OnChainRejection_MALFORMED            = "MALFORMED"
+	OnChainRejection_SENDER_MISMATCH      = "SENDER_MISMATCH"
+	OnChainRejection_PAYMENT_MISMATCH     = "PAYMENT_MISMATCH"
+	OnChainRejection_INVOICE_EXPIRED      = "INVOICE_EXPIRED"
+	OnChainRejection_INVOICE_CANCELLED    = "INVOICE_CANCELLED"
+	OnChainRejection_INVOICE_PAID         = "INVOICE_PAID"
+	OnChainRejection_INSUFFICIENT_BALANCE = "INSUFFICIENT_BALANCE"
+	OnChainRejection_INVALID              = "INVALID"
+)
+
+// ErrSenderMismatch is returned when the address that signed the transaction is not the party the action belongs to.
+var ErrSenderMismatch = errors.New("transaction sender does not match")
+
 func getOnChainTransactionsCount(s *TokenisationStore) (int, error) {
 	rows, err := s.DB.Query("SELECT COUNT(*) FROM onchain_transactions")
 	if err != nil {
@@ -27,7 +46,13 @@ func getOnChainTransactionsCount(s *TokenisationStore) (int, error) {
 	return count, nil
 }
 
-func (s *TokenisationStore) SaveOnChainTransaction(tx_hash string, height int64, blockHash string, transaction_number int, action_type uint8, action_version uint8, action_data []byte, address string, values StringInterfaceMap) (string, error) {
+func (s *TokenisationStore) SaveOnChainTransaction(tx_hash string, height int64, blockHash string, transaction_number int, action_type uint8, action_version uint8, action_data []byte, address string, values KoinuValues) (string, error) {
+	return s.SaveOnChainTransactionWithBlockTime(tx_hash, height, blockHash, 0, transaction_number, action_type, action_version, action_data, address, values)
+}
+
+// SaveOnChainTransactionWithBlockTime records the block time (seconds since epoch) so that
+// time based rules are evaluated against the chain rather than the local clock.
+func (s *TokenisationStore) SaveOnChainTransactionWithBlockTime(tx_hash string, height int64, blockHash string, blockTime int64, transaction_number int, action_type uint8, action_version uint8, action_data []byte, address string, values KoinuValues) (string, error) {
 	id := uuid.New().String()
 
 	jsonValues, err := json.Marshal(values)
@@ -35,14 +60,76 @@ func (s *TokenisationStore) SaveOnChainTransaction(tx_hash string, height int64,
 		return "", err
 	}
 	_, err = s.DB.Exec(`
-	INSERT INTO onchain_transactions (id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values")
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`, id, tx_hash, height, blockHash, transaction_number, action_type, action_version, action_data, address, jsonValues)
+	INSERT INTO onchain_transactions (id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time, values_unit)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 'koinu')
+	`, id, tx_hash, height, blockHash, transaction_number, action_type, action_version, action_data, address, jsonValues, blockTime)
 	return id, err
 }
 
+/*
+* convertLegacyOnChainValues rewrites the output values of on-chain transactions recorded
+* before values were kept in koinu. The legacy values are the DOGE amounts as JSON numbers,
+* which are parsed as decimals so the conversion is exact.
+ */
+func (s *TokenisationStore) convertLegacyOnChainValues() error {
+	rows, err := s.DB.Query(`SELECT id, "values" FROM onchain_transactions WHERE values_unit = 'doge'`)
+	if err != nil {
+		return err
+	}
+
+	legacyValues := map[string]KoinuValues{}
+	for rows.Next() {
+		var id string
+		var source []byte
+		if err := rows.Scan(&id, &source); err != nil {
+			rows.Close()
+			return err
+		}
+
+		values, err := legacyValuesToKoinu(source)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("converting values of onchain transaction %s: %w", id, err)
+		}
+		legacyValues[id] = values
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, values := range legacyValues {
+		jsonValues, err := json.Marshal(values)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.DB.Exec(`UPDATE onchain_transactions SET "values" = $1, values_unit = 'koinu' WHERE id = $2`, jsonValues, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func legacyValuesToKoinu(source []byte) (KoinuValues, error) {
+	var dogeValues map[string]decimal.Decimal
+	if err := json.Unmarshal(source, &dogeValues); err != nil {
+		return nil, err
+	}
+
+	values := KoinuValues{}
+	for address, value := range dogeValues {
+		values[address] = value.Shift(8).Round(0).IntPart()
+	}
+
+	return values, nil
+}
+
 func (s *TokenisationStore) GetOldOnchainTransactions(blockHeight int) ([]OnChainTransaction, error) {
-	rows, err := s.DB.Query(`SELECT id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values" FROM onchain_transactions WHERE block_height < $1`, blockHeight)
+	rows, err := s.DB.Query(`SELECT id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time FROM onchain_transactions WHERE block_height < $1 AND requeued = FALSE`, blockHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +138,7 @@ func (s *TokenisationStore) GetOldOnchainTransactions(blockHeight int) ([]OnChai
 	var transactions []OnChainTransaction
 	for rows.Next() {
 		var transaction OnChainTransaction
-		if err := rows.Scan(&transaction.Id, &transaction.TxHash, &transaction.Height, &transaction.BlockHash, &transaction.TransactionNumber, &transaction.ActionType, &transaction.ActionVersion, &transaction.ActionData, &transaction.Address, &transaction.Values); err != nil {
+		if err := rows.Scan(&transaction.Id, &transaction.TxHash, &transaction.Height, &transaction.BlockHash, &transaction.TransactionNumber, &transaction.ActionType, &transaction.ActionVersion, &transaction.ActionData, &transaction.Address, &transaction.Values, &transaction.BlockTime); err != nil {
 			return nil, err
 		}
 		transactions = append(transactions, transaction)
@@ -64,15 +151,6 @@ func (s *TokenisationStore) GetOldOnchainTransactions(blockHeight int) ([]OnChai
 	return transactions, nil
 }
 
-func (s *TokenisationStore) TrimOldOnChainTransactions(blockHeightToKeep int) error {
-	sqlQuery := fmt.Sprintf("DELETE FROM onchain_transactions WHERE block_height < %d", blockHeightToKeep)
-	_, err := s.DB.Exec(sqlQuery)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func (s *TokenisationStore) RemoveOnChainTransaction(id string) error {
 	_, err := s.DB.Exec("DELETE FROM onchain_transactions WHERE id = $1", id)
 	if err != nil {
@@ -92,7 +170,7 @@ func (s *TokenisationStore) CountOnChainTransactions(blockHeight int64) (int, er
 }
 
 func (s *TokenisationStore) GetOnChainTransactions(offset int, limit int) ([]OnChainTransaction, error) {
-	rows, err := s.DB.Query(`SELECT id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values" FROM onchain_transactions ORDER BY block_height ASC, transaction_number ASC LIMIT $1 OFFSET $2`, limit, offset)
+	rows, err := s.DB.Query(`SELECT id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time FROM onchain_transactions ORDER BY block_height ASC, transaction_number ASC LIMIT $1 OFFSET $2`, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +179,7 @@ func (s *TokenisationStore) GetOnChainTransactions(offset int, limit int) ([]OnC
 	var transactions []OnChainTransaction
 	for rows.Next() {
 		var transaction OnChainTransaction
-		if err := rows.Scan(&transaction.Id, &transaction.TxHash, &transaction.Height, &transaction.BlockHash, &transaction.TransactionNumber, &transaction.ActionType, &transaction.ActionVersion, &transaction.ActionData, &transaction.Address, &transaction.Values); err != nil {
+		if err := rows.Scan(&transaction.Id, &transaction.TxHash, &transaction.Height, &transaction.BlockHash, &transaction.TransactionNumber, &transaction.ActionType, &transaction.ActionVersion, &transaction.ActionData, &transaction.Address, &transaction.Values, &transaction.BlockTime); err != nil {
 			return nil, err
 		}
 		transactions = append(transactions, transaction)
@@ -113,3 +191,65 @@ func (s *TokenisationStore) GetOnChainTransactions(offset int, limit int) ([]OnC
 
 	return transactions, nil
 }
+
+/*
+* GetOnChainTransactionsAfter returns the transactions waiting to be processed that come after the position
+* (blockHeight, transactionNumber), in processing order. Paging by position rather than offset keeps the
+* pages stable while processed transactions are removed.
+ */
+func (s *TokenisationStore) GetOnChainTransactionsAfter(blockHeight int64, transactionNumber int, limit int) ([]OnChainTransaction, error) {
+	rows, err := s.DB.Query(`
+	SELECT id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", block_time
+	FROM onchain_transactions
+	WHERE block_height > $1 OR (block_height = $1 AND transaction_number > $2)
+	ORDER BY block_height ASC, transaction_number ASC
+	LIMIT $3
+	`, blockHeight, transactionNumber, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []OnChainTransaction
+	for rows.Next() {
+		var transaction OnChainTransaction
+		if err := rows.Scan(&transaction.Id, &transaction.TxHash, &transaction.Height, &transaction.BlockHash, &transaction.TransactionNumber, &transaction.ActionType, &transaction.ActionVersion, &transaction.ActionData, &transaction.Address, &transaction.Values, &transaction.BlockTime); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+// SetOnChainTransactionConfirmations records the confirmations last seen for a transaction waiting for its confirmation depth.
+func (s *TokenisationStore) SetOnChainTransactionConfirmations(id string, confirmations int, requiredConfirmations int) error {
+	_, err := s.DB.Exec("UPDATE onchain_transactions SET confirmations = $1, required_confirmations = $2 WHERE id = $3", confirmations, requiredConfirmations, id)
+	return err
+}
+
+// RejectOnChainTransaction discards the transaction, recording why it cannot be processed as its rejected outcome.
+func (s *TokenisationStore) RejectOnChainTransaction(onchainTransaction OnChainTransaction, reasonCode string, reason string) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.RejectOnChainTransactionWithTx(tx, onchainTransaction, reasonCode, reason)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *TokenisationStore) RejectOnChainTransactionWithTx(tx *sql.Tx, onchainTransaction OnChainTransaction, reasonCode string, reason string) error {
+	err := saveOnChainTransactionOutcomeWithTx(tx, onchainTransaction, OnChainOutcome_REJECTED, reasonCode, reason)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	return err
+}
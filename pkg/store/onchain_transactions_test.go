@@ -18,8 +18,8 @@ func TestSaveOnChainTransaction(t *testing.T) {
 	actionVersion := uint8(1)
 	actionData := []byte("test action data")
 	address := "DTestAddress123"
-	value := map[string]interface{}{
-		address: 100.5,
+	value := store.KoinuValues{
+		address: 10_050_000_000,
 	}
 
 	id, err := db.SaveOnChainTransaction(txHash, height, "blockHash", transactionNumber, actionType, actionVersion, actionData, address, value)
@@ -37,22 +37,22 @@ func TestSaveOnChainTransaction(t *testing.T) {
 	assert.Equal(t, transactions[0].ActionVersion, actionVersion)
 	assert.DeepEqual(t, transactions[0].ActionData, actionData)
 	assert.Equal(t, transactions[0].Address, address)
-	assert.Assert(t, transactions[0].Values.Equal(store.StringInterfaceMap{address: 100.5}))
+	assert.DeepEqual(t, transactions[0].Values, store.KoinuValues{address: 10_050_000_000})
 }
 
 func TestGetOldOnchainTransactions(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save transactions at different block heights
-	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx2", 200, "blockHash", 1, 1, 1, []byte("data2"), "addr2", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx2", 200, "blockHash", 1, 1, 1, []byte("data2"), "addr2", store.KoinuValues{
 		"addr2": 20,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx3", 300, "blockHash", 1, 1, 1, []byte("data3"), "addr3", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx3", 300, "blockHash", 1, 1, 1, []byte("data3"), "addr3", store.KoinuValues{
 		"addr3": 30,
 	})
 	assert.NilError(t, err)
@@ -69,19 +69,24 @@ func TestTrimOldOnChainTransactions(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save transactions at different block heights
-	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx2", 200, "blockHash", 1, 1, 1, []byte("data2"), "addr2", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx2", 200, "blockHash", 1, 1, 1, []byte("data2"), "addr2", store.KoinuValues{
 		"addr2": 20,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx3", 300, "blockHash", 1, 1, 1, []byte("data3"), "addr3", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx3", 300, "blockHash", 1, 1, 1, []byte("data3"), "addr3", store.KoinuValues{
 		"addr3": 30,
 	})
 	assert.NilError(t, err)
 
+	pending, err := db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = db.SaveAwaitingOnChainTransaction(pending[1], store.OnChainAwaiting_DATA, "invoice not gossiped")
+	assert.NilError(t, err)
+
 	// Trim transactions older than block height 250
 	err = db.TrimOldOnChainTransactions(250)
 	assert.NilError(t, err)
@@ -91,13 +96,27 @@ func TestTrimOldOnChainTransactions(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, len(transactions), 1)
 	assert.Equal(t, transactions[0].TxHash, "tx3")
+
+	// Trimmed transactions are recorded as expired, with the reason they were waiting for
+	expired, err := db.GetOnChainTransactionOutcomes(store.OnChainOutcome_EXPIRED, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(expired), 2)
+	assert.Equal(t, expired[0].TxHash, "tx2")
+	assert.Equal(t, expired[0].ReasonCode, store.OnChainAwaiting_DATA)
+	assert.Equal(t, expired[0].Reason, "not processed before block height 250: invoice not gossiped")
+	assert.DeepEqual(t, expired[0].ActionData, []byte("data2"))
+	assert.Equal(t, expired[1].TxHash, "tx1")
+
+	awaiting, err := db.GetOnChainTransactionOutcomes(store.OnChainOutcome_AWAITING_DATA, "", 0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(awaiting), 0)
 }
 
 func TestRemoveOnChainTransaction(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save a transaction
-	id, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	id, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
@@ -116,15 +135,15 @@ func TestCountOnChainTransactions(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save transactions at different block heights
-	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", store.KoinuValues{
 		"addr2": 20,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", store.KoinuValues{
 		"addr3": 30,
 	})
 	assert.NilError(t, err)
@@ -149,23 +168,23 @@ func TestGetOnChainTransactionsPagination(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save 5 transactions with different heights and transaction numbers
-	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	_, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", store.KoinuValues{
 		"addr2": 20,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", store.KoinuValues{
 		"addr3": 30,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx4", 200, "blockHash", 2, 1, 1, []byte("data4"), "addr4", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx4", 200, "blockHash", 2, 1, 1, []byte("data4"), "addr4", store.KoinuValues{
 		"addr4": 40,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx5", 300, "blockHash", 1, 1, 1, []byte("data5"), "addr5", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx5", 300, "blockHash", 1, 1, 1, []byte("data5"), "addr5", store.KoinuValues{
 		"addr5": 50,
 	})
 	assert.NilError(t, err)
@@ -195,19 +214,19 @@ func TestGetOnChainTransactionsOrdering(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Save transactions out of order
-	_, err := db.SaveOnChainTransaction("tx1", 200, "blockHash", 2, 1, 1, []byte("data1"), "addr1", map[string]interface{}{
+	_, err := db.SaveOnChainTransaction("tx1", 200, "blockHash", 2, 1, 1, []byte("data1"), "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 1, 1, []byte("data2"), "addr2", store.KoinuValues{
 		"addr2": 20,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx3", 200, "blockHash", 1, 1, 1, []byte("data3"), "addr3", store.KoinuValues{
 		"addr3": 30,
 	})
 	assert.NilError(t, err)
-	_, err = db.SaveOnChainTransaction("tx4", 100, "blockHash", 1, 1, 1, []byte("data4"), "addr4", map[string]interface{}{
+	_, err = db.SaveOnChainTransaction("tx4", 100, "blockHash", 1, 1, 1, []byte("data4"), "addr4", store.KoinuValues{
 		"addr4": 40,
 	})
 	assert.NilError(t, err)
@@ -228,14 +247,14 @@ func TestOnChainTransactionEdgeCases(t *testing.T) {
 	db := support.SetupTestDB()
 
 	// Test with empty action data
-	id, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte{}, "addr1", map[string]interface{}{
+	id, err := db.SaveOnChainTransaction("tx1", 100, "blockHash", 1, 1, 1, []byte{}, "addr1", store.KoinuValues{
 		"addr1": 10,
 	})
 	assert.NilError(t, err)
 	assert.Assert(t, id != "")
 
 	// Test with zero value
-	id2, err := db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 0, 0, []byte("data"), "addr2", map[string]interface{}{
+	id2, err := db.SaveOnChainTransaction("tx2", 100, "blockHash", 2, 0, 0, []byte("data"), "addr2", store.KoinuValues{
 		"addr2": 0,
 	})
 	assert.NilError(t, err)
@@ -246,7 +265,7 @@ func TestOnChainTransactionEdgeCases(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, len(transactions), 2)
 	assert.Equal(t, len(transactions[0].ActionData), 0)
-	assert.Assert(t, transactions[0].Values.Equal(store.StringInterfaceMap{"addr1": 10}))
+	assert.DeepEqual(t, transactions[0].Values, store.KoinuValues{"addr1": 10})
 	assert.Equal(t, transactions[1].ActionType, uint8(0))
 	assert.Equal(t, transactions[1].ActionVersion, uint8(0))
 }
@@ -267,3 +286,22 @@ func TestGetOnChainTransactionsEmptyResult(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, len(transactions), 0)
 }
+
+func TestMigrateConvertsLegacyValuesToKoinu(t *testing.T) {
+	db := support.SetupTestDB()
+
+	// Recorded before values were kept in koinu, as DOGE floats
+	_, err := db.DB.Exec(`
+	INSERT INTO onchain_transactions (id, tx_hash, block_height, block_hash, transaction_number, action_type, action_version, action_data, address, "values", values_unit)
+	VALUES ('legacyTx', 'legacyTx', 1, 'blockHash', 1, 1, 1, '', 'addr1', '{"addr1": 12.34567891, "addr2": 0.3}', 'doge')
+	`)
+	assert.NilError(t, err)
+
+	err = db.Migrate()
+	assert.ErrorContains(t, err, "no change")
+
+	transactions, err := db.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(transactions), 1)
+	assert.DeepEqual(t, transactions[0].Values, store.KoinuValues{"addr1": 1_234_567_891, "addr2": 30_000_000})
+}
@@ -0,0 +1,299 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+)
+
+var ErrMintOwnershipTransferNotFound = errors.New("no unconfirmed mint ownership transfer found")
+var ErrMintOwnershipTransferNotAccepted = errors.New("mint ownership transfer has not been accepted")
+
+/*
+* MintOwnershipTransferBody is the payload signed by both the current owner (the offer)
+* and the new owner (the acceptance) of a mint.
+ */
+type MintOwnershipTransferBody struct {
+	MintHash          string `json:"mint_hash"`
+	NewOwnerAddress   string `json:"new_owner_address"`
+	NewOwnerPublicKey string `json:"new_owner_public_key"`
+	Timestamp         int64  `json:"timestamp"`
+}
+
+type MintOwnershipTransfer struct {
+	MintOwnershipTransferBody
+	Hash                 string    `json:"hash"`
+	OwnerPublicKey       string    `json:"owner_public_key"`
+	OwnerSignature       string    `json:"owner_signature"`
+	NewOwnerSignature    string    `json:"new_owner_signature"`
+	PreviousOwnerAddress string    `json:"previous_owner_address,omitempty"`
+	TransactionHash      string    `json:"transaction_hash,omitempty"`
+	TransactionNumber    int       `json:"transaction_number,omitempty"`
+	BlockHeight          int64     `json:"block_height,omitempty"`
+	BlockHash            string    `json:"block_hash,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// MintOwner is an entry of the ownership history of a mint. The first entry is the minter.
+type MintOwner struct {
+	OwnerAddress    string `json:"owner_address"`
+	PublicKey       string `json:"public_key"`
+	TransferHash    string `json:"transfer_hash,omitempty"`
+	TransactionHash string `json:"transaction_hash"`
+	BlockHeight     int64  `json:"block_height"`
+}
+
+func (b *MintOwnershipTransferBody) GenerateHash() (string, error) {
+	jsonBytes, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(jsonBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func (t *MintOwnershipTransfer) Accepted() bool {
+	return t.NewOwnerSignature != ""
+}
+
+/*
+* ValidateMintOwnershipTransfer checks the offer is signed by the current owner of the mint
+* and, once accepted, that the acceptance is signed by the key of the new owner address.
+ */
+func ValidateMintOwnershipTransfer(mint Mint, transfer MintOwnershipTransfer) error {
+	if transfer.MintHash != mint.Hash {
+		return fmt.Errorf("ownership transfer is not for mint: %s", mint.Hash)
+	}
+
+	hash, err := transfer.GenerateHash()
+	if err != nil {
+		return err
+	}
+
+	if hash != transfer.Hash {
+		return fmt.Errorf("ownership transfer hash does not match payload: %s", transfer.Hash)
+	}
+
+	if transfer.OwnerPublicKey != mint.PublicKey {
+		return fmt.Errorf("public key does not match the mint owner")
+	}
+
+	if transfer.NewOwnerAddress == mint.OwnerAddress {
+		return fmt.Errorf("new owner is already the owner of the mint")
+	}
+
+	if !doge.AddressMatchesPublicKey(transfer.NewOwnerAddress, transfer.NewOwnerPublicKey) {
+		return fmt.Errorf("new owner address does not match new owner public key")
+	}
+
+	if err := doge.ValidateSignature(transfer.MintOwnershipTransferBody, transfer.OwnerPublicKey, transfer.OwnerSignature); err != nil {
+		return fmt.Errorf("invalid owner signature: %w", err)
+	}
+
+	if transfer.Accepted() {
+		if err := doge.ValidateSignature(transfer.MintOwnershipTransferBody, transfer.NewOwnerPublicKey, transfer.NewOwnerSignature); err != nil {
+			return fmt.Errorf("invalid new owner signature: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SaveUnconfirmedMintOwnershipTransfer stores an offer, or records the acceptance of a stored offer.
+func (s *TokenisationStore) SaveUnconfirmedMintOwnershipTransfer(transfer *MintOwnershipTransfer) error {
+	_, err := s.DB.Exec(`
+	INSERT INTO unconfirmed_mint_ownership_transfers (hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (hash) DO UPDATE SET new_owner_signature = EXCLUDED.new_owner_signature
+	WHERE unconfirmed_mint_ownership_transfers.new_owner_signature = ''
+	`, transfer.Hash, transfer.MintHash, transfer.NewOwnerAddress, transfer.NewOwnerPublicKey, transfer.Timestamp, transfer.OwnerPublicKey, transfer.OwnerSignature, transfer.NewOwnerSignature, time.Now())
+
+	return err
+}
+
+func scanMintOwnershipTransfer(scanner interface{ Scan(...interface{}) error }, confirmed bool) (MintOwnershipTransfer, error) {
+	var transfer MintOwnershipTransfer
+	var err error
+	if confirmed {
+		err = scanner.Scan(&transfer.Hash, &transfer.MintHash, &transfer.NewOwnerAddress, &transfer.NewOwnerPublicKey, &transfer.Timestamp, &transfer.OwnerPublicKey, &transfer.OwnerSignature, &transfer.NewOwnerSignature, &transfer.CreatedAt, &transfer.PreviousOwnerAddress, &transfer.TransactionHash, &transfer.TransactionNumber, &transfer.BlockHeight, &transfer.BlockHash)
+	} else {
+		err = scanner.Scan(&transfer.Hash, &transfer.MintHash, &transfer.NewOwnerAddress, &transfer.NewOwnerPublicKey, &transfer.Timestamp, &transfer.OwnerPublicKey, &transfer.OwnerSignature, &transfer.NewOwnerSignature, &transfer.CreatedAt)
+	}
+
+	return transfer, err
+}
+
+// GetUnconfirmedMintOwnershipTransfer returns ErrMintOwnershipTransferNotFound if the transfer is unknown.
+func (s *TokenisationStore) GetUnconfirmedMintOwnershipTransfer(hash string) (MintOwnershipTransfer, error) {
+	row := s.DB.QueryRow(`
+	SELECT hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at
+	FROM unconfirmed_mint_ownership_transfers WHERE hash = $1
+	`, hash)
+
+	transfer, err := scanMintOwnershipTransfer(row, false)
+	if err == sql.ErrNoRows {
+		return MintOwnershipTransfer{}, fmt.Errorf("%w: %s", ErrMintOwnershipTransferNotFound, hash)
+	}
+
+	return transfer, err
+}
+
+func (s *TokenisationStore) GetUnconfirmedMintOwnershipTransfers(mintHash string) ([]MintOwnershipTransfer, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at
+	FROM unconfirmed_mint_ownership_transfers WHERE mint_hash = $1 ORDER BY timestamp
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []MintOwnershipTransfer{}
+	for rows.Next() {
+		transfer, err := scanMintOwnershipTransfer(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// GetMintOwnershipTransfers returns the confirmed ownership transfers of the mint in chain order.
+func (s *TokenisationStore) GetMintOwnershipTransfers(mintHash string) ([]MintOwnershipTransfer, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at, previous_owner_address, transaction_hash, transaction_number, block_height, block_hash
+	FROM mint_ownership_transfers WHERE mint_hash = $1 ORDER BY block_height, transaction_number
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []MintOwnershipTransfer{}
+	for rows.Next() {
+		transfer, err := scanMintOwnershipTransfer(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, rows.Err()
+}
+
+// GetMintOwners returns the ownership history of the mint, oldest first. The last entry is the current owner.
+func (s *TokenisationStore) GetMintOwners(mint Mint) ([]MintOwner, error) {
+	transfers, err := s.GetMintOwnershipTransfers(mint.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := []MintOwner{}
+	if len(transfers) == 0 {
+		owners = append(owners, MintOwner{
+			OwnerAddress:    mint.OwnerAddress,
+			PublicKey:       mint.PublicKey,
+			TransactionHash: mint.TransactionHash,
+			BlockHeight:     mint.BlockHeight,
+		})
+	} else {
+		owners = append(owners, MintOwner{
+			OwnerAddress:    transfers[0].PreviousOwnerAddress,
+			PublicKey:       transfers[0].OwnerPublicKey,
+			TransactionHash: mint.TransactionHash,
+			BlockHeight:     mint.BlockHeight,
+		})
+	}
+
+	for _, transfer := range transfers {
+		owners = append(owners, MintOwner{
+			OwnerAddress:    transfer.NewOwnerAddress,
+			PublicKey:       transfer.NewOwnerPublicKey,
+			TransferHash:    transfer.Hash,
+			TransactionHash: transfer.TransactionHash,
+			BlockHeight:     transfer.BlockHeight,
+		})
+	}
+
+	return owners, nil
+}
+
+/*
+* ConfirmMintOwnershipTransfer records the accepted transfer anchored by the on chain transaction
+* and hands the mint (owner_address and public_key) to the new owner.
+ */
+func (s *TokenisationStore) ConfirmMintOwnershipTransfer(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainMintOwnershipTransferMessage) error {
+	if onchainTransaction.ActionType != protocol.ACTION_MINT_OWNERSHIP_TRANSFER {
+		return fmt.Errorf("action type is not mint ownership transfer: %d", onchainTransaction.ActionType)
+	}
+
+	transferHash := hex.EncodeToString(onchainMessage.TransferHash)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRow(`
+	SELECT hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at
+	FROM unconfirmed_mint_ownership_transfers WHERE hash = $1
+	`, transferHash)
+
+	transfer, err := scanMintOwnershipTransfer(row, false)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrMintOwnershipTransferNotFound, transferHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !transfer.Accepted() {
+		return fmt.Errorf("%w: %s", ErrMintOwnershipTransferNotAccepted, transferHash)
+	}
+
+	var previousOwnerAddress string
+	err = tx.QueryRow("SELECT owner_address FROM mints WHERE hash = $1", transfer.MintHash).Scan(&previousOwnerAddress)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO mint_ownership_transfers (hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, previous_owner_address, transaction_hash, transaction_number, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`, transfer.Hash, transfer.MintHash, transfer.NewOwnerAddress, transfer.NewOwnerPublicKey, transfer.Timestamp, transfer.OwnerPublicKey, transfer.OwnerSignature, transfer.NewOwnerSignature, previousOwnerAddress, onchainTransaction.TxHash, onchainTransaction.TransactionNumber, onchainTransaction.Height, onchainTransaction.BlockHash, transfer.CreatedAt)
+	if err != nil {
+		log.Println("Error saving mint ownership transfer:", err)
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE mints SET owner_address = $1, public_key = $2 WHERE hash = $3", transfer.NewOwnerAddress, transfer.NewOwnerPublicKey, transfer.MintHash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM unconfirmed_mint_ownership_transfers WHERE hash = $1", transfer.Hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,122 @@
+package store_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestValidateMintOwnershipTransfer(t *testing.T) {
+	ownerPrivHex, ownerPubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	newPrivHex, newPubHex, newAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	_, _, otherAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mint := store.Mint{MintWithoutID: store.MintWithoutID{Hash: support.GenerateRandomHash(), PublicKey: ownerPubHex, OwnerAddress: ownerAddress}}
+
+	body := store.MintOwnershipTransferBody{MintHash: mint.Hash, NewOwnerAddress: newAddress, NewOwnerPublicKey: newPubHex, Timestamp: 1}
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	ownerSignature, err := doge.SignPayload(body, ownerPrivHex, ownerPubHex)
+	assert.NilError(t, err)
+
+	transfer := store.MintOwnershipTransfer{MintOwnershipTransferBody: body, Hash: hash, OwnerPublicKey: ownerPubHex, OwnerSignature: ownerSignature}
+	assert.NilError(t, store.ValidateMintOwnershipTransfer(mint, transfer))
+	assert.Assert(t, !transfer.Accepted())
+
+	// Only the key of the new owner address may accept
+	transfer.NewOwnerSignature = ownerSignature
+	assert.ErrorContains(t, store.ValidateMintOwnershipTransfer(mint, transfer), "invalid new owner signature")
+
+	transfer.NewOwnerSignature, err = doge.SignPayload(body, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+	assert.NilError(t, store.ValidateMintOwnershipTransfer(mint, transfer))
+
+	mismatched := body
+	mismatched.NewOwnerAddress = otherAddress
+	mismatchedHash, err := mismatched.GenerateHash()
+	assert.NilError(t, err)
+	err = store.ValidateMintOwnershipTransfer(mint, store.MintOwnershipTransfer{MintOwnershipTransferBody: mismatched, Hash: mismatchedHash, OwnerPublicKey: ownerPubHex})
+	assert.ErrorContains(t, err, "does not match new owner public key")
+}
+
+func TestConfirmMintOwnershipTransfer(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	ownerPrivHex, ownerPubHex, ownerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	newPrivHex, newPubHex, newAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	mintHash := support.GenerateRandomHash()
+	_, err = tokenStore.SaveMint(&store.MintWithoutID{Hash: mintHash, Title: "Mint", FractionCount: 10, PublicKey: ownerPubHex, TransactionHash: "mintTx"}, ownerAddress)
+	assert.NilError(t, err)
+
+	body := store.MintOwnershipTransferBody{MintHash: mintHash, NewOwnerAddress: newAddress, NewOwnerPublicKey: newPubHex, Timestamp: 1}
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+	ownerSignature, err := doge.SignPayload(body, ownerPrivHex, ownerPubHex)
+	assert.NilError(t, err)
+	newOwnerSignature, err := doge.SignPayload(body, newPrivHex, newPubHex)
+	assert.NilError(t, err)
+
+	transfer := store.MintOwnershipTransfer{MintOwnershipTransferBody: body, Hash: hash, OwnerPublicKey: ownerPubHex, OwnerSignature: ownerSignature}
+	err = tokenStore.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	assert.NilError(t, err)
+
+	transferHashBytes, _ := hex.DecodeString(hash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	message := &protocol.OnChainMintOwnershipTransferMessage{TransferHash: transferHashBytes, MintHash: mintHashBytes}
+	encoded, err := proto.Marshal(message)
+	assert.NilError(t, err)
+	id, err := tokenStore.SaveOnChainTransaction(support.GenerateRandomHash(), 30, "blockHash30", 0, protocol.ACTION_MINT_OWNERSHIP_TRANSFER, protocol.DEFAULT_VERSION, encoded, ownerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+	onchainTx := store.OnChainTransaction{Id: id, TxHash: "transferTx", Height: 30, BlockHash: "blockHash30", ActionType: protocol.ACTION_MINT_OWNERSHIP_TRANSFER, ActionData: encoded}
+
+	err = tokenStore.ConfirmMintOwnershipTransfer(onchainTx, message)
+	assert.Assert(t, errors.Is(err, store.ErrMintOwnershipTransferNotAccepted))
+
+	// The acceptance is recorded on the stored offer
+	transfer.NewOwnerSignature = newOwnerSignature
+	err = tokenStore.SaveUnconfirmedMintOwnershipTransfer(&transfer)
+	assert.NilError(t, err)
+
+	err = tokenStore.ConfirmMintOwnershipTransfer(onchainTx, message)
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.OwnerAddress, newAddress)
+	assert.Equal(t, mint.PublicKey, newPubHex)
+
+	owners, err := tokenStore.GetMintOwners(mint)
+	assert.NilError(t, err)
+	assert.Equal(t, len(owners), 2)
+	assert.Equal(t, owners[0].OwnerAddress, ownerAddress)
+	assert.Equal(t, owners[0].TransactionHash, "mintTx")
+	assert.Equal(t, owners[1].OwnerAddress, newAddress)
+	assert.Equal(t, owners[1].TransferHash, hash)
+	assert.Equal(t, owners[1].BlockHeight, int64(30))
+
+	// Rolling back hands the mint back to the previous owner
+	err = tokenStore.RollbackToBlockHeight(29)
+	assert.NilError(t, err)
+
+	mint, err = tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.OwnerAddress, ownerAddress)
+	assert.Equal(t, mint.PublicKey, ownerPubHex)
+
+	pending, err := tokenStore.GetUnconfirmedMintOwnershipTransfer(hash)
+	assert.NilError(t, err)
+	assert.Assert(t, pending.Accepted())
+}
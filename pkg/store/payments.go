@@ -1,14 +1,26 @@
 package store
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"dogecoin.org/fractal-engine/pkg/protocol"
-	"google.golang.org/protobuf/proto"
 )
 
+// ErrPaymentMismatch is returned when a payment transaction pays nothing to the invoice's payment address.
+var ErrPaymentMismatch = errors.New("payment does not pay the invoice")
+
+// ErrInvoiceNotFound is returned when a payment references an invoice that has not been confirmed yet.
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+/*
+* ProcessPayment records the koinu the transaction paid to the invoice's payment address. Payments
+* accumulate until the invoice total is reached, which settles the invoice by moving the pending
+* balance to the buyer. Anything paid beyond the total is kept as the invoice's surplus.
+ */
 func (s *TokenisationStore) ProcessPayment(onchainTransaction OnChainTransaction, invoice Invoice) error {
 	tx, err := s.DB.Begin()
 	if err != nil {
@@ -17,9 +29,8 @@ func (s *TokenisationStore) ProcessPayment(onchainTransaction OnChainTransaction
 
 	defer tx.Rollback()
 
-	_, err = tx.Exec("UPDATE invoices SET paid_at = $1 WHERE id = $2", time.Now().UTC(), invoice.Id)
+	err = s.applyPayment(onchainTransaction, invoice, onchainTransaction.Values[invoice.PayableAddress()], tx)
 	if err != nil {
-		log.Println("Error updating invoice:", err)
 		return err
 	}
 
@@ -29,66 +40,104 @@ func (s *TokenisationStore) ProcessPayment(onchainTransaction OnChainTransaction
 		return err
 	}
 
-	pendingTokenBalance, err := s.GetPendingTokenBalanceForQuantity(invoice.Hash, invoice.MintHash, invoice.Quantity, tx)
+	err = tx.Commit()
 	if err != nil {
-		log.Println("Error getting pending token balance:", err)
 		return err
 	}
 
-	err = s.MovePendingToTokenBalance(pendingTokenBalance, invoice.BuyerAddress, tx)
+	return nil
+}
+
+// applyPayment records the amount as paid towards the invoice, settling it once the total is reached.
+func (s *TokenisationStore) applyPayment(onchainTransaction OnChainTransaction, invoice Invoice, amount int64, tx *sql.Tx) error {
+	_, err := tx.Exec(`
+	INSERT INTO invoice_payments (tx_hash, invoice_hash, amount_koinu, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	`, onchainTransaction.TxHash, invoice.Hash, amount, onchainTransaction.Height, onchainTransaction.BlockHash, time.Now().UTC())
 	if err != nil {
-		log.Println("Error moving pending to token balance:", err)
+		log.Println("Error saving invoice payment:", err)
 		return err
 	}
 
-	err = tx.Commit()
+	_, err = tx.Exec("UPDATE invoices SET paid_koinu = paid_koinu + $1 WHERE id = $2", amount, invoice.Id)
 	if err != nil {
+		log.Println("Error updating invoice:", err)
+		return err
+	}
+
+	if !invoice.SettledBy(amount) {
+		return nil
+	}
+
+	_, err = tx.Exec("UPDATE invoices SET paid_at = $1, paid_block_height = $2, paid_block_hash = $3 WHERE id = $4", time.Now().UTC(), onchainTransaction.Height, onchainTransaction.BlockHash, invoice.Id)
+	if err != nil {
+		log.Println("Error updating invoice:", err)
+		return err
+	}
+
+	pendingTokenBalance, err := s.GetPendingTokenBalanceForQuantity(invoice.Hash, invoice.MintHash, invoice.Quantity, tx)
+	if err != nil {
+		log.Println("Error getting pending token balance:", err)
+		return err
+	}
+
+	err = s.MovePendingToTokenBalance(pendingTokenBalance, invoice.BuyerAddress, onchainTransaction.Height, onchainTransaction.BlockHash, tx)
+	if err != nil {
+		log.Println("Error moving pending to token balance:", err)
 		return err
 	}
 
 	return nil
 }
 
-func (s *TokenisationStore) MatchPayment(onchainTransaction OnChainTransaction) (Invoice, error) {
+func (s *TokenisationStore) MatchPayment(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainPaymentMessage) (Invoice, error) {
 	if onchainTransaction.ActionType != protocol.ACTION_PAYMENT {
 		return Invoice{}, fmt.Errorf("action type is not payment: %d", onchainTransaction.ActionType)
 	}
 
-	var onchainMessage protocol.OnChainPaymentMessage
-	err := proto.Unmarshal(onchainTransaction.ActionData, &onchainMessage)
+	invoice, err := s.getPayableInvoice(onchainMessage.Hash)
 	if err != nil {
 		return Invoice{}, err
 	}
 
-	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price, created_at, seller_address FROM invoices WHERE hash = $1", onchainMessage.Hash)
+	if onchainTransaction.Address != invoice.BuyerAddress {
+		return Invoice{}, fmt.Errorf("%w: invoice %s is bought by %s, paid by %s", ErrSenderMismatch, invoice.Hash, invoice.BuyerAddress, onchainTransaction.Address)
+	}
+
+	if err := s.CheckPaymentOnTime(invoice, onchainTransaction.Height); err != nil {
+		return Invoice{}, err
+	}
+
+	if onchainTransaction.Values[invoice.PayableAddress()] <= 0 {
+		return Invoice{}, fmt.Errorf("%w: %s paid nothing to %s, the payment address of invoice %s", ErrPaymentMismatch, onchainTransaction.TxHash, invoice.PayableAddress(), invoice.Hash)
+	}
+
+	return invoice, nil
+}
+
+// getPayableInvoice returns the invoice with the payment state needed to match a payment to it.
+func (s *TokenisationStore) getPayableInvoice(invoiceHash string) (Invoice, error) {
+	rows, err := s.DB.Query("SELECT id, hash, payment_address, buyer_address, mint_hash, quantity, price_koinu, created_at, seller_address, paid_at, paid_koinu, expiry_height FROM invoices WHERE hash = $1", invoiceHash)
 	if err != nil {
 		log.Println("Error querying invoices:", err)
 		return Invoice{}, err
 	}
+	defer rows.Close()
 
 	var invoice Invoice
 
 	if rows.Next() {
-		err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.Price, &invoice.CreatedAt, &invoice.SellerAddress)
+		err := rows.Scan(&invoice.Id, &invoice.Hash, &invoice.PaymentAddress, &invoice.BuyerAddress, &invoice.MintHash, &invoice.Quantity, &invoice.PriceKoinu, &invoice.CreatedAt, &invoice.SellerAddress, &invoice.PaidAt, &invoice.PaidKoinu, &invoice.ExpiryHeight)
 		if err != nil {
 			log.Println("Error scanning invoice:", err)
 			return Invoice{}, err
 		}
 	}
 
-	rows.Close()
-
 	if invoice.Id == "" {
-		return Invoice{}, fmt.Errorf("invoice not found")
-	}
-
-	value := float64(invoice.Quantity * invoice.Price)
-
-	paymentValue := onchainTransaction.Values[invoice.SellerAddress]
-
-	if paymentValue != value {
-		return Invoice{}, fmt.Errorf("payment value is not equal to buy offer value: %f != %f", paymentValue, value)
+		return Invoice{}, fmt.Errorf("%w: %s", ErrInvoiceNotFound, invoiceHash)
 	}
+	invoice.setPaymentState()
 
 	return invoice, nil
 }
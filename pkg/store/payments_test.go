@@ -3,6 +3,7 @@ package store_test
 import (
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -37,7 +38,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 	sellerAddress := test_support.GenerateDogecoinAddress(true)
 	buyerAddress := test_support.GenerateDogecoinAddress(true)
 	quantity := 50
-	value := 50.0 * 100
+	value := int64(50 * 100)
 
 	// Step 1: Create and match mint
 	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
@@ -52,7 +53,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 
 	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
 	encodedMintMsg, _ := proto.Marshal(mintMsg)
-	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, map[string]interface{}{
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{
 		sellerAddress: 100,
 	})
 	assert.NilError(t, err)
@@ -62,7 +63,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 	mintTx := findTransactionById(txs, mintTxId)
 	assert.Assert(t, mintTx != nil)
 
-	err = tokenStore.MatchUnconfirmedMint(*mintTx)
+	err = tokenStore.MatchUnconfirmedMint(*mintTx, mintMsg)
 	assert.NilError(t, err)
 
 	// Step 2: Create and match invoice
@@ -72,7 +73,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 		BuyerAddress:   buyerAddress,
 		MintHash:       mintHash,
 		Quantity:       quantity,
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  sellerAddress,
 	})
@@ -89,8 +90,8 @@ func TestMatchPaymentSuccess(t *testing.T) {
 		Quantity:    int32(quantity),
 	}
 	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
-	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, map[string]interface{}{
-		sellerAddress: quantity,
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{
+		sellerAddress: int64(quantity),
 	})
 	assert.NilError(t, err)
 
@@ -104,7 +105,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 	err = tokenStore.UpsertPendingTokenBalance(invoiceHash, mintHash, quantity, invoiceTx.Id, sellerAddress)
 	assert.NilError(t, err)
 
-	err = tokenStore.MatchUnconfirmedInvoice(*invoiceTx)
+	err = tokenStore.MatchUnconfirmedInvoice(*invoiceTx, invoiceMsg)
 	assert.NilError(t, err)
 
 	// Step 3: Create and match payment
@@ -112,7 +113,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 		Hash: invoiceHash,
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
-	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 3, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 3, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		sellerAddress: value,
 	})
 	assert.NilError(t, err)
@@ -123,7 +124,7 @@ func TestMatchPaymentSuccess(t *testing.T) {
 	assert.Assert(t, paymentTx != nil)
 
 	// Test MatchPayment
-	invoice, err := tokenStore.MatchPayment(*paymentTx)
+	invoice, err := tokenStore.MatchPayment(*paymentTx, paymentMsg)
 	assert.NilError(t, err)
 
 	err = tokenStore.ProcessPayment(*paymentTx, invoice)
@@ -173,24 +174,10 @@ func TestMatchPaymentWrongActionType(t *testing.T) {
 		ActionType: protocol.ACTION_MINT, // Wrong type
 	}
 
-	_, err := tokenStore.MatchPayment(paymentTx)
+	_, err := tokenStore.MatchPayment(paymentTx, &protocol.OnChainPaymentMessage{Hash: "invoiceHash"})
 	assert.ErrorContains(t, err, "action type is not payment")
 }
 
-func TestMatchPaymentInvalidProtobuf(t *testing.T) {
-	tokenStore := test_support.SetupTestDB()
-
-	paymentTx := store.OnChainTransaction{
-		Id:         "payment123",
-		TxHash:     "paymentTx",
-		ActionType: protocol.ACTION_PAYMENT,
-		ActionData: []byte("invalid protobuf data"),
-	}
-
-	_, err := tokenStore.MatchPayment(paymentTx)
-	assert.Assert(t, err != nil, "Should fail with invalid protobuf")
-}
-
 func TestMatchPaymentInvoiceNotFound(t *testing.T) {
 	tokenStore := test_support.SetupTestDB()
 
@@ -201,7 +188,7 @@ func TestMatchPaymentInvoiceNotFound(t *testing.T) {
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
 
-	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		buyerAddress: 50,
 	})
 	assert.NilError(t, err)
@@ -211,7 +198,7 @@ func TestMatchPaymentInvoiceNotFound(t *testing.T) {
 	paymentTx := findTransactionById(txs, paymentTxId)
 	assert.Assert(t, paymentTx != nil)
 
-	_, err = tokenStore.MatchPayment(*paymentTx)
+	_, err = tokenStore.MatchPayment(*paymentTx, paymentMsg)
 	assert.ErrorContains(t, err, "invoice not found")
 }
 
@@ -229,7 +216,7 @@ func TestMatchPaymentValueMismatch(t *testing.T) {
 		MintHash:       mintHash,
 		BuyerAddress:   buyerAddress,
 		Quantity:       50,
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  sellerAddress,
 	}
@@ -242,7 +229,7 @@ func TestMatchPaymentValueMismatch(t *testing.T) {
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
 
-	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		buyerAddress: 25,
 	}) // Wrong value
 	assert.NilError(t, err)
@@ -252,8 +239,46 @@ func TestMatchPaymentValueMismatch(t *testing.T) {
 	paymentTx := findTransactionById(txs, paymentTxId)
 	assert.Assert(t, paymentTx != nil)
 
-	_, err = tokenStore.MatchPayment(*paymentTx)
-	assert.ErrorContains(t, err, "payment value is not equal to buy offer value")
+	_, err = tokenStore.MatchPayment(*paymentTx, paymentMsg)
+	assert.Assert(t, errors.Is(err, store.ErrPaymentMismatch))
+}
+
+func TestMatchPaymentSenderIsNotBuyer(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	invoiceHash := "testInvoice123"
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+	otherAddress := test_support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveInvoice(&store.Invoice{
+		Hash:           invoiceHash,
+		PaymentAddress: sellerAddress,
+		MintHash:       test_support.GenerateRandomHash(),
+		BuyerAddress:   buyerAddress,
+		Quantity:       50,
+		PriceKoinu:     100,
+		CreatedAt:      time.Now(),
+		SellerAddress:  sellerAddress,
+	})
+	assert.NilError(t, err)
+
+	paymentMsg := &protocol.OnChainPaymentMessage{Hash: invoiceHash}
+	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
+
+	// Paid in full, but signed by someone other than the buyer
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, otherAddress, store.KoinuValues{
+		sellerAddress: 5000,
+	})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	paymentTx := findTransactionById(txs, paymentTxId)
+	assert.Assert(t, paymentTx != nil)
+
+	_, err = tokenStore.MatchPayment(*paymentTx, paymentMsg)
+	assert.Assert(t, errors.Is(err, store.ErrSenderMismatch))
 }
 
 func TestMatchPaymentPendingBalanceMismatch(t *testing.T) {
@@ -271,7 +296,7 @@ func TestMatchPaymentPendingBalanceMismatch(t *testing.T) {
 		BuyerAddress:   buyerAddress,
 		MintHash:       mintHash,
 		Quantity:       50, // Expected quantity
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  sellerAddress,
 	}
@@ -288,7 +313,7 @@ func TestMatchPaymentPendingBalanceMismatch(t *testing.T) {
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
 
-	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		sellerAddress: 5000,
 	})
 	assert.NilError(t, err)
@@ -298,7 +323,7 @@ func TestMatchPaymentPendingBalanceMismatch(t *testing.T) {
 	paymentTx := findTransactionById(txs, paymentTxId)
 	assert.Assert(t, paymentTx != nil)
 
-	invoice, err := tokenStore.MatchPayment(*paymentTx)
+	invoice, err := tokenStore.MatchPayment(*paymentTx, paymentMsg)
 	assert.NilError(t, err)
 
 	err = tokenStore.ProcessPayment(*paymentTx, invoice)
@@ -319,7 +344,7 @@ func TestMatchPaymentNoPendingBalance(t *testing.T) {
 		BuyerAddress:   buyerAddress,
 		MintHash:       "mint123",
 		Quantity:       50,
-		Price:          100,
+		PriceKoinu:     100,
 		CreatedAt:      time.Now(),
 		SellerAddress:  sellerAddress,
 	}
@@ -332,7 +357,7 @@ func TestMatchPaymentNoPendingBalance(t *testing.T) {
 	}
 	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
 
-	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, map[string]interface{}{
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
 		buyerAddress: 50,
 	})
 	assert.NilError(t, err)
@@ -343,7 +368,7 @@ func TestMatchPaymentNoPendingBalance(t *testing.T) {
 	assert.Assert(t, paymentTx != nil)
 
 	// Should fail due to missing pending balance
-	inv, err := tokenStore.MatchPayment(*paymentTx)
+	inv, err := tokenStore.MatchPayment(*paymentTx, paymentMsg)
 	assert.Assert(t, err != nil, "Should fail without pending balance")
 
 	err = tokenStore.ProcessPayment(*paymentTx, inv)
@@ -355,3 +380,48 @@ func TestMatchPaymentNoPendingBalance(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Assert(t, !paidAt.Valid, "Invoice should NOT be paid due to rollback")
 }
+
+func TestMatchPaymentUsesPaymentAddress(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	invoiceHash := test_support.GenerateRandomHash()
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+	paymentAddress := test_support.GenerateDogecoinAddress(true)
+
+	_, err := tokenStore.SaveInvoice(&store.Invoice{
+		Hash:           invoiceHash,
+		PaymentAddress: paymentAddress,
+		MintHash:       test_support.GenerateRandomHash(),
+		BuyerAddress:   buyerAddress,
+		Quantity:       50,
+		PriceKoinu:     100,
+		CreatedAt:      time.Now(),
+		SellerAddress:  sellerAddress,
+	})
+	assert.NilError(t, err)
+
+	paymentMsg := &protocol.OnChainPaymentMessage{Hash: invoiceHash}
+	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
+
+	toSellerTxId, err := tokenStore.SaveOnChainTransaction("toSellerTx", 1, "blockHash", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
+		sellerAddress: 5000,
+	})
+	assert.NilError(t, err)
+
+	toPaymentAddressTxId, err := tokenStore.SaveOnChainTransaction("toPaymentAddressTx", 1, "blockHash", 2, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
+		paymentAddress: 5000,
+	})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+
+	_, err = tokenStore.MatchPayment(*findTransactionById(txs, toSellerTxId), paymentMsg)
+	assert.Assert(t, errors.Is(err, store.ErrPaymentMismatch))
+
+	invoice, err := tokenStore.MatchPayment(*findTransactionById(txs, toPaymentAddressTxId), paymentMsg)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.PayableAddress(), paymentAddress)
+	assert.Equal(t, invoice.PaymentStatus, store.InvoicePaymentStatus_UNPAID)
+}
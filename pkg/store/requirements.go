@@ -0,0 +1,609 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/validation"
+	"github.com/google/uuid"
+)
+
+var ErrMintAllowlistEntryNotFound = errors.New("no unconfirmed allowlist entry found")
+
+const (
+	TradeRejection_NOT_ALLOWLISTED           = "NOT_ALLOWLISTED"
+	TradeRejection_MAX_HOLDERS_EXCEEDED      = "MAX_HOLDERS_EXCEEDED"
+	TradeRejection_MAX_HOLDER_SHARE_EXCEEDED = "MAX_HOLDER_SHARE_EXCEEDED"
+)
+
+/*
+* Requirements is the typed schema of MintWithoutID.Requirements.
+* When the allowlist is enabled, buyers must match an address or public key on the
+* allowlist. The allowlist starts with the entries in the mint and is maintained
+* afterwards by the asset managers.
+* MaxHolderShareBps caps the share of the fraction count a single holder may own, in basis points.
+ */
+type Requirements struct {
+	AllowlistEnabled       bool     `json:"allowlist_enabled,omitempty"`
+	Allowlist              []string `json:"allowlist,omitempty"`
+	MaxHolders             int      `json:"max_holders,omitempty"`
+	MaxHolderShareBps      int      `json:"max_holder_share_bps,omitempty"`
+	BurnRequiresSignatures bool     `json:"burn_requires_signatures,omitempty"`
+}
+
+// TradeViolation is returned when a trade would break the requirements of a mint.
+type TradeViolation struct {
+	Code   string
+	Reason string
+}
+
+func (v *TradeViolation) Error() string {
+	return fmt.Sprintf("%s: %s", v.Code, v.Reason)
+}
+
+type TradeRejection struct {
+	Id              string    `json:"id"`
+	InvoiceHash     string    `json:"invoice_hash"`
+	MintHash        string    `json:"mint_hash"`
+	BuyerAddress    string    `json:"buyer_address"`
+	SellerAddress   string    `json:"seller_address"`
+	Quantity        int       `json:"quantity"`
+	ReasonCode      string    `json:"reason_code"`
+	Reason          string    `json:"reason"`
+	ActionType      uint8     `json:"action_type"`
+	TransactionHash string    `json:"transaction_hash"`
+	BlockHeight     int64     `json:"block_height"`
+	BlockHash       string    `json:"block_hash"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// MintAllowlistEntryBody is the payload an asset manager signs to add or remove an allowlist entry.
+type MintAllowlistEntryBody struct {
+	MintHash  string `json:"mint_hash"`
+	Entry     string `json:"entry"`
+	Allowed   bool   `json:"allowed"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+/*
+* MintAllowlistEntry is an allowlist update. It is gossiped and kept unconfirmed until an on chain
+* transaction anchors its hash, and applies to trades after that transaction.
+ */
+type MintAllowlistEntry struct {
+	MintAllowlistEntryBody
+	Hash              string    `json:"hash"`
+	PublicKey         string    `json:"public_key"`
+	Signature         string    `json:"signature"`
+	TransactionHash   string    `json:"transaction_hash,omitempty"`
+	TransactionNumber int       `json:"transaction_number,omitempty"`
+	BlockHeight       int64     `json:"block_height,omitempty"`
+	BlockHash         string    `json:"block_hash,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func (b *MintAllowlistEntryBody) GenerateHash() (string, error) {
+	jsonBytes, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(jsonBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func ParseRequirements(requirements StringInterfaceMap) (Requirements, error) {
+	var typedRequirements Requirements
+	if len(requirements) == 0 {
+		return typedRequirements, nil
+	}
+
+	jsonBytes, err := json.Marshal(requirements)
+	if err != nil {
+		return Requirements{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&typedRequirements); err != nil {
+		return Requirements{}, err
+	}
+
+	if err := typedRequirements.Validate(); err != nil {
+		return Requirements{}, err
+	}
+
+	return typedRequirements, nil
+}
+
+func (r Requirements) Validate() error {
+	if r.MaxHolders < 0 {
+		return errors.New("max_holders must not be negative")
+	}
+
+	if r.MaxHolderShareBps < 0 || r.MaxHolderShareBps > 10000 {
+		return errors.New("max_holder_share_bps must be between 0 and 10000")
+	}
+
+	if !r.AllowlistEnabled && len(r.Allowlist) > 0 {
+		return errors.New("allowlist entries require allowlist_enabled")
+	}
+
+	for _, entry := range r.Allowlist {
+		if err := ValidateAllowlistEntry(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateAllowlistEntry accepts a dogecoin address or a public key.
+func ValidateAllowlistEntry(entry string) error {
+	if validation.ValidateAddress(entry) == nil || validation.ValidatePublicKey(entry) == nil {
+		return nil
+	}
+
+	return fmt.Errorf("invalid allowlist entry (expected address or public key): %s", entry)
+}
+
+// TypedRequirements returns the typed requirements of the mint.
+// Mints that predate the typed schema may carry free form requirements, which are not enforced.
+func (m *Mint) TypedRequirements() Requirements {
+	requirements, err := ParseRequirements(m.Requirements)
+	if err != nil {
+		log.Println("Ignoring requirements for mint", m.Hash+":", err)
+		return Requirements{}
+	}
+
+	return requirements
+}
+
+func allowlistEntryMatches(entry string, address string) bool {
+	if entry == address {
+		return true
+	}
+
+	if validation.ValidatePublicKey(entry) != nil {
+		return false
+	}
+
+	for _, prefix := range []byte{doge.PrefixMainnet, doge.PrefixTestnet, doge.PrefixRegtest} {
+		entryAddress, err := doge.PublicKeyToDogeAddress(entry, prefix)
+		if err == nil && entryAddress == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetMintAllowlist returns the effective allowlist after every confirmed asset manager update.
+func (s *TokenisationStore) GetMintAllowlist(mint Mint) ([]string, error) {
+	return s.GetMintAllowlistAt(mint, math.MaxInt64, math.MaxInt32)
+}
+
+/*
+* GetMintAllowlistAt returns the allowlist effective for the on chain transaction at blockHeight and
+* transactionNumber: the mint's entries with the updates confirmed before it applied in chain order.
+* An update is ignored if the entry was already updated with a newer timestamp.
+ */
+func (s *TokenisationStore) GetMintAllowlistAt(mint Mint, blockHeight int64, transactionNumber int) ([]string, error) {
+	requirements := mint.TypedRequirements()
+
+	allowed := map[string]bool{}
+	for _, entry := range requirements.Allowlist {
+		allowed[entry] = true
+	}
+
+	rows, err := s.DB.Query(`
+	SELECT entry, allowed, timestamp FROM mint_allowlist_entries
+	WHERE mint_hash = $1 AND (block_height < $2 OR (block_height = $2 AND transaction_number < $3))
+	ORDER BY block_height, transaction_number
+	`, mint.Hash, blockHeight, transactionNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	timestamps := map[string]int64{}
+	for rows.Next() {
+		var entry string
+		var isAllowed bool
+		var timestamp int64
+		if err := rows.Scan(&entry, &isAllowed, &timestamp); err != nil {
+			return nil, err
+		}
+
+		if latest, ok := timestamps[entry]; ok && latest > timestamp {
+			continue
+		}
+
+		timestamps[entry] = timestamp
+		allowed[entry] = isAllowed
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := []string{}
+	for _, entry := range requirements.Allowlist {
+		if allowed[entry] {
+			entries = append(entries, entry)
+			delete(allowed, entry)
+		}
+	}
+
+	var added []string
+	for entry, isAllowed := range allowed {
+		if isAllowed {
+			added = append(added, entry)
+		}
+	}
+	sort.Strings(added)
+
+	return append(entries, added...), nil
+}
+
+func (s *TokenisationStore) SaveUnconfirmedMintAllowlistEntry(entry *MintAllowlistEntry) error {
+	_, err := s.DB.Exec(`
+	INSERT INTO unconfirmed_mint_allowlist_entries (hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (hash) DO NOTHING
+	`, entry.Hash, entry.MintHash, entry.Entry, entry.Allowed, entry.Timestamp, entry.PublicKey, entry.Signature, time.Now())
+
+	return err
+}
+
+func scanMintAllowlistEntry(scanner interface{ Scan(...interface{}) error }, confirmed bool) (MintAllowlistEntry, error) {
+	var entry MintAllowlistEntry
+	var err error
+	if confirmed {
+		err = scanner.Scan(&entry.Hash, &entry.MintHash, &entry.Entry, &entry.Allowed, &entry.Timestamp, &entry.PublicKey, &entry.Signature, &entry.CreatedAt, &entry.TransactionHash, &entry.TransactionNumber, &entry.BlockHeight, &entry.BlockHash)
+	} else {
+		err = scanner.Scan(&entry.Hash, &entry.MintHash, &entry.Entry, &entry.Allowed, &entry.Timestamp, &entry.PublicKey, &entry.Signature, &entry.CreatedAt)
+	}
+
+	return entry, err
+}
+
+// GetUnconfirmedMintAllowlistEntry returns ErrMintAllowlistEntryNotFound if the entry is unknown.
+func (s *TokenisationStore) GetUnconfirmedMintAllowlistEntry(hash string) (MintAllowlistEntry, error) {
+	row := s.DB.QueryRow(`
+	SELECT hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_allowlist_entries WHERE hash = $1
+	`, hash)
+
+	entry, err := scanMintAllowlistEntry(row, false)
+	if err == sql.ErrNoRows {
+		return MintAllowlistEntry{}, fmt.Errorf("%w: %s", ErrMintAllowlistEntryNotFound, hash)
+	}
+
+	return entry, err
+}
+
+// GetUnconfirmedMintAllowlistEntries returns the updates of a mint waiting to be anchored, oldest first.
+func (s *TokenisationStore) GetUnconfirmedMintAllowlistEntries(mintHash string) ([]MintAllowlistEntry, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_allowlist_entries WHERE mint_hash = $1 ORDER BY timestamp
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []MintAllowlistEntry{}
+	for rows.Next() {
+		entry, err := scanMintAllowlistEntry(rows, false)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// GetMintAllowlistEntries returns the confirmed updates of a mint in chain order.
+func (s *TokenisationStore) GetMintAllowlistEntries(mintHash string) ([]MintAllowlistEntry, error) {
+	rows, err := s.DB.Query(`
+	SELECT hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at, transaction_hash, transaction_number, block_height, block_hash
+	FROM mint_allowlist_entries WHERE mint_hash = $1 ORDER BY block_height, transaction_number
+	`, mintHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []MintAllowlistEntry{}
+	for rows.Next() {
+		entry, err := scanMintAllowlistEntry(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+/*
+* ConfirmMintAllowlistEntry confirms the gossiped allowlist update anchored by the on chain transaction.
+* Returns ErrMintAllowlistEntryNotFound if the update has not been gossiped yet.
+ */
+func (s *TokenisationStore) ConfirmMintAllowlistEntry(onchainTransaction OnChainTransaction, onchainMessage *protocol.OnChainAllowlistEntryMessage) error {
+	if onchainTransaction.ActionType != protocol.ACTION_ALLOWLIST_ENTRY {
+		return fmt.Errorf("action type is not allowlist entry: %d", onchainTransaction.ActionType)
+	}
+
+	entryHash := hex.EncodeToString(onchainMessage.EntryHash)
+	mintHash := hex.EncodeToString(onchainMessage.MintHash)
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	row := tx.QueryRow(`
+	SELECT hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at
+	FROM unconfirmed_mint_allowlist_entries WHERE hash = $1 AND mint_hash = $2
+	`, entryHash, mintHash)
+
+	entry, err := scanMintAllowlistEntry(row, false)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrMintAllowlistEntryNotFound, entryHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO mint_allowlist_entries (hash, mint_hash, entry, allowed, timestamp, public_key, signature, transaction_hash, transaction_number, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, entry.Hash, entry.MintHash, entry.Entry, entry.Allowed, entry.Timestamp, entry.PublicKey, entry.Signature, onchainTransaction.TxHash, onchainTransaction.TransactionNumber, onchainTransaction.Height, onchainTransaction.BlockHash, entry.CreatedAt)
+	if err != nil {
+		log.Println("Error saving allowlist entry:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM unconfirmed_mint_allowlist_entries WHERE hash = $1", entry.Hash)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ValidateMintAllowlistEntry checks that the entry is signed by one of the mint's asset managers.
+func ValidateMintAllowlistEntry(mint Mint, entry MintAllowlistEntry) error {
+	if err := ValidateAllowlistEntry(entry.Entry); err != nil {
+		return err
+	}
+
+	if entry.MintHash != mint.Hash {
+		return fmt.Errorf("allowlist entry is not for mint: %s", mint.Hash)
+	}
+
+	hash, err := entry.GenerateHash()
+	if err != nil {
+		return err
+	}
+
+	if hash != entry.Hash {
+		return fmt.Errorf("allowlist entry hash does not match payload: %s", entry.Hash)
+	}
+
+	if !mint.AssetManagers.Contains(entry.PublicKey) {
+		return fmt.Errorf("public key does not match any asset managers")
+	}
+
+	if err := doge.ValidateSignature(entry.MintAllowlistEntryBody, entry.PublicKey, entry.Signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TokenisationStore) isAllowlisted(mint Mint, address string, onchainTransaction OnChainTransaction) (bool, error) {
+	entries, err := s.GetMintAllowlistAt(mint, onchainTransaction.Height, onchainTransaction.TransactionNumber)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if allowlistEntryMatches(entry, address) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getTokenBalanceTotalAt sums the balance changes of blocks up to blockHeight. Balances without a block predate block attribution.
+func (s *TokenisationStore) getTokenBalanceTotalAt(address string, mintHash string, blockHeight int64) (int, error) {
+	var total int
+	err := s.DB.QueryRow(`
+	SELECT COALESCE(SUM(quantity), 0) FROM token_balances
+	WHERE address = $1 AND mint_hash = $2 AND (block_height IS NULL OR block_height <= $3)
+	`, address, mintHash, blockHeight).Scan(&total)
+	return total, err
+}
+
+func (s *TokenisationStore) GetHolderCount(mintHash string) (int, error) {
+	return s.GetHolderCountAt(mintHash, math.MaxInt64)
+}
+
+// GetHolderCountAt returns the number of addresses holding fractions of the mint after the block at blockHeight.
+func (s *TokenisationStore) GetHolderCountAt(mintHash string, blockHeight int64) (int, error) {
+	var count int
+	err := s.DB.QueryRow(`
+	SELECT COUNT(*) FROM (
+		SELECT address FROM token_balances
+		WHERE mint_hash = $1 AND (block_height IS NULL OR block_height <= $2)
+		GROUP BY address HAVING SUM(quantity) > 0
+	) holders
+	`, mintHash, blockHeight).Scan(&count)
+	return count, err
+}
+
+/*
+* CheckTradeRequirements returns a *TradeViolation if moving quantity tokens from the seller
+* to the buyer would break the mint's requirements. The allowlist and balances are taken as of
+* the on chain transaction making the trade, so every node reaches the same verdict, whenever
+* it processes the transaction. Transactions are processed in chain order, so the balances of
+* its own block come from the transactions before it.
+ */
+func (s *TokenisationStore) CheckTradeRequirements(mint Mint, sellerAddress string, buyerAddress string, quantity int, onchainTransaction OnChainTransaction) error {
+	requirements := mint.TypedRequirements()
+
+	if requirements.AllowlistEnabled {
+		allowlisted, err := s.isAllowlisted(mint, buyerAddress, onchainTransaction)
+		if err != nil {
+			return err
+		}
+
+		if !allowlisted {
+			return &TradeViolation{Code: TradeRejection_NOT_ALLOWLISTED, Reason: fmt.Sprintf("buyer %s is not on the allowlist", buyerAddress)}
+		}
+	}
+
+	if requirements.MaxHolders == 0 && requirements.MaxHolderShareBps == 0 {
+		return nil
+	}
+
+	buyerBalance, err := s.getTokenBalanceTotalAt(buyerAddress, mint.Hash, onchainTransaction.Height)
+	if err != nil {
+		return err
+	}
+
+	if requirements.MaxHolderShareBps > 0 {
+		if (buyerBalance+quantity)*10000 > requirements.MaxHolderShareBps*mint.FractionCount {
+			return &TradeViolation{Code: TradeRejection_MAX_HOLDER_SHARE_EXCEEDED, Reason: fmt.Sprintf("buyer would hold %d of %d fractions, above %d bps", buyerBalance+quantity, mint.FractionCount, requirements.MaxHolderShareBps)}
+		}
+	}
+
+	if requirements.MaxHolders > 0 {
+		sellerBalance, err := s.getTokenBalanceTotalAt(sellerAddress, mint.Hash, onchainTransaction.Height)
+		if err != nil {
+			return err
+		}
+
+		holders, err := s.GetHolderCountAt(mint.Hash, onchainTransaction.Height)
+		if err != nil {
+			return err
+		}
+
+		if buyerBalance <= 0 {
+			holders++
+		}
+
+		if sellerBalance-quantity <= 0 {
+			holders--
+		}
+
+		if holders > requirements.MaxHolders {
+			return &TradeViolation{Code: TradeRejection_MAX_HOLDERS_EXCEEDED, Reason: fmt.Sprintf("trade would result in %d holders, above %d", holders, requirements.MaxHolders)}
+		}
+	}
+
+	return nil
+}
+
+func (s *TokenisationStore) SaveTradeRejection(rejection TradeRejection, tx *sql.Tx) (string, error) {
+	id := uuid.New().String()
+
+	query := `
+	INSERT INTO trade_rejections (id, invoice_hash, mint_hash, buyer_address, seller_address, quantity, reason_code, reason, action_type, transaction_hash, block_height, block_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	args := []interface{}{id, rejection.InvoiceHash, rejection.MintHash, rejection.BuyerAddress, rejection.SellerAddress, rejection.Quantity, rejection.ReasonCode, rejection.Reason, rejection.ActionType, rejection.TransactionHash, rejection.BlockHeight, rejection.BlockHash, time.Now()}
+
+	var err error
+	if tx != nil {
+		_, err = tx.Exec(query, args...)
+	} else {
+		_, err = s.DB.Exec(query, args...)
+	}
+
+	return id, err
+}
+
+// RejectTrade records the violation for the invoice and rejects the on chain transaction with its code.
+func (s *TokenisationStore) RejectTrade(onchainTransaction OnChainTransaction, invoiceHash string, mintHash string, sellerAddress string, buyerAddress string, quantity int, violation *TradeViolation) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = s.SaveTradeRejection(TradeRejection{
+		InvoiceHash:     invoiceHash,
+		MintHash:        mintHash,
+		BuyerAddress:    buyerAddress,
+		SellerAddress:   sellerAddress,
+		Quantity:        quantity,
+		ReasonCode:      violation.Code,
+		Reason:          violation.Reason,
+		ActionType:      onchainTransaction.ActionType,
+		TransactionHash: onchainTransaction.TxHash,
+		BlockHeight:     onchainTransaction.Height,
+		BlockHash:       onchainTransaction.BlockHash,
+	}, tx)
+	if err != nil {
+		log.Println("Error saving trade rejection:", err)
+		return err
+	}
+
+	err = s.RejectOnChainTransactionWithTx(tx, onchainTransaction, violation.Code, violation.Reason)
+	if err != nil {
+		log.Println("Error rejecting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *TokenisationStore) GetTradeRejections(mintHash string, invoiceHash string, offset int, limit int) ([]TradeRejection, error) {
+	rows, err := s.DB.Query(`
+	SELECT id, invoice_hash, mint_hash, buyer_address, seller_address, quantity, reason_code, reason, action_type, transaction_hash, block_height, block_hash, created_at
+	FROM trade_rejections
+	WHERE ($1 = '' OR mint_hash = $1) AND ($2 = '' OR invoice_hash = $2)
+	ORDER BY block_height DESC, created_at DESC
+	LIMIT $3 OFFSET $4
+	`, mintHash, invoiceHash, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rejections := []TradeRejection{}
+	for rows.Next() {
+		var rejection TradeRejection
+		if err := rows.Scan(&rejection.Id, &rejection.InvoiceHash, &rejection.MintHash, &rejection.BuyerAddress, &rejection.SellerAddress, &rejection.Quantity, &rejection.ReasonCode, &rejection.Reason, &rejection.ActionType, &rejection.TransactionHash, &rejection.BlockHeight, &rejection.BlockHash, &rejection.CreatedAt); err != nil {
+			return nil, err
+		}
+		rejections = append(rejections, rejection)
+	}
+
+	return rejections, rows.Err()
+}
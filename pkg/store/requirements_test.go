@@ -0,0 +1,198 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestParseRequirements(t *testing.T) {
+	address := support.GenerateDogecoinAddress(true)
+
+	requirements, err := store.ParseRequirements(store.StringInterfaceMap{})
+	assert.NilError(t, err)
+	assert.Equal(t, requirements.AllowlistEnabled, false)
+
+	requirements, err = store.ParseRequirements(store.StringInterfaceMap{
+		"allowlist_enabled":    true,
+		"allowlist":            []interface{}{address},
+		"max_holders":          float64(10),
+		"max_holder_share_bps": float64(2500),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, requirements.Allowlist, []string{address})
+	assert.Equal(t, requirements.MaxHolders, 10)
+	assert.Equal(t, requirements.MaxHolderShareBps, 2500)
+
+	_, err = store.ParseRequirements(store.StringInterfaceMap{"req": "value"})
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = store.ParseRequirements(store.StringInterfaceMap{"max_holder_share_bps": float64(10001)})
+	assert.ErrorContains(t, err, "max_holder_share_bps")
+
+	_, err = store.ParseRequirements(store.StringInterfaceMap{"allowlist_enabled": true, "allowlist": []interface{}{"nope"}})
+	assert.ErrorContains(t, err, "invalid allowlist entry")
+}
+
+func signAllowlistEntry(t *testing.T, body store.MintAllowlistEntryBody, privHex string, pubHex string) store.MintAllowlistEntry {
+	signature, err := doge.SignPayload(body, privHex, pubHex)
+	assert.NilError(t, err)
+
+	hash, err := body.GenerateHash()
+	assert.NilError(t, err)
+
+	return store.MintAllowlistEntry{MintAllowlistEntryBody: body, Hash: hash, PublicKey: pubHex, Signature: signature}
+}
+
+func confirmAllowlistEntry(t *testing.T, tokenStore *store.TokenisationStore, entry store.MintAllowlistEntry, blockHeight int64, transactionNumber int) {
+	assert.NilError(t, tokenStore.SaveUnconfirmedMintAllowlistEntry(&entry))
+
+	envelope := protocol.NewAllowlistEntryTransactionEnvelope(entry.Hash, entry.MintHash, protocol.ACTION_ALLOWLIST_ENTRY)
+	message, err := protocol.DecodeOnChainMessage(envelope.Action, envelope.Version, envelope.Data)
+	assert.NilError(t, err)
+
+	err = tokenStore.ConfirmMintAllowlistEntry(store.OnChainTransaction{
+		TxHash:            support.GenerateRandomHash(),
+		Height:            blockHeight,
+		BlockHash:         "blockHash",
+		ActionType:        protocol.ACTION_ALLOWLIST_ENTRY,
+		ActionData:        envelope.Data,
+		TransactionNumber: transactionNumber,
+	}, message.(*protocol.OnChainAllowlistEntryMessage))
+	assert.NilError(t, err)
+}
+
+func TestMintAllowlist(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	privHex, pubHex, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+	_, buyerPubHex, buyerAddress, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+	assert.NilError(t, err)
+
+	initialAddress := support.GenerateDogecoinAddress(true)
+	mint := store.Mint{MintWithoutID: store.MintWithoutID{
+		Hash:          support.GenerateRandomHash(),
+		FractionCount: 100,
+		Requirements: store.StringInterfaceMap{
+			"allowlist_enabled": true,
+			"allowlist":         []interface{}{initialAddress},
+		},
+		AssetManagers: []store.AssetManager{{Name: "Manager", PublicKey: pubHex}},
+	}}
+
+	seller := support.GenerateDogecoinAddress(true)
+	before := store.OnChainTransaction{Height: 5, TransactionNumber: 0}
+	after := store.OnChainTransaction{Height: 5, TransactionNumber: 2}
+
+	entry := signAllowlistEntry(t, store.MintAllowlistEntryBody{MintHash: mint.Hash, Entry: buyerPubHex, Allowed: true, Timestamp: 2}, privHex, pubHex)
+	assert.NilError(t, store.ValidateMintAllowlistEntry(mint, entry))
+
+	// A gossiped entry does not apply until it is anchored
+	assert.NilError(t, tokenStore.SaveUnconfirmedMintAllowlistEntry(&entry))
+	err = tokenStore.CheckTradeRequirements(mint, seller, buyerAddress, 1, after)
+	var violation *store.TradeViolation
+	assert.Assert(t, errors.As(err, &violation))
+	assert.Equal(t, violation.Code, store.TradeRejection_NOT_ALLOWLISTED)
+
+	confirmAllowlistEntry(t, tokenStore, entry, 5, 1)
+
+	pending, err := tokenStore.GetUnconfirmedMintAllowlistEntries(mint.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(pending), 0)
+
+	// It applies to trades after its anchor, and a public key entry matches the address derived from it
+	err = tokenStore.CheckTradeRequirements(mint, seller, buyerAddress, 1, before)
+	assert.Assert(t, errors.As(err, &violation))
+	assert.NilError(t, tokenStore.CheckTradeRequirements(mint, seller, buyerAddress, 1, after))
+
+	// Older updates anchored later do not override newer ones
+	stale := signAllowlistEntry(t, store.MintAllowlistEntryBody{MintHash: mint.Hash, Entry: buyerPubHex, Allowed: false, Timestamp: 1}, privHex, pubHex)
+	confirmAllowlistEntry(t, tokenStore, stale, 6, 0)
+
+	removeInitial := signAllowlistEntry(t, store.MintAllowlistEntryBody{MintHash: mint.Hash, Entry: initialAddress, Allowed: false, Timestamp: 3}, privHex, pubHex)
+	confirmAllowlistEntry(t, tokenStore, removeInitial, 7, 0)
+
+	allowlist, err := tokenStore.GetMintAllowlist(mint)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, allowlist, []string{buyerPubHex})
+
+	// Rolled back entries wait to be anchored again
+	assert.NilError(t, tokenStore.RollbackToBlockHeight(6))
+
+	allowlist, err = tokenStore.GetMintAllowlist(mint)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, allowlist, []string{initialAddress, buyerPubHex})
+
+	_, err = tokenStore.GetUnconfirmedMintAllowlistEntry(removeInitial.Hash)
+	assert.NilError(t, err)
+
+	confirmed, err := tokenStore.GetMintAllowlistEntries(mint.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, len(confirmed), 2)
+	assert.Equal(t, confirmed[0].BlockHeight, int64(5))
+
+	invalid := entry
+	invalid.Signature = entry.Signature[:len(entry.Signature)-2] + "00"
+	assert.Assert(t, store.ValidateMintAllowlistEntry(mint, invalid) != nil)
+
+	invalid = entry
+	invalid.Allowed = false
+	assert.ErrorContains(t, store.ValidateMintAllowlistEntry(mint, invalid), "hash does not match")
+
+	invalid = entry
+	invalid.PublicKey = buyerPubHex
+	assert.ErrorContains(t, store.ValidateMintAllowlistEntry(mint, invalid), "asset managers")
+}
+
+func TestCheckTradeRequirementsHolderCaps(t *testing.T) {
+	tokenStore := support.SetupTestDB()
+
+	seller := support.GenerateDogecoinAddress(true)
+	holder := support.GenerateDogecoinAddress(true)
+	buyer := support.GenerateDogecoinAddress(true)
+
+	mint := store.Mint{MintWithoutID: store.MintWithoutID{
+		Hash:          support.GenerateRandomHash(),
+		FractionCount: 100,
+		Requirements: store.StringInterfaceMap{
+			"max_holders":          float64(2),
+			"max_holder_share_bps": float64(5000),
+		},
+	}}
+
+	creditTokenBalance(t, tokenStore, seller, mint.Hash, 80, 1)
+	creditTokenBalance(t, tokenStore, holder, mint.Hash, 20, 1)
+	tx := store.OnChainTransaction{Height: 2}
+
+	holders, err := tokenStore.GetHolderCount(mint.Hash)
+	assert.NilError(t, err)
+	assert.Equal(t, holders, 2)
+
+	var violation *store.TradeViolation
+
+	// A third holder is not allowed
+	err = tokenStore.CheckTradeRequirements(mint, seller, buyer, 10, tx)
+	assert.Assert(t, errors.As(err, &violation))
+	assert.Equal(t, violation.Code, store.TradeRejection_MAX_HOLDERS_EXCEEDED)
+
+	// Unless an existing holder exits completely
+	err = tokenStore.CheckTradeRequirements(mint, holder, buyer, 20, tx)
+	assert.NilError(t, err)
+
+	err = tokenStore.CheckTradeRequirements(mint, seller, holder, 40, tx)
+	assert.Assert(t, errors.As(err, &violation))
+	assert.Equal(t, violation.Code, store.TradeRejection_MAX_HOLDER_SHARE_EXCEEDED)
+
+	assert.NilError(t, tokenStore.CheckTradeRequirements(mint, seller, holder, 30, tx))
+
+	// Balances of later blocks are not counted
+	creditTokenBalance(t, tokenStore, holder, mint.Hash, 30, 3)
+	creditTokenBalance(t, tokenStore, seller, mint.Hash, -30, 3)
+	assert.NilError(t, tokenStore.CheckTradeRequirements(mint, seller, holder, 30, tx))
+}
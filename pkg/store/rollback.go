@@ -0,0 +1,253 @@
+package store
+
+import (
+	"database/sql"
+	"log"
+)
+
+// RollbackToBlockHeight undoes all derived state attributed to blocks above blockHeight.
+func (s *TokenisationStore) RollbackToBlockHeight(blockHeight int64) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.rollbackToBlockHeightWithTx(blockHeight, tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RollbackToChainPosition undoes all derived state above blockHeight and rewinds
+// the chain position in the same transaction.
+func (s *TokenisationStore) RollbackToChainPosition(blockHeight int64, blockHash string, waitingForNextHash bool) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = s.rollbackToBlockHeightWithTx(blockHeight, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO chain_position (id, block_height, block_hash, waiting_for_next_hash)
+	VALUES (1, $1, $2, $3)
+	ON CONFLICT (id)
+	DO UPDATE SET block_height = EXCLUDED.block_height,
+				  block_hash = EXCLUDED.block_hash,
+				  waiting_for_next_hash = EXCLUDED.waiting_for_next_hash
+	`, blockHeight, blockHash, waitingForNextHash)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/*
+* Mints, mint amendments, asset manager rotations, allowlist entries, mint ownership transfers and invoices confirmed above the rollback point are moved back to their
+* unconfirmed tables so they can be matched again when the new branch is ingested.
+* Mints transferred above the rollback point are handed back to their previous owner.
+* Payments above the rollback point are undone, with their amounts taken off the invoices and their batch outcomes removed, and pending balances restored.
+* Reservations released by expiry or cancellation above the rollback point are restored.
+* Distribution payouts paid above the rollback point are marked unpaid again.
+* Balances, pending balances, burns, distributions, trade rejections, rejected and pending on chain transactions and their outcomes above the rollback point are removed.
+ */
+func (s *TokenisationStore) rollbackToBlockHeightWithTx(blockHeight int64, tx *sql.Tx) error {
+	log.Println("Rolling back derived state above block height:", blockHeight)
+
+	statements := []struct {
+		name  string
+		query string
+	}{
+		{
+			name: "restore unconfirmed mints",
+			query: `
+			INSERT INTO unconfirmed_mints (id, title, description, fraction_count, tags, transaction_hash, owner_address, metadata, hash, requirements, lockup_options, signature_requirement_type, asset_managers, min_signatures, feed_url, public_key, contract_of_sale, created_at, redeem_script, multisig_signatures)
+			SELECT id, title, description, fraction_count, tags, transaction_hash, owner_address, metadata, hash, requirements, lockup_options, signature_requirement_type, asset_managers, min_signatures, feed_url, public_key, contract_of_sale, created_at, redeem_script, multisig_signatures
+			FROM mints WHERE block_height > $1 AND hash NOT IN (SELECT hash FROM unconfirmed_mints WHERE hash IS NOT NULL)
+			`,
+		},
+		{
+			name: "restore unconfirmed mint amendments",
+			query: `
+			INSERT INTO unconfirmed_mint_amendments (hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at)
+			SELECT hash, mint_hash, description, metadata, feed_url, contract_of_sale, timestamp, public_key, signature, created_at
+			FROM mint_amendments WHERE block_height > $1
+			ON CONFLICT (hash) DO NOTHING
+			`,
+		},
+		{
+			name:  "remove mint amendments",
+			query: "DELETE FROM mint_amendments WHERE block_height > $1",
+		},
+		{
+			name: "restore unconfirmed asset manager rotations",
+			query: `
+			INSERT INTO unconfirmed_asset_manager_rotations (hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at)
+			SELECT hash, mint_hash, action, public_key, manager, timestamp, approvals, created_at
+			FROM asset_manager_rotations WHERE block_height > $1
+			ON CONFLICT (hash) DO NOTHING
+			`,
+		},
+		{
+			name:  "remove asset manager rotations",
+			query: "DELETE FROM asset_manager_rotations WHERE block_height > $1",
+		},
+		{
+			name: "restore unconfirmed mint allowlist entries",
+			query: `
+			INSERT INTO unconfirmed_mint_allowlist_entries (hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at)
+			SELECT hash, mint_hash, entry, allowed, timestamp, public_key, signature, created_at
+			FROM mint_allowlist_entries WHERE block_height > $1
+			ON CONFLICT (hash) DO NOTHING
+			`,
+		},
+		{
+			name:  "remove mint allowlist entries",
+			query: "DELETE FROM mint_allowlist_entries WHERE block_height > $1",
+		},
+		{
+			name: "restore mint owners",
+			query: `
+			UPDATE mints SET
+				owner_address = (
+					SELECT t.previous_owner_address FROM mint_ownership_transfers t
+					WHERE t.mint_hash = mints.hash AND t.block_height > $1
+					ORDER BY t.block_height, t.transaction_number LIMIT 1
+				),
+				public_key = (
+					SELECT t.owner_public_key FROM mint_ownership_transfers t
+					WHERE t.mint_hash = mints.hash AND t.block_height > $1
+					ORDER BY t.block_height, t.transaction_number LIMIT 1
+				)
+			WHERE hash IN (SELECT mint_hash FROM mint_ownership_transfers WHERE block_height > $1)
+			`,
+		},
+		{
+			name: "restore unconfirmed mint ownership transfers",
+			query: `
+			INSERT INTO unconfirmed_mint_ownership_transfers (hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at)
+			SELECT hash, mint_hash, new_owner_address, new_owner_public_key, timestamp, owner_public_key, owner_signature, new_owner_signature, created_at
+			FROM mint_ownership_transfers WHERE block_height > $1
+			ON CONFLICT (hash) DO NOTHING
+			`,
+		},
+		{
+			name:  "remove mint ownership transfers",
+			query: "DELETE FROM mint_ownership_transfers WHERE block_height > $1",
+		},
+		{
+			name:  "remove mints",
+			query: "DELETE FROM mints WHERE block_height > $1",
+		},
+		{
+			name: "restore released pending token balances",
+			query: `
+			INSERT INTO pending_token_balances (owner_address, invoice_hash, mint_hash, quantity, onchain_transaction_id, created_at, block_height, block_hash, expiry_height)
+			SELECT owner_address, invoice_hash, mint_hash, quantity, onchain_transaction_id, CURRENT_TIMESTAMP, reserved_block_height, reserved_block_hash, expiry_height
+			FROM invoice_releases WHERE block_height > $1 AND quantity > 0 AND reserved_block_height <= $1
+			ON CONFLICT (invoice_hash, mint_hash) DO NOTHING
+			`,
+		},
+		{
+			name:  "remove invoice releases",
+			query: "DELETE FROM invoice_releases WHERE block_height > $1",
+		},
+		{
+			name: "restore pending token balances for payments",
+			query: `
+			INSERT INTO pending_token_balances (owner_address, invoice_hash, mint_hash, quantity, onchain_transaction_id, created_at, block_height, block_hash, expiry_height)
+			SELECT seller_address, hash, mint_hash, quantity, COALESCE(transaction_hash, ''), CURRENT_TIMESTAMP, block_height, block_hash, expiry_height
+			FROM invoices WHERE paid_block_height > $1 AND block_height <= $1
+			ON CONFLICT (invoice_hash, mint_hash) DO NOTHING
+			`,
+		},
+		{
+			name: "undo invoice payment amounts",
+			query: `
+			UPDATE invoices SET paid_koinu = paid_koinu - (
+				SELECT COALESCE(SUM(p.amount_koinu), 0) FROM invoice_payments p
+				WHERE p.invoice_hash = invoices.hash AND p.block_height > $1
+			)
+			WHERE hash IN (SELECT invoice_hash FROM invoice_payments WHERE block_height > $1)
+			`,
+		},
+		{
+			name:  "remove invoice payments",
+			query: "DELETE FROM invoice_payments WHERE block_height > $1",
+		},
+		{
+			name:  "remove batch payment outcomes",
+			query: "DELETE FROM batch_payment_outcomes WHERE block_height > $1",
+		},
+		{
+			name:  "undo payments",
+			query: "UPDATE invoices SET paid_at = NULL, paid_block_height = NULL, paid_block_hash = NULL WHERE paid_block_height > $1",
+		},
+		{
+			name: "restore unconfirmed invoices",
+			query: `
+			INSERT INTO unconfirmed_invoices (id, hash, buyer_address, mint_hash, quantity, price_koinu, payment_address, seller_address, created_at, public_key, signature, status, redeem_script, multisig_signatures)
+			SELECT id, hash, buyer_address, mint_hash, quantity, price_koinu, COALESCE(payment_address, ''), seller_address, created_at, public_key, signature, 'draft', redeem_script, multisig_signatures
+			FROM invoices WHERE block_height > $1 AND hash NOT IN (SELECT hash FROM unconfirmed_invoices)
+			`,
+		},
+		{
+			name:  "remove invoices",
+			query: "DELETE FROM invoices WHERE block_height > $1",
+		},
+		{
+			name:  "remove pending token balances",
+			query: "DELETE FROM pending_token_balances WHERE block_height > $1",
+		},
+		{
+			name:  "remove token burns",
+			query: "DELETE FROM token_burns WHERE block_height > $1",
+		},
+		{
+			name:  "undo distribution payouts",
+			query: "UPDATE distribution_payouts SET paid_transaction_hash = NULL, paid_block_height = NULL, paid_block_hash = NULL WHERE paid_block_height > $1",
+		},
+		{
+			name:  "remove distribution payouts",
+			query: "DELETE FROM distribution_payouts WHERE distribution_hash IN (SELECT hash FROM distributions WHERE block_height > $1)",
+		},
+		{
+			name:  "remove distributions",
+			query: "DELETE FROM distributions WHERE block_height > $1",
+		},
+		{
+			name:  "remove trade rejections",
+			query: "DELETE FROM trade_rejections WHERE block_height > $1",
+		},
+		{
+			name:  "remove token balances",
+			query: "DELETE FROM token_balances WHERE block_height > $1",
+		},
+		{
+			name:  "remove onchain transaction outcomes",
+			query: "DELETE FROM onchain_transaction_outcomes WHERE block_height > $1",
+		},
+		{
+			name:  "remove onchain transactions",
+			query: "DELETE FROM onchain_transactions WHERE block_height > $1",
+		},
+	}
+
+	for _, statement := range statements {
+		_, err := tx.Exec(statement.query, blockHeight)
+		if err != nil {
+			log.Println("Error during rollback ("+statement.name+"):", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package store_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/doge"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func sumTokenBalances(t *testing.T, tokenStore *store.TokenisationStore, address string, mintHash string) int {
+	balances, err := tokenStore.GetTokenBalances(address, mintHash)
+	assert.NilError(t, err)
+
+	total := 0
+	for _, balance := range balances {
+		total += balance.Quantity
+	}
+
+	return total
+}
+
+// setupPaidInvoice confirms a mint at height 1, an invoice at height 2 and a payment at height 3.
+func setupPaidInvoice(t *testing.T, tokenStore *store.TokenisationStore, mintHash, invoiceHash, sellerAddress, buyerAddress string) {
+	_, err := tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:          mintHash,
+		Title:         "Test Mint",
+		Description:   "Test Description",
+		FractionCount: 100,
+	})
+	assert.NilError(t, err)
+
+	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
+	encodedMintMsg, _ := proto.Marshal(mintMsg)
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash1", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = tokenStore.MatchUnconfirmedMint(*findTransactionById(txs, mintTxId), mintMsg)
+	assert.NilError(t, err)
+
+	_, err = tokenStore.SaveUnconfirmedInvoice(&store.UnconfirmedInvoice{
+		Hash:           invoiceHash,
+		PaymentAddress: sellerAddress,
+		BuyerAddress:   buyerAddress,
+		MintHash:       mintHash,
+		Quantity:       40,
+		PriceKoinu:     10,
+		CreatedAt:      time.Now(),
+		SellerAddress:  sellerAddress,
+		Status:         "draft",
+	})
+	assert.NilError(t, err)
+
+	invoiceHashBytes, _ := hex.DecodeString(invoiceHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	invoiceMsg := &protocol.OnChainInvoiceMessage{InvoiceHash: invoiceHashBytes, MintHash: mintHashBytes, Quantity: 40}
+	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash2", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash, 40, invoiceTxId, sellerAddress, 2, "blockHash2", 0, nil)
+	assert.NilError(t, err)
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = tokenStore.MatchUnconfirmedInvoice(*findTransactionById(txs, invoiceTxId), invoiceMsg)
+	assert.NilError(t, err)
+
+	paymentMsg := &protocol.OnChainPaymentMessage{Hash: invoiceHash}
+	encodedPaymentMsg, _ := proto.Marshal(paymentMsg)
+	paymentTxId, err := tokenStore.SaveOnChainTransaction("paymentTx", 3, "blockHash3", 1, protocol.ACTION_PAYMENT, protocol.DEFAULT_VERSION, encodedPaymentMsg, buyerAddress, store.KoinuValues{
+		sellerAddress: 400,
+	})
+	assert.NilError(t, err)
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	paymentTx := findTransactionById(txs, paymentTxId)
+
+	invoice, err := tokenStore.MatchPayment(*paymentTx, paymentMsg)
+	assert.NilError(t, err)
+	err = tokenStore.ProcessPayment(*paymentTx, invoice)
+	assert.NilError(t, err)
+}
+
+func TestRollbackUndoesPayment(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	mintHash := test_support.GenerateRandomHash()
+	invoiceHash := test_support.GenerateRandomHash()
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+
+	setupPaidInvoice(t, tokenStore, mintHash, invoiceHash, sellerAddress, buyerAddress)
+
+	assert.Equal(t, sumTokenBalances(t, tokenStore, buyerAddress, mintHash), 40)
+	assert.Equal(t, sumTokenBalances(t, tokenStore, sellerAddress, mintHash), 60)
+
+	err := tokenStore.RollbackToBlockHeight(2)
+	assert.NilError(t, err)
+
+	invoice, err := tokenStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Assert(t, !invoice.PaidAt.Valid)
+	assert.Equal(t, invoice.PaidKoinu, int64(0))
+	assert.Equal(t, invoice.PaymentStatus, store.InvoicePaymentStatus_UNPAID)
+
+	pendingTokenBalance, err := tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, pendingTokenBalance.Quantity, 40)
+	assert.Equal(t, pendingTokenBalance.OwnerAddress, sellerAddress)
+
+	assert.Equal(t, sumTokenBalances(t, tokenStore, buyerAddress, mintHash), 0)
+	assert.Equal(t, sumTokenBalances(t, tokenStore, sellerAddress, mintHash), 100)
+}
+
+func TestRollbackRestoresUnconfirmedState(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	mintHash := test_support.GenerateRandomHash()
+	invoiceHash := test_support.GenerateRandomHash()
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+
+	setupPaidInvoice(t, tokenStore, mintHash, invoiceHash, sellerAddress, buyerAddress)
+
+	_, err := tokenStore.SaveOnChainTransaction("laterTx", 4, "blockHash4", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, []byte{}, sellerAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = tokenStore.RollbackToChainPosition(0, "genesisHash", false)
+	assert.NilError(t, err)
+
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.Id, "")
+
+	unconfirmedMints, err := tokenStore.GetUnconfirmedMints(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unconfirmedMints), 1)
+	assert.Equal(t, unconfirmedMints[0].Hash, mintHash)
+
+	_, err = tokenStore.GetInvoiceByHash(invoiceHash)
+	assert.ErrorContains(t, err, "no rows")
+
+	unconfirmedInvoice, err := tokenStore.GetUnconfirmedInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, unconfirmedInvoice.Quantity, 40)
+
+	_, err = tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.ErrorContains(t, err, "no pending token balance found")
+
+	assert.Equal(t, sumTokenBalances(t, tokenStore, sellerAddress, mintHash), 0)
+	assert.Equal(t, sumTokenBalances(t, tokenStore, buyerAddress, mintHash), 0)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 0)
+
+	blockHeight, blockHash, _, err := tokenStore.GetChainPosition()
+	assert.NilError(t, err)
+	assert.Equal(t, blockHeight, int64(0))
+	assert.Equal(t, blockHash, "genesisHash")
+}
+
+func TestRollbackRestoresExpiredReservation(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	mintHash := test_support.GenerateRandomHash()
+	invoiceHash := test_support.GenerateRandomHash()
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+
+	err := tokenStore.UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash, 40, "invoiceTxId", sellerAddress, 2, "blockHash2", 3, nil)
+	assert.NilError(t, err)
+
+	expired, err := tokenStore.ExpireInvoices(5)
+	assert.NilError(t, err)
+	assert.Equal(t, expired, 1)
+
+	_, err = tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.ErrorContains(t, err, "no pending token balance found")
+
+	release, err := tokenStore.GetInvoiceRelease(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, release.Reason, store.InvoiceRelease_EXPIRED)
+	assert.Equal(t, release.BlockHeight, int64(4))
+
+	// The reservation was released in block 4, which is rolled back
+	err = tokenStore.RollbackToBlockHeight(3)
+	assert.NilError(t, err)
+
+	pendingTokenBalance, err := tokenStore.GetPendingTokenBalance(invoiceHash, mintHash, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, pendingTokenBalance.Quantity, 40)
+	assert.Equal(t, pendingTokenBalance.OwnerAddress, sellerAddress)
+
+	_, err = tokenStore.GetInvoiceRelease(invoiceHash)
+	assert.ErrorContains(t, err, "no rows")
+}
+
+func TestRollbackKeepsMultisigSigners(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	var publicKeys []string
+	for i := 0; i < 2; i++ {
+		_, publicKey, _, err := doge.GenerateDogecoinKeypair(doge.PrefixRegtest)
+		assert.NilError(t, err)
+		publicKeys = append(publicKeys, publicKey)
+	}
+	redeemScript, err := doge.NewMultisigRedeemScript(2, publicKeys)
+	assert.NilError(t, err)
+	multisigAddress, err := doge.ScriptHashAddress(redeemScript, doge.PrefixRegtest)
+	assert.NilError(t, err)
+	signatures := store.MultisigSignatures{
+		{PublicKey: publicKeys[0], Signature: "signature0"},
+		{PublicKey: publicKeys[1], Signature: "signature1"},
+	}
+
+	mintHash := test_support.GenerateRandomHash()
+	invoiceHash := test_support.GenerateRandomHash()
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+
+	_, err = tokenStore.SaveUnconfirmedMint(&store.MintWithoutID{
+		Hash:               mintHash,
+		Title:              "Multisig Mint",
+		FractionCount:      100,
+		RedeemScript:       redeemScript,
+		MultisigSignatures: signatures,
+	})
+	assert.NilError(t, err)
+
+	mintMsg := &protocol.OnChainMintMessage{Hash: mintHash}
+	encodedMintMsg, _ := proto.Marshal(mintMsg)
+	mintTxId, err := tokenStore.SaveOnChainTransaction("mintTx", 1, "blockHash1", 1, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, encodedMintMsg, multisigAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	txs, err := tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = tokenStore.MatchUnconfirmedMint(*findTransactionById(txs, mintTxId), mintMsg)
+	assert.NilError(t, err)
+
+	_, err = tokenStore.SaveUnconfirmedInvoice(&store.UnconfirmedInvoice{
+		Hash:               invoiceHash,
+		PaymentAddress:     multisigAddress,
+		BuyerAddress:       buyerAddress,
+		MintHash:           mintHash,
+		Quantity:           40,
+		PriceKoinu:         10,
+		CreatedAt:          time.Now(),
+		SellerAddress:      multisigAddress,
+		Status:             "draft",
+		RedeemScript:       redeemScript,
+		MultisigSignatures: signatures,
+	})
+	assert.NilError(t, err)
+
+	invoiceHashBytes, _ := hex.DecodeString(invoiceHash)
+	mintHashBytes, _ := hex.DecodeString(mintHash)
+	invoiceMsg := &protocol.OnChainInvoiceMessage{InvoiceHash: invoiceHashBytes, MintHash: mintHashBytes, Quantity: 40}
+	encodedInvoiceMsg, _ := proto.Marshal(invoiceMsg)
+	invoiceTxId, err := tokenStore.SaveOnChainTransaction("invoiceTx", 2, "blockHash2", 1, protocol.ACTION_INVOICE, protocol.DEFAULT_VERSION, encodedInvoiceMsg, multisigAddress, store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertPendingTokenBalanceWithTx(invoiceHash, mintHash, 40, invoiceTxId, multisigAddress, 2, "blockHash2", 0, nil)
+	assert.NilError(t, err)
+
+	txs, err = tokenStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	err = tokenStore.MatchUnconfirmedInvoice(*findTransactionById(txs, invoiceTxId), invoiceMsg)
+	assert.NilError(t, err)
+
+	// The signer is kept when the mint and invoice are confirmed
+	mint, err := tokenStore.GetMintByHash(mintHash)
+	assert.NilError(t, err)
+	assert.Equal(t, mint.RedeemScript, redeemScript)
+	assert.DeepEqual(t, mint.MultisigSignatures, signatures)
+
+	invoice, err := tokenStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.RedeemScript, redeemScript)
+	assert.DeepEqual(t, invoice.MultisigSignatures, signatures)
+
+	// and when they are restored to unconfirmed by a reorg
+	err = tokenStore.RollbackToChainPosition(0, "genesisHash", false)
+	assert.NilError(t, err)
+
+	unconfirmedMints, err := tokenStore.GetUnconfirmedMints(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(unconfirmedMints), 1)
+	assert.Equal(t, unconfirmedMints[0].RedeemScript, redeemScript)
+	assert.DeepEqual(t, unconfirmedMints[0].MultisigSignatures, signatures)
+
+	unconfirmedInvoice, err := tokenStore.GetUnconfirmedInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, unconfirmedInvoice.RedeemScript, redeemScript)
+	assert.DeepEqual(t, unconfirmedInvoice.MultisigSignatures, signatures)
+}
@@ -13,9 +13,9 @@ func (s *TokenisationStore) GetSellOffers(offset int, limit int, mintHash string
 
 	if offererAddress != "" {
 		log.Println("Getting sell offers for mint:", mintHash, "and offerer address:", offererAddress, "with limit:", limit, "and offset:", offset, s)
-		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, hash, mint_hash, quantity, price, public_key FROM sell_offers WHERE mint_hash = $1 AND offerer_address = $2 LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, hash, mint_hash, quantity, price_koinu, public_key FROM sell_offers WHERE mint_hash = $1 AND offerer_address = $2 LIMIT $3 OFFSET $4", mintHash, offererAddress, limit, offset)
 	} else {
-		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, hash, mint_hash, quantity, price, public_key FROM sell_offers WHERE mint_hash = $1 LIMIT $2 OFFSET $3", mintHash, limit, offset)
+		rows, err = s.DB.Query("SELECT id, created_at, offerer_address, hash, mint_hash, quantity, price_koinu, public_key FROM sell_offers WHERE mint_hash = $1 LIMIT $2 OFFSET $3", mintHash, limit, offset)
 	}
 	if err != nil {
 		return nil, err
@@ -26,7 +26,7 @@ func (s *TokenisationStore) GetSellOffers(offset int, limit int, mintHash string
 
 	for rows.Next() {
 		var offer SellOffer
-		if err := rows.Scan(&offer.Id, &offer.CreatedAt, &offer.OffererAddress, &offer.Hash, &offer.MintHash, &offer.Quantity, &offer.Price, &offer.PublicKey); err != nil {
+		if err := rows.Scan(&offer.Id, &offer.CreatedAt, &offer.OffererAddress, &offer.Hash, &offer.MintHash, &offer.Quantity, &offer.PriceKoinu, &offer.PublicKey); err != nil {
 			return nil, err
 		}
 
@@ -51,9 +51,9 @@ func (s *TokenisationStore) SaveSellOffer(d *SellOfferWithoutID) (string, error)
 	id := uuid.New().String()
 
 	_, err := s.DB.Exec(`
-	INSERT INTO sell_offers (id, offerer_address, hash, mint_hash, quantity, price, created_at, public_key, signature)
+	INSERT INTO sell_offers (id, offerer_address, hash, mint_hash, quantity, price_koinu, created_at, public_key, signature)
 	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`, id, d.OffererAddress, d.Hash, d.MintHash, d.Quantity, d.Price, d.CreatedAt, d.PublicKey, d.Signature)
+	`, id, d.OffererAddress, d.Hash, d.MintHash, d.Quantity, d.PriceKoinu, d.CreatedAt, d.PublicKey, d.Signature)
 
 	return id, err
 }
@@ -0,0 +1,434 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SNAPSHOT_VERSION is the version of the snapshot format written by ExportSnapshot.
+const SNAPSHOT_VERSION = 1
+
+var ErrSnapshotVersion = errors.New("unsupported snapshot version")
+var ErrSnapshotHash = errors.New("snapshot hash does not match its contents")
+var ErrSnapshotSchema = errors.New("snapshot does not match the database schema")
+var ErrSnapshotNotEmpty = errors.New("database already has a chain position")
+
+/*
+* SnapshotTables are the tables written to a snapshot: the state derived from the chain, the on chain
+* transactions still waiting to be processed, and the off chain payloads received over gossip.
+* The chain position is kept in the snapshot header. Health, the mempool and the admin audit log
+* describe the node the snapshot was taken on, so they are left out.
+ */
+var SnapshotTables = []string{
+	"mints",
+	"unconfirmed_mints",
+	"mint_amendments",
+	"unconfirmed_mint_amendments",
+	"asset_manager_rotations",
+	"unconfirmed_asset_manager_rotations",
+	"mint_ownership_transfers",
+	"unconfirmed_mint_ownership_transfers",
+	"mint_allowlist_entries",
+	"unconfirmed_mint_allowlist_entries",
+	"invoices",
+	"unconfirmed_invoices",
+	"invoice_signatures",
+	"invoice_payments",
+	"invoice_releases",
+	"batch_payment_outcomes",
+	"token_balances",
+	"pending_token_balances",
+	"token_burns",
+	"sell_offers",
+	"buy_offers",
+	"distributions",
+	"distribution_payouts",
+	"trade_rejections",
+	"onchain_transactions",
+	"onchain_transaction_outcomes",
+}
+
+/*
+* Snapshot is the state of the engine at a block, used to bootstrap a new node without replaying the
+* chain and waiting for gossip. Hash commits to every other field, so a snapshot that was changed or
+* cut short is refused on import.
+ */
+type Snapshot struct {
+	Version     int             `json:"version"`
+	BlockHeight int64           `json:"block_height"`
+	BlockHash   string          `json:"block_hash"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Tables      []SnapshotTable `json:"tables"`
+	Hash        string          `json:"hash"`
+}
+
+/*
+* SnapshotTable holds the rows of a table, each value in the order of Columns.
+* Timestamps are written in RFC 3339 and binary values in hex.
+ */
+type SnapshotTable struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// ComputeHash returns the SHA-256 of the snapshot without its hash, in hex.
+func (s Snapshot) ComputeHash() (string, error) {
+	s.Hash = ""
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Verify checks the version and hash of the snapshot.
+func (s Snapshot) Verify() error {
+	if s.Version != SNAPSHOT_VERSION {
+		return fmt.Errorf("%w: %d", ErrSnapshotVersion, s.Version)
+	}
+
+	hash, err := s.ComputeHash()
+	if err != nil {
+		return err
+	}
+
+	if hash != s.Hash {
+		return fmt.Errorf("%w: expected %s, got %s", ErrSnapshotHash, s.Hash, hash)
+	}
+
+	return nil
+}
+
+// ReadSnapshot decodes a snapshot, keeping numbers exact so its hash can be checked.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var snapshot Snapshot
+	err := decoder.Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+/*
+* ExportSnapshot reads the state at the current chain position. Everything is read in one transaction,
+* so a follower or processor running at the same time does not leave the snapshot half way through a block.
+ */
+func (s *TokenisationStore) ExportSnapshot() (*Snapshot, error) {
+	tx, err := s.DB.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	snapshot := &Snapshot{Version: SNAPSHOT_VERSION, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+
+	err = tx.QueryRow("SELECT block_height, block_hash FROM chain_position").Scan(&snapshot.BlockHeight, &snapshot.BlockHash)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("no chain position to take a snapshot at")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range SnapshotTables {
+		table, err := exportSnapshotTableWithTx(tx, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		snapshot.Tables = append(snapshot.Tables, table)
+	}
+
+	snapshot.Hash, err = snapshot.ComputeHash()
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func exportSnapshotTableWithTx(tx *sql.Tx, name string) (SnapshotTable, error) {
+	columns, kinds, err := snapshotColumnsWithTx(tx, name)
+	if err != nil {
+		return SnapshotTable{}, err
+	}
+
+	// Every column is in the order, so the rows and the hash are the same on each export
+	order := []string{}
+	for idx := range columns {
+		order = append(order, fmt.Sprint(idx+1))
+	}
+
+	rows, err := tx.Query(fmt.Sprintf("SELECT %s FROM %s ORDER BY %s", quoteColumns(columns), name, strings.Join(order, ", ")))
+	if err != nil {
+		return SnapshotTable{}, err
+	}
+	defer rows.Close()
+
+	table := SnapshotTable{Name: name, Columns: columns, Rows: [][]any{}}
+	for rows.Next() {
+		targets := make([]any, len(kinds))
+		for idx, kind := range kinds {
+			targets[idx] = kind.scanTarget()
+		}
+
+		if err := rows.Scan(targets...); err != nil {
+			return SnapshotTable{}, err
+		}
+
+		row := make([]any, len(kinds))
+		for idx, kind := range kinds {
+			row[idx] = kind.encode(targets[idx])
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table, rows.Err()
+}
+
+/*
+* ImportSnapshot loads a verified snapshot into a database without a chain position, replacing anything
+* received over gossip while it was empty, and sets the chain position to the block of the snapshot.
+* The caller checks the block against the node first.
+ */
+func (s *TokenisationStore) ImportSnapshot(snapshot *Snapshot) error {
+	err := snapshot.Verify()
+	if err != nil {
+		return err
+	}
+
+	_, blockHash, _, err := s.GetChainPosition()
+	if err != nil {
+		return err
+	}
+	if blockHash != "" {
+		return ErrSnapshotNotEmpty
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(snapshot.Tables) != len(SnapshotTables) {
+		return fmt.Errorf("%w: expected %d tables, got %d", ErrSnapshotSchema, len(SnapshotTables), len(snapshot.Tables))
+	}
+
+	for idx, table := range snapshot.Tables {
+		if table.Name != SnapshotTables[idx] {
+			return fmt.Errorf("%w: expected table %s, got %s", ErrSnapshotSchema, SnapshotTables[idx], table.Name)
+		}
+
+		err = importSnapshotTableWithTx(tx, table)
+		if err != nil {
+			return fmt.Errorf("%s: %w", table.Name, err)
+		}
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO chain_position (id, block_height, block_hash, waiting_for_next_hash)
+	VALUES (1, $1, $2, $3)
+	ON CONFLICT (id)
+	DO UPDATE SET block_height = EXCLUDED.block_height,
+				  block_hash = EXCLUDED.block_hash,
+				  waiting_for_next_hash = EXCLUDED.waiting_for_next_hash
+	`, snapshot.BlockHeight, snapshot.BlockHash, false)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func importSnapshotTableWithTx(tx *sql.Tx, table SnapshotTable) error {
+	columns, kinds, err := snapshotColumnsWithTx(tx, table.Name)
+	if err != nil {
+		return err
+	}
+
+	if strings.Join(columns, ",") != strings.Join(table.Columns, ",") {
+		return fmt.Errorf("%w: expected columns %v, got %v", ErrSnapshotSchema, columns, table.Columns)
+	}
+
+	_, err = tx.Exec("DELETE FROM " + table.Name)
+	if err != nil {
+		return err
+	}
+
+	placeholders := []string{}
+	for idx := range columns {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", idx+1))
+	}
+	statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.Name, quoteColumns(columns), strings.Join(placeholders, ", "))
+
+	for _, row := range table.Rows {
+		if len(row) != len(kinds) {
+			return fmt.Errorf("%w: expected %d values, got %d", ErrSnapshotSchema, len(kinds), len(row))
+		}
+
+		values := make([]any, len(kinds))
+		for idx, kind := range kinds {
+			values[idx], err = kind.decode(row[idx])
+			if err != nil {
+				return fmt.Errorf("column %s: %w", columns[idx], err)
+			}
+		}
+
+		_, err = tx.Exec(statement, values...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshotColumnsWithTx returns the columns of the table, in the order the database declares them, with how each is written.
+func snapshotColumnsWithTx(tx *sql.Tx, name string) ([]string, []snapshotKind, error) {
+	rows, err := tx.Query(fmt.Sprintf("SELECT * FROM %s LIMIT 0", name))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := []string{}
+	kinds := []snapshotKind{}
+	for _, columnType := range columnTypes {
+		columns = append(columns, columnType.Name())
+		kinds = append(kinds, snapshotKindOf(columnType.DatabaseTypeName()))
+	}
+
+	return columns, kinds, nil
+}
+
+func quoteColumns(columns []string) string {
+	quoted := []string{}
+	for _, column := range columns {
+		quoted = append(quoted, `"`+column+`"`)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+type snapshotKind int
+
+const (
+	snapshotKind_TEXT snapshotKind = iota
+	snapshotKind_INTEGER
+	snapshotKind_BOOLEAN
+	snapshotKind_TIMESTAMP
+	snapshotKind_BYTES
+)
+
+// snapshotKindOf maps the type sqlite or postgres report for a column to how it is written, text by default (JSONB and UUID included).
+func snapshotKindOf(databaseType string) snapshotKind {
+	databaseType = strings.ToUpper(databaseType)
+
+	switch {
+	case strings.Contains(databaseType, "TIMESTAMP"):
+		return snapshotKind_TIMESTAMP
+	case databaseType == "BYTEA" || databaseType == "BLOB":
+		return snapshotKind_BYTES
+	case strings.HasPrefix(databaseType, "BOOL"):
+		return snapshotKind_BOOLEAN
+	case strings.Contains(databaseType, "INT") || strings.Contains(databaseType, "SERIAL"):
+		return snapshotKind_INTEGER
+	}
+
+	return snapshotKind_TEXT
+}
+
+func (k snapshotKind) scanTarget() any {
+	switch k {
+	case snapshotKind_INTEGER:
+		return &sql.NullInt64{}
+	case snapshotKind_BOOLEAN:
+		return &sql.NullBool{}
+	case snapshotKind_TIMESTAMP:
+		return &sql.NullTime{}
+	case snapshotKind_BYTES:
+		return &[]byte{}
+	}
+	return &sql.NullString{}
+}
+
+// encode returns the scanned value as written to the snapshot, nil for NULL.
+func (k snapshotKind) encode(target any) any {
+	switch value := target.(type) {
+	case *sql.NullInt64:
+		if value.Valid {
+			return value.Int64
+		}
+	case *sql.NullBool:
+		if value.Valid {
+			return value.Bool
+		}
+	case *sql.NullTime:
+		if value.Valid {
+			return value.Time.UTC().Format(time.RFC3339Nano)
+		}
+	case *[]byte:
+		if *value != nil {
+			return hex.EncodeToString(*value)
+		}
+	case *sql.NullString:
+		if value.Valid {
+			return value.String
+		}
+	}
+	return nil
+}
+
+// decode returns the value read from a snapshot as it is inserted into the database.
+func (k snapshotKind) decode(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch k {
+	case snapshotKind_INTEGER:
+		switch number := value.(type) {
+		case json.Number:
+			return number.Int64()
+		case int64:
+			return number, nil
+		}
+	case snapshotKind_BOOLEAN:
+		if boolean, ok := value.(bool); ok {
+			return boolean, nil
+		}
+	case snapshotKind_TIMESTAMP:
+		if text, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, text)
+		}
+	case snapshotKind_BYTES:
+		if text, ok := value.(string); ok {
+			return hex.DecodeString(text)
+		}
+	case snapshotKind_TEXT:
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected value %v", value)
+}
@@ -0,0 +1,93 @@
+package store_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	test_support "dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	mintHash := test_support.GenerateRandomHash()
+	invoiceHash := test_support.GenerateRandomHash()
+	sellerAddress := test_support.GenerateDogecoinAddress(true)
+	buyerAddress := test_support.GenerateDogecoinAddress(true)
+
+	setupPaidInvoice(t, tokenStore, mintHash, invoiceHash, sellerAddress, buyerAddress)
+
+	// A transaction still waiting to be processed is carried over
+	_, err := tokenStore.SaveOnChainTransaction("pendingTx", 3, "blockHash3", 2, protocol.ACTION_MINT, protocol.DEFAULT_VERSION, []byte{1, 2}, sellerAddress, store.KoinuValues{sellerAddress: 100})
+	assert.NilError(t, err)
+
+	err = tokenStore.UpsertChainPosition(3, "blockHash3", false)
+	assert.NilError(t, err)
+
+	snapshot, err := tokenStore.ExportSnapshot()
+	assert.NilError(t, err)
+	assert.Equal(t, snapshot.BlockHeight, int64(3))
+	assert.Equal(t, snapshot.BlockHash, "blockHash3")
+
+	var buffer bytes.Buffer
+	err = json.NewEncoder(&buffer).Encode(snapshot)
+	assert.NilError(t, err)
+
+	read, err := store.ReadSnapshot(&buffer)
+	assert.NilError(t, err)
+
+	newStore := test_support.SetupTestDB()
+	err = newStore.ImportSnapshot(read)
+	assert.NilError(t, err)
+
+	blockHeight, blockHash, _, err := newStore.GetChainPosition()
+	assert.NilError(t, err)
+	assert.Equal(t, blockHeight, int64(3))
+	assert.Equal(t, blockHash, "blockHash3")
+
+	invoice, err := newStore.GetInvoiceByHash(invoiceHash)
+	assert.NilError(t, err)
+	assert.Equal(t, invoice.PaymentStatus, store.InvoicePaymentStatus_PAID)
+
+	assert.Equal(t, sumTokenBalances(t, newStore, buyerAddress, mintHash), 40)
+	assert.Equal(t, sumTokenBalances(t, newStore, sellerAddress, mintHash), 60)
+
+	txs, err := newStore.GetOnChainTransactions(0, 10)
+	assert.NilError(t, err)
+	assert.Equal(t, len(txs), 1)
+	assert.DeepEqual(t, txs[0].ActionData, []byte{1, 2})
+	assert.Equal(t, txs[0].Values[sellerAddress], int64(100))
+
+	// The state is the same, so is the snapshot of it
+	reexported, err := newStore.ExportSnapshot()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reexported.Tables, snapshot.Tables)
+
+	err = newStore.ImportSnapshot(read)
+	assert.Assert(t, errors.Is(err, store.ErrSnapshotNotEmpty))
+}
+
+func TestSnapshotRefusesChangedContents(t *testing.T) {
+	tokenStore := test_support.SetupTestDB()
+
+	err := tokenStore.UpsertChainPosition(3, "blockHash3", false)
+	assert.NilError(t, err)
+
+	snapshot, err := tokenStore.ExportSnapshot()
+	assert.NilError(t, err)
+	assert.NilError(t, snapshot.Verify())
+
+	snapshot.BlockHeight = 4
+	err = test_support.SetupTestDB().ImportSnapshot(snapshot)
+	assert.Assert(t, errors.Is(err, store.ErrSnapshotHash))
+
+	snapshot.BlockHeight = 3
+	snapshot.Version = store.SNAPSHOT_VERSION + 1
+	err = test_support.SetupTestDB().ImportSnapshot(snapshot)
+	assert.Assert(t, errors.Is(err, store.ErrSnapshotVersion))
+}
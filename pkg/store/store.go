@@ -2,6 +2,7 @@ package store
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -63,11 +64,15 @@ func (s *TokenisationStore) Migrate() error {
 	}
 
 	err = m.Up()
-	if err != nil {
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return err
 	}
 
-	return nil
+	if convertErr := s.convertLegacyOnChainValues(); convertErr != nil {
+		return convertErr
+	}
+
+	return err
 }
 
 func (s *TokenisationStore) getMigrationDriver() (database.Driver, error) {
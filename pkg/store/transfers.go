@@ -0,0 +1,73 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+)
+
+var ErrInsufficientTokenBalance = errors.New("insufficient available token balance")
+
+// GetAvailableTokenBalance returns the balance of an address that is not reserved by pending invoices.
+func (s *TokenisationStore) GetAvailableTokenBalance(address string, mintHash string, tx *sql.Tx) (int, error) {
+	query := `
+	SELECT
+		(SELECT COALESCE(SUM(quantity), 0) FROM token_balances WHERE address = $1 AND mint_hash = $2) -
+		(SELECT COALESCE(SUM(quantity), 0) FROM pending_token_balances WHERE owner_address = $1 AND mint_hash = $2)
+	`
+
+	var available int
+	var err error
+	if tx != nil {
+		err = tx.QueryRow(query, address, mintHash).Scan(&available)
+	} else {
+		err = s.DB.QueryRow(query, address, mintHash).Scan(&available)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return available, nil
+}
+
+// ProcessTransfer moves unlocked tokens from the sender of the on chain transaction to toAddress.
+func (s *TokenisationStore) ProcessTransfer(onchainTransaction OnChainTransaction, mintHash string, toAddress string, quantity int) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	available, err := s.GetTransferableTokenBalance(onchainTransaction.Address, mintHash, onchainTransaction.Height, onchainTransaction.EffectiveBlockTime(), tx)
+	if err != nil {
+		log.Println("Error getting transferable token balance:", err)
+		return err
+	}
+
+	if available < quantity {
+		return fmt.Errorf("%w: %d < %d", ErrInsufficientTokenBalance, available, quantity)
+	}
+
+	err = s.UpsertTokenBalanceWithTransaction(onchainTransaction.Address, mintHash, -quantity, onchainTransaction.Height, onchainTransaction.BlockHash, tx)
+	if err != nil {
+		log.Println("Error debiting token balance:", err)
+		return err
+	}
+
+	err = s.UpsertTokenBalanceWithTransaction(toAddress, mintHash, quantity, onchainTransaction.Height, onchainTransaction.BlockHash, tx)
+	if err != nil {
+		log.Println("Error crediting token balance:", err)
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM onchain_transactions WHERE id = $1", onchainTransaction.Id)
+	if err != nil {
+		log.Println("Error deleting onchain transaction:", err)
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,79 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"dogecoin.org/fractal-engine/internal/test/support"
+	"dogecoin.org/fractal-engine/pkg/protocol"
+	"dogecoin.org/fractal-engine/pkg/store"
+	"gotest.tools/assert"
+)
+
+func TestGetAvailableTokenBalance(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	err = db.UpsertPendingTokenBalance("invoice1", "mintHash1", 30, "onchainTx1", "owner1")
+	assert.NilError(t, err)
+
+	available, err := db.GetAvailableTokenBalance("owner1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 70)
+
+	available, err = db.GetAvailableTokenBalance("owner2", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 0)
+}
+
+func TestProcessTransfer(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	id, err := db.SaveOnChainTransaction("transferTx", 10, "blockHash10", 0, protocol.ACTION_TRANSFER, protocol.DEFAULT_VERSION, []byte{}, "owner1", store.KoinuValues{})
+	assert.NilError(t, err)
+
+	err = db.ProcessTransfer(store.OnChainTransaction{Id: id, Height: 10, BlockHash: "blockHash10", Address: "owner1"}, "mintHash1", "recipient1", 40)
+	assert.NilError(t, err)
+
+	available, err := db.GetAvailableTokenBalance("owner1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 60)
+
+	available, err = db.GetAvailableTokenBalance("recipient1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 40)
+
+	count, err := db.CountOnChainTransactions(10)
+	assert.NilError(t, err)
+	assert.Equal(t, count, 0)
+
+	// The transfer is attributed to its block and is undone by a rollback
+	err = db.RollbackToBlockHeight(9)
+	assert.NilError(t, err)
+
+	available, err = db.GetAvailableTokenBalance("recipient1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 0)
+}
+
+func TestProcessTransferRespectsPendingBalances(t *testing.T) {
+	db := support.SetupTestDB()
+
+	err := db.UpsertTokenBalance("owner1", "mintHash1", 100)
+	assert.NilError(t, err)
+
+	err = db.UpsertPendingTokenBalance("invoice1", "mintHash1", 80, "onchainTx1", "owner1")
+	assert.NilError(t, err)
+
+	err = db.ProcessTransfer(store.OnChainTransaction{Id: "transferTx", Height: 10, Address: "owner1"}, "mintHash1", "recipient1", 40)
+	assert.Assert(t, errors.Is(err, store.ErrInsufficientTokenBalance))
+
+	available, err := db.GetAvailableTokenBalance("owner1", "mintHash1", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, available, 20)
+}
@@ -61,6 +61,36 @@ func (m *StringInterfaceMap) Scan(src interface{}) error {
 	return json.Unmarshal(source, m)
 }
 
+// KoinuValues is the total paid to each address by the outputs of a transaction, in koinu.
+type KoinuValues map[string]int64
+
+func (m KoinuValues) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *KoinuValues) Scan(src interface{}) error {
+	var source []byte
+	switch src := src.(type) {
+	case string:
+		source = []byte(src)
+	case []byte:
+		source = src
+	case nil:
+		*m = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type: %T", src)
+	}
+	return json.Unmarshal(source, m)
+}
+
 type AssetManager struct {
 	Name      string `json:"name"`
 	PublicKey string `json:"public_key"`
@@ -90,6 +120,17 @@ func (a AssetManagers) Value() (driver.Value, error) {
 	return string(b), nil // or return b ([]byte) — both work
 }
 
+// Contains reports whether publicKey belongs to one of the asset managers.
+func (a AssetManagers) Contains(publicKey string) bool {
+	for _, assetManager := range a {
+		if assetManager.PublicKey == publicKey {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Scan implements sql.Scanner — converts DB value to the slice.
 func (a *AssetManagers) Scan(src interface{}) error {
 	if a == nil {
@@ -127,6 +168,38 @@ const (
 	SignatureRequirementType_NONE           SignatureRequirementType = "NONE"
 )
 
+// MultisigSignatures are the signatures of the redeem script keys of a P2SH multisig signer.
+type MultisigSignatures []doge.MultisigSignature
+
+func (m MultisigSignatures) Value() (driver.Value, error) {
+	if m == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *MultisigSignatures) Scan(src interface{}) error {
+	var source []byte
+	switch src := src.(type) {
+	case string:
+		source = []byte(src)
+	case []byte:
+		source = src
+	case nil:
+		*m = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type: %T", src)
+	}
+	return json.Unmarshal(source, m)
+}
+
+const BurnRequiresSignaturesRequirement = "burn_requires_signatures"
+
 type MintWithoutID struct {
 	Hash                     string                   `json:"hash"`
 	Title                    string                   `json:"title"`
@@ -136,6 +209,7 @@ type MintWithoutID struct {
 	Metadata                 StringInterfaceMap       `json:"metadata"`
 	TransactionHash          string                   `json:"transaction_hash"`
 	BlockHeight              int64                    `json:"block_height"`
+	BlockHash                string                   `json:"block_hash"`
 	CreatedAt                time.Time                `json:"created_at"`
 	Requirements             StringInterfaceMap       `json:"requirements"`
 	LockupOptions            StringInterfaceMap       `json:"lockup_options"`
@@ -147,6 +221,14 @@ type MintWithoutID struct {
 	SignatureRequirementType SignatureRequirementType `json:"signature_requirement_type"`
 	AssetManagers            AssetManagers            `json:"asset_managers"`
 	MinSignatures            int                      `json:"min_signatures"`
+	// Set instead of the public key and signature when the owner is a P2SH multisig address
+	RedeemScript       string             `json:"redeem_script,omitempty"`
+	MultisigSignatures MultisigSignatures `json:"multisig_signatures,omitempty"`
+}
+
+// Signer returns the key, or multisig redeem script, that signed the mint.
+func (m *MintWithoutID) Signer() doge.Signer {
+	return doge.Signer{PublicKey: m.PublicKey, Signature: m.Signature, RedeemScript: m.RedeemScript, Signatures: m.MultisigSignatures}
 }
 
 type MintHash struct {
@@ -163,19 +245,31 @@ type MintHash struct {
 	SignatureRequirementType SignatureRequirementType `json:"signature_requirement_type"`
 	AssetManagers            AssetManagers            `json:"asset_managers"`
 	MinSignatures            int                      `json:"min_signatures"`
+	RedeemScript             string                   `json:"redeem_script,omitempty"`
 }
 
 type OnChainTransaction struct {
-	Id                string             `json:"id"`
-	TxHash            string             `json:"tx_hash"`
-	Height            int64              `json:"height"`
-	BlockHash         string             `json:"block_hash"`
-	ActionType        uint8              `json:"action_type"`
-	ActionVersion     uint8              `json:"action_version"`
-	ActionData        []byte             `json:"action_data"`
-	Address           string             `json:"address"`
-	Values            StringInterfaceMap `json:"values"`
-	TransactionNumber int                `json:"transaction_number"`
+	Id                string      `json:"id"`
+	TxHash            string      `json:"tx_hash"`
+	Height            int64       `json:"height"`
+	BlockHash         string      `json:"block_hash"`
+	BlockTime         int64       `json:"block_time"`
+	ActionType        uint8       `json:"action_type"`
+	ActionVersion     uint8       `json:"action_version"`
+	ActionData        []byte      `json:"action_data"`
+	Address           string      `json:"address"`
+	Values            KoinuValues `json:"values"`
+	TransactionNumber int         `json:"transaction_number"`
+}
+
+// EffectiveBlockTime returns the block time, falling back to the local clock for
+// transactions recorded before block times were stored.
+func (t OnChainTransaction) EffectiveBlockTime() int64 {
+	if t.BlockTime == 0 {
+		return time.Now().Unix()
+	}
+
+	return t.BlockTime
 }
 
 func (m *MintWithoutID) GenerateHash() (string, error) {
@@ -192,6 +286,7 @@ func (m *MintWithoutID) GenerateHash() (string, error) {
 		SignatureRequirementType: m.SignatureRequirementType,
 		AssetManagers:            m.AssetManagers,
 		MinSignatures:            m.MinSignatures,
+		RedeemScript:             m.RedeemScript,
 	}
 
 	// Serialize to JSON with sorted keys
@@ -220,19 +315,84 @@ func (m *Mint) SignatureRequired() bool {
 	return true
 }
 
+// HasRequiredSignatures counts the distinct asset managers of the mint that signed.
+// Signatures from keys that are not (or no longer) asset managers of the mint do not count.
 func (m *Mint) HasRequiredSignatures(signatures []InvoiceSignature) bool {
+	publicKeys := make([]string, 0, len(signatures))
+	for _, signature := range signatures {
+		publicKeys = append(publicKeys, signature.PublicKey)
+	}
+
+	return m.hasRequiredSigners(publicKeys)
+}
+
+func (m *Mint) hasRequiredSigners(publicKeys []string) bool {
+	signers := map[string]bool{}
+	for _, publicKey := range publicKeys {
+		if m.AssetManagers.Contains(publicKey) {
+			signers[publicKey] = true
+		}
+	}
+
 	switch m.SignatureRequirementType {
 	case SignatureRequirementType_ALL_SIGNATURES:
-		return len(signatures) == len(m.AssetManagers)
+		return len(signers) > 0 && len(signers) == len(m.AssetManagers)
 	case SignatureRequirementType_ONE_SIGNATURE:
-		return len(signatures) == 1
+		return len(signers) >= 1
 	case SignatureRequirementType_MIN_SIGNATURES:
-		return len(signatures) >= m.MinSignatures
+		return len(signers) >= m.MinSignatures
 	}
 
 	return false
 }
 
+// BurnSignatureRequired reports whether the asset managers must co-sign burns.
+// It is opted into with the "burn_requires_signatures" requirement.
+func (m *Mint) BurnSignatureRequired() bool {
+	if !m.SignatureRequired() {
+		return false
+	}
+
+	return m.TypedRequirements().BurnRequiresSignatures
+}
+
+type TokenBurn struct {
+	Id              string    `json:"id"`
+	MintHash        string    `json:"mint_hash"`
+	Address         string    `json:"address"`
+	Quantity        int       `json:"quantity"`
+	BurnHash        string    `json:"burn_hash"`
+	TransactionHash string    `json:"transaction_hash"`
+	BlockHeight     int64     `json:"block_height"`
+	BlockHash       string    `json:"block_hash"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type TokenBurnHash struct {
+	MintHash  string    `json:"mint_hash"`
+	Address   string    `json:"address"`
+	Quantity  int       `json:"quantity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (b *TokenBurn) GenerateHash() (string, error) {
+	input := TokenBurnHash{
+		MintHash:  b.MintHash,
+		Address:   b.Address,
+		Quantity:  b.Quantity,
+		CreatedAt: b.CreatedAt,
+	}
+
+	jsonBytes, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(jsonBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
 type OnChainMint struct {
 	MintId          string `json:"mint_id"`
 	TransactionHash string `json:"transaction_hash"`
@@ -245,7 +405,7 @@ type BuyOfferWithoutID struct {
 	OffererAddress string    `json:"offerer_address"`
 	SellerAddress  string    `json:"seller_address"`
 	Quantity       int       `json:"quantity"`
-	Price          int       `json:"price"`
+	PriceKoinu     int64     `json:"price_koinu"`
 	CreatedAt      time.Time `json:"created_at"`
 	PublicKey      string    `json:"public_key"`
 	Signature      string    `json:"signature"`
@@ -256,7 +416,7 @@ type SellOfferWithoutID struct {
 	MintHash       string    `json:"mint_hash"`
 	OffererAddress string    `json:"offerer_address"`
 	Quantity       int       `json:"quantity"`
-	Price          int       `json:"price"`
+	PriceKoinu     int64     `json:"price_koinu"`
 	CreatedAt      time.Time `json:"created_at"`
 	PublicKey      string    `json:"public_key"`
 	Signature      string    `json:"signature"`
@@ -267,7 +427,7 @@ type BuyOfferHash struct {
 	OffererAddress string `json:"offerer_address"`
 	SellerAddress  string `json:"seller_address"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	PublicKey      string `json:"public_key"`
 }
 
@@ -277,7 +437,7 @@ func (o *BuyOfferWithoutID) GenerateHash() (string, error) {
 		OffererAddress: o.OffererAddress,
 		SellerAddress:  o.SellerAddress,
 		Quantity:       o.Quantity,
-		Price:          o.Price,
+		PriceKoinu:     o.PriceKoinu,
 		PublicKey:      o.PublicKey,
 	}
 
@@ -295,7 +455,7 @@ type SellOfferHash struct {
 	MintHash       string `json:"mint_hash"`
 	OffererAddress string `json:"offerer_address"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	PublicKey      string `json:"public_key"`
 	Signature      string `json:"signature"`
 }
@@ -305,7 +465,7 @@ func (o *SellOfferWithoutID) GenerateHash() (string, error) {
 		MintHash:       o.MintHash,
 		OffererAddress: o.OffererAddress,
 		Quantity:       o.Quantity,
-		Price:          o.Price,
+		PriceKoinu:     o.PriceKoinu,
 		PublicKey:      o.PublicKey,
 		Signature:      o.Signature,
 	}
@@ -336,23 +496,33 @@ type UnconfirmedInvoice struct {
 	BuyerAddress   string    `json:"buyer_address"`
 	MintHash       string    `json:"mint_hash"`
 	Quantity       int       `json:"quantity"`
-	Price          int       `json:"price"`
+	PriceKoinu     int64     `json:"price_koinu"`
 	CreatedAt      time.Time `json:"created_at"`
 	PaymentAddress string    `json:"payment_address"`
 	SellerAddress  string    `json:"seller_address"`
 	PublicKey      string    `json:"public_key"`
 	Signature      string    `json:"signature"`
 	Status         string    `json:"status"`
+	// Set instead of the public key and signature when the seller is a P2SH multisig address
+	RedeemScript       string             `json:"redeem_script,omitempty"`
+	MultisigSignatures MultisigSignatures `json:"multisig_signatures,omitempty"`
+}
+
+// Signer returns the key, or multisig redeem script, that signed the invoice.
+func (u *UnconfirmedInvoice) Signer() doge.Signer {
+	return doge.Signer{PublicKey: u.PublicKey, Signature: u.Signature, RedeemScript: u.RedeemScript, Signatures: u.MultisigSignatures}
 }
 
 func (u *UnconfirmedInvoice) GenerateHash() (string, error) {
 	input := UnconfirmedInvoiceHash{
-		MintHash:      u.MintHash,
-		Quantity:      u.Quantity,
-		Price:         u.Price,
-		BuyerAddress:  u.BuyerAddress,
-		SellerAddress: u.SellerAddress,
-		PublicKey:     u.PublicKey,
+		MintHash:       u.MintHash,
+		Quantity:       u.Quantity,
+		PriceKoinu:     u.PriceKoinu,
+		BuyerAddress:   u.BuyerAddress,
+		PaymentAddress: u.PaymentAddress,
+		SellerAddress:  u.SellerAddress,
+		PublicKey:      u.PublicKey,
+		RedeemScript:   u.RedeemScript,
 	}
 
 	jsonBytes, err := json.Marshal(input)
@@ -368,18 +538,19 @@ func (u *UnconfirmedInvoice) GenerateHash() (string, error) {
 type UnconfirmedInvoiceHash struct {
 	MintHash       string `json:"mint_hash"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	BuyerAddress   string `json:"buyer_address"`
 	PaymentAddress string `json:"payment_address"`
 	SellerAddress  string `json:"seller_address"`
 	PublicKey      string `json:"public_key"`
 	Signature      string `json:"signature"`
+	RedeemScript   string `json:"redeem_script,omitempty"`
 }
 
 type InvoiceHash struct {
 	MintHash       string `json:"mint_hash"`
 	Quantity       int    `json:"quantity"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	PaymentAddress string `json:"payment_address"`
 	SellerAddress  string `json:"seller_address"`
 	PublicKey      string `json:"public_key"`
@@ -393,22 +564,88 @@ type Invoice struct {
 	BuyerAddress          string       `json:"buyer_address"`
 	MintHash              string       `json:"mint_hash"`
 	Quantity              int          `json:"quantity"`
-	Price                 int          `json:"price"`
+	PriceKoinu            int64        `json:"price_koinu"`
 	CreatedAt             time.Time    `json:"created_at"`
 	SellerAddress         string       `json:"seller_address"`
 	BlockHeight           int64        `json:"block_height"`
+	BlockHash             string       `json:"block_hash"`
 	TransactionHash       string       `json:"transaction_hash"`
 	PendingTokenBalanceId string       `json:"pending_token_balance_id"`
 	PublicKey             string       `json:"public_key"`
 	Signature             string       `json:"signature"`
 	PaidAt                sql.NullTime `json:"paid_at"`
+	// Total paid to the payment address across all payments, and what was paid beyond the invoice total
+	PaidKoinu     int64                `json:"paid_koinu"`
+	SurplusKoinu  int64                `json:"surplus_koinu"`
+	PaymentStatus InvoicePaymentStatus `json:"payment_status"`
+	// Last block height a payment is accepted at (0 for no expiry), and why the reservation was released if it was
+	ExpiryHeight  int64  `json:"expiry_height"`
+	ReleaseReason string `json:"release_reason"`
+	// Payment of the invoice seen in the mempool and not yet in a block
+	MempoolPaymentTxHash string `json:"mempool_payment_tx_hash,omitempty"`
+	// P2SH multisig signer of the invoice, empty for a single key signer
+	RedeemScript       string             `json:"redeem_script,omitempty"`
+	MultisigSignatures MultisigSignatures `json:"multisig_signatures,omitempty"`
+}
+
+type InvoicePaymentStatus string
+
+const (
+	InvoicePaymentStatus_UNPAID         InvoicePaymentStatus = "unpaid"
+	InvoicePaymentStatus_PARTIALLY_PAID InvoicePaymentStatus = "partially_paid"
+	InvoicePaymentStatus_PAID           InvoicePaymentStatus = "paid"
+	InvoicePaymentStatus_OVERPAID       InvoicePaymentStatus = "overpaid"
+)
+
+// GetPaymentStatus compares the koinu paid so far with the invoice total.
+func (i *Invoice) GetPaymentStatus() InvoicePaymentStatus {
+	total := i.TotalKoinu()
+
+	switch {
+	case i.PaidKoinu <= 0:
+		return InvoicePaymentStatus_UNPAID
+	case i.PaidKoinu < total:
+		return InvoicePaymentStatus_PARTIALLY_PAID
+	case i.PaidKoinu == total:
+		return InvoicePaymentStatus_PAID
+	default:
+		return InvoicePaymentStatus_OVERPAID
+	}
+}
+
+// SettledBy reports whether paying the amount reaches the invoice total for the first time.
+func (i *Invoice) SettledBy(amount int64) bool {
+	return !i.PaidAt.Valid && i.PaidKoinu+amount >= i.TotalKoinu()
+}
+
+// PayableAddress is where the buyer pays the invoice: its payment address, or the seller for invoices without one.
+func (i *Invoice) PayableAddress() string {
+	if i.PaymentAddress != "" {
+		return i.PaymentAddress
+	}
+
+	return i.SellerAddress
+}
+
+// setPaymentState derives the payment status and surplus from the koinu paid.
+func (i *Invoice) setPaymentState() {
+	i.PaymentStatus = i.GetPaymentStatus()
+	i.SurplusKoinu = 0
+	if i.PaidKoinu > i.TotalKoinu() {
+		i.SurplusKoinu = i.PaidKoinu - i.TotalKoinu()
+	}
+}
+
+// TotalKoinu is the amount the buyer pays the seller for the invoice.
+func (i *Invoice) TotalKoinu() int64 {
+	return int64(i.Quantity) * i.PriceKoinu
 }
 
 func (i *Invoice) GenerateHash() (string, error) {
 	input := InvoiceHash{
 		MintHash:       i.MintHash,
 		Quantity:       i.Quantity,
-		Price:          i.Price,
+		PriceKoinu:     i.PriceKoinu,
 		PaymentAddress: i.PaymentAddress,
 		SellerAddress:  i.SellerAddress,
 		PublicKey:      i.PublicKey,
@@ -436,31 +673,37 @@ type InvoiceSignature struct {
 type InvoiceSignatureBody struct {
 	Hash           string `json:"hash"`
 	MintHash       string `json:"mint_hash"`
-	Price          int    `json:"price"`
+	PriceKoinu     int64  `json:"price_koinu"`
 	Quantity       int    `json:"quantity"`
 	BuyerAddress   string `json:"buyer_address"`
 	PaymentAddress string `json:"payment_address"`
 	SellerAddress  string `json:"seller_address"`
 }
 
-func (i *InvoiceSignature) Validate(mint Mint, invoice UnconfirmedInvoice) error {
-	var assetManager AssetManager
+type BurnSignatureBody struct {
+	Hash     string `json:"hash"`
+	MintHash string `json:"mint_hash"`
+	Quantity int    `json:"quantity"`
+	Address  string `json:"address"`
+}
 
-	for _, am := range mint.AssetManagers {
-		if am.PublicKey == i.PublicKey {
-			assetManager = am
-			break
-		}
+func (i *InvoiceSignature) matchAssetManager(mint Mint) error {
+	if mint.AssetManagers.Contains(i.PublicKey) {
+		return nil
 	}
 
-	if assetManager.PublicKey == "" {
-		return fmt.Errorf("public key does not match any asset managers")
+	return fmt.Errorf("public key does not match any asset managers")
+}
+
+func (i *InvoiceSignature) Validate(mint Mint, invoice UnconfirmedInvoice) error {
+	if err := i.matchAssetManager(mint); err != nil {
+		return err
 	}
 
 	invoiceBody := InvoiceSignatureBody{
 		Hash:           invoice.Hash,
 		MintHash:       invoice.MintHash,
-		Price:          invoice.Price,
+		PriceKoinu:     invoice.PriceKoinu,
 		Quantity:       invoice.Quantity,
 		BuyerAddress:   invoice.BuyerAddress,
 		PaymentAddress: invoice.PaymentAddress,
@@ -475,6 +718,32 @@ func (i *InvoiceSignature) Validate(mint Mint, invoice UnconfirmedInvoice) error
 	return nil
 }
 
+// ValidateBurn checks an asset manager co-signature over a burn.
+// Burn signatures share the invoice signature store, keyed by the burn hash.
+func (i *InvoiceSignature) ValidateBurn(mint Mint, burnHash string, address string, quantity int) error {
+	if err := i.matchAssetManager(mint); err != nil {
+		return err
+	}
+
+	if i.InvoiceHash != burnHash {
+		return fmt.Errorf("signature is not for burn: %s", burnHash)
+	}
+
+	burnBody := BurnSignatureBody{
+		Hash:     burnHash,
+		MintHash: mint.Hash,
+		Quantity: quantity,
+		Address:  address,
+	}
+
+	err := doge.ValidateSignature(burnBody, i.PublicKey, i.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return nil
+}
+
 type TokenBalanceWithMint struct {
 	Mint
 	Address   string    `json:"address"`
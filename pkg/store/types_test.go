@@ -18,7 +18,7 @@ func TestInvoiceGenerateHash(t *testing.T) {
 	invoice := store.UnconfirmedInvoice{
 		MintHash:      "buyOfferMintHash",
 		Quantity:      100,
-		Price:         20,
+		PriceKoinu:    20,
 		BuyerAddress:  MintHash,
 		SellerAddress: sellOfferAddress,
 		PublicKey:     "publicKey",
@@ -27,7 +27,7 @@ func TestInvoiceGenerateHash(t *testing.T) {
 	inputHash := store.UnconfirmedInvoiceHash{
 		MintHash:      invoice.MintHash,
 		Quantity:      invoice.Quantity,
-		Price:         invoice.Price,
+		PriceKoinu:    invoice.PriceKoinu,
 		BuyerAddress:  invoice.BuyerAddress,
 		SellerAddress: invoice.SellerAddress,
 		PublicKey:     invoice.PublicKey,
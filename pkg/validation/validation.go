@@ -3,6 +3,7 @@ package validation
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -18,9 +19,9 @@ const (
 	MaxMetadataSize      = 10000 // JSON bytes
 
 	// Numeric limits
-	MaxQuantity      = 1000000000 // 1 billion
-	MaxPrice         = 1000000000 // 1 billion (in smallest unit)
-	MaxFractionCount = 1000000000 // 1 billion
+	MaxQuantity      = 1000000000         // 1 billion
+	MaxPrice         = 100000000000000000 // 1 billion DOGE, in koinu
+	MaxFractionCount = 1000000000         // 1 billion
 
 	// Hash and address formats
 	HashLength       = 64 // SHA256 hex length
@@ -30,8 +31,8 @@ const (
 
 var (
 	// Dogecoin address regex patterns
-	// Mainnet: P2PKH (D) or P2SH (A)
-	mainnetRegex = regexp.MustCompile(`^(D|A)[1-9A-HJ-NP-Za-km-z]{25,34}$`)
+	// Mainnet: P2PKH (D) or P2SH (9 or A)
+	mainnetRegex = regexp.MustCompile(`^(D|9|A)[1-9A-HJ-NP-Za-km-z]{25,34}$`)
 
 	// Testnet/Regtest: P2PKH (m or n) or P2SH (2)
 	testnetRegex = regexp.MustCompile(`^([mn2])[1-9A-HJ-NP-Za-km-z]{25,34}$`)
@@ -184,8 +185,8 @@ func ValidateQuantity(field string, quantity int) error {
 	return nil
 }
 
-// ValidatePrice validates price values
-func ValidatePrice(field string, price int) error {
+// ValidatePrice validates price values, in koinu
+func ValidatePrice(field string, price int64) error {
 	if price <= 0 {
 		return fmt.Errorf("%s must be greater than 0", field)
 	}
@@ -197,6 +198,15 @@ func ValidatePrice(field string, price int) error {
 	return nil
 }
 
+// ValidateTotalKoinu validates the total of quantity times price fits in koinu
+func ValidateTotalKoinu(field string, quantity int, price int64) error {
+	if quantity > 0 && price > math.MaxInt64/int64(quantity) {
+		return fmt.Errorf("%s overflows: %d x %d koinu", field, quantity, price)
+	}
+
+	return nil
+}
+
 // ValidateTags validates tag array
 func ValidateTags(tags []string) error {
 	if len(tags) > MaxTagCount {
@@ -13,6 +13,8 @@ func TestValidateAddress(t *testing.T) {
 	}{
 		{"Valid mainnet address", "D7P2jVEK6JGiGepUGTAHqELKK8QJ8GCahZ", false},
 		{"Valid testnet address", "nTQNmAFNcpZUMoLCVh7GU8Jd5HaYLrDr7b", false},
+		{"Valid mainnet P2SH address", "A6HA1Fy91JJN3DryXqmWm72bTA257Bh64D", false},
+		{"Valid testnet P2SH address", "2N767L9qGYgvpLe83nqiy8vPUxvrCmqKhbK", false},
 		{"Empty address", "", true},
 		{"Too short", "D123", true},
 		{"Too long", strings.Repeat("D", 70), true},
@@ -126,6 +128,28 @@ func TestValidateQuantity(t *testing.T) {
 	}
 }
 
+func TestValidateTotalKoinu(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		price    int64
+		wantErr  bool
+	}{
+		{"Valid total", 10, 150000000, false},
+		{"Max quantity at max price", MaxQuantity, MaxPrice, true},
+		{"Max quantity at one DOGE", MaxQuantity, 100000000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTotalKoinu("total", tt.quantity, tt.price)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTotalKoinu() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateTags(t *testing.T) {
 	tests := []struct {
 		name    string
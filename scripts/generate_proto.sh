@@ -2,9 +2,17 @@
 
 set -e
 
+protoc --proto_path=. --go_out=. ./pkg/protocol/multisig.proto
 protoc --proto_path=. --go_out=. ./pkg/protocol/buy_offers.proto
 protoc --proto_path=. --go_out=. ./pkg/protocol/invoices.proto
 protoc --proto_path=. --go_out=. ./pkg/protocol/mint.proto
 protoc --proto_path=. --go_out=. ./pkg/protocol/payment.proto
 protoc --proto_path=. --go_out=. ./pkg/protocol/sell_offers.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/transfer.proto
 
+protoc --proto_path=. --go_out=. ./pkg/protocol/burn.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/allowlist.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/distribution.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/mint_amendment.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/asset_manager_rotation.proto
+protoc --proto_path=. --go_out=. ./pkg/protocol/mint_ownership.proto